@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// copyAssetFiles copies the given assets (whose paths are relative to the source file) in the directory of the output file,
+// preserving their relative paths. Assets which do not exist or which are not within the directory of the source file are skipped.
+func copyAssetFiles(sourcePath, outputPath string, assets []string) error {
+	sourceDir, err := filepath.Abs(filepath.Dir(sourcePath))
+	if err != nil {
+		return fmt.Errorf("unable to copy assets of '%s': %w", sourcePath, err)
+	}
+	outputDir, err := filepath.Abs(filepath.Dir(outputPath))
+	if err != nil {
+		return fmt.Errorf("unable to copy assets of '%s': %w", sourcePath, err)
+	}
+	if sourceDir == outputDir {
+		// nothing to copy
+		return nil
+	}
+	for _, asset := range assets {
+		p := filepath.FromSlash(path.Clean(asset))
+		if p == ".." || strings.HasPrefix(p, ".."+string(filepath.Separator)) {
+			log.Warnf("skipping asset '%s' which is not within the directory of '%s'", asset, sourcePath)
+			continue
+		}
+		if err := copyFile(filepath.Join(sourceDir, p), filepath.Join(outputDir, p)); os.IsNotExist(err) {
+			log.Warnf("skipping asset '%s' which does not exist", asset)
+		} else if err != nil {
+			return fmt.Errorf("unable to copy asset '%s': %w", asset, err)
+		}
+	}
+	return nil
+}
+
+// copyFile copies the `src` file into `dst`, creating the parent directories of `dst` if needed
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// number of unchanged lines to show around each change in the unified diff
+const diffContextLines = 3
+
+// compareWithGolden renders the source file of the given configuration and compares the result with the content
+// of the given golden file. If they differ, then a unified diff is written in the given output and an error is returned
+func compareWithGolden(out io.Writer, config configuration.Configuration, goldenPath string) error {
+	expected, err := ioutil.ReadFile(goldenPath)
+	if err != nil {
+		return fmt.Errorf("unable to read golden file: %w", err)
+	}
+	actual := &bytes.Buffer{}
+	if _, err := convertFile(actual, config); err != nil {
+		return err
+	}
+	if bytes.Equal(expected, actual.Bytes()) {
+		return nil
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(expected)),
+		B:        difflib.SplitLines(actual.String()),
+		FromFile: goldenPath,
+		ToFile:   config.Filename,
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to compare with golden file: %w", err)
+	}
+	if _, err := io.WriteString(out, diff); err != nil {
+		return err
+	}
+	return fmt.Errorf("rendered output of '%s' differs from '%s'", config.Filename, goldenPath)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCompletionCmd returns the command which generates the completion script for a given shell
+func NewCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish]",
+		Short: "Generate the completion script for the given shell",
+		Long: `Generate the completion script for the given shell.
+
+For example, to load the completions in the current bash session:
+
+  source <(libasciidoc completion bash)
+`,
+		DisableFlagsInUseLine: true,
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		Args:                  cobra.ExactValidArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch args[0] {
+			case "bash":
+				return cmd.Root().GenBashCompletion(cmd.OutOrStdout())
+			case "zsh":
+				return cmd.Root().GenZshCompletion(cmd.OutOrStdout())
+			default: // fish
+				return cmd.Root().GenFishCompletion(cmd.OutOrStdout(), true)
+			}
+		},
+	}
+}
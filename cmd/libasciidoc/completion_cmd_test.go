@@ -0,0 +1,44 @@
+package main_test
+
+import (
+	"bytes"
+
+	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
+
+	. "github.com/onsi/ginkgo"                  //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
+)
+
+var _ = Describe("completion cmd", func() {
+
+	DescribeTable("ok",
+		func(shell, expected string) {
+			// given
+			root := main.NewCommandTree()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"completion", shell})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring(expected))
+		},
+		Entry("bash", "bash", "# bash completion for libasciidoc"),
+		Entry("zsh", "zsh", "#compdef _libasciidoc libasciidoc"),
+		Entry("fish", "fish", "# fish completion for libasciidoc"),
+	)
+
+	It("fail with unknown shell", func() {
+		// given
+		root := main.NewCommandTree()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"completion", "powershell"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
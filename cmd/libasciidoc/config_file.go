@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// defaultConfigFilename the name of the project-level configuration file which is read in the current directory
+// (unless another file is specified with the `config` flag)
+const defaultConfigFilename = ".libasciidoc.yaml"
+
+// the key of the document attributes in the configuration file
+const configAttributesKey = "attributes"
+
+// the flags which cannot be set in the configuration file
+var excludedConfigFlags = map[string]bool{
+	"config": true,
+	"help":   true,
+}
+
+// the settings which are supported by Asciidoctor but not (yet) by the command line interface
+var unsupportedConfigSettings = map[string]bool{
+	"safe-mode":    true,
+	"template-dir": true,
+}
+
+// loadConfigFile reads the configuration file with the given name, and applies its settings on the flags of the command which were
+// not set in the command line (ie, the flags take precedence over the configuration file).
+// The keys of the configuration file are the (long) names of the flags, except for the `attributes` which are
+// in a map of names and values, and which are returned to be used as the default document attributes.
+// If the given filename is empty, then the `.libasciidoc.yaml` file in the current directory is read if it exists.
+func loadConfigFile(cmd *cobra.Command, filename string) (map[string]string, error) {
+	if filename == "" {
+		if _, err := os.Stat(defaultConfigFilename); err != nil {
+			// no configuration file to read
+			return map[string]string{}, nil
+		}
+		filename = defaultConfigFilename
+	}
+	log.Debugf("reading configuration file '%s'", filename)
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read configuration file: %w", err)
+	}
+	settings := yaml.MapSlice{}
+	if err := yaml.Unmarshal(content, &settings); err != nil {
+		return nil, fmt.Errorf("unable to parse configuration file '%s': %w", filename, err)
+	}
+	flags := cmd.Flags()
+	attributes := map[string]string{}
+	for _, setting := range settings {
+		name := fmt.Sprintf("%v", setting.Key)
+		if name == configAttributesKey {
+			if attributes, err = parseConfigAttributes(setting.Value); err != nil {
+				return nil, fmt.Errorf("invalid '%s' in configuration file '%s': %w", name, filename, err)
+			}
+			continue
+		}
+		if unsupportedConfigSettings[name] {
+			return nil, fmt.Errorf("unsupported setting '%s' in configuration file '%s'", name, filename)
+		}
+		flag := flags.Lookup(name)
+		if flag == nil || excludedConfigFlags[name] {
+			return nil, fmt.Errorf("unknown setting '%s' in configuration file '%s'", name, filename)
+		}
+		if flag.Changed {
+			// the flag set in the command line takes precedence
+			continue
+		}
+		values, err := configValues(setting.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' in configuration file '%s': %w", name, filename, err)
+		}
+		for _, v := range values {
+			if err := flags.Set(name, v); err != nil {
+				return nil, fmt.Errorf("invalid '%s' in configuration file '%s': %w", name, filename, err)
+			}
+		}
+	}
+	return attributes, nil
+}
+
+// parseConfigAttributes returns the document attributes in the given map of names and values.
+// An attribute without value (or with a `true` value) is set with an empty value,
+// and an attribute with a `false` value is reset
+func parseConfigAttributes(value interface{}) (map[string]string, error) {
+	if value == nil {
+		return map[string]string{}, nil
+	}
+	attrs, ok := value.(yaml.MapSlice)
+	if !ok {
+		return nil, fmt.Errorf("expected a map of names and values, got '%v'", value)
+	}
+	result := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		name := fmt.Sprintf("%v", attr.Key)
+		switch v := attr.Value.(type) {
+		case nil:
+			result[name] = ""
+		case bool:
+			if v {
+				result[name] = ""
+			} else {
+				result["!"+name] = ""
+			}
+		case yaml.MapSlice, []interface{}:
+			return nil, fmt.Errorf("unsupported value of attribute '%s'", name)
+		default:
+			result[name] = fmt.Sprintf("%v", v)
+		}
+	}
+	return result, nil
+}
+
+// configValues returns the value(s) of a setting in the configuration file, as strings which can be set on a flag
+func configValues(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return []string{}, nil
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case []interface{}:
+		result := make([]string, len(v))
+		for i, e := range v {
+			switch e.(type) {
+			case yaml.MapSlice, []interface{}:
+				return nil, fmt.Errorf("unsupported value '%v'", e)
+			}
+			result[i] = fmt.Sprintf("%v", e)
+		}
+		return result, nil
+	case yaml.MapSlice:
+		return nil, fmt.Errorf("unsupported value '%v'", v)
+	default:
+		return []string{fmt.Sprintf("%v", v)}, nil
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+
+	"github.com/spf13/cobra"
+)
+
+// NewDocsCmd returns the command which renders the help of all the commands as a single AsciiDoc or HTML document
+func NewDocsCmd() *cobra.Command {
+	var format string
+	docsCmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Print the documentation of the commands in AsciiDoc or HTML",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source := &strings.Builder{}
+			fmt.Fprintf(source, "= %s\n", cmd.Root().Name())
+			writeCommandDocs(source, cmd.Root())
+			switch format {
+			case "adoc":
+				_, err := io.WriteString(cmd.OutOrStdout(), source.String())
+				return err
+			case "html":
+				_, err := libasciidoc.Convert(strings.NewReader(source.String()), cmd.OutOrStdout(), configuration.NewConfiguration(configuration.WithHeaderFooter(true)))
+				return err
+			default:
+				return fmt.Errorf("unsupported documentation format: '%s'", format)
+			}
+		},
+	}
+	docsCmd.Flags().StringVar(&format, "format", "adoc", "the format of the documentation [adoc|html]")
+	return docsCmd
+}
+
+// writeCommandDocs writes the documentation of the given command and of its (available) sub-commands in the AsciiDoc format
+func writeCommandDocs(out io.Writer, cmd *cobra.Command) {
+	cmd.InitDefaultHelpFlag() // so that the `--help` flag is listed for all commands
+	fmt.Fprintf(out, "\n== %s\n\n", cmd.CommandPath())
+	fmt.Fprintf(out, "%s\n", cmd.Short)
+	if cmd.Long != "" {
+		fmt.Fprintf(out, "\n....\n%s\n....\n", strings.TrimSpace(cmd.Long))
+	}
+	if cmd.Runnable() {
+		fmt.Fprintf(out, "\n.Usage\n----\n%s\n----\n", cmd.UseLine())
+	}
+	if cmd.HasExample() {
+		fmt.Fprintf(out, "\n.Examples\n----\n%s\n----\n", cmd.Example)
+	}
+	if flags := cmd.NonInheritedFlags(); flags.HasAvailableFlags() {
+		fmt.Fprintf(out, "\n.Options\n----\n%s----\n", flags.FlagUsages())
+	}
+	if flags := cmd.InheritedFlags(); flags.HasAvailableFlags() {
+		fmt.Fprintf(out, "\n.Options inherited from parent commands\n----\n%s----\n", flags.FlagUsages())
+	}
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		writeCommandDocs(out, c)
+	}
+}
@@ -0,0 +1,55 @@
+package main_test
+
+import (
+	"bytes"
+
+	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("docs cmd", func() {
+
+	It("render as asciidoc", func() {
+		// given
+		root := main.NewCommandTree()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"docs"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(HavePrefix("= libasciidoc\n\n== libasciidoc\n"))
+		Expect(buf.String()).To(ContainSubstring("\n== libasciidoc version\n\nPrint the version and build info\n"))
+		Expect(buf.String()).To(ContainSubstring("\n.Usage\n----\nlibasciidoc completion [bash|zsh|fish]\n----\n"))
+		Expect(buf.String()).ToNot(ContainSubstring("== libasciidoc help"))
+	})
+
+	It("render as html", func() {
+		// given
+		root := main.NewCommandTree()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"docs", "--format", "html"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("<title>libasciidoc</title>"))
+		Expect(buf.String()).To(ContainSubstring(`<h2 id="_libasciidoc_gen_man">libasciidoc gen-man</h2>`))
+	})
+
+	It("fail with unknown format", func() {
+		// given
+		root := main.NewCommandTree()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"docs", "--format", "pdf"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
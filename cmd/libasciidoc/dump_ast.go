@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// dumpAST parses the given file and writes the resulting AST in the given format (`yaml` or `json`)
+func dumpAST(out io.Writer, config configuration.Configuration, format string) error {
+	f, err := os.Open(config.Filename)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", config.Filename, err)
+	}
+	defer f.Close()
+	doc, err := parser.ParseDocument(f, config)
+	if err != nil {
+		return err
+	}
+	// the version of the AST is written along with the type of the document
+	tree := astTree(reflect.ValueOf(doc)).(astNode)
+	tree = append(astNode{tree[0], {key: "version", value: types.ASTVersion}}, tree[1:]...)
+	var result []byte
+	switch format {
+	case "yaml":
+		if result, err = yaml.Marshal(tree); err != nil {
+			return fmt.Errorf("unable to dump the AST in YAML: %w", err)
+		}
+	case "json":
+		if result, err = json.MarshalIndent(tree, "", "  "); err != nil {
+			return fmt.Errorf("unable to dump the AST in JSON: %w", err)
+		}
+		result = append(result, '\n')
+	default:
+		return fmt.Errorf("unsupported AST format: '%s'", format)
+	}
+	_, err = out.Write(result)
+	return err
+}
+
+// astNode a node of the AST, with its fields in the same order as in the source struct or map
+type astNode []astField
+
+type astField struct {
+	key   string
+	value interface{}
+}
+
+// MarshalYAML returns the node as an ordered YAML map
+func (n astNode) MarshalYAML() (interface{}, error) {
+	result := make(yaml.MapSlice, len(n))
+	for i, f := range n {
+		result[i] = yaml.MapItem{Key: f.key, Value: f.value}
+	}
+	return result, nil
+}
+
+// MarshalJSON returns the node as an ordered JSON object
+func (n astNode) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+	for i, f := range n {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		k, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteString(":")
+		buf.Write(v)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// astTree converts the given value into a tree of nodes in which each struct is
+// annotated with its type, and where nil and empty fields are omitted
+func astTree(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return astTree(v.Elem())
+	case reflect.Struct:
+		t := v.Type()
+		node := astNode{{key: "type", value: t.Name()}}
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" || isEmpty(v.Field(i)) { // skip unexported and empty fields
+				continue
+			}
+			node = append(node, astField{key: t.Field(i).Name, value: astTree(v.Field(i))})
+		}
+		return node
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		node := make(astNode, len(keys))
+		for i, k := range keys {
+			node[i] = astField{key: fmt.Sprint(k.Interface()), value: astTree(v.MapIndex(k))}
+		}
+		return node
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 { // []byte
+			return string(v.Bytes())
+		}
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result[i] = astTree(v.Index(i))
+		}
+		return result
+	default:
+		return v.Interface()
+	}
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	default:
+		return false // keep zero values such as the level of a section
+	}
+}
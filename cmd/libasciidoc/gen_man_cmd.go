@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// NewGenManCmd returns the command which generates the man pages of all the commands
+func NewGenManCmd() *cobra.Command {
+	var dir string
+	genManCmd := &cobra.Command{
+		Use:   "gen-man",
+		Short: "Generate the man pages of the commands",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("unable to create the man pages directory: %w", err)
+			}
+			header := &doc.GenManHeader{
+				Title:   "LIBASCIIDOC",
+				Section: "1",
+				Source:  "libasciidoc",
+			}
+			if err := doc.GenManTree(cmd.Root(), header, dir); err != nil {
+				return fmt.Errorf("unable to generate the man pages: %w", err)
+			}
+			return nil
+		},
+	}
+	genManCmd.Flags().StringVarP(&dir, "dir", "d", ".", "the directory in which the man pages are written")
+	return genManCmd
+}
@@ -0,0 +1,36 @@
+package main_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("gen-man cmd", func() {
+
+	It("ok", func() {
+		// given
+		dir, err := ioutil.TempDir("", "libasciidoc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		root := main.NewCommandTree()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"gen-man", "--dir", dir})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		for _, name := range []string{"libasciidoc.1", "libasciidoc-version.1", "libasciidoc-completion.1", "libasciidoc-gen-man.1", "libasciidoc-docs.1"} {
+			content, err := ioutil.ReadFile(filepath.Join(dir, name))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring(`.TH "LIBASCIIDOC" "1"`))
+		}
+	})
+})
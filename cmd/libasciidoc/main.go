@@ -5,28 +5,34 @@ import (
 	"os"
 	"strings"
 
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 func main() {
-	rootCmd := NewRootCmd()
-	versionCmd := NewVersionCmd()
-	rootCmd.AddCommand(versionCmd)
-	rootCmd.SetHelpCommand(helpCommand)
-	if err := rootCmd.Execute(); err != nil {
+	if err := NewCommandTree().Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
+// NewCommandTree returns the root command along with all its sub-commands
+func NewCommandTree() *cobra.Command {
+	rootCmd := NewRootCmd()
+	rootCmd.AddCommand(NewVersionCmd())
+	rootCmd.AddCommand(NewCompletionCmd())
+	rootCmd.AddCommand(NewGenManCmd())
+	rootCmd.AddCommand(NewDocsCmd())
+	rootCmd.SetHelpCommand(helpCommand)
+	return rootCmd
+}
+
 var helpCommand = &cobra.Command{
 	Use:   "help [command]",
 	Short: "Help about the command",
 	RunE: func(c *cobra.Command, args []string) error {
 		cmd, args, e := c.Root().Find(args)
 		if cmd == nil || e != nil || len(args) > 0 {
-			return errors.Errorf("unknown help topic: %v", strings.Join(args, " "))
+			return fmt.Errorf("unknown help topic: %v", strings.Join(args, " "))
 		}
 		helpFunc := cmd.HelpFunc()
 		helpFunc(cmd, args)
@@ -5,11 +5,14 @@ import (
 	"io"
 	"os"
 	"strings"
+	"text/template"
+	"time"
 
 	"path/filepath"
 
 	"github.com/bytesparadise/libasciidoc"
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -20,16 +23,60 @@ func NewRootCmd() *cobra.Command {
 
 	var noHeaderFooter bool
 	var outputName string
+	var outputTemplate string
 	var logLevel string
 	var css string
 	var backend string
 	var attributes []string
+	var envAttributePrefixes []string
+	var dumpASTFormat string
+	var sourceMap bool
+	var compareWith string
+	var excludedRoles []string
+	var includedRoles []string
+	var outputFormat string
+	var destinationDir string
+	var copyAssets bool
+	var strict bool
+	var autoImageDims bool
+	var hugoShortcodes bool
+	var inlineCache bool
+	var timings bool
+	var configFile string
+	var configAttributes map[string]string
+
+	// newConfiguration returns the configuration to process the given source file with the given attributes,
+	// using the values of the flags. The given settings are applied last
+	newConfiguration := func(sourcePath string, attrs map[string]string, settings ...configuration.Setting) configuration.Configuration {
+		return configuration.NewConfiguration(append([]configuration.Setting{
+			configuration.WithFilename(sourcePath),
+			configuration.WithAttributes(attrs),
+			configuration.WithCSS(css),
+			configuration.WithBackEnd(backend),
+			configuration.WithHeaderFooter(!noHeaderFooter),
+			configuration.WithSourceMap(sourceMap),
+			configuration.WithExcludedRoles(excludedRoles...),
+			configuration.WithIncludedRoles(includedRoles...),
+			configuration.WithOutputFormat(outputFormat),
+			configuration.WithStrict(strict),
+			configuration.WithAutoImageDimensions(autoImageDims),
+			configuration.WithHugoShortcodes(hugoShortcodes),
+			configuration.WithInlineCache(inlineCache),
+		}, settings...)...)
+	}
 
 	rootCmd := &cobra.Command{
 		Use:   "libasciidoc [flags] FILE",
 		Short: `libasciidoc is a tool to convert from Asciidoc to HTML`,
 		Args:  cobra.ArbitraryArgs,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.HasParent() {
+				// the settings in the configuration file only apply to the root command
+				var err error
+				if configAttributes, err = loadConfigFile(cmd, configFile); err != nil {
+					return err
+				}
+			}
 			lvl, err := log.ParseLevel(logLevel)
 			if err != nil {
 				fmt.Fprintf(cmd.OutOrStderr(), "unable to parse log level '%v'", logLevel)
@@ -48,23 +95,83 @@ func NewRootCmd() *cobra.Command {
 			if len(args) == 0 {
 				return helpCommand.RunE(cmd, args)
 			}
-			attrs := parseAttributes(attributes)
-			for _, sourcePath := range args {
-				out, close := getOut(cmd, sourcePath, outputName)
-				if out != nil {
-					defer close() //nolint errcheck
-					// path, _ := filepath.Abs(sourcePath)
-					// log.Debugf("Starting to process file %v", path)
-					config := configuration.NewConfiguration(
-						configuration.WithFilename(sourcePath),
-						configuration.WithAttributes(attrs),
-						configuration.WithCSS(css),
-						configuration.WithBackEnd(backend),
-						configuration.WithHeaderFooter(!noHeaderFooter))
-					_, err := libasciidoc.ConvertFile(out, config)
+			if outputName != "" && outputTemplate != "" {
+				return fmt.Errorf("the 'out-file' and 'out-template' flags cannot be used together")
+			}
+			if destinationDir != "" && (outputName != "" || outputTemplate != "") {
+				return fmt.Errorf("the 'destination-dir' flag cannot be used with the 'out-file' and 'out-template' flags")
+			}
+			if copyAssets && (outputName == "-" || dumpASTFormat != "" || compareWith != "") {
+				return fmt.Errorf("the 'copy-assets' flag can only be used when the output is written in files")
+			}
+			if compareWith != "" {
+				if len(args) > 1 || outputName != "" || outputTemplate != "" || dumpASTFormat != "" {
+					return fmt.Errorf("the 'compare' flag can only be used with a single input file and without the 'out-file', 'out-template' and 'dump-ast' flags")
+				}
+				config := newConfiguration(args[0], collectAttributes(configAttributes, attributes, envAttributePrefixes))
+				return compareWithGolden(cmd.OutOrStdout(), config, compareWith)
+			}
+			var outTmpl *template.Template
+			if outputTemplate != "" {
+				var err error
+				if outTmpl, err = template.New("out").Parse(outputTemplate); err != nil {
+					return fmt.Errorf("invalid output template: %w", err)
+				}
+			}
+			attrs := collectAttributes(configAttributes, attributes, envAttributePrefixes)
+			sourcePaths, err := collectSourcePaths(args)
+			if err != nil {
+				return err
+			}
+			for _, sourcePath := range sourcePaths {
+				if dumpASTFormat != "" {
+					// only dump the AST, without rendering the document
+					out, close := cmd.OutOrStdout(), defaultCloseFunc()
+					if outputName != "" {
+						out, close = getOut(cmd, sourcePath, outputName)
+					}
+					config := newConfiguration(sourcePath, attrs,
+						configuration.WithSourceMap(true)) // include the source line of the blocks in the AST
+					err := dumpAST(out, config, dumpASTFormat)
+					if cerr := close(); err == nil {
+						err = cerr
+					}
 					if err != nil {
 						return err
 					}
+					continue
+				}
+				outputName := outputName
+				if outTmpl != nil {
+					var err error
+					if outputName, err = newOutputPath(outTmpl, sourcePath); err != nil {
+						return err
+					}
+				} else if destinationDir != "" {
+					var err error
+					if outputName, err = newDestinationPath(destinationDir, sourcePath); err != nil {
+						return err
+					}
+				}
+				out, close := getOut(cmd, sourcePath, outputName)
+				// path, _ := filepath.Abs(sourcePath)
+				// log.Debugf("Starting to process file %v", path)
+				config := newConfiguration(sourcePath, attrs)
+				start := time.Now()
+				metadata, err := convertFile(out, config)
+				if cerr := close(); err == nil {
+					err = cerr
+				}
+				if err != nil {
+					return err
+				}
+				if timings {
+					printTimings(cmd.ErrOrStderr(), sourcePath, time.Since(start), metadata)
+				}
+				if copyAssets {
+					if err := copyAssetFiles(sourcePath, getOutputPath(sourcePath, outputName), metadata.Assets); err != nil {
+						return err
+					}
 				}
 			}
 			return nil
@@ -74,48 +181,253 @@ func NewRootCmd() *cobra.Command {
 	flags := rootCmd.Flags()
 	flags.BoolVarP(&noHeaderFooter, "no-header-footer", "s", false, "do not render header/footer (default: false)")
 	flags.StringVarP(&outputName, "out-file", "o", "", "output file (default: based on path of input file); use - to output to STDOUT")
+	flags.StringVar(&outputTemplate, "out-template", "", "template of the output file path for each input file, eg: '{{.Dir}}/out/{{.Name}}.html' or 'dist/{{.RelPath}}/{{.Name}}.html', where 'Dir' is the directory of the input file, 'RelPath' is this directory relative to the current directory, 'Name' is the name of the input file without its extension and 'Ext' is its extension")
 	flags.StringVar(&logLevel, "log", "warning", "log level to set [debug|info|warning|error|fatal|panic]")
 	flags.StringVar(&css, "css", "", "the path to the CSS file to link to the document")
 	flags.StringArrayVarP(&attributes, "attribute", "a", []string{}, "a document attribute to set in the form of name, name!, or name=value pair")
+	flags.StringArrayVar(&envAttributePrefixes, "attribute-from-env", []string{}, "a prefix of the environment variables to set as document attributes, eg: 'CI_' to set the 'CI_BUILD_NUMBER' variable as the 'build-number' attribute (attributes set with the 'attribute' flag take precedence)")
 	flags.StringVarP(&backend, "backend", "b", "html5", "backend to format the file")
+	flags.StringVar(&dumpASTFormat, "dump-ast", "", "dump the parsed document (AST), with the source line of its top-level blocks, instead of rendering it [yaml|json] (default: yaml)")
+	flags.Lookup("dump-ast").NoOptDefVal = "yaml"
+	flags.BoolVar(&sourceMap, "source-map", false, "add a 'data-sourceline' attribute with the source line on each top-level block (default: false)")
+	flags.StringVar(&compareWith, "compare", "", "render the input file and compare the result with the given (golden) file, and print a unified diff and exit with a non-zero status if they differ")
+	flags.StringArrayVar(&excludedRoles, "exclude-role", []string{}, "a role of the blocks to skip when rendering the document (eg: 'internal-only')")
+	flags.StringArrayVar(&includedRoles, "include-role", []string{}, "a role of the blocks to render, among all blocks which have a role (blocks without role are always rendered)")
+	flags.StringVarP(&destinationDir, "destination-dir", "D", "", "the directory in which the output files are written, preserving the paths of the input files relative to the current directory")
+	flags.BoolVar(&copyAssets, "copy-assets", false, "copy the local images referenced in each document next to the output file, preserving their relative paths (default: false)")
+	flags.BoolVar(&strict, "strict", false, "fail on deprecated or ambiguous constructs (eg: tabs after list item markers, delimited blocks without a blank line before, unknown admonition labels) instead of accepting them (default: false)")
+	flags.BoolVar(&autoImageDims, "auto-image-dims", false, "read the dimensions of the local PNG, JPEG and GIF images which have no 'width' and 'height' attributes, and render them in the output (only the images in the directory of the document are read) (default: false)")
+	flags.BoolVar(&hugoShortcodes, "hugo-shortcodes", false, "retain the Hugo shortcodes (eg: '{{< figure src=\"/cover.png\" >}}') as-is, without applying any substitution on them (default: false)")
+	flags.BoolVar(&inlineCache, "inline-cache", false, "reuse the rendered content of the identical inline elements (eg: icons, images, links or quoted text) within a document (default: false)")
+	flags.BoolVar(&timings, "timings", false, "print the time taken to convert each file, along with the hit ratio of the inline cache if it is enabled (default: false)")
+	flags.StringVar(&outputFormat, "output-format", "pretty", "the format of the output [pretty|compact|minified]")
+	flags.StringVar(&configFile, "config", "", "the configuration file with the default values of the flags, using their names as keys, and with the default document attributes in an 'attributes' map (default: '"+defaultConfigFilename+"' in the current directory, if it exists). The flags take precedence over the configuration file")
 	return rootCmd
 }
 
+// convertFile converts the source file of the given configuration, which is read (and parsed) from
+// an `io.Reader` so that its content is not buffered more than once
+func convertFile(out io.Writer, config configuration.Configuration) (types.Metadata, error) {
+	f, err := os.Open(config.Filename)
+	if err != nil {
+		return types.Metadata{}, fmt.Errorf("error opening %s: %w", config.Filename, err)
+	}
+	defer f.Close()
+	// use the file mtime as the `last updated` value
+	stat, err := f.Stat()
+	if err != nil {
+		return types.Metadata{}, fmt.Errorf("error opening %s: %w", config.Filename, err)
+	}
+	config.LastUpdated = stat.ModTime()
+	return libasciidoc.Convert(f, out, config)
+}
+
+// printTimings prints the time taken to convert the given source file, along with the statistics of the inline cache (if enabled)
+func printTimings(out io.Writer, sourcePath string, duration time.Duration, metadata types.Metadata) {
+	if metadata.InlineCache != nil {
+		fmt.Fprintf(out, "%s: converted in %v, inline cache: %s\n", sourcePath, duration, metadata.InlineCache)
+		return
+	}
+	fmt.Fprintf(out, "%s: converted in %v\n", sourcePath, duration)
+}
+
 type closeFunc func() error
 
 func defaultCloseFunc() closeFunc {
 	return func() error { return nil }
 }
 
-func newCloseFileFunc(c io.Closer) closeFunc {
-	return func() error {
-		return c.Close()
-	}
-}
-
 func getOut(cmd *cobra.Command, sourcePath, outputName string) (io.Writer, closeFunc) {
 	if outputName == "-" {
 		// outfile is STDOUT
 		return cmd.OutOrStdout(), defaultCloseFunc()
 	} else if outputName != "" {
 		// outfile is specified in the command line
-		outfile, e := os.Create(outputName)
-		if e != nil {
-			log.Warnf("Cannot create output file - %v, skipping", outputName)
-		}
-		return outfile, newCloseFileFunc(outfile)
+		out := &outputFile{name: outputName}
+		return out, out.Close
 	} else if sourcePath != "" {
 		// outfile is based on sourcePath
-		path, _ := filepath.Abs(sourcePath)
-		outname := strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
-		outfile, err := os.Create(outname)
+		out := &outputFile{name: getOutputPath(sourcePath, "")}
+		return out, out.Close
+	}
+	return cmd.OutOrStdout(), defaultCloseFunc()
+}
+
+// outputFile a writer which creates the output file (and its parent directories)
+// on the first write, so that nothing is created when the document cannot be rendered
+type outputFile struct {
+	name string
+	file *os.File
+}
+
+func (f *outputFile) Write(p []byte) (int, error) {
+	if f.file == nil {
+		if err := os.MkdirAll(filepath.Dir(f.name), 0755); err != nil {
+			return 0, fmt.Errorf("unable to create output directory of '%s': %w", f.name, err)
+		}
+		file, err := os.Create(f.name)
 		if err != nil {
-			log.Warnf("Cannot create output file - %v, skipping", outname)
-			return nil, nil
+			return 0, fmt.Errorf("unable to create output file '%s': %w", f.name, err)
 		}
-		return outfile, newCloseFileFunc(outfile)
+		f.file = file
 	}
-	return cmd.OutOrStdout(), defaultCloseFunc()
+	return f.file.Write(p)
+}
+
+func (f *outputFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Close()
+}
+
+// getOutputPath returns the path of the output file for the given source file: the given output name if it is not empty,
+// otherwise the path of the source file with the `.html` extension
+func getOutputPath(sourcePath, outputName string) string {
+	if outputName != "" {
+		return outputName
+	}
+	path, _ := filepath.Abs(sourcePath)
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
+}
+
+// outputPathData the data available in the `--out-template` template
+type outputPathData struct {
+	Dir     string // the directory of the source file
+	RelPath string // the directory of the source file, relative to the current working directory
+	Name    string // the name of the source file, without its extension
+	Ext     string // the extension of the source file
+}
+
+// newOutputPath returns the path of the output file for the given source file, using the given template.
+func newOutputPath(tmpl *template.Template, sourcePath string) (string, error) {
+	abs, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("unable to compute output path of '%s': %w", sourcePath, err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("unable to compute output path of '%s': %w", sourcePath, err)
+	}
+	relPath, err := filepath.Rel(wd, filepath.Dir(abs))
+	if err != nil {
+		return "", fmt.Errorf("unable to compute output path of '%s': %w", sourcePath, err)
+	}
+	ext := filepath.Ext(sourcePath)
+	result := &strings.Builder{}
+	if err := tmpl.Execute(result, outputPathData{
+		Dir:     filepath.Dir(sourcePath),
+		RelPath: relPath,
+		Name:    strings.TrimSuffix(filepath.Base(sourcePath), ext),
+		Ext:     ext,
+	}); err != nil {
+		return "", fmt.Errorf("unable to compute output path of '%s': %w", sourcePath, err)
+	}
+	return filepath.Clean(result.String()), nil
+}
+
+// newDestinationPath returns the path of the output file for the given source file in the given destination directory,
+// preserving the path of the source file relative to the current directory (or at the root of the destination directory
+// if the source file is not within the current directory)
+func newDestinationPath(destinationDir, sourcePath string) (string, error) {
+	abs, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("unable to compute output path of '%s': %w", sourcePath, err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("unable to compute output path of '%s': %w", sourcePath, err)
+	}
+	relPath, err := filepath.Rel(wd, filepath.Dir(abs))
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		relPath = ""
+	}
+	name := strings.TrimSuffix(filepath.Base(sourcePath), filepath.Ext(sourcePath)) + ".html"
+	return filepath.Join(destinationDir, relPath, name), nil
+}
+
+// the extensions of the files to convert when a directory is given as an argument
+var sourceFileExtensions = map[string]bool{
+	".adoc":     true,
+	".asciidoc": true,
+	".asc":      true,
+	".ad":       true,
+}
+
+// collectSourcePaths returns the paths of the files to convert: the given files,
+// and the Asciidoc files in the given directories and their (non-hidden) sub-directories
+func collectSourcePaths(args []string) ([]string, error) {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err != nil || !info.IsDir() {
+			result = append(result, arg)
+			continue
+		}
+		err := filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if path != arg && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if sourceFileExtensions[filepath.Ext(path)] {
+				result = append(result, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list the files in '%s': %w", arg, err)
+		}
+	}
+	return result, nil
+}
+
+// collectAttributes returns the given default attributes (from the configuration file), overridden by the attributes
+// set with the environment variables with one of the given prefixes, and then by the given attributes
+func collectAttributes(defaults map[string]string, attributes []string, envPrefixes []string) map[string]string {
+	result := make(map[string]string, len(defaults))
+	for k, v := range defaults {
+		setAttribute(result, k, v)
+	}
+	for k, v := range parseEnvAttributes(os.Environ(), envPrefixes) {
+		setAttribute(result, k, v)
+	}
+	for k, v := range parseAttributes(attributes) {
+		setAttribute(result, k, v)
+	}
+	return result
+}
+
+// setAttribute sets the attribute with the given key (`name` or `!name`) and value in the given map,
+// and removes its counterpart (resp. `!name` or `name`) which was set with a lower precedence
+func setAttribute(attributes map[string]string, key, value string) {
+	if strings.HasPrefix(key, "!") {
+		delete(attributes, strings.TrimPrefix(key, "!"))
+	} else {
+		delete(attributes, "!"+key)
+	}
+	attributes[key] = value
+}
+
+// parseEnvAttributes returns the attributes set with the given environment variables (in the `KEY=value` form)
+// which have one of the given prefixes. The name of the attribute is the name of the variable without its prefix,
+// in lower case and with dashes instead of underscores (eg: `CI_BUILD_NUMBER` with the `CI_` prefix becomes `build-number`)
+func parseEnvAttributes(environ []string, prefixes []string) map[string]string {
+	result := map[string]string{}
+	for _, prefix := range prefixes {
+		for _, env := range environ {
+			data := strings.SplitN(env, "=", 2)
+			if len(data) != 2 || !strings.HasPrefix(data[0], prefix) || data[0] == prefix {
+				continue
+			}
+			name := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(data[0], prefix), "_", "-"))
+			result[name] = data[1]
+		}
+	}
+	return result
 }
 
 // converts the `name`, `!name` and `name=value` into a map
@@ -2,7 +2,11 @@ package main_test
 
 import (
 	"bytes"
+	"image"
+	"image/png"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	main "github.com/bytesparadise/libasciidoc/cmd/libasciidoc"
 
@@ -84,6 +88,290 @@ var _ = Describe("root cmd", func() {
 `))
 	})
 
+	It("render with attributes from environment variables", func() {
+		// given
+		os.Setenv("LIBASCIIDOC_TEST_BUILD_NUMBER", "42")
+		os.Setenv("LIBASCIIDOC_TEST_GIT_SHA", "cafebabe")
+		defer os.Unsetenv("LIBASCIIDOC_TEST_BUILD_NUMBER")
+		defer os.Unsetenv("LIBASCIIDOC_TEST_GIT_SHA")
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--attribute-from-env", "LIBASCIIDOC_TEST_", "-agit-sha=deadbeef", "test/doc_with_env_attributes.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(`<div class="paragraph">
+<p>42 at deadbeef</p>
+</div>
+`))
+	})
+
+	Context("configuration file", func() {
+
+		writeConfigFile := func(content string) string {
+			dir, err := ioutil.TempDir("", "libasciidoc")
+			Expect(err).ToNot(HaveOccurred())
+			filename := filepath.Join(dir, ".libasciidoc.yaml")
+			Expect(ioutil.WriteFile(filename, []byte(content), 0644)).To(Succeed())
+			return filename
+		}
+
+		It("render with settings and attributes from configuration file", func() {
+			// given
+			config := writeConfigFile(`no-header-footer: true
+exclude-role:
+- internal-only
+attributes:
+  foo1: bar1
+  foo2: bar2`)
+			defer os.RemoveAll(filepath.Dir(config))
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--config", config, "-o", "-", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(`<div class="paragraph">
+<p>bar1 and bar2</p>
+</div>
+`))
+		})
+
+		It("render with flags taking precedence over configuration file", func() {
+			// given
+			config := writeConfigFile(`backend: xhtml5
+no-header-footer: true
+attributes:
+  foo1: bar1
+  foo2: bar2`)
+			defer os.RemoveAll(filepath.Dir(config))
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--config", config, "-b", "html5", "-o", "-", "-afoo2=baz2", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(`<div class="paragraph">
+<p>bar1 and baz2</p>
+</div>
+`))
+		})
+
+		It("render with attribute of configuration file unset in command line", func() {
+			// given
+			config := writeConfigFile(`no-header-footer: true
+attributes:
+  foo1: bar1
+  foo2: bar2`)
+			defer os.RemoveAll(filepath.Dir(config))
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--config", config, "-o", "-", "-a!foo2", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.String()).To(ContainSubstring(`<p>bar1 and {foo2}</p>`))
+		})
+
+		It("render with configuration file in current directory", func() {
+			// given
+			config := writeConfigFile(`no-header-footer: true
+attributes:
+  foo1: bar1
+  foo2: bar2`)
+			defer os.RemoveAll(filepath.Dir(config))
+			source, err := filepath.Abs("test/doc_with_attributes.adoc")
+			Expect(err).ToNot(HaveOccurred())
+			wd, err := os.Getwd()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.Chdir(filepath.Dir(config))).To(Succeed())
+			defer os.Chdir(wd) //nolint errcheck
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"-o", "-", source})
+			// when
+			err = root.Execute()
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.String()).To(Equal(`<div class="paragraph">
+<p>bar1 and bar2</p>
+</div>
+`))
+		})
+
+		It("fail with unknown setting in configuration file", func() {
+			// given
+			config := writeConfigFile(`unknown: true`)
+			defer os.RemoveAll(filepath.Dir(config))
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--config", config, "-o", "-", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("unknown setting 'unknown' in configuration file '" + config + "'"))
+		})
+
+		It("fail with unsupported safe mode setting in configuration file", func() {
+			// given
+			config := writeConfigFile(`safe-mode: secure`)
+			defer os.RemoveAll(filepath.Dir(config))
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--config", config, "-o", "-", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("unsupported setting 'safe-mode' in configuration file '" + config + "'"))
+		})
+
+		It("fail with unsupported template dir setting in configuration file", func() {
+			// given
+			config := writeConfigFile(`template-dir: templates`)
+			defer os.RemoveAll(filepath.Dir(config))
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--config", config, "-o", "-", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(MatchError("unsupported setting 'template-dir' in configuration file '" + config + "'"))
+		})
+
+		It("fail with missing configuration file", func() {
+			// given
+			root := main.NewRootCmd()
+			buf := new(bytes.Buffer)
+			root.SetOutput(buf)
+			root.SetArgs([]string{"--config", "test/doesnotexist.yaml", "-o", "-", "test/doc_with_attributes.adoc"})
+			// when
+			err := root.Execute()
+			// then
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("render with source map", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--source-map", "-afoo1=bar1", "-afoo2=bar2", "test/doc_with_attributes.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring(`<div class="paragraph" data-sourceline=`))
+	})
+
+	It("render with timings and inline cache", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--inline-cache", "--timings", "test/doc_with_attributes.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(MatchRegexp(`test/doc_with_attributes.adoc: converted in .+, inline cache: \d+ hit\(s\), \d+ miss\(es\) \(\d+\.\d% hit ratio\)`))
+	})
+
+	It("render with timings and without inline cache", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--timings", "test/doc_with_attributes.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(MatchRegexp(`test/doc_with_attributes.adoc: converted in .+\n`))
+		Expect(buf.String()).NotTo(ContainSubstring("inline cache"))
+	})
+
+	It("render with excluded role", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--exclude-role", "internal-only", "test/doc_with_roles.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(`<div class="paragraph">
+<p>a public paragraph</p>
+</div>
+`))
+	})
+
+	It("render with minified output format", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--output-format", "minified", "--exclude-role", "internal-only", "test/doc_with_roles.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(`<div class="paragraph"><p>a public paragraph</p></div>`))
+	})
+
+	It("fail to render with unsupported output format", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--output-format", "ugly", "test/doc_with_roles.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(MatchError("unsupported output format: 'ugly'"))
+	})
+
+	It("fail to render in strict mode", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--strict", "test/doc_with_unknown_admonition.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(MatchError("non-compliant content in strict mode: test/doc_with_unknown_admonition.adoc: line 1: unknown admonition label 'DANGER'"))
+	})
+
+	It("render with hugo shortcodes", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--hugo-shortcodes", "test/doc_with_shortcodes.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(Equal(`<div class="paragraph">
+<p>see {{< ref "other.adoc" >}}</p>
+</div>
+`))
+	})
+
 	It("render with attribute reset", func() {
 		// given
 		root := main.NewRootCmd()
@@ -116,6 +404,235 @@ var _ = Describe("root cmd", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("render multiple files with output template", func() {
+		// given
+		dir, err := ioutil.TempDir("", "libasciidoc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "--out-template", filepath.Join(dir, "{{.RelPath}}", "{{.Name}}.html"), "test/admonition.adoc", "test/test.adoc"})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		content, err := ioutil.ReadFile(filepath.Join(dir, "test", "admonition.html"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).ToNot(BeEmpty())
+		content, err = ioutil.ReadFile(filepath.Join(dir, "test", "test.html"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).ToNot(BeEmpty())
+	})
+
+	It("render with output template using source directory", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "--out-template", "{{.Dir}}/out/{{.Name}}.html", "test/test.adoc"})
+		defer os.RemoveAll("test/out")
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		content, err := ioutil.ReadFile("test/out/test.html")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).ToNot(BeEmpty())
+	})
+
+	It("render directory in destination directory with assets", func() {
+		// given
+		src, err := ioutil.TempDir("test", "src")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(src)
+		dest, err := ioutil.TempDir("", "libasciidoc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dest)
+		Expect(os.MkdirAll(filepath.Join(src, "chapters", "images"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(src, "index.adoc"), []byte("image::images/cookie.png[]\n\nimage::missing.png[]\n\nimage::../outside.png[]"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(src, "chapters", "chapter.adoc"), []byte("image::images/chapter.png[]\n\nimage::https://example.com/remote.png[]"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(src, "chapters", "notes.txt"), []byte("not converted"), 0644)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(src, "images"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(src, "images", "cookie.png"), []byte("cookie"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(src, "chapters", "images", "chapter.png"), []byte("chapter"), 0644)).To(Succeed())
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-D", dest, "--copy-assets", src})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filepath.Join(dest, src, "index.html")).To(BeAnExistingFile())
+		Expect(filepath.Join(dest, src, "chapters", "chapter.html")).To(BeAnExistingFile())
+		Expect(filepath.Join(dest, src, "chapters", "notes.html")).ToNot(BeAnExistingFile())
+		content, err := ioutil.ReadFile(filepath.Join(dest, src, "images", "cookie.png"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("cookie"))
+		content, err = ioutil.ReadFile(filepath.Join(dest, src, "chapters", "images", "chapter.png"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("chapter"))
+		Expect(filepath.Join(dest, src, "missing.png")).ToNot(BeAnExistingFile())
+		Expect(filepath.Join(dest, "test", "outside.png")).ToNot(BeAnExistingFile())
+	})
+
+	It("fail to render in destination directory without creating it", func() {
+		// given
+		dest, err := ioutil.TempDir("", "libasciidoc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dest)
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-D", dest, "--strict", "test/doc_with_unknown_admonition.adoc"})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(filepath.Join(dest, "test")).ToNot(BeADirectory())
+	})
+
+	It("render with auto image dimensions", func() {
+		// given
+		dir, err := ioutil.TempDir("", "libasciidoc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		Expect(ioutil.WriteFile(filepath.Join(dir, "index.adoc"), []byte("image::cookie.png[]"), 0644)).To(Succeed())
+		f, err := os.Create(filepath.Join(dir, "cookie.png"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(png.Encode(f, image.NewGray(image.Rect(0, 0, 16, 9)))).To(Succeed())
+		Expect(f.Close()).To(Succeed())
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "-o", "-", "--auto-image-dims", filepath.Join(dir, "index.adoc")})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring(`<img src="cookie.png" alt="cookie" width="16" height="9">`))
+	})
+
+	It("fail to render with both destination directory and output file", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-o", "-", "-D", "out", "test/test.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fail to copy assets when rendering to STDOUT", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-o", "-", "--copy-assets", "test/test.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fail to render with both output file and output template", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-o", "-", "--out-template", "{{.Name}}.html", "test/test.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fail to render with invalid output template", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"--out-template", "{{.Name", "test/test.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("compare with identical golden file", func() {
+		// given
+		dir, err := ioutil.TempDir("", "libasciidoc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		golden := filepath.Join(dir, "admonition.html")
+		root := main.NewRootCmd()
+		root.SetOutput(new(bytes.Buffer))
+		root.SetArgs([]string{"-s", "-o", golden, "test/admonition.adoc"})
+		Expect(root.Execute()).To(Succeed())
+		root = main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "--compare", golden, "test/admonition.adoc"})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(BeEmpty())
+	})
+
+	It("compare with different golden file", func() {
+		// given
+		dir, err := ioutil.TempDir("", "libasciidoc")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+		golden := filepath.Join(dir, "admonition.html")
+		root := main.NewRootCmd()
+		root.SetOutput(new(bytes.Buffer))
+		root.SetArgs([]string{"-s", "-o", golden, "test/admonition.adoc"})
+		Expect(root.Execute()).To(Succeed())
+		content, err := ioutil.ReadFile(golden)
+		Expect(err).ToNot(HaveOccurred())
+		err = ioutil.WriteFile(golden, bytes.Replace(content, []byte("this is a note"), []byte("this is not a note"), 1), 0644)
+		Expect(err).ToNot(HaveOccurred())
+		root = main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "--compare", golden, "test/admonition.adoc"})
+		// when
+		err = root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(buf.String()).To(HavePrefix("--- " + golden + "\n+++ test/admonition.adoc\n@@ -5,7 +5,7 @@\n"))
+		Expect(buf.String()).To(ContainSubstring("\n-this is not a note\n+this is a note\n"))
+	})
+
+	It("fail to compare with missing golden file", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "--compare", "test/doesnotexist.html", "test/admonition.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fail to compare with multiple files", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"-s", "--compare", "test/test.html", "test/admonition.adoc", "test/test.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("when rendering multiple files, return last error", func() {
 		// given
 		root := main.NewRootCmd()
@@ -140,4 +657,45 @@ var _ = Describe("root cmd", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("dump AST in YAML", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"--dump-ast", "test/admonition.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(HavePrefix("type: Document\nversion: 2\n"))
+		Expect(buf.String()).To(ContainSubstring("- type: Paragraph\n  Attributes:\n    sourceline: 1\n"))
+	})
+
+	It("dump AST in JSON", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"--dump-ast=json", "test/admonition.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf.String()).To(HavePrefix("{\n  \"type\": \"Document\",\n  \"version\": 2,\n"))
+		Expect(buf.String()).To(ContainSubstring(`"type": "Paragraph"`))
+		Expect(buf.String()).To(ContainSubstring(`"sourceline": 1`))
+	})
+
+	It("fail to dump AST in unsupported format", func() {
+		// given
+		root := main.NewRootCmd()
+		buf := new(bytes.Buffer)
+		root.SetOutput(buf)
+		root.SetArgs([]string{"--dump-ast=xml", "test/admonition.adoc"})
+		// when
+		err := root.Execute()
+		// then
+		Expect(err).To(MatchError("unsupported AST format: 'xml'"))
+	})
+
 })
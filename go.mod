@@ -1,8 +1,9 @@
 module github.com/bytesparadise/libasciidoc
 
-go 1.11
+go 1.13
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/alecthomas/chroma v0.7.1
 	github.com/davecgh/go-spew v1.1.1
 	github.com/google/go-cmp v0.5.2 // indirect
@@ -13,7 +14,7 @@ require (
 	github.com/niemeyer/pretty v0.0.0-20200227124842-a10e7caefd8e // indirect
 	github.com/onsi/ginkgo v1.13.0
 	github.com/onsi/gomega v1.10.1
-	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/sergi/go-diff v1.0.0
 	github.com/sirupsen/logrus v1.7.0
 	github.com/sozorogami/gover v0.0.0-20171022184752-b58185e213c5
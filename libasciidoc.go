@@ -3,9 +3,11 @@
 package libasciidoc
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/xhtml5"
@@ -16,7 +18,6 @@ import (
 	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/html5"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	"github.com/bytesparadise/libasciidoc/pkg/validator"
-	"github.com/pkg/errors"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -30,19 +31,25 @@ var (
 	BuildTime = ""
 )
 
+// ErrUnsupportedBackend the error returned when the configured backend is not supported
+var ErrUnsupportedBackend = errors.New("not supported")
+
+// ErrUnsupportedOutputFormat the error returned when the configured output format is not supported
+var ErrUnsupportedOutputFormat = errors.New("unsupported output format")
+
 // ConvertFile converts the content of the given filename into an output document.
 // The conversion result is written in the given writer `output`, whereas the document metadata (title, etc.) (or an error if a problem occurred) is returned
 // as the result of the function call.  The output format is determined by config.Backend (HTML5 default).
 func ConvertFile(output io.Writer, config configuration.Configuration) (types.Metadata, error) {
 	file, err := os.Open(config.Filename)
 	if err != nil {
-		return types.Metadata{}, errors.Wrapf(err, "error opening %s", config.Filename)
+		return types.Metadata{}, fmt.Errorf("error opening %s: %w", config.Filename, err)
 	}
 	defer file.Close()
 	// use the file mtime as the `last updated` value
-	stat, err := os.Stat(config.Filename)
+	stat, err := file.Stat()
 	if err != nil {
-		return types.Metadata{}, errors.Wrapf(err, "error opening %s", config.Filename)
+		return types.Metadata{}, fmt.Errorf("error opening %s: %w", config.Filename, err)
 	}
 	config.LastUpdated = stat.ModTime()
 	return Convert(file, output, config)
@@ -51,31 +58,47 @@ func ConvertFile(output io.Writer, config configuration.Configuration) (types.Me
 // Convert converts the content of the given reader `r` into a full output document, written in the given writer `output`.
 // Returns an error if a problem occurred. The default will be HTML5, but depends on the config.BackEnd value.
 func Convert(r io.Reader, output io.Writer, config configuration.Configuration) (types.Metadata, error) {
-
-	var render func(*renderer.Context, types.Document, io.Writer) (types.Metadata, error)
-	switch config.BackEnd {
-	case "html", "html5", "":
-		render = html5.Render
-	case "xhtml", "xhtml5":
-		render = xhtml5.Render
-	default:
-		return types.Metadata{}, fmt.Errorf("backend '%s' not supported", config.BackEnd)
-	}
-
 	start := time.Now()
 	defer func() {
 		duration := time.Since(start)
 		log.Debugf("rendered the output in %v", duration)
 	}()
+	if _, err := renderFunc(config); err != nil {
+		return types.Metadata{}, err
+	}
+	doc, err := Parse(r, config)
+	if err != nil {
+		return types.Metadata{}, err
+	}
+	return Render(doc, output, config)
+}
+
+// ConvertString converts the given `source` into an output document configured with the given settings,
+// and returns the result along with the document metadata (title, table of contents, attributes, etc.).
+// Returns an error if a problem occurred.
+func ConvertString(source string, settings ...configuration.Setting) (string, types.Metadata, error) {
+	output := &strings.Builder{}
+	metadata, err := Convert(strings.NewReader(source), output, configuration.NewConfiguration(settings...))
+	if err != nil {
+		return "", types.Metadata{}, err
+	}
+	return output.String(), metadata, nil
+}
+
+// Parse parses and validates the content of the given reader `r`, so that the resulting document can then be rendered
+// (multiple times, if needed) with the `Render` function.
+// The attribute overrides of the given configuration are applied when the document is parsed, since they may affect
+// its structure (eg: in conditional inclusions).
+func Parse(r io.Reader, config configuration.Configuration) (types.Document, error) {
 	// log.Debugf("parsing the asciidoc source...")
 	doc, err := parser.ParseDocument(r, config)
 	if err != nil {
-		return types.Metadata{}, err
+		return types.Document{}, err
 	}
 	// validate the document
 	problems, err := validator.Validate(&doc)
 	if err != nil {
-		return types.Metadata{}, err
+		return types.Document{}, err
 	}
 	for _, problem := range problems {
 		switch problem.Severity {
@@ -85,13 +108,56 @@ func Convert(r io.Reader, output io.Writer, config configuration.Configuration)
 			log.Warn(problem.Message)
 		}
 	}
-	// render
+	return doc, nil
+}
+
+// Render renders the given (parsed) document into a full output document, written in the given writer `output`.
+// The document is not modified during the rendering, hence the same document can be rendered multiple times,
+// including concurrently, with different configurations (eg: with different backends, role filters or attributes).
+// Returns an error if a problem occurred. The default will be HTML5, but depends on the config.BackEnd value.
+func Render(doc types.Document, output io.Writer, config configuration.Configuration) (types.Metadata, error) {
+	render, err := renderFunc(config)
+	if err != nil {
+		return types.Metadata{}, err
+	}
 	ctx := renderer.NewContext(doc, config)
 	metadata, err := render(ctx, doc, output)
 	if err != nil {
 		return types.Metadata{}, err
 	}
+	if ctx.InlineCacheEnabled() {
+		hits, misses := ctx.InlineCacheStats()
+		metadata.InlineCache = &types.InlineCacheStats{
+			Hits:   hits,
+			Misses: misses,
+		}
+		log.Debugf("inline cache: %s", metadata.InlineCache)
+	}
 	// log.Debugf("Done processing document")
 	return metadata, nil
+}
+
+// ExtractText returns the plain text of the given (parsed) document, without any markup, split in sections
+// with their ID, level and title (eg: to feed a search index). The first section is the document header (if any) along with
+// the preamble. The content of the code blocks (listing, source, fenced, literal and passthrough blocks) is included only if
+// `includeCode` is `true`.
+func ExtractText(doc types.Document, includeCode bool) []types.TextSection {
+	return doc.Text(includeCode)
+}
 
+// renderFunc returns the function to render a document with the backend and output format of the given configuration
+func renderFunc(config configuration.Configuration) (func(*renderer.Context, types.Document, io.Writer) (types.Metadata, error), error) {
+	switch config.OutputFormat {
+	case configuration.PrettyOutput, configuration.CompactOutput, configuration.MinifiedOutput, "":
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedOutputFormat, config.OutputFormat)
+	}
+	switch config.BackEnd {
+	case "html", "html5", "":
+		return html5.Render, nil
+	case "xhtml", "xhtml5":
+		return xhtml5.Render, nil
+	default:
+		return nil, fmt.Errorf("backend '%s' %w", config.BackEnd, ErrUnsupportedBackend)
+	}
 }
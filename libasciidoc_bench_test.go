@@ -1,8 +1,13 @@
 package libasciidoc_test
 
 import (
+	"bytes"
+	"io/ioutil"
 	"testing"
 
+	"github.com/bytesparadise/libasciidoc"
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
 	"github.com/bytesparadise/libasciidoc/testsupport"
 	"github.com/stretchr/testify/require"
 )
@@ -14,3 +19,61 @@ func BenchmarkLibasciidoc(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+// BenchmarkParse measures the parsing throughput (in MB/s of source document)
+func BenchmarkParse(b *testing.B) {
+	filename := "./test/bench/mocking.adoc"
+	source, err := ioutil.ReadFile(filename)
+	require.NoError(b, err)
+	config := configuration.NewConfiguration(configuration.WithFilename(filename))
+	b.SetBytes(int64(len(source)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := libasciidoc.Parse(bytes.NewReader(source), config)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkRender measures the rendering throughput (in MB/s of source document) of an already parsed document,
+// ie, without the cost of the parsing
+func BenchmarkRender(b *testing.B) {
+	filename := "./test/bench/mocking.adoc"
+	source, err := ioutil.ReadFile(filename)
+	require.NoError(b, err)
+	config := configuration.NewConfiguration(configuration.WithFilename(filename), configuration.WithBackEnd("html5"))
+	doc, err := libasciidoc.Parse(bytes.NewReader(source), config)
+	require.NoError(b, err)
+	b.SetBytes(int64(len(source)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := libasciidoc.Render(doc, ioutil.Discard, config)
+		require.NoError(b, err)
+	}
+}
+
+// BenchmarkParserPool compares the parsing of a small document with a new parser on each call,
+// and with a parser taken from a pool (see the allocations reported with the `-benchmem` flag)
+func BenchmarkParserPool(b *testing.B) {
+	source := []byte(`== a section
+
+a paragraph with *bold* and _italic_ content, and a https://example.com[link].
+
+* an item
+* another item
+`)
+	b.Run("new parser", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := parser.ParseReader("", bytes.NewReader(source), parser.Entrypoint("RawDocument"))
+			require.NoError(b, err)
+		}
+	})
+	b.Run("pooled parser", func(b *testing.B) {
+		p := parser.NewPool()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := p.ParseReader("", bytes.NewReader(source), parser.Entrypoint("RawDocument"))
+			require.NoError(b, err)
+		}
+	})
+}
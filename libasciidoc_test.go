@@ -1,9 +1,13 @@
 package libasciidoc_test
 
 import (
+	"errors"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/bytesparadise/libasciidoc"
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
@@ -52,6 +56,17 @@ var _ = Describe("documents", func() {
 				Expect(MetadataTitle(source)).To(Equal(expectedTitle))
 			})
 
+			It("document with local and remote images", func() {
+				source := `image::images/cookie.png[]
+
+image:images/cookie.png[] image:https://example.com/remote.png[] icon:tip[] image:/absolute/path.png[]
+
+image::data:image/png;base64,AAAA[]`
+				metadata, err := DocumentMetadata(source, lastUpdated)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(metadata.Assets).To(Equal([]string{"images/cookie.png", "images/icons/tip.png"}))
+			})
+
 			It("section levels 0 and 1", func() {
 				source := `= a document title
 
@@ -127,14 +142,8 @@ a paragraph`
 								ID:    "_section_a",
 								Level: 1,
 								Title: "Section A",
-								Children: []types.ToCSection{
-									{
-										ID:       "_section_a_a_a",
-										Level:    3,
-										Title:    "Section A.a.a",
-										Children: []types.ToCSection{},
-									},
-								},
+								// section A.a.a (level 3) is not included since `toclevels` is 2 by default
+								Children: []types.ToCSection{},
 							},
 						},
 					},
@@ -564,9 +573,286 @@ Our story begins.`
 					configuration.WithHeaderFooter(true))
 				Expect(doc).To(BeEmpty())
 				Expect(err).To(MatchError("backend 'wordperfect' not supported"))
+				Expect(errors.Is(err, libasciidoc.ErrUnsupportedBackend)).To(BeTrue())
 			})
 
+			It("should render minified document", func() {
+				source := `= Story
+
+Our story
+begins.`
+				expectedContent := `<!DOCTYPE html><html lang="en"><head><meta charset="UTF-8"><meta http-equiv="X-UA-Compatible" content="IE=edge"><meta name="viewport" content="width=device-width, initial-scale=1.0"><meta name="generator" content="libasciidoc"><title>Story</title></head><body class="article"><div id="header"><h1>Story</h1></div><div id="content"><div class="paragraph"><p>Our story begins.</p></div></div><div id="footer"><div id="footer-text">Last updated {{.LastUpdated}}</div></div></body></html>`
+				Expect(Render(source,
+					configuration.WithOutputFormat(configuration.MinifiedOutput),
+					configuration.WithLastUpdated(lastUpdated),
+					configuration.WithHeaderFooter(true))).To(MatchHTMLTemplate(expectedContent, lastUpdated))
+			})
+
+			It("should fail given bogus output format", func() {
+				source := `= Story
+
+Our story begins.`
+				doc, err := Render(source,
+					configuration.WithOutputFormat("ugly"),
+					configuration.WithLastUpdated(lastUpdated),
+					configuration.WithHeaderFooter(true))
+				Expect(doc).To(BeEmpty())
+				Expect(err).To(MatchError("unsupported output format: 'ugly'"))
+				Expect(errors.Is(err, libasciidoc.ErrUnsupportedOutputFormat)).To(BeTrue())
+			})
+
+		})
+
+	})
+
+	Context("parsed documents", func() {
+
+		source := `= eve(1)
+:doctype: manpage
+
+== Name
+
+[.summary]
+eve - analyzes an image to determine if it's a picture of a life form
+
+== Synopsis
+
+[.internal]
+*eve* [_OPTION_]... _FILE_...
+
+.A picture
+image::eve.png[]`
+
+		parse := func() types.Document {
+			doc, err := libasciidoc.Parse(strings.NewReader(source), configuration.NewConfiguration())
+			Expect(err).NotTo(HaveOccurred())
+			return doc
+		}
+
+		render := func(doc types.Document, settings ...configuration.Setting) string {
+			out := &strings.Builder{}
+			_, err := libasciidoc.Render(doc, out, configuration.NewConfiguration(settings...))
+			Expect(err).NotTo(HaveOccurred())
+			return out.String()
+		}
+
+		It("should render the same document multiple times with different configurations", func() {
+			doc := parse()
+			// when
+			result1 := render(doc)
+			result2 := render(doc, configuration.WithExcludedRoles("internal"), configuration.WithAttribute(types.AttrFigureCaption, "Picture"))
+			result3 := render(doc)
+			// then
+			Expect(result1).To(ContainSubstring(`<strong>eve</strong>`))
+			Expect(result1).To(ContainSubstring(`Figure 1. A picture`))
+			Expect(result2).NotTo(ContainSubstring(`<strong>eve</strong>`))
+			Expect(result2).To(ContainSubstring(`Picture 1. A picture`))
+			Expect(result3).To(Equal(result1))
+			// the document was not modified
+			Expect(doc).To(Equal(parse()))
 		})
 
+		It("should render the same document concurrently", func() {
+			doc := parse()
+			settings := [][]configuration.Setting{
+				{},
+				{configuration.WithExcludedRoles("internal")},
+				{configuration.WithBackEnd("xhtml5"), configuration.WithHeaderFooter(true)},
+				{configuration.WithOutputFormat(configuration.MinifiedOutput)},
+			}
+			expected := make([]string, len(settings))
+			for i := range settings {
+				expected[i] = render(doc, settings[i]...)
+			}
+			// when
+			results := make([]string, 8*len(settings))
+			wg := sync.WaitGroup{}
+			for i := range results {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					defer GinkgoRecover()
+					results[i] = render(doc, settings[i%len(settings)]...)
+				}(i)
+			}
+			wg.Wait()
+			// then
+			for i, r := range results {
+				Expect(r).To(Equal(expected[i%len(settings)]))
+			}
+			Expect(doc).To(Equal(parse()))
+		})
+	})
+
+	Context("text extraction", func() {
+
+		source := `= A *title*
+
+a preamble with a https://example.com[link] and
+some __formatted__ text.
+
+== Section A
+
+* an item
+* another item
+
+// a comment
+
+[source,go]
+----
+fmt.Println("hello")
+----
+
+[#custom]
+=== Section A.1
+
+a paragraph with "quotes" & ampersand.
+
+== Section B
+
+|===
+| a cell | another cell
+|===`
+
+		parse := func() types.Document {
+			doc, err := libasciidoc.Parse(strings.NewReader(source), configuration.NewConfiguration())
+			Expect(err).NotTo(HaveOccurred())
+			return doc
+		}
+
+		It("should extract text without code", func() {
+			expected := []types.TextSection{
+				{
+					ID:    "_a_title",
+					Level: 0,
+					Title: "A title",
+					Text:  "a preamble with a link and some formatted text.",
+				},
+				{
+					ID:    "_section_a",
+					Level: 1,
+					Title: "Section A",
+					Text:  "an item another item",
+				},
+				{
+					ID:    "custom",
+					Level: 2,
+					Title: "Section A.1",
+					Text:  `a paragraph with "quotes" & ampersand.`,
+				},
+				{
+					ID:    "_section_b",
+					Level: 1,
+					Title: "Section B",
+					Text:  "a cell another cell",
+				},
+			}
+			Expect(libasciidoc.ExtractText(parse(), false)).To(Equal(expected))
+		})
+
+		It("should extract text with code", func() {
+			expected := types.TextSection{
+				ID:    "_section_a",
+				Level: 1,
+				Title: "Section A",
+				Text:  "an item another item\nfmt.Println(\"hello\")",
+			}
+			Expect(libasciidoc.ExtractText(parse(), true)).To(ContainElement(expected))
+		})
+	})
+
+	Context("string conversion", func() {
+
+		It("should return the output and the metadata", func() {
+			source := `= A title
+:author: Xavier
+:description: a description
+
+== Section A
+
+some *content*`
+			// when
+			output, metadata, err := libasciidoc.ConvertString(source)
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal(`<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>some <strong>content</strong></p>
+</div>
+</div>
+</div>
+`))
+			Expect(metadata.Title).To(Equal("A title"))
+			Expect(metadata.TableOfContents).To(Equal(types.TableOfContents{
+				Sections: []types.ToCSection{
+					{
+						ID:       "_section_a",
+						Level:    1,
+						Title:    "Section A",
+						Children: []types.ToCSection{},
+					},
+				},
+			}))
+			Expect(metadata.Attributes).To(HaveKeyWithValue("description", "a description"))
+		})
+
+		It("should return the statistics of the inline cache in the metadata", func() {
+			source := `*bold* and *bold* and _italic_`
+			// when
+			_, metadata, err := libasciidoc.ConvertString(source, configuration.WithInlineCache(true))
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metadata.InlineCache).To(Equal(&types.InlineCacheStats{
+				Hits:   1,
+				Misses: 2,
+			}))
+			Expect(metadata.InlineCache.HitRatio()).To(BeNumerically("~", 0.333, 0.001))
+			Expect(metadata.InlineCache.String()).To(Equal("1 hit(s), 2 miss(es) (33.3% hit ratio)"))
+		})
+
+		It("should not return the statistics of the inline cache in the metadata when disabled", func() {
+			_, metadata, err := libasciidoc.ConvertString(`*bold* and *bold*`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metadata.InlineCache).To(BeNil())
+		})
+
+		It("should fail given bogus backend", func() {
+			_, _, err := libasciidoc.ConvertString("content", configuration.WithBackEnd("bogus"))
+			Expect(err).To(MatchError("backend 'bogus' not supported"))
+		})
+	})
+	Context("deterministic output", func() {
+
+		It("should render the attributes of the elements in the same order", func() {
+			source := `[#cover.left.framed,width=200,height=100,link=https://example.com]
+image::cover.png[Cover]
+
+[source,go,id=listing,role=code]
+----
+package main
+----
+
+[#data.striped%header]
+|===
+| a | b
+| c | d
+|===`
+			expected, _, err := libasciidoc.ConvertString(source, configuration.WithSourceMap(true))
+			Expect(err).NotTo(HaveOccurred())
+			// attributes are written in the order defined in the templates: `id`, `class`, `style` then `data-*`
+			Expect(expected).To(ContainSubstring(`<div id="cover" class="imageblock left framed" data-sourceline="2">`))
+			Expect(expected).To(ContainSubstring(`<img src="cover.png" alt="Cover" width="200" height="100">`))
+			Expect(expected).To(ContainSubstring(`<div id="listing" class="listingblock code" data-sourceline="5">`))
+			Expect(expected).To(ContainSubstring(`<code class="language-go" data-lang="go">`))
+			Expect(expected).To(ContainSubstring(`<table id="data" class="tableblock frame-all grid-all stretch striped"`))
+			Expect(expected).To(ContainSubstring(`<col style="width: 50%;">`))
+			for i := 0; i < 10; i++ {
+				output, _, err := libasciidoc.ConvertString(source, configuration.WithSourceMap(true))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(Equal(expected))
+			}
+		})
 	})
 })
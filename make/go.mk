@@ -37,6 +37,7 @@ generate: install-pigeon
 	@if [ "pkg/parser/parser.go" -ot "pkg/parser/parser.peg" ]; then \
 		echo "generating the parser..."; \
 		pigeon ./pkg/parser/parser.peg > ./pkg/parser/parser.go; \
+		cd ./pkg/parser && go run deprecate.go && cd ../..; \
 	else \
 		echo "no need to regenerate the parser."; \
 	fi;
@@ -26,3 +26,10 @@ test-with-coverage: generate-optimized install-ginkgo install-gover
 ## run all fixtures tests
 test-fixtures: generate-optimized
 	@ginkgo -r --randomizeAllSpecs --randomizeSuites --failOnPending --trace --race --compilers=2 -tags=fixtures --focus=fixtures
+
+.PHONY: test-compat
+## compare the output of the documents in the corpus with asciidoctor (or with their golden file) and report the compatibility score
+test-compat: generate-optimized
+	@mkdir -p ./tmp/compat
+	@cd test && COMPAT_REPORT=../tmp/compat/report.md go test -count=1 -tags=compat -run TestTest .
+	@cat ./tmp/compat/report.md
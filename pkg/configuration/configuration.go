@@ -2,6 +2,9 @@ package configuration
 
 import (
 	"time"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/diagram"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
 )
 
 // NewConfiguration returns a new configuration
@@ -9,6 +12,8 @@ func NewConfiguration(settings ...Setting) Configuration {
 	config := Configuration{
 		AttributeOverrides: map[string]string{},
 		Macros:             map[string]MacroTemplate{},
+		BlockMacros:        map[string]BlockMacroProcessor{},
+		Diagrams:           map[string]DiagramRenderer{},
 	}
 	for _, set := range settings {
 		set(&config)
@@ -26,8 +31,91 @@ type Configuration struct {
 	CSS                   string
 	BackEnd               string
 	Macros                map[string]MacroTemplate
+	// BlockMacros the extensions which render the generic block macros (eg: `plantuml::diagram.puml[]`), indexed by macro name
+	BlockMacros map[string]BlockMacroProcessor
+	// Diagrams the extensions which render the listing blocks with a diagram style (eg: `[plantuml]`) as images, indexed by style
+	Diagrams map[string]DiagramRenderer
+	// URLSchemes the additional URL schemes (eg: `file://`, `ssh://`) which are recognized in links
+	URLSchemes []string
+	// URLRewriter the optional function to rewrite the URLs emitted by the renderer (eg: to prefix them with a CDN host)
+	URLRewriter URLRewriter
+	// SectionTitleDecorator the optional function to render some custom content before and after the title of each section
+	// (eg: a link to the section or a button to edit the section)
+	SectionTitleDecorator SectionTitleDecorator
+	// SourceMap flag to emit the line of the source document at which each top-level block starts (as a `data-sourceline` attribute)
+	SourceMap bool
+	// MaxInputSize the maximum number of bytes to read from the source document and from each included file (0 means no limit)
+	MaxInputSize int64
+	// ExcludedRoles the roles of the elements to skip when rendering the document
+	ExcludedRoles []string
+	// IncludedRoles the roles of the elements to render, if they have any role (elements without role are always rendered)
+	IncludedRoles []string
+	// OutputFormat the format of the rendered output: `pretty` (default), `compact` or `minified`
+	OutputFormat string
+	// ShowUnresolvedDirectives flag to replace the file inclusions which cannot be resolved with an `Unresolved directive` paragraph
+	// (and log the error) instead of failing
+	ShowUnresolvedDirectives bool
+	// FrontMatterKinds the kinds of front matter which are parsed at the top of the document,
+	// and whose values are set as document attributes (only YAML if empty)
+	FrontMatterKinds []FrontMatterKind
+	// SkipFrontMatter flag to consume the front matter of any kind at the top of the document,
+	// without setting its values as document attributes
+	SkipFrontMatter bool
+	// Strict flag to fail on deprecated or ambiguous constructs in the source document (eg: tabs after list item markers,
+	// delimited blocks which are not preceded by a blank line or admonitions with an unknown label) instead of accepting them
+	Strict bool
+	// InlineCache flag to reuse the rendered content of the inline elements (eg: icons, images, links or quoted text)
+	// which appear multiple times in the document, instead of rendering them from scratch every time
+	InlineCache bool
+	// AutoImageDimensions flag to read the dimensions of the local images (PNG, JPEG or GIF) which have no `width` and `height` attributes,
+	// so that they can be rendered in the output document
+	AutoImageDimensions bool
+	// HugoShortcodes flag to retain the Hugo shortcodes (eg: `{{< figure src="/cover.png" >}}` or `{{% notice %}}`) as-is,
+	// ie, without applying any substitution on them, so that they can be processed by Hugo once the document is rendered
+	HugoShortcodes bool
 }
 
+// FrontMatterKind the kind of front matter at the top of a document
+type FrontMatterKind string
+
+const (
+	// YAMLFrontMatter the front matter in YAML, delimited with `---` lines
+	YAMLFrontMatter FrontMatterKind = "yaml"
+	// TOMLFrontMatter the front matter in TOML, delimited with `+++` lines
+	TOMLFrontMatter FrontMatterKind = "toml"
+)
+
+// BlockMacroProcessor a function which renders a generic block macro, given its name, target and attributes
+type BlockMacroProcessor func(name, target string, attributes map[string]interface{}) (string, error)
+
+// URLRewriter a function which rewrites a URL of the given kind (eg: `image`, `link`, etc.) before it is rendered
+type URLRewriter func(kind, url string) string
+
+// SectionTitleDecorator a function which returns the content to render before and after the title of the given section,
+// within the heading element. The returned content is rendered as-is (ie, it is not escaped)
+type SectionTitleDecorator func(section types.Section) (before, after string)
+
+const (
+	// LinkURL the kind of URL in links and cross-references to other documents
+	LinkURL string = "link"
+	// ImageURL the kind of URL in block and inline images
+	ImageURL string = "image"
+	// IconURL the kind of URL in icon images
+	IconURL string = "icon"
+	// StylesheetURL the kind of URL of the stylesheet in the document header
+	StylesheetURL string = "stylesheet"
+)
+
+const (
+	// PrettyOutput the default output format, with a newline between each block element
+	PrettyOutput string = "pretty"
+	// CompactOutput the output format without newlines between the elements
+	CompactOutput string = "compact"
+	// MinifiedOutput the compact output format in which the consecutive whitespaces are also collapsed,
+	// except in the verbatim elements (eg: `<pre>`)
+	MinifiedOutput string = "minified"
+)
+
 const (
 	// LastUpdatedFormat key to the time format for the `last updated` document attribute
 	LastUpdatedFormat string = "2006-01-02 15:04:05 -0700"
@@ -91,3 +179,143 @@ func WithMacroTemplate(name string, t MacroTemplate) Setting {
 		config.Macros[name] = t
 	}
 }
+
+// WithBlockMacroProcessor registers the given function to render the generic block macros with the given name
+func WithBlockMacroProcessor(name string, p BlockMacroProcessor) Setting {
+	return func(config *Configuration) {
+		if config.BlockMacros == nil {
+			config.BlockMacros = map[string]BlockMacroProcessor{}
+		}
+		config.BlockMacros[name] = p
+	}
+}
+
+// WithDiagramRenderer registers the given renderer for the listing blocks with the given style (eg: `plantuml`),
+// which are then rendered as images embedded in the document.
+// The rendered images are cached by content, so the same renderer is called only once for identical diagrams
+// (up to `diagram.DefaultCacheSize` images are retained).
+func WithDiagramRenderer(style string, r DiagramRenderer) Setting {
+	c := diagram.NewCachingRenderer(r, diagram.DefaultCacheSize)
+	return func(config *Configuration) {
+		if config.Diagrams == nil {
+			config.Diagrams = map[string]DiagramRenderer{}
+		}
+		config.Diagrams[style] = c
+	}
+}
+
+// WithDiagramCommand registers the given command to render the listing blocks with the given style (eg: `graphviz`)
+// See `NewCommandDiagramRenderer`
+func WithDiagramCommand(style, name string, args ...string) Setting {
+	return WithDiagramRenderer(style, NewCommandDiagramRenderer(name, args...))
+}
+
+// WithURLScheme registers an additional URL scheme (eg: `file://`, `ssh://`, etc.) to recognize in links
+func WithURLScheme(scheme string) Setting {
+	return func(config *Configuration) {
+		config.URLSchemes = append(config.URLSchemes, scheme)
+	}
+}
+
+// WithURLRewriter sets the function to rewrite all URLs emitted by the renderer (links, images, icons and stylesheet)
+func WithURLRewriter(f URLRewriter) Setting {
+	return func(config *Configuration) {
+		config.URLRewriter = f
+	}
+}
+
+// WithSectionTitleDecorator sets the function to render some custom content before and after the title of each section
+func WithSectionTitleDecorator(f SectionTitleDecorator) Setting {
+	return func(config *Configuration) {
+		config.SectionTitleDecorator = f
+	}
+}
+
+// WithSourceMap function to set the `source map` setting in the config
+func WithSourceMap(value bool) Setting {
+	return func(config *Configuration) {
+		config.SourceMap = value
+	}
+}
+
+// WithMaxInputSize function to set the `max input size` setting in the config
+func WithMaxInputSize(size int64) Setting {
+	return func(config *Configuration) {
+		config.MaxInputSize = size
+	}
+}
+
+// WithExcludedRoles function to skip the elements with one of the given roles (eg: `internal-only`) when rendering the document
+func WithExcludedRoles(roles ...string) Setting {
+	return func(config *Configuration) {
+		config.ExcludedRoles = append(config.ExcludedRoles, roles...)
+	}
+}
+
+// WithIncludedRoles function to render only the elements with one of the given roles (eg: `public`),
+// among all elements which have a role
+func WithIncludedRoles(roles ...string) Setting {
+	return func(config *Configuration) {
+		config.IncludedRoles = append(config.IncludedRoles, roles...)
+	}
+}
+
+// WithOutputFormat function to set the `output format` setting in the config,
+// valid values are "pretty", "compact", "minified", and "" (defaults to pretty)
+func WithOutputFormat(format string) Setting {
+	return func(config *Configuration) {
+		config.OutputFormat = format
+	}
+}
+
+// WithShowUnresolvedDirectives function to set the `show unresolved directives` setting in the config
+func WithShowUnresolvedDirectives(value bool) Setting {
+	return func(config *Configuration) {
+		config.ShowUnresolvedDirectives = value
+	}
+}
+
+// WithStrict function to set the `strict` setting in the config
+func WithStrict(value bool) Setting {
+	return func(config *Configuration) {
+		config.Strict = value
+	}
+}
+
+// WithInlineCache function to set the `inlineCache` setting in the config
+func WithInlineCache(value bool) Setting {
+	return func(config *Configuration) {
+		config.InlineCache = value
+	}
+}
+
+// WithAutoImageDimensions function to set the `autoImageDimensions` setting in the config
+func WithAutoImageDimensions(value bool) Setting {
+	return func(config *Configuration) {
+		config.AutoImageDimensions = value
+	}
+}
+
+// WithHugoShortcodes function to set the `hugoShortcodes` setting in the config
+func WithHugoShortcodes(value bool) Setting {
+	return func(config *Configuration) {
+		config.HugoShortcodes = value
+	}
+}
+
+// ParseFrontMatter function to parse the front matter of the given kind at the top of the document and to set its values as document attributes.
+// Only the YAML front matter is parsed by default, and calling this function several times allows for multiple kinds of front matter
+// (eg: YAML and TOML)
+func ParseFrontMatter(kind FrontMatterKind) Setting {
+	return func(config *Configuration) {
+		config.FrontMatterKinds = append(config.FrontMatterKinds, kind)
+	}
+}
+
+// SkipFrontMatter function to consume the front matter of any kind at the top of the document,
+// without setting its values as document attributes (eg: when they are handled by a site generator)
+func SkipFrontMatter() Setting {
+	return func(config *Configuration) {
+		config.SkipFrontMatter = true
+	}
+}
@@ -0,0 +1,18 @@
+package configuration
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/diagram"
+)
+
+// DiagramRenderer the interface of the extensions which render the source of a diagram block (eg: `[plantuml]` or `[graphviz]`)
+// into an image in the given format (`svg` or `png`)
+type DiagramRenderer interface {
+	RenderDiagram(kind, format, source string) ([]byte, error)
+}
+
+// NewCommandDiagramRenderer returns a DiagramRenderer which writes the source of the diagrams on the standard input of
+// the given command and reads the resulting image on its standard output.
+// See `diagram.NewCommandRenderer`
+func NewCommandDiagramRenderer(name string, args ...string) DiagramRenderer {
+	return diagram.NewCommandRenderer(name, args...)
+}
@@ -0,0 +1,126 @@
+// Package expression evaluates the conditions of the `ifdef`, `ifndef` and `ifeval` preprocessor directives
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Attributes the attributes against which the conditions are evaluated
+type Attributes interface {
+	Has(key string) bool
+	GetAsString(key string) (string, bool)
+}
+
+// Defined returns `true` if the given attributes are defined, where the names are separated with `,` when
+// any attribute must be defined (eg: `foo,bar`), or with `+` when all attributes must be defined (eg: `foo+bar`)
+func Defined(names string, attributes Attributes) bool {
+	var all bool
+	var keys []string
+	switch {
+	case strings.Contains(names, ","):
+		keys = strings.Split(names, ",")
+	case strings.Contains(names, "+"):
+		keys = strings.Split(names, "+")
+		all = true
+	default:
+		keys = []string{names}
+		all = true
+	}
+	defined := 0
+	for _, key := range keys {
+		if attributes.Has(key) {
+			defined++
+		}
+	}
+	if all {
+		return defined == len(keys)
+	}
+	return defined > 0
+}
+
+// the comparison operators of the expressions (the 2-char operators must be matched first)
+var comparisonOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// Eval evaluates the given expression (eg: `{sectnumlevels} >= 3`), in which the attribute references are replaced
+// with the values of the given attributes. Both operands are compared as numbers if they are numeric values,
+// otherwise they are compared as strings.
+func Eval(expression string, attributes Attributes) (bool, error) {
+	left, op, right, found := split(expression)
+	if !found {
+		return false, fmt.Errorf("invalid expression: '%s'", expression)
+	}
+	l := evalOperand(left, attributes)
+	r := evalOperand(right, attributes)
+	if lv, err := strconv.ParseFloat(l, 64); err == nil {
+		if rv, err := strconv.ParseFloat(r, 64); err == nil {
+			return compare(op, lv-rv), nil
+		}
+	}
+	return compare(op, float64(strings.Compare(l, r))), nil
+}
+
+// split splits the given expression in a left operand, a comparison operator and a right operand.
+// The operators within quoted strings are ignored.
+func split(expression string) (string, string, string, bool) {
+	var quote rune
+	for i, r := range expression {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		default:
+			for _, op := range comparisonOperators {
+				if strings.HasPrefix(expression[i:], op) {
+					return expression[:i], op, expression[i+len(op):], true
+				}
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// evalOperand returns the value of the given operand, with its attribute references replaced with their values
+// (or with an empty string if the attribute is not set), and without its surrounding quotes
+func evalOperand(operand string, attributes Attributes) string {
+	operand = strings.TrimSpace(operand)
+	if len(operand) >= 2 && (operand[0] == '"' || operand[0] == '\'') && operand[len(operand)-1] == operand[0] {
+		operand = operand[1 : len(operand)-1]
+	}
+	result := &strings.Builder{}
+	for {
+		start := strings.Index(operand, "{")
+		end := strings.Index(operand, "}")
+		if start < 0 || end < start {
+			break
+		}
+		result.WriteString(operand[:start])
+		value, _ := attributes.GetAsString(operand[start+1 : end])
+		result.WriteString(value)
+		operand = operand[end+1:]
+	}
+	result.WriteString(operand)
+	return strings.TrimSpace(result.String())
+}
+
+// compare returns the result of the given comparison operator, given the difference between the left and right operands
+func compare(op string, diff float64) bool {
+	switch op {
+	case "==":
+		return diff == 0
+	case "!=":
+		return diff != 0
+	case "<":
+		return diff < 0
+	case "<=":
+		return diff <= 0
+	case ">":
+		return diff > 0
+	default: // ">="
+		return diff >= 0
+	}
+}
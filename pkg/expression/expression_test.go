@@ -0,0 +1,56 @@
+package expression_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/expression"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo"                  //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
+)
+
+var _ = Describe("expressions", func() {
+
+	attributes := types.AttributesWithOverrides{
+		Content: map[string]interface{}{
+			"foo":           "",
+			"bar":           "",
+			"sectnumlevels": "4",
+			"backend":       "html5",
+		},
+		Overrides: map[string]string{},
+	}
+
+	DescribeTable("should check if attributes are defined",
+		func(names string, expected bool) {
+			Expect(expression.Defined(names, attributes)).To(Equal(expected))
+		},
+		Entry("foo", "foo", true),
+		Entry("unknown", "unknown", false),
+		Entry("foo,unknown", "foo,unknown", true),
+		Entry("unknown,other", "unknown,other", false),
+		Entry("foo+unknown", "foo+unknown", false),
+		Entry("foo+bar", "foo+bar", true),
+	)
+
+	DescribeTable("should evaluate",
+		func(expr string, expected bool) {
+			result, err := expression.Eval(expr, attributes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("{sectnumlevels} >= 3", "{sectnumlevels} >= 3", true),
+		Entry("{sectnumlevels} < 3", "{sectnumlevels} < 3", false),
+		Entry("{sectnumlevels} == 4", "{sectnumlevels} == 4", true),
+		Entry("{sectnumlevels} <= 10 (numbers)", "{sectnumlevels} <= 10", true),
+		Entry(`"{backend}" == "html5"`, `"{backend}" == "html5"`, true),
+		Entry(`"{backend}" != "html5"`, `"{backend}" != "html5"`, false),
+		Entry(`"{unknown}" == ""`, `"{unknown}" == ""`, true),
+		Entry(`"a == b" == 'a == b' (operator in quotes)`, `"a == b" == 'a == b'`, true),
+	)
+
+	It("should fail to evaluate expression without operator", func() {
+		_, err := expression.Eval("{sectnumlevels}", attributes)
+		Expect(err).To(MatchError("invalid expression: '{sectnumlevels}'"))
+	})
+})
@@ -517,6 +517,16 @@ var _ = DescribeTable("valid block attributes",
 			types.AttrOptions: []interface{}{"hardbreaks"},
 		},
 	),
+	Entry(`[%header,options="footer, autowidth",opts=header]`, `[%header,options="footer, autowidth",opts=header]`,
+		types.Attributes{
+			types.AttrOptions: []interface{}{"header", "footer", "autowidth"},
+		},
+	),
+	Entry("multiple options", "[opts=nowrap]\n[%header]",
+		types.Attributes{
+			types.AttrOptions: []interface{}{"nowrap", "header"},
+		},
+	),
 
 	// id (alone)
 	Entry(`[#an_id]`, `[#an_id]`,
@@ -558,9 +568,23 @@ var _ = DescribeTable("valid block attributes",
 		},
 	),
 
-	Entry(`[[here, an id]]`, `[[here, an id]]`,
+	Entry(`[[here, a reftext]]`, `[[here, a reftext]]`,
 		types.Attributes{
-			types.AttrID: `here, an id`,
+			types.AttrID:      `here`,
+			types.AttrReftext: `a reftext`,
+		},
+	),
+	Entry(`[[here,a reftext with {attr}]]`, `[[here,a reftext with {attr}]]`,
+		types.Attributes{
+			types.AttrID: `here`,
+			types.AttrReftext: []interface{}{
+				types.StringElement{
+					Content: "a reftext with ",
+				},
+				types.AttributeSubstitution{
+					Name: "attr",
+				},
+			},
 		},
 	),
 	Entry(`[[another id.not_a_role]]`, `[[another id.not_a_role]]`,
@@ -110,6 +110,65 @@ with some content linked to <<thetitle,a label to the title>>!`
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
+
+			It("cross references to inline anchors", func() {
+				source := `an [[first]]anchor and [[second,the second anchor]]another one.
+
+see <<first>> and <<second>>`
+				expected := types.Document{
+					ElementReferences: types.ElementReferences{
+						"first": nil, // no reftext
+						"second": []interface{}{
+							types.StringElement{
+								Content: "the second anchor",
+							},
+						},
+					},
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "an ",
+									},
+									types.InlineAnchor{
+										ID: "first",
+									},
+									types.StringElement{
+										Content: "anchor and ",
+									},
+									types.InlineAnchor{
+										ID:      "second",
+										RefText: "the second anchor",
+									},
+									types.StringElement{
+										Content: "another one.",
+									},
+								},
+							},
+						},
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "see ",
+									},
+									types.InternalCrossReference{
+										ID: "first",
+									},
+									types.StringElement{
+										Content: " and ",
+									},
+									types.InternalCrossReference{
+										ID: "second",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
 		})
 
 		Context("external references", func() {
@@ -154,6 +213,40 @@ with some content linked to <<thetitle,a label to the title>>!`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("external cross reference to other doc with label and role", func() {
+				source := `some content linked to xref:another-doc.adoc[another doc,role=external]!`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "some content linked to ",
+									},
+									types.ExternalCrossReference{
+										Location: types.Location{
+											Path: []interface{}{
+												types.StringElement{
+													Content: "another-doc.adoc",
+												},
+											},
+										},
+										Label: "another doc",
+										Attributes: types.Attributes{
+											types.AttrRoles: []interface{}{"external"},
+										},
+									},
+									types.StringElement{
+										Content: "!",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("external cross reference to other doc with document attribute in location", func() {
 				source := `some content linked to xref:{foo}.adoc[another doc]!`
 				expected := types.Document{
@@ -191,8 +284,8 @@ with some content linked to <<thetitle,a label to the title>>!`
 
 some content linked to xref:{foo}[another_doc()]!`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"foo": "another-doc.adoc",
+					Attributes: types.DocumentAttributes{
+						{Name: "foo", Value: "another-doc.adoc"},
 					},
 					Elements: []interface{}{
 						types.Paragraph{
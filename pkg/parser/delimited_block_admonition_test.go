@@ -134,7 +134,6 @@ paragraphs
 										},
 									},
 								},
-								types.BlankLine{},
 								types.Paragraph{
 									Lines: [][]interface{}{
 										{
@@ -150,6 +149,62 @@ paragraphs
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
+
+			It("example block as admonition with nested blocks", func() {
+				source := `[NOTE]
+====
+a paragraph
+
+----
+some listing
+----
+
+TIP: a nested admonition
+====
+`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.ExampleBlock{
+							Attributes: types.Attributes{
+								types.AttrStyle: types.Note,
+							},
+							Elements: []interface{}{
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{
+												Content: "a paragraph",
+											},
+										},
+									},
+								},
+								types.ListingBlock{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{
+												Content: "some listing",
+											},
+										},
+									},
+								},
+								types.Paragraph{
+									Attributes: types.Attributes{
+										types.AttrStyle: types.Tip,
+									},
+									Lines: [][]interface{}{
+										{
+											types.StringElement{
+												Content: "a nested admonition",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
 		})
 	})
 })
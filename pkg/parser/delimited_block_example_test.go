@@ -354,7 +354,6 @@ with *bold content*
 										},
 									},
 								},
-								types.BlankLine{},
 								types.UnorderedList{
 									Items: []types.UnorderedListItem{
 										{
@@ -401,6 +400,12 @@ End of file here`
 							},
 						},
 					},
+					Warnings: []types.SourceWarning{
+						{
+							Line:    1,
+							Message: "unterminated example block: the '====' delimiter at line 1 is not closed before the end of the document",
+						},
+					},
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
@@ -441,6 +446,88 @@ foo
 							Elements: []interface{}{},
 						},
 					},
+					Warnings: []types.SourceWarning{
+						{
+							Line:    1,
+							Message: "unterminated example block: the '====' delimiter at line 1 is not closed before the end of the document",
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("with nested example block using a longer delimiter", func() {
+				source := `====
+outer
+
+.nested
+======
+inner
+======
+
+====`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.ExampleBlock{
+							Elements: []interface{}{
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{
+												Content: "outer",
+											},
+										},
+									},
+								},
+								types.ExampleBlock{
+									Attributes: types.Attributes{
+										types.AttrTitle: "nested",
+									},
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{
+														Content: "inner",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("with nested example block using a shorter delimiter", func() {
+				source := `======
+====
+inner
+====
+======`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.ExampleBlock{
+							Elements: []interface{}{
+								types.ExampleBlock{
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{
+														Content: "inner",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
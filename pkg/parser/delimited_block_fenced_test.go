@@ -433,6 +433,12 @@ var _ = Describe("fenced blocks", func() {
 							},
 						},
 					},
+					Warnings: []types.SourceWarning{
+						{
+							Line:    1,
+							Message: "unterminated fenced block: the '```' delimiter at line 1 is not closed before the end of the document",
+						},
+					},
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
@@ -571,6 +571,58 @@ in the middle
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("with lines which look like delimiters of another length", func() {
+				source := `----
++------+
+---------
+ ----
+----`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.ListingBlock{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "+------+",
+									},
+								},
+								{
+									types.StringElement{
+										Content: "---------",
+									},
+								},
+								{
+									types.StringElement{
+										Content: " ----",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("with longer delimiters", func() {
+				source := `------
+----
+------`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.ListingBlock{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "----",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("with unrendered list", func() {
 				source := `----
 * some 
@@ -692,6 +744,12 @@ End of file here.`
 							},
 						},
 					},
+					Warnings: []types.SourceWarning{
+						{
+							Line:    1,
+							Message: "unterminated listing block: the '----' delimiter at line 1 is not closed before the end of the document",
+						},
+					},
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
@@ -738,6 +796,52 @@ import <1>
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("with single callout and title on callout list", func() {
+				source := `----
+import <1>
+----
+.Callouts
+<1> an import`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.ListingBlock{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "import ",
+									},
+									types.Callout{
+										Ref: 1,
+									},
+								},
+							},
+						},
+						types.CalloutList{
+							Attributes: types.Attributes{
+								types.AttrTitle: "Callouts",
+							},
+							Items: []types.CalloutListItem{
+								{
+									Ref: 1,
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{
+														Content: "an import",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("with multiple callouts on different lines", func() {
 				source := `----
 import <1>
@@ -72,6 +72,33 @@ lines.`
 				Expect(result).To(MatchDraftDocument(expected))
 			})
 
+			It("literal block from paragraph with tab on first line", func() {
+				source := "\tsome literal content\non 2 lines."
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.LiteralBlock{
+							Attributes: types.Attributes{
+								types.AttrStyle:            types.Literal,
+								types.AttrLiteralBlockType: types.LiteralBlockWithSpacesOnFirstLine,
+							},
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "\tsome literal content",
+									},
+								},
+								{
+									types.StringElement{
+										Content: "on 2 lines.",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
 			It("mixing literal block with attributes followed by a paragraph ", func() {
 				source := `.title
 [#ID]
@@ -260,6 +287,48 @@ a normal paragraph.`
 			})
 		})
 
+		Context("paragraphs with normal style", func() {
+
+			It("indented paragraph with normal style", func() {
+				source := `[normal]
+  some *normal* content
+    on two lines.`
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Attributes: types.Attributes{
+								types.AttrStyle: types.Normal,
+							},
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "some ",
+									},
+									types.QuotedText{
+										Kind: types.SingleQuoteBold,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "normal",
+											},
+										},
+									},
+									types.StringElement{
+										Content: " content",
+									},
+								},
+								{
+									types.StringElement{
+										Content: "  on two lines.",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+		})
+
 		Context("with custom substitutions", func() {
 
 			// testing custom substitutions on a literal block only
@@ -641,6 +641,12 @@ foo
 							},
 						},
 					},
+					Warnings: []types.SourceWarning{
+						{
+							Line:    2,
+							Message: "unterminated quote block: the '____' delimiter at line 2 is not closed before the end of the document",
+						},
+					},
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
@@ -249,7 +249,6 @@ bar
 										},
 									},
 								},
-								types.BlankLine{}, // blankline is required between paragraph and the next block
 								types.ListingBlock{
 									Lines: [][]interface{}{
 										{
@@ -363,7 +362,6 @@ bar
 										},
 									},
 								},
-								types.BlankLine{}, // blankline is required between paragraph and the next block
 								types.ListingBlock{
 									Lines: [][]interface{}{
 										{
@@ -400,6 +398,46 @@ bar
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("with nested sidebar block using a longer delimiter", func() {
+				source := `****
+outer
+
+*****
+inner
+*****
+****`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.SidebarBlock{
+							Elements: []interface{}{
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{
+												Content: "outer",
+											},
+										},
+									},
+								},
+								types.SidebarBlock{
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{
+														Content: "inner",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
 		})
 	})
 })
@@ -233,6 +233,60 @@ const Cookie = "cookie"
 				}
 				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 			})
+
+			It("with highlighted lines", func() {
+				source := `[source,go,highlight=2..3;1]
+----
+const Cookie = "cookie"
+----`
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.ListingBlock{
+							Attributes: types.Attributes{
+								types.AttrStyle:    types.Source,
+								types.AttrLanguage: "go",
+								types.AttrHighlight: types.LineRanges{
+									{StartLine: 1, EndLine: 1},
+									{StartLine: 2, EndLine: 3},
+								},
+							},
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: `const Cookie = "cookie"`,
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("with invalid highlighted lines", func() {
+				source := `[source,go,highlight=foo]
+----
+const Cookie = "cookie"
+----`
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.ListingBlock{
+							Attributes: types.Attributes{
+								types.AttrStyle:    types.Source,
+								types.AttrLanguage: "go",
+							},
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: `const Cookie = "cookie"`,
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
 		})
 	})
 
@@ -343,6 +397,11 @@ get '/hi' do
 end
 ----`
 				expected := types.Document{
+					ElementReferences: types.ElementReferences{
+						"id-for-source-block": []interface{}{
+							types.StringElement{Content: "app.rb"},
+						},
+					},
 					Elements: []interface{}{
 						types.ListingBlock{
 							Attributes: types.Attributes{
@@ -0,0 +1,42 @@
+//go:build ignore
+// +build ignore
+
+// deprecate adds a deprecation notice on the `Parse` function generated by pigeon,
+// since the parser should be given an `io.Reader` instead of a slice of bytes.
+// Usage: go run deprecate.go (in the directory of the generated `parser.go` file)
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	parseDoc = "// Parse parses the data from b using filename as information in the\n// error messages.\n"
+
+	deprecationNotice = "//\n// Deprecated: use ParseReader (or Pool.ParseReader) instead, along with the MaxInputSize option\n// to limit the size of the data to parse.\n"
+)
+
+const filename = "parser.go"
+
+func main() {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if bytes.Contains(content, []byte(parseDoc+deprecationNotice)) {
+		return // already deprecated
+	}
+	if !bytes.Contains(content, []byte(parseDoc+"func Parse(")) {
+		fmt.Fprintf(os.Stderr, "unable to find the 'Parse' function in %s\n", filename)
+		os.Exit(1)
+	}
+	content = bytes.Replace(content, []byte(parseDoc+"func Parse("), []byte(parseDoc+deprecationNotice+"func Parse("), 1)
+	if err := ioutil.WriteFile(filename, content, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	. "github.com/onsi/ginkgo" //nolint golint
 	. "github.com/onsi/gomega" //nolint golint
+	log "github.com/sirupsen/logrus"
 )
 
 var _ = Describe("document attributes", func() {
@@ -60,19 +61,19 @@ John  Foo    Doe  <johndoe@example.com>`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "John Foo Doe"},
+							{Name: "authorinitials", Value: "JFD"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "John Foo Doe",
 									Email:    "johndoe@example.com",
 								},
-							},
-							"firstname":      "John",
-							"middlename":     "Foo",
-							"lastname":       "Doe",
-							"author":         "John Foo Doe",
-							"authorinitials": "JFD",
-							"email":          "johndoe@example.com",
+							}},
+							{Name: "email", Value: "johndoe@example.com"},
+							{Name: "firstname", Value: "John"},
+							{Name: "lastname", Value: "Doe"},
+							{Name: "middlename", Value: "Foo"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -100,18 +101,18 @@ Jane the_Doe <jane@example.com>`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "Jane the Doe"},
+							{Name: "authorinitials", Value: "Jt"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "Jane the Doe",
 									Email:    "jane@example.com",
 								},
-							},
-							"firstname":      "Jane",
-							"lastname":       "the Doe",
-							"author":         "Jane the Doe",
-							"authorinitials": "Jt",
-							"email":          "jane@example.com",
+							}},
+							{Name: "email", Value: "jane@example.com"},
+							{Name: "firstname", Value: "Jane"},
+							{Name: "lastname", Value: "the Doe"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -139,19 +140,19 @@ Jane Foo the Doe <jane@example.com>`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "Jane Foo the Doe"},
+							{Name: "authorinitials", Value: "JFt"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "Jane Foo the Doe",
 									Email:    "jane@example.com",
 								},
-							},
-							"firstname":      "Jane",
-							"middlename":     "Foo",
-							"lastname":       "the Doe",
-							"author":         "Jane Foo the Doe",
-							"authorinitials": "JFt",
-							"email":          "jane@example.com",
+							}},
+							{Name: "email", Value: "jane@example.com"},
+							{Name: "firstname", Value: "Jane"},
+							{Name: "lastname", Value: "the Doe"},
+							{Name: "middlename", Value: "Foo"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -179,16 +180,16 @@ John Doe`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "John Doe"},
+							{Name: "authorinitials", Value: "JD"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "John Doe",
 								},
-							},
-							"firstname":      "John",
-							"lastname":       "Doe",
-							"author":         "John Doe",
-							"authorinitials": "JD",
+							}},
+							{Name: "firstname", Value: "John"},
+							{Name: "lastname", Value: "Doe"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -216,15 +217,15 @@ Doe`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "Doe"},
+							{Name: "authorinitials", Value: "D"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "Doe",
 								},
-							},
-							"firstname":      "Doe",
-							"author":         "Doe",
-							"authorinitials": "D",
+							}},
+							{Name: "firstname", Value: "Doe"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -252,17 +253,17 @@ Doe`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "John Foo Doe"},
+							{Name: "authorinitials", Value: "JFD"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "John Foo Doe",
 								},
-							},
-							"firstname":      "John",
-							"middlename":     "Foo",
-							"lastname":       "Doe",
-							"author":         "John Foo Doe",
-							"authorinitials": "JFD",
+							}},
+							{Name: "firstname", Value: "John"},
+							{Name: "lastname", Value: "Doe"},
+							{Name: "middlename", Value: "Foo"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -293,8 +294,12 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "John Foo Doe"},
+							{Name: "author_2", Value: "Jane the Doe"},
+							{Name: "authorinitials", Value: "JFD"},
+							{Name: "authorinitials_2", Value: "Jt"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "John Foo Doe",
 									Email:    "johndoe@example.com",
@@ -303,18 +308,14 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 									FullName: "Jane the Doe",
 									Email:    "jane@example.com",
 								},
-							},
-							"firstname":        "John",
-							"middlename":       "Foo",
-							"lastname":         "Doe",
-							"author":           "John Foo Doe",
-							"authorinitials":   "JFD",
-							"email":            "johndoe@example.com",
-							"firstname_2":      "Jane",
-							"lastname_2":       "the Doe",
-							"author_2":         "Jane the Doe",
-							"authorinitials_2": "Jt",
-							"email_2":          "jane@example.com",
+							}},
+							{Name: "email", Value: "johndoe@example.com"},
+							{Name: "email_2", Value: "jane@example.com"},
+							{Name: "firstname", Value: "John"},
+							{Name: "firstname_2", Value: "Jane"},
+							{Name: "lastname", Value: "Doe"},
+							{Name: "lastname_2", Value: "the Doe"},
+							{Name: "middlename", Value: "Foo"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -372,8 +373,12 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "John Foo Doe"},
+							{Name: "author_2", Value: "Jane the Doe"},
+							{Name: "authorinitials", Value: "JFD"},
+							{Name: "authorinitials_2", Value: "Jt"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "John Foo Doe",
 									Email:    "johndoe@example.com",
@@ -382,18 +387,14 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 									FullName: "Jane the Doe",
 									Email:    "jane@example.com",
 								},
-							},
-							"firstname":        "John",
-							"middlename":       "Foo",
-							"lastname":         "Doe",
-							"author":           "John Foo Doe",
-							"authorinitials":   "JFD",
-							"email":            "johndoe@example.com",
-							"firstname_2":      "Jane",
-							"lastname_2":       "the Doe",
-							"author_2":         "Jane the Doe",
-							"authorinitials_2": "Jt",
-							"email_2":          "jane@example.com",
+							}},
+							{Name: "email", Value: "johndoe@example.com"},
+							{Name: "email_2", Value: "jane@example.com"},
+							{Name: "firstname", Value: "John"},
+							{Name: "firstname_2", Value: "Jane"},
+							{Name: "lastname", Value: "Doe"},
+							{Name: "lastname_2", Value: "the Doe"},
+							{Name: "middlename", Value: "Foo"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -424,8 +425,12 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							types.AttrAuthors: []types.DocumentAuthor{
+						Attributes: types.DocumentAttributes{
+							{Name: "author", Value: "John Foo Doe"},
+							{Name: "author_2", Value: "Jane the Doe"},
+							{Name: "authorinitials", Value: "JFD"},
+							{Name: "authorinitials_2", Value: "Jt"},
+							{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 								{
 									FullName: "John Foo Doe",
 									Email:    "johndoe@example.com",
@@ -434,18 +439,14 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 									FullName: "Jane the Doe",
 									Email:    "jane@example.com",
 								},
-							},
-							"firstname":        "John",
-							"middlename":       "Foo",
-							"lastname":         "Doe",
-							"author":           "John Foo Doe",
-							"authorinitials":   "JFD",
-							"email":            "johndoe@example.com",
-							"firstname_2":      "Jane",
-							"lastname_2":       "the Doe",
-							"author_2":         "Jane the Doe",
-							"authorinitials_2": "Jt",
-							"email_2":          "jane@example.com",
+							}},
+							{Name: "email", Value: "johndoe@example.com"},
+							{Name: "email_2", Value: "jane@example.com"},
+							{Name: "firstname", Value: "John"},
+							{Name: "firstname_2", Value: "Jane"},
+							{Name: "lastname", Value: "Doe"},
+							{Name: "lastname_2", Value: "the Doe"},
+							{Name: "middlename", Value: "Foo"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_title": title,
@@ -478,24 +479,24 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "revdate", Value: "March 29, 2020"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
 							Revdate:   "March 29, 2020",
 							Revremark: "Updated revision",
-						},
-						"revnumber": "1.0",
-						"revdate":   "March 29, 2020",
-						"revremark": "Updated revision",
+						}},
+						{Name: "revnumber", Value: "1.0"},
+						{Name: "revremark", Value: "Updated revision"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -525,24 +526,24 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "revdate", Value: "March 29, 2020"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
 							Revdate:   "March 29, 2020",
 							Revremark: "Updated revision",
-						},
-						"revnumber": "1.0",
-						"revdate":   "March 29, 2020",
-						"revremark": "Updated revision",
+						}},
+						{Name: "revnumber", Value: "1.0"},
+						{Name: "revremark", Value: "Updated revision"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -572,24 +573,24 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "revdate", Value: "March 29, 2020"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
 							Revdate:   "March 29, 2020",
 							Revremark: "Updated revision",
-						},
-						"revnumber": "1.0",
-						"revdate":   "March 29, 2020",
-						"revremark": "Updated revision",
+						}},
+						{Name: "revnumber", Value: "1.0"},
+						{Name: "revremark", Value: "Updated revision"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -618,22 +619,22 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "revdate", Value: "March 29, 2020"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
 							Revdate:   "March 29, 2020",
-						},
-						"revnumber": "1.0",
-						"revdate":   "March 29, 2020",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -662,22 +663,22 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "revdate", Value: "March 29, 2020"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
 							Revdate:   "March 29, 2020",
-						},
-						"revnumber": "1.0",
-						"revdate":   "March 29, 2020",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -705,20 +706,20 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
-						},
-						"revnumber": "1.0",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -747,20 +748,20 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "revdate", Value: "1.0"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revdate: "1.0",
-						},
-						"revdate": "1.0",
+						}},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -789,20 +790,20 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "revdate", Value: "1.0a"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revdate: "1.0a",
-						},
-						"revdate": "1.0a",
+						}},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -831,20 +832,20 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
-						},
-						"revnumber": "1.0",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -873,20 +874,20 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
-						},
-						"revnumber": "1.0",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -915,20 +916,20 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
-						},
-						"revnumber": "1.0",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -957,20 +958,20 @@ John  Foo Doe  <johndoe@example.com>; Jane the_Doe <jane@example.com>`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
-						},
-						"revnumber": "1.0",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_title": title,
@@ -999,20 +1000,20 @@ v1.0:`
 					},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Doe"},
+						{Name: "authorinitials", Value: "JD"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Doe",
 							},
-						},
-						"firstname":      "John",
-						"lastname":       "Doe",
-						"author":         "John Doe",
-						"authorinitials": "JD",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "firstname", Value: "John"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
-						},
-						"revnumber": "1.0",
+						}},
+						{Name: "revnumber", Value: "1.0"},
 						// "revremark": "", // found but is empty
 					},
 					ElementReferences: types.ElementReferences{
@@ -1044,19 +1045,48 @@ v1.0:`
 :0Author: Xavier
 :Auth0r: Xavier`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"a":       nil,
-						"author":  "Xavier",
-						"_author": "Xavier",
-						"Author":  "Xavier",
-						"0Author": "Xavier",
-						"Auth0r":  "Xavier",
+					Attributes: types.DocumentAttributes{
+						{Name: "a", Value: nil},
+						{Name: "author", Value: "Xavier"},
+						{Name: "_author", Value: "Xavier"},
+						{Name: "Author", Value: "Xavier"},
+						{Name: "0Author", Value: "Xavier"},
+						{Name: "Auth0r", Value: "Xavier"},
 					},
 					Elements: []interface{}{},
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("multi-line attribute values", func() {
+				source := `:description: a description \
+  on multiple \
+lines
+:hardbreak: a line + \
+another line
+:legacy: a value +
+continued
+:trailing: a value \
+
+a paragraph`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: "description", Value: "a description on multiple lines"},
+						{Name: "hardbreak", Value: "a line +\nanother line"},
+						{Name: "legacy", Value: "a value continued"},
+						{Name: "trailing", Value: "a value"},
+					},
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{types.StringElement{Content: "a paragraph"}},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("attributes and paragraph without blank line in-between", func() {
 				source := `:toc:
 :date:  2017-01-01
@@ -1064,11 +1094,11 @@ v1.0:`
 :hardbreaks:
 a paragraph`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"toc":                        nil,
-						"date":                       "2017-01-01",
-						"author":                     "Xavier",
-						types.DocumentAttrHardBreaks: nil,
+					Attributes: types.DocumentAttributes{
+						{Name: "toc", Value: nil},
+						{Name: "date", Value: "2017-01-01"},
+						{Name: "author", Value: "Xavier"},
+						{Name: types.DocumentAttrHardBreaks, Value: nil},
 					},
 					Elements: []interface{}{
 						types.TableOfContentsPlaceHolder{},
@@ -1089,10 +1119,10 @@ a paragraph`
 
 a paragraph`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"toc":    nil,
-						"date":   "2017-01-01",
-						"author": "Xavier",
+					Attributes: types.DocumentAttributes{
+						{Name: "toc", Value: nil},
+						{Name: "date", Value: "2017-01-01"},
+						{Name: "author", Value: "Xavier"},
 					},
 					Elements: []interface{}{
 						types.TableOfContentsPlaceHolder{},
@@ -1116,14 +1146,22 @@ a paragraph`
 
 a paragraph`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"toc":        nil,
-						"date":       "2017-01-01",
-						"author":     "Xavier",
-						"hardbreaks": nil,
+					Attributes: types.DocumentAttributes{
+						{Name: "toc", Value: nil},
+						{Name: "date", Value: "2017-01-01"},
+						{Name: "author", Value: "Xavier", Body: true},
+						{Name: "hardbreaks", Value: nil, Body: true},
 					},
 					Elements: []interface{}{
 						types.TableOfContentsPlaceHolder{},
+						// attributes declared in the body are applied in order during the rendering
+						types.AttributeDeclaration{
+							Name:  "author",
+							Value: "Xavier",
+						},
+						types.AttributeDeclaration{
+							Name: "hardbreaks",
+						},
 						types.Paragraph{
 							Lines: [][]interface{}{
 								{types.StringElement{Content: "a paragraph"}},
@@ -1141,22 +1179,71 @@ a paragraph`
 :date: 2017-01-01
 :author: Xavier`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"toc":    nil,
-						"date":   "2017-01-01",
-						"author": "Xavier",
+					Attributes: types.DocumentAttributes{
+						// `toc` can only be set in the header
+						{Name: "date", Value: "2017-01-01", Body: true},
+						{Name: "author", Value: "Xavier", Body: true},
 					},
 					Elements: []interface{}{
-						types.TableOfContentsPlaceHolder{},
 						types.Paragraph{
 							Lines: [][]interface{}{
 								{types.StringElement{Content: "a paragraph"}},
 							},
 						},
+						// `toc` can only be set in the header
+						types.AttributeDeclaration{
+							Name:  "date",
+							Value: "2017-01-01",
+						},
+						types.AttributeDeclaration{
+							Name:  "author",
+							Value: "Xavier",
+						},
 					},
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
+			It("should warn when a header-only attribute is set in the body", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `a paragraph
+
+:toc:`
+				_, err := ParseDocument(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "attribute 'toc' can only be set in the document header"))
+			})
+
+			It("should warn when a header-only attribute is reset in the body", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `= Title
+:toc:
+
+a paragraph
+
+:toc!:`
+				_, err := ParseDocument(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "attribute 'toc' can only be reset in the document header"))
+			})
+
+			It("should not warn when header attributes are interleaved with comments", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `= Title
+// a single-line comment
+:toc:
+////
+a comment block
+////
+:description: a description
+
+a paragraph`
+				_, err := ParseDocument(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).ToNot(ContainAnyMessageWithLevels(log.ErrorLevel, log.WarnLevel))
+			})
 		})
 
 		Context("document attribute substitutions", func() {
@@ -1166,8 +1253,8 @@ a paragraph`
 			
 a paragraph written by {author}.`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"author": "Xavier",
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "Xavier"},
 					},
 					Elements: []interface{}{
 						types.Paragraph{
@@ -1180,6 +1267,34 @@ a paragraph written by {author}.`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("paragraph with attribute substitution with underscores in name", func() {
+				source := `:first_name: Xavier
+			
+a paragraph written by {first_name}, with {curly braces}, {$100} and \{first_name}.`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: "first_name", Value: "Xavier"},
+					},
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a paragraph written by Xavier, with {curly braces}, {$100} and "},
+									types.InlinePassthrough{
+										Kind: types.EscapedPassthrough,
+										Elements: []interface{}{
+											types.StringElement{Content: "{first_name}"},
+										},
+									},
+									types.StringElement{Content: "."},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("paragraph with attribute resets", func() {
 				source := `:author: Xavier
 							
@@ -1187,12 +1302,18 @@ a paragraph written by {author}.`
 :author2!:
 a paragraph written by {author}.`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"author":  "Xavier",
-						"author1": nil,
-						"author2": nil,
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "Xavier"},
+						{Name: "author1", Value: types.UnsetAttribute{}, Body: true},
+						{Name: "author2", Value: types.UnsetAttribute{}, Body: true},
 					},
 					Elements: []interface{}{
+						types.AttributeReset{
+							Name: "author1",
+						},
+						types.AttributeReset{
+							Name: "author2",
+						},
 						types.Paragraph{
 							Lines: [][]interface{}{
 								{types.StringElement{Content: "a paragraph written by Xavier."}},
@@ -1215,10 +1336,12 @@ This journey continues`
 					types.StringElement{Content: "Document Title"},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"toc":      nil,
-						"keywords": "documentation, team, obstacles, journey, victory",
-						types.AttrAuthors: []types.DocumentAuthor{
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "John Foo Doe"},
+						{Name: "author_2", Value: "Jane the Doe"},
+						{Name: "authorinitials", Value: "JFD"},
+						{Name: "authorinitials_2", Value: "Jt"},
+						{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 							{
 								FullName: "John Foo Doe",
 								Email:    "johndoe@example.com",
@@ -1227,26 +1350,24 @@ This journey continues`
 								FullName: "Jane the Doe",
 								Email:    "jane@example.com",
 							},
-						},
-						"firstname":        "John",
-						"middlename":       "Foo",
-						"lastname":         "Doe",
-						"author":           "John Foo Doe",
-						"authorinitials":   "JFD",
-						"email":            "johndoe@example.com",
-						"firstname_2":      "Jane",
-						"lastname_2":       "the Doe",
-						"author_2":         "Jane the Doe",
-						"authorinitials_2": "Jt",
-						"email_2":          "jane@example.com",
-						types.AttrRevision: types.DocumentRevision{
+						}},
+						{Name: "email", Value: "johndoe@example.com"},
+						{Name: "email_2", Value: "jane@example.com"},
+						{Name: "firstname", Value: "John"},
+						{Name: "firstname_2", Value: "Jane"},
+						{Name: "lastname", Value: "Doe"},
+						{Name: "lastname_2", Value: "the Doe"},
+						{Name: "middlename", Value: "Foo"},
+						{Name: "revdate", Value: "March 29, 2020"},
+						{Name: types.AttrRevision, Value: types.DocumentRevision{
 							Revnumber: "1.0",
 							Revdate:   "March 29, 2020",
 							Revremark: "Updated revision",
-						},
-						"revnumber": "1.0",
-						"revdate":   "March 29, 2020",
-						"revremark": "Updated revision",
+						}},
+						{Name: "revnumber", Value: "1.0"},
+						{Name: "revremark", Value: "Updated revision"},
+						{Name: "toc", Value: nil},
+						{Name: "keywords", Value: "documentation, team, obstacles, journey, victory"},
 					},
 					ElementReferences: types.ElementReferences{
 						"_document_title": title,
@@ -1283,8 +1404,8 @@ author: Xavier
 			
 a paragraph written by {author}.`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"author": "Xavier",
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "Xavier"},
 					},
 					Elements: []interface{}{
 						types.Paragraph{
@@ -1361,9 +1482,9 @@ a paragraph written by {author}.`
 			}
 			source := `{icons}`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"icons":              "font",
-					"source-highlighter": "pygments",
+				Attributes: types.DocumentAttributes{
+					{Name: "icons", Value: "font"},
+					{Name: "source-highlighter", Value: "pygments"},
 				},
 				Elements: []interface{}{
 					types.Paragraph{
@@ -1378,4 +1499,97 @@ a paragraph written by {author}.`
 			Expect(ParseDocument(source, configuration.WithAttributes(attrs))).To(Equal(expected))
 		})
 	})
+
+	Context("in header and body", func() {
+
+		It("should retain the attributes in their order of declaration", func() {
+			source := `= Title
+:version: 1.0
+:draft:
+
+:version: 2.0
+:!draft:
+:status: final
+
+content`
+			doc, err := ParseDocument(source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Attributes).To(Equal(types.DocumentAttributes{
+				{Name: "version", Value: "1.0"},
+				{Name: "draft", Value: nil},
+				{Name: "version", Value: "2.0", Body: true},
+				{Name: "draft", Value: types.UnsetAttribute{}, Body: true},
+				{Name: "status", Value: "final", Body: true},
+			}))
+			Expect(doc.Attributes.Names()).To(Equal([]string{"version", "draft", "status"}))
+			Expect(doc.Attributes.GetAsStringWithDefault("version", "")).To(Equal("2.0"))
+			Expect(doc.Attributes.GetAsStringWithDefault("status", "")).To(Equal("final"))
+			Expect(doc.Attributes.Has("draft")).To(BeFalse())
+			Expect(doc.Attributes.Values()).To(HaveKeyWithValue("draft", types.UnsetAttribute{}))
+			// values at the end of the header
+			Expect(doc.Attributes.Header()).To(Equal(types.Attributes{
+				"version": "1.0",
+				"draft":   nil,
+			}))
+		})
+
+		It("should retain the history of the attributes set and reset in an included file", func() {
+			source := `= Title
+:version: 1.0
+:draft:
+
+version {version}
+
+include::../../test/includes/attributes.adoc[]
+
+:version: 3.0
+
+version {version}`
+			doc, err := ParseDocument(source)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Attributes.History("version")).To(Equal([]types.DocumentAttribute{
+				{Name: "version", Value: "1.0"},
+				{Name: "version", Value: "2.0", Body: true},
+				{Name: "version", Value: "3.0", Body: true},
+			}))
+			Expect(doc.Attributes.History("draft")).To(Equal([]types.DocumentAttribute{
+				{Name: "draft", Value: nil},
+				{Name: "draft", Value: types.UnsetAttribute{}, Body: true},
+			}))
+			// each paragraph uses the value of the attribute at its position in the document
+			header, _ := doc.Header()
+			Expect(header.Elements).To(ContainElement(types.Paragraph{
+				Lines: [][]interface{}{
+					{types.StringElement{Content: "version 1.0"}},
+				},
+			}))
+			Expect(header.Elements).To(ContainElement(types.Paragraph{
+				Lines: [][]interface{}{
+					{types.StringElement{Content: "included content with version 2.0"}},
+				},
+			}))
+			Expect(header.Elements).To(ContainElement(types.Paragraph{
+				Lines: [][]interface{}{
+					{types.StringElement{Content: "version 3.0"}},
+				},
+			}))
+		})
+
+		It("should not retain the declarations of overridden attributes", func() {
+			source := `= Title
+:version: 1.0
+
+:version: 2.0
+
+content`
+			doc, err := ParseDocument(source, configuration.WithAttributes(map[string]string{
+				"version": "3.0",
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Attributes).To(Equal(types.DocumentAttributes{
+				{Name: "version", Value: "3.0"},
+			}))
+			Expect(doc.Attributes.GetAsStringWithDefault("version", "")).To(Equal("3.0"))
+		})
+	})
 })
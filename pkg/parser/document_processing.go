@@ -12,12 +12,12 @@ import (
 
 // ParseDocument parses the content of the reader identitied by the filename
 func ParseDocument(r io.Reader, config configuration.Configuration, options ...Option) (types.Document, error) {
-	rawDoc, err := ParseRawDocument(r, config, options...)
+	rawDoc, sections, err := parseRawDocument(r, config, options...)
 	if err != nil {
 		return types.Document{}, err
 	}
 
-	draftDoc, err := ApplySubstitutions(rawDoc, config)
+	draftDoc, docAttrs, err := applySubstitutionsOnDocument(rawDoc, config)
 	if err != nil {
 		return types.Document{}, err
 	}
@@ -26,25 +26,33 @@ func ParseDocument(r io.Reader, config configuration.Configuration, options ...O
 		spew.Fdump(log.StandardLogger().Out, draftDoc)
 	}
 
+	header := len(draftDoc.Header())
 	// now, merge list items into proper lists
 	blocks, err := rearrangeListItems(draftDoc.Elements, false)
 	if err != nil {
 		return types.Document{}, err
 	}
-	// filter out blocks not needed in the final doc
-	blocks = filter(blocks, allMatchers...)
+	// filter out blocks not needed in the final doc.
+	// The attribute declarations and resets in the body are retained, since they are applied in order during the rendering
+	blocks = append(filter(blocks[:header], allMatchers...), filter(blocks[header:], bodyMatchers...)...)
 
 	blocks, footnotes := processFootnotes(blocks)
 	// now, rearrange elements in a hierarchical manner
-	doc, err := rearrangeSections(blocks)
+	doc, err := rearrangeSections(blocks, draftDoc.Attributes, sections)
 	if err != nil {
 		return types.Document{}, err
 	}
 	// also, set the footnotes
 	doc.Footnotes = footnotes
+	doc.Warnings = rawDoc.Warnings
 	// insert the preamble at the right location
 	doc = includePreamble(doc)
-	doc.Attributes = doc.Attributes.SetAll(draftDoc.Attributes)
+	doc.Attributes = docAttrs
+	// collect the glossary entries (and link the terms in the document if needed)
+	doc = processGlossary(doc, types.AttributesWithOverrides{
+		Content:   doc.Attributes.Header(),
+		Overrides: config.AttributeOverrides,
+	})
 	// also insert the table of contents
 	doc = includeTableOfContentsPlaceHolder(doc)
 	// finally
@@ -63,22 +71,47 @@ const LevelOffset ContextKey = "leveloffset"
 
 // ParseRawDocument parses a document's content and applies the preprocessing directives (file inclusions)
 func ParseRawDocument(r io.Reader, config configuration.Configuration, options ...Option) (types.RawDocument, error) {
+	doc, _, err := parseRawDocument(r, config, options...)
+	return doc, err
+}
+
+// parseRawDocument parses a document's content and applies the preprocessing directives (file inclusions),
+// and also returns the positions in the original source of the sections (including the document header), in their order of appearance
+func parseRawDocument(r io.Reader, config configuration.Configuration, options ...Option) (types.RawDocument, []types.SourcePosition, error) {
 	// first, let's find all file inclusions and replace with the actual content to include
-	source, err := ParseRawSource(r, config, options...)
+	source, positions, err := preprocess(r, config, options...)
 	if err != nil {
-		return types.RawDocument{}, err
+		return types.RawDocument{}, nil, err
 	}
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debug("source to parse:")
 		fmt.Fprintf(log.StandardLogger().Out, "'%s'\n", source)
 	}
+	// check the content once the preprocessing directives have been applied, so that the content of the included files is checked
+	// but not the content which was excluded by a conditional inclusion
+	if config.Strict {
+		if err := checkStrictMode(source, positions, config.Filename); err != nil {
+			return types.RawDocument{}, nil, err
+		}
+	}
 	// then let's parse the "source" to detect raw blocks
-	options = append(options, Entrypoint("RawDocument"), GlobalStore(usermacrosKey, config.Macros))
-	if result, err := Parse(config.Filename, source, options...); err != nil {
-		return types.RawDocument{}, err
-	} else if doc, ok := result.(types.RawDocument); ok {
-		return doc, nil
-	} else {
-		return types.RawDocument{}, fmt.Errorf("unexpected type of content: '%T'", result)
+	options = append(options, Entrypoint("RawDocument"), GlobalStore(usermacrosKey, config.Macros), GlobalStore(urlSchemesKey, config.URLSchemes), GlobalStore(sourceMapKey, config.SourceMap), GlobalStore(sourcePositionsKey, positions), GlobalStore(frontMatterKindsKey, config.FrontMatterKinds), GlobalStore(skipFrontMatterKey, config.SkipFrontMatter), GlobalStore(hugoShortcodesKey, config.HugoShortcodes))
+	// also, collect the delimited blocks which are not closed (since they silently include the rest of the document)
+	// and the lines of the sections
+	state := &rawDocumentState{}
+	options = append(options, GlobalStore(rawDocumentStateKey, state))
+	result, err := parsers.parse(config.Filename, source, options...)
+	if err != nil {
+		return types.RawDocument{}, nil, err
+	}
+	doc, ok := result.(types.RawDocument)
+	if !ok {
+		return types.RawDocument{}, nil, fmt.Errorf("unexpected type of content: '%T'", result)
+	}
+	doc.Warnings = unterminatedBlockWarnings(state.unterminatedDelimiters, positions, config.Filename)
+	sections := make([]types.SourcePosition, len(state.sectionLines))
+	for i, line := range state.sectionLines {
+		sections[i] = positions.locate(line, config.Filename)
 	}
+	return doc, sections, nil
 }
@@ -6,9 +6,9 @@ import (
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/substitution"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	"github.com/davecgh/go-spew/spew"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -19,6 +19,13 @@ import (
 // ApplySubstitutions applies all the substitutions on delimited blocks, standalone paragraphs and paragraphs
 // in continued list items, and then attribute substitutions, and as a result returns a `DraftDocument`.
 func ApplySubstitutions(rawDoc types.RawDocument, config configuration.Configuration) (types.DraftDocument, error) {
+	draftDoc, _, err := applySubstitutionsOnDocument(rawDoc, config)
+	return draftDoc, err
+}
+
+// applySubstitutionsOnDocument same as `ApplySubstitutions`, but also returns the document attributes
+// in the order in which they were set (or reset) in the front-matter, in the header and in the body of the document
+func applySubstitutionsOnDocument(rawDoc types.RawDocument, config configuration.Configuration) (types.DraftDocument, types.DocumentAttributes, error) {
 	attrs := types.AttributesWithOverrides{
 		Content:   types.Attributes{},
 		Overrides: config.AttributeOverrides,
@@ -27,29 +34,57 @@ func ApplySubstitutions(rawDoc types.RawDocument, config configuration.Configura
 	// also, add all front-matter key/values
 	attrs.Add(rawDoc.FrontMatter.Content)
 	// also, add all AttributeDeclaration at the top of the document
-	attrs.Add(rawDoc.Attributes())
+	headerAttrs := rawDoc.Attributes()
+	attrs.Add(headerAttrs)
+	// the document attributes, in order: the front-matter, the authors and revision of the header section,
+	// the overrides and then the attribute declarations and resets, which are appended while they are processed
+	docAttrs := types.DocumentAttributes{}.SetAll(rawDoc.FrontMatter.Content)
+	for _, name := range headerDeclarations(rawDoc) {
+		delete(headerAttrs, name)
+	}
+	docAttrs = docAttrs.SetAll(headerAttrs)
+	docAttrs = docAttrs.SetAll(types.AttributesWithOverrides{
+		Overrides: config.AttributeOverrides,
+	}.All())
 
 	ctx := substitutionContext{
-		attributes: attrs,
-		config:     config,
+		attributes:         attrs,
+		config:             config,
+		header:             true,
+		documentAttributes: &docAttrs,
+	}
+	header := rawDoc.Header()
+	elements, err := applySubstitutions(ctx, header)
+	if err != nil {
+		return types.DraftDocument{}, nil, err
 	}
-	elements, err := applySubstitutions(ctx, rawDoc.Elements)
+	ctx.header = false
+	body, err := applySubstitutions(ctx, rawDoc.Elements[len(header):])
 	if err != nil {
-		return types.DraftDocument{}, err
+		return types.DraftDocument{}, nil, err
 	}
+	elements = append(elements, body...)
 	if len(elements) == 0 {
 		elements = nil // avoid carrying empty slice
 	}
+	if len(docAttrs) == 0 {
+		docAttrs = nil // avoid carrying empty slice
+	}
 	return types.DraftDocument{
 		Attributes:  attrs.All(),
 		FrontMatter: rawDoc.FrontMatter,
 		Elements:    elements,
-	}, nil
+	}, docAttrs, nil
 }
 
 type substitutionContext struct {
 	attributes types.AttributesWithOverrides
 	config     configuration.Configuration
+	header     bool // true when processing the elements of the document header
+	// documentAttributes the attributes of the document, to which the attribute declarations and resets
+	// in the body are appended (or `nil` in an inner document, whose attributes do not leak in the enclosing document)
+	documentAttributes *types.DocumentAttributes
+	lines              bool // true when processing lines which can be dropped (see the `attribute-missing` attribute)
 }
 
 // applySubstitutions applies the substitutions on paragraphs and delimited blocks (including when in continued list elements)
@@ -69,6 +104,7 @@ func applySubstitutions(ctx substitutionContext, elements []interface{}) ([]inte
 			if err != nil {
 				return nil, err
 			}
+			attrs = parseHighlightedLines(attrs)
 			e = a.ReplaceAttributes(attrs)
 		}
 		var err error
@@ -90,7 +126,9 @@ func applySubstitutions(ctx substitutionContext, elements []interface{}) ([]inte
 			if err != nil {
 				return nil, err
 			}
-			elements, err := applySubstitutionsOnLines(ctx, e.LinesToSubstitute(), subs)
+			linesCtx := ctx
+			linesCtx.lines = true
+			elements, err := applySubstitutionsOnLines(linesCtx, e.LinesToSubstitute(), subs)
 			if err != nil {
 				return nil, err
 			}
@@ -118,6 +156,11 @@ func applySubstitutions(ctx substitutionContext, elements []interface{}) ([]inte
 				return nil, err
 			}
 			result[i] = e
+		case types.Table:
+			if e, err = applySubstitutionsOnTable(ctx, e); err != nil {
+				return nil, err
+			}
+			result[i] = e
 		default:
 			// no support for element substitution here
 			// so let's proceed with attribute substitutions
@@ -139,84 +182,43 @@ func applySubstitutions(ctx substitutionContext, elements []interface{}) ([]inte
 // ----------------------------------------------------------------------------
 
 var substitutions = map[string]elementsSubstitution{
-	"inline_passthrough": substituteInlinePassthrough,
-	"callouts":           substituteCallouts,
-	"specialcharacters":  substituteSpecialCharacters,
-	"specialchars":       substituteSpecialCharacters,
-	"quotes":             substituteQuotedTexts,
-	"attributes":         substituteAttributes,
-	"replacements":       substituteReplacements,
-	"macros":             substituteInlineMacros,
-	"post_replacements":  substitutePostReplacements,
-	"none":               substituteNone,
+	substitution.InlinePassthrough: substituteInlinePassthrough,
+	substitution.Callouts:          substituteCallouts,
+	substitution.SpecialCharacters: substituteSpecialCharacters,
+	substitution.SpecialChars:      substituteSpecialCharacters,
+	substitution.Quotes:            substituteQuotedTexts,
+	substitution.Attributes:        substituteAttributes,
+	substitution.Replacements:      substituteReplacements,
+	substitution.Macros:            substituteInlineMacros,
+	substitution.PostReplacements:  substitutePostReplacements,
+	substitution.None:              substituteNone,
 }
 
 func substitutionsFor(block types.WithCustomSubstitutions) ([]elementsSubstitution, error) {
-	subs := make(funcs, 0, len(substitutions))
-	subsitutionsToApply, err := block.SubstitutionsToApply()
+	subs, err := block.SubstitutionsToApply()
 	if err != nil {
 		return nil, err
 	}
-	for _, s := range subsitutionsToApply {
-		switch s {
-		case "normal":
-			subs = subs.append(
-				"specialcharacters",
-				"quotes",
-				"attributes",
-				"replacements",
-				"macros",
-				"post_replacements",
-			)
-		case "inline_passthrough", "callouts", "specialcharacters", "specialchars", "quotes", "attributes", "macros", "replacements", "post_replacements", "none":
-			subs = subs.append(s)
-		case "+callouts", "+specialcharacters", "+specialchars", "+quotes", "+attributes", "+macros", "+replacements", "+post_replacements", "+none":
-			if len(subs) == 0 {
-				subs = subs.append(block.DefaultSubstitutions()...)
-			}
-			subs = subs.append(strings.ReplaceAll(s, "+", ""))
-		case "callouts+", "specialcharacters+", "specialchars+", "quotes+", "attributes+", "macros+", "replacements+", "post_replacements+", "none+":
-			if len(subs) == 0 {
-				subs = subs.append(block.DefaultSubstitutions()...)
-			}
-			subs = subs.prepend(strings.ReplaceAll(s, "+", ""))
-		case "-callouts", "-specialcharacters", "-specialchars", "-quotes", "-attributes", "-macros", "-replacements", "-post_replacements", "-none":
-			if len(subs) == 0 {
-				subs = subs.append(block.DefaultSubstitutions()...)
-			}
-			subs = subs.remove(strings.ReplaceAll(s, "-", ""))
-		default:
-			return nil, fmt.Errorf("unsupported substitution: '%s", s)
-		}
+	pipeline, err := substitution.Resolve(subs, block.DefaultSubstitutions())
+	if err != nil {
+		return nil, err
 	}
-	result := make([]elementsSubstitution, 0, len(subs))
-	for _, s := range subs {
+	return append(newPipeline(pipeline), splitLines), nil
+}
+
+// newPipeline returns the substitution funcs matching the given pipeline, in the same order
+func newPipeline(p substitution.Pipeline) []elementsSubstitution {
+	result := make([]elementsSubstitution, 0, len(p)+1)
+	for _, s := range p {
 		if f, exists := substitutions[s]; exists {
 			result = append(result, f)
 		}
-	}
-	result = append(result, splitLines)
-	return result, nil
-}
-
-type funcs []string
-
-func (f funcs) append(others ...string) funcs {
-	return append(f, others...)
-}
-
-func (f funcs) prepend(other string) funcs {
-	return append(funcs{other}, f...)
-}
-
-func (f funcs) remove(other string) funcs {
-	for i, s := range f {
-		if s == other {
-			return append(f[:i], f[i+1:]...)
+		if s == substitution.InlinePassthrough {
+			// the passthrough macros' own substitutions are applied as soon as they have been extracted
+			result = append(result, substitutePassthroughMacros)
 		}
 	}
-	// unchanged
-	return f
+	return result
 }
 
 func applySubstitutionsOnElements(ctx substitutionContext, elements []interface{}, subs []elementsSubstitution) ([]interface{}, error) {
@@ -255,15 +257,7 @@ func applySubstitutionsOnLines(ctx substitutionContext, lines [][]interface{}, s
 }
 
 func applySubstitutionsOnMarkdownQuoteBlock(ctx substitutionContext, b types.MarkdownQuoteBlock) (types.MarkdownQuoteBlock, error) {
-	funcs := []elementsSubstitution{
-		substituteInlinePassthrough,
-		substituteSpecialCharacters,
-		substituteQuotedTexts,
-		substituteAttributes,
-		substituteReplacements,
-		substituteInlineMacros,
-		substitutePostReplacements,
-		splitLines}
+	funcs := append(newPipeline(substitution.InlinePipeline), splitLines)
 	// attempt to extract the block attributions
 	var author string
 	if b.Lines, author = extractMarkdownQuoteAttribution(b.Lines); author != "" {
@@ -292,7 +286,7 @@ func extractMarkdownQuoteAttribution(lines [][]interface{}) ([][]interface{}, st
 		return lines, ""
 	}
 	if l, ok := lines[len(lines)-1][0].(types.StringElement); ok {
-		a, err := ParseReader("", strings.NewReader(l.Content), Entrypoint("MarkdownQuoteAttribution"))
+		a, err := parsers.ParseReader("", strings.NewReader(l.Content), Entrypoint("MarkdownQuoteAttribution"))
 		// assume that the last line is not an author attribution if an error occurred
 		if err != nil {
 			return lines, ""
@@ -312,15 +306,7 @@ func extractMarkdownQuoteAttribution(lines [][]interface{}) ([][]interface{}, st
 // applies the elements and attributes substitutions on the given section title.
 func applySubstitutionsOnSection(ctx substitutionContext, s types.Section) (types.Section, error) {
 	elements := [][]interface{}{s.Title} // wrap to match the `elementsSubstitution` arg type
-	subs := []elementsSubstitution{
-		substituteInlinePassthrough,
-		substituteSpecialCharacters,
-		substituteQuotedTexts,
-		substituteAttributes,
-		substituteReplacements,
-		substituteInlineMacros,
-		substitutePostReplacements,
-	}
+	subs := newPipeline(substitution.InlinePipeline)
 	var err error
 	for _, sub := range subs {
 		if elements, err = sub(ctx, elements); err != nil {
@@ -338,6 +324,60 @@ func applySubstitutionsOnSection(ctx substitutionContext, s types.Section) (type
 	return s, nil
 }
 
+// ----------------------------------------------------------------------------
+// Table substitutions
+// ----------------------------------------------------------------------------
+
+// parses the content of the table cells with the `a` style as inner documents
+func applySubstitutionsOnTable(ctx substitutionContext, t types.Table) (types.Table, error) {
+	for _, l := range append([]types.TableLine{t.Footer}, t.Lines...) {
+		for i, cell := range l.Cells {
+			if len(cell) != 1 {
+				continue
+			}
+			if d, ok := cell[0].(types.InnerDocument); ok {
+				elements, err := parseInnerDocument(ctx, d.Source)
+				if err != nil {
+					return types.Table{}, err
+				}
+				l.Cells[i] = []interface{}{
+					types.InnerDocument{
+						Elements: elements,
+					},
+				}
+			}
+		}
+	}
+	return t, nil
+}
+
+// parseInnerDocument parses the given source as a standalone document which inherits
+// the attributes of the enclosing document. Attributes declared or reset in the inner
+// document do not leak in the enclosing document.
+func parseInnerDocument(ctx substitutionContext, source string) ([]interface{}, error) {
+	rawDoc, err := ParseRawDocument(strings.NewReader(source), ctx.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inner document: %w", err)
+	}
+	innerCtx := substitutionContext{
+		attributes: types.AttributesWithOverrides{
+			Content:   types.Attributes{},
+			Overrides: ctx.attributes.Overrides,
+			Counters:  ctx.attributes.Counters,
+		},
+		config: ctx.config,
+	}
+	innerCtx.attributes.Add(ctx.attributes.Content)
+	elements, err := applySubstitutions(innerCtx, rawDoc.Elements)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inner document: %w", err)
+	}
+	if elements, err = rearrangeListItems(elements, false); err != nil {
+		return nil, fmt.Errorf("failed to parse inner document: %w", err)
+	}
+	return filter(elements, allMatchers...), nil
+}
+
 // ----------------------------------------------------------------------------
 // Location substitutions
 // ----------------------------------------------------------------------------
@@ -345,7 +385,7 @@ func applySubstitutionsOnSection(ctx substitutionContext, s types.Section) (type
 // applies the elements and attributes substitutions on the given image block.
 func applySubstitutionsOnLocation(ctx substitutionContext, l types.Location) (types.Location, error) {
 	elements := [][]interface{}{l.Path} // wrap to match the `elementsSubstitution` arg type
-	subs := []elementsSubstitution{substituteAttributes}
+	subs := newPipeline(substitution.AttributesPipeline)
 	var err error
 	for _, sub := range subs {
 		if elements, err = sub(ctx, elements); err != nil {
@@ -353,7 +393,7 @@ func applySubstitutionsOnLocation(ctx substitutionContext, l types.Location) (ty
 		}
 	}
 	l.Path = elements[0]
-	l = l.WithPathPrefix(ctx.attributes.GetAsStringWithDefault("imagesdir", ""))
+	l = l.WithPathPrefix(ctx.attributes.GetAsStringWithDefault(types.AttrImagesDir, ""))
 	return l, nil
 }
 
@@ -361,18 +401,44 @@ func applySubstitutionsOnLocation(ctx substitutionContext, l types.Location) (ty
 // Individual substitution funcs
 // ----------------------------------------------------------------------------
 
+// applies the substitutions of the passthrough macros (eg: `pass:q,a[...]`) on their own content.
+// Since passthroughs are replaced with placeholders during the subsequent substitutions,
+// their content is never altered afterwards.
+func substitutePassthroughMacros(ctx substitutionContext, lines [][]interface{}) ([][]interface{}, error) {
+	for _, line := range lines {
+		for i, element := range line {
+			p, ok := element.(types.InlinePassthrough)
+			if !ok || p.Subs == "" {
+				continue
+			}
+			pipeline, err := substitution.ResolveInline(p.Subs)
+			if err != nil {
+				log.Warnf("unable to apply the substitutions of the passthrough macro: %v", err)
+				pipeline = substitution.Pipeline{}
+			}
+			elements := [][]interface{}{p.Elements}
+			for _, sub := range newPipeline(pipeline) {
+				if elements, err = sub(ctx, elements); err != nil {
+					return nil, err
+				}
+			}
+			p.Elements = elements[0]
+			p.Subs = ""
+			line[i] = p
+		}
+	}
+	return lines, nil
+}
+
 // includes a call to `elementsSubstitution` with some post-processing on the result
 var substituteAttributes = func(ctx substitutionContext, lines [][]interface{}) ([][]interface{}, error) {
 	lines, err := newElementsSubstitution("AttributeSubs")(ctx, lines) // TODO: add a `substituteAttributes` var?
 	if err != nil {
 		return nil, err
 	}
-	for i, line := range lines {
-		line, err := applyAttributeSubstitutionsOnElements(ctx, line)
-		if err != nil {
-			return nil, err
-		}
-		lines[i] = types.Merge(line)
+	lines, err = applyAttributeSubstitutionsOnLines(ctx, lines)
+	if err != nil {
+		return nil, err
 	}
 	// if log.IsLevelEnabled(log.DebugLevel) {
 	// 	// log.Debugf("applied the 'attributes' substitution")
@@ -402,26 +468,16 @@ func newElementsSubstitution(rule string) elementsSubstitution {
 			elements: map[string]interface{}{},
 		}
 		s := serializeLines(lines, placeholders)
-		imagesdirOption := GlobalStore("imagesdir", ctx.attributes.GetAsStringWithDefault("imagesdir", ""))
+		imagesdirOption := GlobalStore(imagesdirKey, ctx.attributes.GetAsStringWithDefault(types.AttrImagesDir, ""))
 		usermacrosOptions := GlobalStore(usermacrosKey, ctx.config.Macros)
+		urlSchemesOptions := GlobalStore(urlSchemesKey, ctx.config.URLSchemes)
+		compatModeOption := GlobalStore(compatModeKey, ctx.attributes.HasFlag(types.AttrCompatMode))
+		hugoShortcodesOption := GlobalStore(hugoShortcodesKey, ctx.config.HugoShortcodes)
 		// process placeholder content (eg: quoted text may contain an inline link)
-		for ref, placeholder := range placeholders.elements {
-			switch placeholder := placeholder.(type) { // TODO: create `PlaceHolder` interface?
-			case types.QuotedString:
-				var err error
-				if placeholder.Elements, err = parserPlaceHolderElements(placeholder.Elements, imagesdirOption, usermacrosOptions, Entrypoint(rule)); err != nil {
-					return nil, err
-				}
-				placeholders.elements[ref] = placeholder
-			case types.QuotedText:
-				var err error
-				if placeholder.Elements, err = parserPlaceHolderElements(placeholder.Elements, imagesdirOption, usermacrosOptions, Entrypoint(rule)); err != nil {
-					return nil, err
-				}
-				placeholders.elements[ref] = placeholder
-			}
+		if err := parsePlaceHolders(placeholders, imagesdirOption, usermacrosOptions, urlSchemesOptions, compatModeOption, hugoShortcodesOption, Entrypoint(rule)); err != nil {
+			return nil, err
 		}
-		elmts, err := parseContent("", s, imagesdirOption, usermacrosOptions, Entrypoint(rule))
+		elmts, err := parseContent("", s, imagesdirOption, usermacrosOptions, urlSchemesOptions, compatModeOption, hugoShortcodesOption, Entrypoint(rule))
 		if err != nil {
 			return nil, err
 		}
@@ -434,27 +490,49 @@ func newElementsSubstitution(rule string) elementsSubstitution {
 	}
 }
 
-func parserPlaceHolderElements(elements []interface{}, options ...Option) ([]interface{}, error) {
-	result := make([]interface{}, 0, len(elements)) // default capacity (but may not be enough)
-	for _, element := range elements {
-		switch element := element.(type) {
-		case types.StringElement:
-			elmts, err := parseContent("", element.Content, options...)
-			if err != nil {
-				return nil, err
+// parsePlaceHolders parses the content of the quoted strings and quoted texts in the given placeholders
+func parsePlaceHolders(placeholders *placeholders, options ...Option) error {
+	for ref, placeholder := range placeholders.elements {
+		switch placeholder := placeholder.(type) { // TODO: create `PlaceHolder` interface?
+		case types.QuotedString:
+			var err error
+			if placeholder.Elements, err = parserPlaceHolderElements(placeholder.Elements, options...); err != nil {
+				return err
 			}
-			result = append(result, elmts...)
-		default:
-			result = append(result, element)
+			placeholders.elements[ref] = placeholder
+		case types.QuotedText:
+			var err error
+			if placeholder.Elements, err = parserPlaceHolderElements(placeholder.Elements, options...); err != nil {
+				return err
+			}
+			placeholders.elements[ref] = placeholder
 		}
 	}
-	return result, nil
+	return nil
+}
+
+// parserPlaceHolderElements parses the given elements at once, so that an element may span over
+// nested quoted texts (eg: a link whose text contains some bold text, within some italic text)
+func parserPlaceHolderElements(elements []interface{}, options ...Option) ([]interface{}, error) {
+	placeholders := &placeholders{
+		seq:      0,
+		elements: map[string]interface{}{},
+	}
+	s := serializeLines([][]interface{}{elements}, placeholders)
+	if err := parsePlaceHolders(placeholders, options...); err != nil {
+		return nil, err
+	}
+	result, err := parseContent("", s, options...)
+	if err != nil {
+		return nil, err
+	}
+	return restorePlaceholderElements(result, placeholders), nil
 }
 
 func parseContent(filename string, content string, options ...Option) ([]interface{}, error) {
-	result, err := ParseReader(filename, strings.NewReader(content), options...)
+	result, err := parsers.ParseReader(filename, strings.NewReader(content), options...)
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to parse '%s'", content)
+		return nil, fmt.Errorf("unable to parse '%s': %w", content, err)
 	}
 	if result, ok := result.([]interface{}); ok {
 		return types.Merge(result), nil
@@ -578,6 +656,7 @@ func applyAttributeSubstitutionsOnElements(ctx substitutionContext, elements []i
 }
 
 func applyAttributeSubstitutionsOnAttributes(ctx substitutionContext, attributes types.Attributes) (types.Attributes, error) {
+	ctx.lines = false
 	for key, value := range attributes {
 		switch key {
 		case types.AttrRoles, types.AttrOptions: // multi-value attributes
@@ -615,22 +694,129 @@ func applyAttributeSubstitutionsOnAttributes(ctx substitutionContext, attributes
 	return attributes, nil
 }
 
+// parseHighlightedLines converts the `highlight` attribute of a source block or paragraph
+// into a set of `LineRanges`. Invalid values are logged and ignored.
+func parseHighlightedLines(attributes types.Attributes) types.Attributes {
+	if attributes.GetAsStringWithDefault(types.AttrStyle, "") != types.Source {
+		return attributes
+	}
+	highlight, found, err := attributes.GetAsString(types.AttrHighlight)
+	if err != nil || !found {
+		return attributes
+	}
+	lr, err := parsers.parse("", []byte(highlight), Entrypoint("LineRanges"))
+	if err != nil {
+		log.Warnf("invalid value for the 'highlight' attribute: '%s'", highlight)
+		delete(attributes, types.AttrHighlight)
+		return attributes
+	}
+	attributes[types.AttrHighlight] = types.NewLineRanges(lr)
+	return attributes
+}
+
 func applyAttributeSubstitutionsOnLines(ctx substitutionContext, lines [][]interface{}) ([][]interface{}, error) {
-	for i, line := range lines {
+	result := make([][]interface{}, 0, len(lines))
+	for _, line := range lines {
 		line, err := applyAttributeSubstitutionsOnElements(ctx, line)
 		if err != nil {
 			return nil, err
 		}
-		lines[i] = types.Merge(line)
+		if isDroppedLine(line) {
+			if line = dropLines(line); len(line) == 0 {
+				continue
+			}
+		}
+		result = append(result, types.Merge(line))
+	}
+	return result, nil
+}
+
+// droppedLine the element which replaces a reference to an undefined attribute when the `attribute-missing`
+// attribute is set to `drop-line`, so that the line which contains it is removed
+type droppedLine struct{}
+
+// isDroppedLine returns `true` if the given line contains a reference to an undefined attribute
+// and the `attribute-missing` attribute is set to `drop-line`
+func isDroppedLine(line []interface{}) bool {
+	for _, e := range line {
+		if _, ok := e.(droppedLine); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dropLines removes the lines (ie, the elements between two `\n` characters) which contain a `droppedLine` element
+func dropLines(elements []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(elements))
+	current := []interface{}{}
+	dropped := false
+	for _, e := range elements {
+		switch e := e.(type) {
+		case droppedLine:
+			dropped = true
+		case types.StringElement:
+			parts := strings.Split(e.Content, "\n")
+			for _, p := range parts[:len(parts)-1] {
+				// end of line
+				if !dropped {
+					result = append(result, current...)
+					result = append(result, types.StringElement{Content: p + "\n"})
+				}
+				current = []interface{}{}
+				dropped = false
+			}
+			if p := parts[len(parts)-1]; p != "" {
+				current = append(current, types.StringElement{Content: p})
+			}
+		default:
+			current = append(current, e)
+		}
+	}
+	if !dropped {
+		return append(result, current...)
+	}
+	// also remove the trailing newline of the previous line
+	if len(result) > 0 {
+		if s, ok := result[len(result)-1].(types.StringElement); ok {
+			result[len(result)-1] = types.StringElement{Content: strings.TrimSuffix(s.Content, "\n")}
+		}
+	}
+	return result
+}
+
+// applyAttributeMissing returns the element which replaces the given reference to an undefined attribute,
+// depending on the `attribute-missing` attribute
+func applyAttributeMissing(ctx substitutionContext, s types.AttributeSubstitution) interface{} {
+	policy, _ := ctx.attributes.GetAsString(types.AttrAttributeMissing)
+	switch policy {
+	case "skip":
+	case "drop":
+		return types.StringElement{}
+	case "drop-line":
+		if ctx.lines {
+			log.Debugf("dropping line with reference to missing attribute '%s'", s.Name)
+			return droppedLine{}
+		}
+		return types.StringElement{} // the reference is removed when it is not in a line
+	default: // "warn"
+		log.Warnf("unable to find attribute '%s'", s.Name)
+	}
+	return types.StringElement{
+		Content: "{" + s.Name + "}",
 	}
-	return lines, nil
 }
 
 func applyAttributeSubstitutionsOnElement(ctx substitutionContext, element interface{}) (interface{}, error) {
 	var err error
 	switch e := element.(type) {
 	case types.AttributeReset:
-		ctx.attributes.Set(e.Name, nil) // This allows us to test for a reset vs. undefined.
+		if !ctx.header && types.IsHeaderOnlyAttribute(e.Name) {
+			log.Warnf("attribute '%s' can only be reset in the document header", e.Name)
+			break
+		}
+		ctx.attributes.Reset(e.Name)
+		ctx.declare(e.Name, types.UnsetAttribute{})
 	case types.AttributeSubstitution:
 		if value, ok := ctx.attributes.GetAsString(e.Name); ok {
 			element = types.StringElement{
@@ -638,9 +824,13 @@ func applyAttributeSubstitutionsOnElement(ctx substitutionContext, element inter
 			}
 			break
 		}
-		log.Warnf("unable to find attribute '%s'", e.Name)
-		element = types.StringElement{
-			Content: "{" + e.Name + "}",
+		element = applyAttributeMissing(ctx, e)
+	case types.PredefinedAttribute:
+		// predefined attributes can be overridden in the document or in the configuration
+		if value, ok := ctx.attributes.GetAsString(e.Name); ok {
+			element = types.StringElement{
+				Content: value,
+			}
 		}
 	case types.CounterSubstitution:
 		if element, err = applyCounterSubstitution(ctx, e); err != nil {
@@ -651,6 +841,9 @@ func applyAttributeSubstitutionsOnElement(ctx substitutionContext, element inter
 		if err != nil {
 			return nil, err
 		}
+		if isDroppedLine(elmts) {
+			return droppedLine{}, nil
+		}
 		element = e.ReplaceElements(types.Merge(elmts))
 	case types.WithLineSubstitution:
 		lines, err := applyAttributeSubstitutionsOnLines(ctx, e.LinesToSubstitute())
@@ -665,11 +858,49 @@ func applyAttributeSubstitutionsOnElement(ctx substitutionContext, element inter
 	}
 	// also, retain the attribute declaration value (if applicable)
 	if e, ok := element.(types.AttributeDeclaration); ok {
-		ctx.attributes.Set(e.Name, e.Value)
+		if !ctx.header && types.IsHeaderOnlyAttribute(e.Name) {
+			log.Warnf("attribute '%s' can only be set in the document header", e.Name)
+		} else {
+			ctx.attributes.Set(e.Name, e.Value)
+			ctx.declare(e.Name, e.Value)
+		}
 	}
 	return element, nil
 }
 
+// headerDeclarations returns the names of the attributes declared or reset in the header of the given document
+func headerDeclarations(rawDoc types.RawDocument) []string {
+	var result []string
+	for _, e := range rawDoc.Header() {
+		switch e := e.(type) {
+		case types.AttributeDeclaration:
+			result = append(result, e.Name)
+		case types.AttributeReset:
+			result = append(result, e.Name)
+		}
+	}
+	return result
+}
+
+// declare appends the given attribute value to the document attributes, unless the attribute is declared
+// in an inner document, or if the attribute is overridden (in which case the declaration has no effect)
+func (ctx substitutionContext) declare(name string, value interface{}) {
+	if ctx.documentAttributes == nil {
+		return
+	}
+	if _, found := ctx.attributes.Overrides[name]; found {
+		return
+	}
+	if _, found := ctx.attributes.Overrides["!"+name]; found {
+		return
+	}
+	*ctx.documentAttributes = append(*ctx.documentAttributes, types.DocumentAttribute{
+		Name:  name,
+		Value: value,
+		Body:  !ctx.header,
+	})
+}
+
 // applyCounterSubstitutions is called by applyAttributeSubstitutionsOnElement.  Unless there is an error with
 // the element (the counter is the wrong type, which should never occur), it will return a `StringElement, true`
 // (because we always either find the element, or allocate one), and `nil`.  On an error it will return `nil, false`,
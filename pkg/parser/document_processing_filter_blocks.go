@@ -6,7 +6,7 @@ import (
 )
 
 // Filter removes all blocks that should not appear in the final document:
-// - blank lines (except in delimited blocks)
+// - blank lines (including in delimited blocks and list items, since the blocks are already delimited at this stage)
 // - all document attribute declaration/substitution/reset
 // - empty preambles
 // - single line comments and comment blocks
@@ -80,7 +80,10 @@ elements:
 }
 
 // AllMatchers all the matchers needed to remove the unneeded blocks/elements from the final document
-var allMatchers = []filterMatcher{attributeMatcher, singleLineCommentMatcher, commentBlockMatcher}
+var allMatchers = []filterMatcher{blankLineMatcher, attributeMatcher, singleLineCommentMatcher, commentBlockMatcher}
+
+// bodyMatchers same as `allMatchers`, but the attribute declarations and resets are retained (to apply them in order during the rendering)
+var bodyMatchers = []filterMatcher{blankLineMatcher, bodyAttributeMatcher, singleLineCommentMatcher, commentBlockMatcher}
 
 // filterMatcher returns true if the given element is to be filtered out
 type filterMatcher func(element interface{}) bool
@@ -96,6 +99,25 @@ var attributeMatcher filterMatcher = func(element interface{}) bool {
 	}
 }
 
+// bodyAttributeMatcher same as `attributeMatcher`, except for the AttributeDeclaration and AttributeReset,
+// which are filtered out only if they apply to an attribute which can only be set in the document header
+var bodyAttributeMatcher filterMatcher = func(element interface{}) bool {
+	switch e := element.(type) {
+	case types.AttributeDeclaration:
+		return types.IsHeaderOnlyAttribute(e.Name)
+	case types.AttributeReset:
+		return types.IsHeaderOnlyAttribute(e.Name)
+	default:
+		return attributeMatcher(element)
+	}
+}
+
+// blankLineMatcher filters the element if it is a BlankLine
+var blankLineMatcher filterMatcher = func(element interface{}) bool {
+	_, ok := element.(types.BlankLine)
+	return ok
+}
+
 // singleLineCommentMatcher filters the element if it is a SingleLineComment
 var singleLineCommentMatcher filterMatcher = func(element interface{}) bool {
 	_, ok := element.(types.SingleLineComment)
@@ -41,6 +41,60 @@ var _ = Describe("block filters", func() {
 		Expect(filter(actual, allMatchers...)).To(Equal(expected))
 	})
 
+	It("should remove blank lines in delimited blocks and list items", func() {
+		actual := []interface{}{
+			types.BlankLine{},
+			types.ExampleBlock{
+				Elements: []interface{}{
+					types.BlankLine{},
+					types.Paragraph{},
+					types.BlankLine{},
+					types.BlankLine{},
+					types.Paragraph{},
+				},
+			},
+			types.BlankLine{},
+			types.UnorderedList{
+				Items: []types.UnorderedListItem{
+					{
+						Elements: []interface{}{
+							types.Paragraph{},
+							types.BlankLine{},
+							types.Paragraph{},
+						},
+					},
+				},
+			},
+		}
+		expected := []interface{}{
+			types.ExampleBlock{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{},
+					},
+					types.Paragraph{
+						Lines: [][]interface{}{},
+					},
+				},
+			},
+			types.UnorderedList{
+				Items: []types.UnorderedListItem{
+					{
+						Elements: []interface{}{
+							types.Paragraph{
+								Lines: [][]interface{}{},
+							},
+							types.Paragraph{
+								Lines: [][]interface{}{},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(filter(actual, allMatchers...)).To(Equal(expected))
+	})
+
 	It("should remove document attribute declaration", func() {
 		actual := []interface{}{
 			types.AttributeDeclaration{},
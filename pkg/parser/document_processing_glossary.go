@@ -0,0 +1,195 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// processGlossary collects the entries of the glossary, ie, the items of the labeled lists
+// with the `glossary` style or which belong to a section with the `glossary` style, and sets their ID.
+// Also, if the `glossary-links` attribute is set, the occurrences of the terms in the paragraphs
+// of the document are replaced with links to their entry.
+func processGlossary(doc types.Document, attrs types.AttributesWithOverrides) types.Document {
+	log.Debug("processing glossary...")
+	glossary := types.GlossaryEntries{}
+	refs := types.ElementReferences{}
+	doc.Elements = collectGlossaryEntries(doc.Elements, false, glossary, refs, types.NewSlugger(attrs))
+	if len(glossary) == 0 {
+		return doc
+	}
+	doc.Glossary = glossary
+	if doc.ElementReferences == nil {
+		doc.ElementReferences = types.ElementReferences{}
+	}
+	for id, title := range refs {
+		doc.ElementReferences[id] = title
+	}
+	if attrs.HasFlag(types.AttrGlossaryLinks) {
+		doc.Elements = linkGlossaryTerms(doc.Elements, glossaryMatcher(glossary))
+	}
+	return doc
+}
+
+func collectGlossaryEntries(elements []interface{}, withinGlossary bool, glossary types.GlossaryEntries, refs types.ElementReferences, slugger types.Slugger) []interface{} {
+	for i, element := range elements {
+		switch e := element.(type) {
+		case types.Section:
+			e.Elements = collectGlossaryEntries(e.Elements, isGlossary(e.Attributes), glossary, refs, slugger)
+			elements[i] = e
+		case types.Preamble:
+			e.Elements = collectGlossaryEntries(e.Elements, false, glossary, refs, slugger)
+			elements[i] = e
+		case types.LabeledList:
+			if !withinGlossary && !isGlossary(e.Attributes) {
+				continue
+			}
+			for j, item := range e.Items {
+				id, found := item.Attributes[types.AttrID].(string)
+				if !found {
+					id = types.NewGlossaryEntryID(item.Term, slugger)
+					item.Attributes = item.Attributes.Set(types.AttrID, id)
+				}
+				glossary.Add(item.Term, id)
+				refs[id] = item.Term
+				e.Items[j] = item
+			}
+			elements[i] = e
+		}
+	}
+	return elements
+}
+
+func isGlossary(attrs types.Attributes) bool {
+	return attrs.GetAsStringWithDefault(types.AttrStyle, "") == types.Glossary
+}
+
+// glossaryMatcher returns a regular expression which matches all the terms of the given glossary
+// (case-insensitive, longest terms first)
+func glossaryMatcher(glossary types.GlossaryEntries) *regexp.Regexp {
+	terms := make([]string, 0, len(glossary))
+	for term := range glossary {
+		terms = append(terms, regexp.QuoteMeta(term))
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i]) != len(terms[j]) {
+			return len(terms[i]) > len(terms[j])
+		}
+		return terms[i] < terms[j]
+	})
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(terms, "|") + `)`)
+}
+
+// findGlossaryTerms returns the locations of the glossary terms in the given content,
+// ignoring the matches which are not surrounded by non-word characters (or by the bounds of the content).
+// Note: `\b` can't be used in the matcher, since it never matches before or after a term
+// which starts or ends with a non-word character, such as `C++` or `.NET`
+func findGlossaryTerms(content string, matcher *regexp.Regexp) [][]int {
+	result := [][]int{}
+	for offset := 0; offset < len(content); {
+		loc := matcher.FindStringIndex(content[offset:])
+		if loc == nil {
+			break
+		}
+		start, end := offset+loc[0], offset+loc[1]
+		if start == end {
+			break
+		}
+		before, _ := utf8.DecodeLastRuneInString(content[:start])
+		after, _ := utf8.DecodeRuneInString(content[end:])
+		if (start == 0 || !isWordCharacter(before)) && (end == len(content) || !isWordCharacter(after)) {
+			result = append(result, []int{start, end})
+			offset = end
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(content[start:])
+		offset = start + size
+	}
+	return result
+}
+
+func isWordCharacter(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// linkGlossaryTerms replaces the occurrences of the glossary terms in the paragraphs
+// with `GlossaryTerm` elements (except in the glossary itself)
+func linkGlossaryTerms(elements []interface{}, matcher *regexp.Regexp) []interface{} {
+	for i, element := range elements {
+		switch e := element.(type) {
+		case types.Section:
+			if isGlossary(e.Attributes) {
+				continue
+			}
+			e.Elements = linkGlossaryTerms(e.Elements, matcher)
+			elements[i] = e
+		case types.Preamble:
+			e.Elements = linkGlossaryTerms(e.Elements, matcher)
+			elements[i] = e
+		case types.Paragraph:
+			if e.Attributes.Has(types.AttrStyle) {
+				continue // no links in verbatim paragraphs, etc.
+			}
+			for j, line := range e.Lines {
+				e.Lines[j] = linkGlossaryTermsInLine(line, matcher)
+			}
+			elements[i] = e
+		case types.UnorderedList:
+			for j, item := range e.Items {
+				item.Elements = linkGlossaryTerms(item.Elements, matcher)
+				e.Items[j] = item
+			}
+			elements[i] = e
+		case types.OrderedList:
+			for j, item := range e.Items {
+				item.Elements = linkGlossaryTerms(item.Elements, matcher)
+				e.Items[j] = item
+			}
+			elements[i] = e
+		case types.LabeledList:
+			if isGlossary(e.Attributes) {
+				continue
+			}
+			for j, item := range e.Items {
+				item.Elements = linkGlossaryTerms(item.Elements, matcher)
+				e.Items[j] = item
+			}
+			elements[i] = e
+		case types.ExampleBlock, types.QuoteBlock, types.SidebarBlock:
+			b := e.(types.WithElementsToSubstitute)
+			elements[i] = b.ReplaceElements(linkGlossaryTerms(b.ElementsToSubstitute(), matcher))
+		}
+	}
+	return elements
+}
+
+func linkGlossaryTermsInLine(line []interface{}, matcher *regexp.Regexp) []interface{} {
+	result := make([]interface{}, 0, len(line))
+	for _, element := range line {
+		switch e := element.(type) {
+		case types.StringElement:
+			start := 0
+			for _, loc := range findGlossaryTerms(e.Content, matcher) {
+				if loc[0] > start {
+					result = append(result, types.StringElement{Content: e.Content[start:loc[0]]})
+				}
+				result = append(result, types.GlossaryTerm{Term: e.Content[loc[0]:loc[1]]})
+				start = loc[1]
+			}
+			if start < len(e.Content) {
+				result = append(result, types.StringElement{Content: e.Content[start:]})
+			}
+		case types.QuotedText:
+			e.Elements = linkGlossaryTermsInLine(e.Elements, matcher)
+			result = append(result, e)
+		default:
+			result = append(result, e)
+		}
+	}
+	return result
+}
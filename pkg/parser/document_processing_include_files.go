@@ -3,6 +3,7 @@ package parser
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -11,54 +12,136 @@ import (
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/expression"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	"github.com/davecgh/go-spew/spew"
-	"github.com/pkg/errors"
 
 	log "github.com/sirupsen/logrus"
 )
 
+// ErrUnresolvedDirective the error returned when a file inclusion cannot be resolved
+var ErrUnresolvedDirective = errors.New("unresolved directive")
+
+// ErrInputTooLarge the error returned when the source document or an included file
+// exceeds the maximum size set in the configuration
+var ErrInputTooLarge = errors.New("input exceeds the maximum size")
+
 // ParseRawSource parses a document's content and applies the preprocessing directives (file inclusions)
 func ParseRawSource(r io.Reader, config configuration.Configuration, options ...Option) ([]byte, error) {
+	source, _, err := preprocess(r, config, options...)
+	return source, err
+}
+
+// preprocess applies the preprocessing directives on the document's content, and returns the resulting source
+// along with the position of each of its lines in the original source
+func preprocess(r io.Reader, config configuration.Configuration, options ...Option) ([]byte, sourceMap, error) {
 	ctx := substitutionContext{
 		attributes: types.AttributesWithOverrides{
 			Content:   map[string]interface{}{},
-			Overrides: map[string]string{},
+			Overrides: config.AttributeOverrides,
 			Counters:  map[string]interface{}{},
 		},
 		config: config,
 	}
-	return parseRawSource(ctx, r, []levelOffset{}, append(options, Entrypoint("RawSource"))...)
+	return parseRawSource(ctx, r, nil, []levelOffset{}, append(options, Entrypoint("RawSource"))...)
 }
 
-func parseRawSource(ctx substitutionContext, r io.Reader, levelOffsets []levelOffset, options ...Option) ([]byte, error) {
-	lines, err := ParseReader(ctx.config.Filename, r, options...)
+// sourceMap the positions in the original source of the lines of the preprocessed source
+// (ie, after the file inclusions and the conditional inclusions have been processed)
+type sourceMap []types.SourcePosition
+
+// position returns the position in the original source of the given line (starting at 1) of the preprocessed source.
+// Lines without a known position (eg: in the main document, which is not mapped) are returned as-is.
+func (m sourceMap) position(line int) types.SourcePosition {
+	if line < 1 || line > len(m) {
+		return types.SourcePosition{
+			Line: line,
+		}
+	}
+	return m[line-1]
+}
+
+// locate returns the position in the original source of the given line (starting at 1) of the preprocessed source,
+// with the given filename (of the main document) if the line is not in an included file
+func (m sourceMap) locate(line int, filename string) types.SourcePosition {
+	result := m.position(line)
+	if result.Filename == "" {
+		result.Filename = filename
+	}
+	return result
+}
+
+// parseRawSource parses the given source and processes the file inclusions and conditional inclusions.
+// Returns the resulting source along with the position of each of its lines in the original source,
+// given the `origin` positions of the lines read from `r` (or `nil` for the main document)
+func parseRawSource(ctx substitutionContext, r io.Reader, origin sourceMap, levelOffsets []levelOffset, options ...Option) ([]byte, sourceMap, error) {
+	lines, err := parsers.ParseReader(ctx.config.Filename, r, append([]Option{MaxInputSize(ctx.config.MaxInputSize)}, options...)...)
 	if err != nil {
 		log.Errorf("failed to parse raw document: %s", err)
-		return nil, err
+		return nil, nil, err
 	}
 	l, ok := lines.([]interface{})
 	if !ok {
-		return nil, fmt.Errorf("unexpected type of raw lines: '%T'", lines)
+		return nil, nil, fmt.Errorf("unexpected type of raw lines: '%T'", lines)
 	}
-	return processFileInclusions(ctx, l, levelOffsets, options...)
+	return processFileInclusions(ctx, l, origin, levelOffsets, options...)
 }
 
-// processFileInclusions processes the file inclusions in the given lines and returns a serialized content which can be parsed again
-func processFileInclusions(ctx substitutionContext, lines []interface{}, levelOffsets []levelOffset, options ...Option) ([]byte, error) {
+// processFileInclusions processes the file inclusions in the given lines and returns a serialized content which can be parsed again,
+// along with the position of each of its lines in the original source
+func processFileInclusions(ctx substitutionContext, lines []interface{}, origin sourceMap, levelOffsets []levelOffset, options ...Option) ([]byte, sourceMap, error) {
 	result := bytes.NewBuffer(nil)
-	for _, line := range lines {
+	positions := sourceMap{}
+	// writes the given content (which may span multiple lines) which starts at the given line of the current document
+	writeLines := func(line int, content string) {
+		result.WriteString(content)
+		// append linefeed
+		result.WriteString("\n")
+		for i := 0; i <= strings.Count(content, "\n"); i++ {
+			positions = append(positions, origin.position(line+i))
+		}
+	}
+	// keep track of the level offsets that applied when entering this document,
+	// so they can be restored when the `leveloffset` attribute is reset
+	// (and so that changes made in this document do not leak into the caller's)
+	initialLevelOffsets := make([]levelOffset, len(levelOffsets))
+	copy(initialLevelOffsets, levelOffsets)
+	levelOffsets = initialLevelOffsets
+	conditions := &conditionalInclusions{}
+	for _, rawLine := range lines {
+		rl, ok := rawLine.(types.RawSourceLine)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected type of raw source line: '%T'", rawLine)
+		}
+		line := rl.Element
+		switch l := line.(type) {
+		case types.ConditionalInclusion:
+			content, err := conditions.push(ctx, l)
+			if err != nil {
+				return nil, nil, err
+			}
+			if content != "" {
+				writeLines(rl.Line, content)
+			}
+			continue
+		case types.EndOfConditionalInclusion:
+			conditions.pop(l)
+			continue
+		}
+		if conditions.skip() {
+			continue
+		}
 		switch l := line.(type) {
 		case []interface{}:
+			content := strings.Builder{}
 			for _, e := range l {
 				if s, ok := e.(types.StringElement); ok {
-					result.WriteString(s.Content)
+					content.WriteString(s.Content)
 					continue
 				}
-				return nil, fmt.Errorf("unexpected type of element in raw line: '%T'", e)
+				return nil, nil, fmt.Errorf("unexpected type of element in raw line: '%T'", e)
 			}
-			// append linefeed
-			result.WriteString("\n")
+			writeLines(rl.Line, content.String())
 		case types.RawSection:
 			for _, offset := range levelOffsets {
 				oldLevel := l.Level
@@ -71,26 +154,147 @@ func processFileInclusions(ctx substitutionContext, lines []interface{}, levelOf
 					}
 				}
 			}
-			result.WriteString(l.Stringify())
-			// append linefeed
-			result.WriteString("\n")
+			// the offsets must not move the section outside of the supported levels
+			if l.Level < 0 || l.Level > types.MaxSectionLevel {
+				level := l.Level
+				if l.Level < 0 {
+					l.Level = 0
+				} else {
+					l.Level = types.MaxSectionLevel
+				}
+				log.Warnf("level offset moved section '%s' out of range (level %d): using level %d instead", l.Title, level, l.Level)
+			}
+			writeLines(rl.Line, l.Stringify())
 		case types.AttributeDeclaration:
+			if l.Name == types.AttrLevelOffset {
+				if value, ok := l.Value.(string); ok {
+					offset, err := newLevelOffset(value)
+					if err != nil {
+						return nil, nil, fmt.Errorf("unable to process level offset: %w", err)
+					}
+					if offset.absolute {
+						// an absolute `leveloffset` document attribute replaces all offsets currently in effect
+						levelOffsets = []levelOffset{relativeOffset(offset.value)}
+					} else {
+						levelOffsets = append(levelOffsets[:len(levelOffsets):len(levelOffsets)], offset)
+					}
+				}
+			}
 			ctx.attributes.Set(l.Name, l.Value)
-			result.WriteString(l.Stringify())
-			// append linefeed
-			result.WriteString("\n")
+			writeLines(rl.Line, l.Stringify())
+		case types.AttributeReset:
+			if l.Name == types.AttrLevelOffset {
+				// restore the level offsets that applied when entering this document
+				levelOffsets = initialLevelOffsets
+			}
+			delete(ctx.attributes.Content, l.Name)
+			writeLines(rl.Line, l.Stringify())
 		case types.FileInclusion:
-			includedLines, err := parseFileToInclude(ctx, l, levelOffsets, options...)
+			includedLines, includedPositions, err := parseFileToInclude(ctx, l, levelOffsets, options...)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			result.Write(includedLines)
+			positions = append(positions, includedPositions...)
+			// lines which are not mapped to the included file (eg: an unresolved directive) are mapped to the file inclusion itself
+			for i := len(includedPositions); i < bytes.Count(includedLines, []byte("\n")); i++ {
+				positions = append(positions, origin.position(rl.Line))
+			}
 		default:
-			return nil, fmt.Errorf("unexpected type of line: '%T'", line)
+			return nil, nil, fmt.Errorf("unexpected type of line: '%T'", line)
+		}
+	}
+	if len(conditions.stack) > 0 {
+		log.Warnf("unterminated preprocessor conditional directive(s): '%s'", strings.Join(conditions.names(), "', '"))
+	}
+	return result.Bytes(), positions, nil
+
+}
+
+// conditionalInclusions the stack of the conditional inclusions (`ifdef`, `ifndef` and `ifeval` directives)
+// which enclose the current line
+type conditionalInclusions struct {
+	stack []conditionalInclusion
+}
+
+type conditionalInclusion struct {
+	condition types.ConditionalInclusion
+	include   bool
+}
+
+// skip returns `true` if the current line is to be skipped, ie, if one of the enclosing conditions is not met
+func (c *conditionalInclusions) skip() bool {
+	for _, e := range c.stack {
+		if !e.include {
+			return true
 		}
 	}
-	return result.Bytes(), nil
+	return false
+}
+
+// evalConditionalInclusion returns `true` if the content of the given conditional inclusion is to be included
+// in the document, given the attributes which are currently set
+func evalConditionalInclusion(c types.ConditionalInclusion, attributes expression.Attributes) (bool, error) {
+	switch c.Kind {
+	case types.IfdefCondition:
+		return expression.Defined(c.Names, attributes), nil
+	case types.IfndefCondition:
+		return !expression.Defined(c.Names, attributes), nil
+	case types.IfevalCondition:
+		return expression.Eval(c.Expression, attributes)
+	default:
+		return false, fmt.Errorf("unsupported kind of conditional inclusion: '%s'", c.Kind)
+	}
+}
+
+// push evaluates the given condition (unless the current line is already skipped), and returns the content to include
+// if this is a single line condition. Otherwise, the condition is pushed on the stack until its matching `endif`
+func (c *conditionalInclusions) push(ctx substitutionContext, condition types.ConditionalInclusion) (string, error) {
+	include := false
+	if !c.skip() {
+		var err error
+		if include, err = evalConditionalInclusion(condition, ctx.attributes); err != nil {
+			log.Warnf("unable to evaluate the '%s' preprocessor directive: %v", condition.Kind, err)
+			include = false
+		}
+	}
+	if condition.SingleLine() {
+		if include {
+			return condition.Content, nil
+		}
+		return "", nil
+	}
+	c.stack = append(c.stack, conditionalInclusion{
+		condition: condition,
+		include:   include,
+	})
+	return "", nil
+}
 
+// pop removes the last condition from the stack, unless the names of the given `endif` do not match
+func (c *conditionalInclusions) pop(end types.EndOfConditionalInclusion) {
+	if len(c.stack) == 0 {
+		log.Warnf("unmatched preprocessor directive: 'endif::%s[]'", end.Names)
+		return
+	}
+	if last := c.stack[len(c.stack)-1].condition; end.Names != "" && end.Names != last.Names {
+		log.Warnf("mismatched preprocessor directive: 'endif::%s[]', expected 'endif::%s[]'", end.Names, last.Names)
+		return
+	}
+	c.stack = c.stack[:len(c.stack)-1]
+}
+
+// names returns the names (or the expressions) of the conditions in the stack
+func (c *conditionalInclusions) names() []string {
+	result := make([]string, len(c.stack))
+	for i, e := range c.stack {
+		if e.condition.Kind == types.IfevalCondition {
+			result[i] = e.condition.Expression
+			continue
+		}
+		result[i] = e.condition.Names
+	}
+	return result
 }
 
 // levelOffset a func that applies a given offset to the sections of a child document to include in a parent doc (the caller)
@@ -100,6 +304,19 @@ type levelOffset struct {
 	apply    func(*types.RawSection)
 }
 
+// newLevelOffset parses the given value of a `leveloffset` attribute.
+// Values with a leading `+` or `-` sign are relative offsets, other values are absolute offsets.
+func newLevelOffset(value string) (levelOffset, error) {
+	offset, err := strconv.Atoi(value)
+	if err != nil {
+		return levelOffset{}, err
+	}
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		return relativeOffset(offset), nil
+	}
+	return absoluteOffset(offset), nil
+}
+
 func relativeOffset(offset int) levelOffset {
 	return levelOffset{
 		absolute: false,
@@ -131,59 +348,67 @@ func applySubstitutionsOnFileInclusion(ctx substitutionContext, f types.FileIncl
 	return f, nil
 }
 
-func parseFileToInclude(ctx substitutionContext, incl types.FileInclusion, levelOffsets []levelOffset, options ...Option) ([]byte, error) {
+// parseFileToInclude reads the content of the file to include and processes its own file inclusions and conditional inclusions.
+// Returns this content along with the position of each of its lines in the included file(s)
+func parseFileToInclude(ctx substitutionContext, incl types.FileInclusion, levelOffsets []levelOffset, options ...Option) ([]byte, sourceMap, error) {
 	incl, err := applySubstitutionsOnFileInclusion(ctx, incl)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	path := incl.Location.Stringify()
 	currentDir := filepath.Dir(ctx.config.Filename)
 	f, absPath, done, err := open(filepath.Join(currentDir, path))
 	defer done()
 	if err != nil {
-		return nil, fmt.Errorf("Unresolved directive in %s - %s", ctx.config.Filename, incl.RawText)
+		return unresolvedDirective(ctx, incl, fmt.Errorf("%w in %s - %s", ErrUnresolvedDirective, ctx.config.Filename, incl.RawText))
+	}
+	content := &includedContent{
+		filename: absPath,
 	}
-	content := bytes.NewBuffer(nil)
 	scanner := bufio.NewScanner(bufio.NewReader(f))
 	if log.IsLevelEnabled(log.DebugLevel) {
 		log.Debug("parsing file to include")
 		spew.Fdump(log.StandardLogger().Out, incl)
 	}
 	if lr, ok := lineRanges(incl, ctx.config); ok {
-		if err := readWithinLines(scanner, content, lr); err != nil {
-			return nil, fmt.Errorf("Unresolved directive in %s - %s", ctx.config.Filename, incl.RawText)
+		count, err := readWithinLines(scanner, content, lr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w in %s - %s", ErrUnresolvedDirective, ctx.config.Filename, incl.RawText)
+		}
+		if err := checkLineRanges(ctx, incl, path, lr, count); err != nil {
+			return unresolvedDirective(ctx, incl, err)
 		}
 	} else if tr, ok := tagRanges(incl, ctx.config); ok {
 		if err := readWithinTags(path, scanner, content, tr); err != nil {
-			return nil, err // keep the underlying error here
+			// keep the underlying error here, but with the position of the file inclusion
+			return unresolvedDirective(ctx, incl, fmt.Errorf("%s: %w", includePosition(ctx, incl), err))
 		}
 	} else {
 		if err := readAll(scanner, content); err != nil {
-			return nil, fmt.Errorf("Unresolved directive in %s - %s", ctx.config.Filename, incl.RawText)
+			return nil, nil, fmt.Errorf("%w in %s - %s", ErrUnresolvedDirective, ctx.config.Filename, incl.RawText)
 		}
 	}
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("Unresolved directive in %s - %s", ctx.config.Filename, incl.RawText)
+		return nil, nil, fmt.Errorf("%w in %s - %s", ErrUnresolvedDirective, ctx.config.Filename, incl.RawText)
 	}
 	// just include the file content if the file to include is not an Asciidoc document.
 	if !IsAsciidoc(absPath) {
-		return content.Bytes(), nil
+		return content.Bytes(), content.positions, nil
 	}
 	// parse the content, and returns the corresponding elements
 	l, found, err := incl.Attributes.GetAsString(types.AttrLevelOffset)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if found {
-		offset, err := strconv.Atoi(l)
+		offset, err := newLevelOffset(l)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to read file to include")
+			return nil, nil, fmt.Errorf("unable to read file to include: %w", err)
 		}
-		if strings.HasPrefix(l, "+") || strings.HasPrefix(l, "-") {
-			levelOffsets = append(levelOffsets, relativeOffset(offset))
+		if offset.absolute {
+			levelOffsets = []levelOffset{offset}
 		} else {
-			levelOffsets = []levelOffset{absoluteOffset(offset)}
-
+			levelOffsets = append(levelOffsets[:len(levelOffsets):len(levelOffsets)], offset)
 		}
 	}
 
@@ -194,7 +419,48 @@ func parseFileToInclude(ctx substitutionContext, incl types.FileInclusion, level
 	}()
 	ctx.config.Filename = absPath
 	// now, let's parse this content and process nested file inclusions
-	return parseRawSource(ctx, content, levelOffsets, options...)
+	return parseRawSource(ctx, &content.Buffer, content.positions, levelOffsets, options...)
+}
+
+// includePosition returns the position of the given file inclusion in the including document (eg: `doc.adoc: line 3`)
+func includePosition(ctx substitutionContext, incl types.FileInclusion) string {
+	return fmt.Sprintf("%s: line %d", ctx.config.Filename, incl.Line)
+}
+
+// unresolvedDirective returns the given error, unless the `ShowUnresolvedDirectives` setting is enabled,
+// in which case the error is logged and an `Unresolved directive` line is returned in place of the content of the file to include
+func unresolvedDirective(ctx substitutionContext, incl types.FileInclusion, err error) ([]byte, sourceMap, error) {
+	if !ctx.config.ShowUnresolvedDirectives {
+		return nil, nil, err
+	}
+	log.Error(err)
+	return []byte(fmt.Sprintf("Unresolved directive in %s - %s\n", ctx.config.Filename, incl.RawText)), nil, nil
+}
+
+// checkLineRanges verifies that the given line ranges are within the bounds of the included file, which has `count` lines.
+// Logs a warning for each range which is out of bounds, or returns an error if none of the ranges is within the bounds.
+func checkLineRanges(ctx substitutionContext, incl types.FileInclusion, path string, lineRanges types.LineRanges, count int) error {
+	outOfBounds := 0
+	for _, lr := range lineRanges {
+		if lr.StartLine <= count {
+			continue
+		}
+		outOfBounds++
+		if outOfBounds < len(lineRanges) {
+			log.Warnf("%s: lines %s out of range in include file: %s (%d lines)", includePosition(ctx, incl), lineRangeString(lr), path, count)
+		}
+	}
+	if outOfBounds > 0 && outOfBounds == len(lineRanges) {
+		return fmt.Errorf("%s: lines %s out of range in include file: %s (%d lines)", includePosition(ctx, incl), lineRangeString(lineRanges[len(lineRanges)-1]), path, count)
+	}
+	return nil
+}
+
+func lineRangeString(lr types.LineRange) string {
+	if lr.StartLine == lr.EndLine {
+		return strconv.Itoa(lr.StartLine)
+	}
+	return fmt.Sprintf("%d..%d", lr.StartLine, lr.EndLine)
 }
 
 // lineRanges parses the `lines` attribute if it exists in the given FileInclusion, and returns
@@ -206,7 +472,7 @@ func lineRanges(incl types.FileInclusion, config configuration.Configuration) (t
 		return types.LineRanges{}, false
 	}
 	if exists {
-		lr, err := Parse("", []byte(lineRanges), Entrypoint("LineRanges"))
+		lr, err := parsers.parse("", []byte(lineRanges), Entrypoint("LineRanges"))
 		if err != nil {
 			log.Errorf("Unresolved directive in %s - %s", config.Filename, incl.RawText)
 			return types.LineRanges{}, false
@@ -226,7 +492,7 @@ func tagRanges(incl types.FileInclusion, config configuration.Configuration) (ty
 	}
 	log.Debugf("tag ranges to include: %v", spew.Sdump(tagRanges))
 	if exists {
-		tr, err := Parse("", []byte(tagRanges), Entrypoint("TagRanges"))
+		tr, err := parsers.parse("", []byte(tagRanges), Entrypoint("TagRanges"))
 		if err != nil {
 			log.Errorf("Unresolved directive in %s - %s", config.Filename, incl.RawText)
 			return types.TagRanges{}, false
@@ -236,18 +502,19 @@ func tagRanges(incl types.FileInclusion, config configuration.Configuration) (ty
 	return types.TagRanges{}, false
 }
 
-func readWithinLines(scanner *bufio.Scanner, content *bytes.Buffer, lineRanges types.LineRanges) error {
+// readWithinLines reads the lines which match the given ranges, and returns the total number of lines in the file
+func readWithinLines(scanner *bufio.Scanner, content *includedContent, lineRanges types.LineRanges) (int, error) {
 	line := 0
 	for scanner.Scan() {
 		line++
 		// parse the line in search for the `tag::<tag>[]` or `end:<tag>[]` macros
-		l, err := Parse("", scanner.Bytes(), Entrypoint("IncludedFileLine"))
+		l, err := parsers.parse("", scanner.Bytes(), Entrypoint("IncludedFileLine"))
 		if err != nil {
-			return err
+			return line, err
 		}
 		fl, ok := l.(types.IncludedFileLine)
 		if !ok {
-			return errors.Errorf("unexpected type of parsed line in file to include: %T", l)
+			return line, fmt.Errorf("unexpected type of parsed line in file to include: %T", l)
 		}
 		// skip if the line has tags
 		if fl.HasTag() {
@@ -255,20 +522,13 @@ func readWithinLines(scanner *bufio.Scanner, content *bytes.Buffer, lineRanges t
 		}
 		// TODO: stop reading if current line above highest range
 		if lineRanges.Match(line) {
-			_, err := content.Write(scanner.Bytes())
-			if err != nil {
-				return err
-			}
-			_, err = content.WriteString("\n")
-			if err != nil {
-				return err
-			}
+			content.writeLine(scanner.Bytes(), line)
 		}
 	}
-	return nil
+	return line, nil
 }
 
-func readWithinTags(path string, scanner *bufio.Scanner, content *bytes.Buffer, expectedRanges types.TagRanges) error {
+func readWithinTags(path string, scanner *bufio.Scanner, content *includedContent, expectedRanges types.TagRanges) error {
 	// log.Debugf("limiting to tag ranges: %v", expectedRanges)
 	currentRanges := make(map[string]*types.CurrentTagRange, len(expectedRanges)) // ensure capacity
 	lineNumber := 0
@@ -276,13 +536,13 @@ func readWithinTags(path string, scanner *bufio.Scanner, content *bytes.Buffer,
 		lineNumber++
 		line := scanner.Bytes()
 		// parse the line in search for the `tag::<tag>[]` or `end:<tag>[]` macros
-		l, err := Parse("", line, Entrypoint("IncludedFileLine"))
+		l, err := parsers.parse("", line, Entrypoint("IncludedFileLine"))
 		if err != nil {
 			return err
 		}
 		fl, ok := l.(types.IncludedFileLine)
 		if !ok {
-			return errors.Errorf("unexpected type of parsed line in file to include: %T", l)
+			return fmt.Errorf("unexpected type of parsed line in file to include: %T", l)
 		}
 		// check if a start or end tag was found in the line
 		if startTag, ok := fl.GetStartTag(); ok {
@@ -295,14 +555,7 @@ func readWithinTags(path string, scanner *bufio.Scanner, content *bytes.Buffer,
 			currentRanges[endTag.Value].EndLine = lineNumber
 		}
 		if expectedRanges.Match(lineNumber, currentRanges) && !fl.HasTag() {
-			_, err := content.Write(scanner.Bytes())
-			if err != nil {
-				return err
-			}
-			_, err = content.WriteString("\n")
-			if err != nil {
-				return err
-			}
+			content.writeLine(scanner.Bytes(), lineNumber)
 		}
 	}
 	// after the file has been processed, let's check if all tags were "found"
@@ -322,33 +575,45 @@ func readWithinTags(path string, scanner *bufio.Scanner, content *bytes.Buffer,
 	return nil
 }
 
-func readAll(scanner *bufio.Scanner, content *bytes.Buffer) error {
+func readAll(scanner *bufio.Scanner, content *includedContent) error {
+	lineNumber := 0
 	for scanner.Scan() {
+		lineNumber++
 		// parse the line in search for the `tag::<tag>[]` or `end:<tag>[]` macros
-		l, err := Parse("", scanner.Bytes(), Entrypoint("IncludedFileLine"))
+		l, err := parsers.parse("", scanner.Bytes(), Entrypoint("IncludedFileLine"))
 		if err != nil {
 			return err
 		}
 		fl, ok := l.(types.IncludedFileLine)
 		if !ok {
-			return errors.Errorf("unexpected type of parsed line in file to include: %T", l)
+			return fmt.Errorf("unexpected type of parsed line in file to include: %T", l)
 		}
 		// skip if the line has tags
 		if fl.HasTag() {
 			continue
 		}
-		_, err = content.Write(scanner.Bytes())
-		if err != nil {
-			return err
-		}
-		_, err = content.WriteString("\n")
-		if err != nil {
-			return err
-		}
+		content.writeLine(scanner.Bytes(), lineNumber)
 	}
 	return nil
 }
 
+// includedContent the content read from a file to include, along with the position of each line in this file
+type includedContent struct {
+	bytes.Buffer
+	filename  string
+	positions sourceMap
+}
+
+// writeLine writes the given line (found at the given line number in the file to include) and a linefeed
+func (c *includedContent) writeLine(line []byte, number int) {
+	c.Write(line)
+	c.WriteString("\n")
+	c.positions = append(c.positions, types.SourcePosition{
+		Filename: c.filename,
+		Line:     number,
+	})
+}
+
 func open(path string) (*os.File, string, func(), error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -400,3 +665,22 @@ func IsAsciidoc(path string) bool {
 	ext := filepath.Ext(path)
 	return ext == ".asciidoc" || ext == ".adoc" || ext == ".ad" || ext == ".asc" || ext == ".txt"
 }
+
+// limitedReader a reader which returns an `ErrInputTooLarge` error if the
+// underlying reader has more than the given number of bytes to read
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1] // read 1 extra byte at most, to detect if the limit was exceeded
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrInputTooLarge
+	}
+	return n, err
+}
@@ -1,8 +1,11 @@
 package parser_test
 
 import (
+	"errors"
+	"path/filepath"
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/parser"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
@@ -857,6 +860,35 @@ include::{includedir}/include.foo[]`
 						Expect(ParseRawDocument(source)).To(MatchRawDocument(expected))
 					})
 
+					It("file inclusion with partially out of range lines", func() {
+						logs, reset := ConfigureLogger(log.WarnLevel)
+						defer reset()
+						source := `include::../../test/includes/chapter-a.adoc[lines=1;5..6]`
+						expected := types.RawDocument{
+							Elements: []interface{}{
+								types.Section{
+									Level: 0,
+									Title: []interface{}{
+										types.StringElement{
+											Content: "Chapter A",
+										},
+									},
+									Elements: []interface{}{},
+								},
+							},
+						}
+						Expect(ParseRawDocument(source)).To(MatchRawDocument(expected))
+						Expect(logs).To(ContainMessageWithLevel(log.WarnLevel,
+							"test.adoc: line 1: lines 5..6 out of range in include file: ../../test/includes/chapter-a.adoc (3 lines)",
+						))
+					})
+
+					It("file inclusion with out of range lines", func() {
+						source := `include::../../test/includes/chapter-a.adoc[lines=4]`
+						_, err := ParseRawDocument(source)
+						Expect(err).To(MatchError("test.adoc: line 1: lines 4 out of range in include file: ../../test/includes/chapter-a.adoc (3 lines)"))
+					})
+
 					It("file inclusion with multiple unquoted lines", func() {
 						source := `include::../../test/includes/chapter-a.adoc[lines=1..2]`
 						expected := types.RawDocument{
@@ -1196,7 +1228,40 @@ include::{includedir}/include.foo[]`
 					// when/then
 					_, err := ParseRawDocument(source)
 					// verify error in logs
-					Expect(err).To(MatchError("tag 'unknown' not found in include file: ../../test/includes/tag-include.adoc"))
+					Expect(err).To(MatchError("test.adoc: line 1: tag 'unknown' not found in include file: ../../test/includes/tag-include.adoc"))
+				})
+
+				It("file inclusion with unknown tag after other lines", func() {
+					// given
+					source := `a paragraph
+
+include::../../test/includes/tag-include.adoc[tag=unknown]`
+					// when/then
+					_, err := ParseRawDocument(source)
+					Expect(err).To(MatchError("test.adoc: line 3: tag 'unknown' not found in include file: ../../test/includes/tag-include.adoc"))
+				})
+
+				It("file inclusion with unknown tag and unresolved directives shown", func() {
+					logs, reset := ConfigureLogger(log.ErrorLevel)
+					defer reset()
+					source := `include::../../test/includes/tag-include.adoc[tag=unknown]`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.Paragraph{
+								Lines: [][]interface{}{
+									{
+										types.StringElement{
+											Content: "Unresolved directive in test.adoc - include::../../test/includes/tag-include.adoc[tag=unknown]",
+										},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source, configuration.WithFilename("test.adoc"), configuration.WithShowUnresolvedDirectives(true))).To(MatchDocument(expected))
+					Expect(logs).To(ContainMessageWithLevel(log.ErrorLevel,
+						"test.adoc: line 1: tag 'unknown' not found in include file: ../../test/includes/tag-include.adoc",
+					))
 				})
 
 				It("file inclusion with no tag", func() {
@@ -1440,19 +1505,20 @@ include::{includedir}/include.foo[]`
 				It("should fail if directory does not exist in standalone block", func() {
 					source := `include::{unknown}/unknown.adoc[leveloffset=+1]`
 					_, err := ParseRawDocument(source)
-					Expect(err).To(MatchError("Unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]"))
+					Expect(err).To(MatchError("unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]"))
+					Expect(errors.Is(err, parser.ErrUnresolvedDirective)).To(BeTrue())
 				})
 
 				It("should fail if file is missing in standalone block", func() {
 					source := `include::{unknown}/unknown.adoc[leveloffset=+1]`
 					_, err := ParseRawDocument(source)
-					Expect(err).To(MatchError("Unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]"))
+					Expect(err).To(MatchError("unresolved directive in test.adoc - include::{unknown}/unknown.adoc[leveloffset=+1]"))
 				})
 
 				It("should fail if file with attribute in path is not resolved in standalone block", func() {
 					source := `include::{includedir}/unknown.adoc[leveloffset=+1]`
 					_, err := ParseRawDocument(source)
-					Expect(err).To(MatchError("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]"))
+					Expect(err).To(MatchError("unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]"))
 				})
 
 				It("should fail if file is missing in delimited block", func() {
@@ -1460,7 +1526,7 @@ include::{includedir}/include.foo[]`
 include::../../test/includes/unknown.adoc[leveloffset=+1]
 ----`
 					_, err := ParseRawDocument(source)
-					Expect(err).To(MatchError("Unresolved directive in test.adoc - include::../../test/includes/unknown.adoc[leveloffset=+1]"))
+					Expect(err).To(MatchError("unresolved directive in test.adoc - include::../../test/includes/unknown.adoc[leveloffset=+1]"))
 				})
 
 				It("should fail if file with attribute in path is not resolved in delimited block", func() {
@@ -1469,7 +1535,7 @@ include::../../test/includes/unknown.adoc[leveloffset=+1]
 include::{includedir}/unknown.adoc[leveloffset=+1]
 ----`
 					_, err := ParseRawDocument(source)
-					Expect(err).To(MatchError("Unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]"))
+					Expect(err).To(MatchError("unresolved directive in test.adoc - include::{includedir}/unknown.adoc[leveloffset=+1]"))
 				})
 			})
 
@@ -1983,5 +2049,172 @@ include::../../test/includes/hello_world.go.txt[lines=1]
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
+
+		It("should set the source positions in the included file when the source map is enabled", func() {
+			source := `ifdef::foo[]
+skipped
+endif::[]
+include::../../test/includes/chapter-a.adoc[lines=3]
+
+a paragraph`
+			filename, err := filepath.Abs("../../test/includes/chapter-a.adoc")
+			Expect(err).NotTo(HaveOccurred())
+			expected := types.Document{
+				Elements: []interface{}{
+					types.Paragraph{
+						Attributes: types.Attributes{
+							types.AttrSourceLine: 3,
+							types.AttrSourceFile: filename,
+						},
+						Lines: [][]interface{}{
+							{
+								types.StringElement{
+									Content: "content",
+								},
+							},
+						},
+					},
+					types.Paragraph{
+						Attributes: types.Attributes{
+							types.AttrSourceLine: 6,
+						},
+						Lines: [][]interface{}{
+							{
+								types.StringElement{
+									Content: "a paragraph",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source, configuration.WithFilename("test.adoc"), configuration.WithSourceMap(true))).To(MatchDocument(expected))
+		})
+	})
+})
+
+var _ = Describe("conditional inclusions", func() {
+
+	It("should include content when attributes are defined", func() {
+		source := `:foo:
+:bar:
+
+ifdef::foo+bar[]
+foo and bar
+endif::foo+bar[]
+ifndef::foo,baz[]
+neither foo nor baz
+endif::[]
+ifdef::baz,bar[baz or bar]`
+		expected := types.Document{
+			Attributes: types.DocumentAttributes{
+				{Name: "foo", Value: nil},
+				{Name: "bar", Value: nil},
+			},
+			Elements: []interface{}{
+				types.Paragraph{
+					Lines: [][]interface{}{
+						{
+							types.StringElement{
+								Content: "foo and bar",
+							},
+						},
+						{
+							types.StringElement{
+								Content: "baz or bar",
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(ParseDocument(source)).To(MatchDocument(expected))
+	})
+
+	It("should include content when attribute is passed in configuration", func() {
+		source := `ifndef::foo[]
+not foo
+endif::[]
+ifdef::foo[]
+foo
+endif::[]`
+		expected := types.Document{
+			Attributes: types.DocumentAttributes{
+				{Name: "foo", Value: ""},
+			},
+			Elements: []interface{}{
+				types.Paragraph{
+					Lines: [][]interface{}{
+						{
+							types.StringElement{
+								Content: "foo",
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(ParseDocument(source, configuration.WithAttributes(map[string]string{"foo": ""}))).To(MatchDocument(expected))
+	})
+
+	It("should skip nested conditions", func() {
+		source := `:levels: 2
+
+ifeval::[{levels} > 2]
+deep
+ifdef::levels[]
+nested
+endif::levels[]
+endif::[]
+ifeval::[{levels} <= 2]
+shallow
+endif::[]`
+		expected := types.Document{
+			Attributes: types.DocumentAttributes{
+				{Name: "levels", Value: "2"},
+			},
+			Elements: []interface{}{
+				types.Paragraph{
+					Lines: [][]interface{}{
+						{
+							types.StringElement{
+								Content: "shallow",
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(ParseDocument(source)).To(MatchDocument(expected))
+	})
+
+	It("should not evaluate escaped directive", func() {
+		source := `\ifdef::foo[]`
+		expected := types.Document{
+			Elements: []interface{}{
+				types.Paragraph{
+					Lines: [][]interface{}{
+						{
+							types.StringElement{
+								Content: "ifdef::foo[]",
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(ParseDocument(source)).To(MatchDocument(expected))
+	})
+
+	It("should warn about unterminated condition", func() {
+		logs, reset := ConfigureLogger(log.WarnLevel)
+		defer reset()
+		source := `ifdef::foo[]
+foo`
+		expected := types.Document{
+			Elements: []interface{}{},
+		}
+		Expect(ParseDocument(source)).To(MatchDocument(expected))
+		Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "unterminated preprocessor conditional directive(s): 'foo'"))
 	})
 })
@@ -8,7 +8,7 @@ import (
 // IncludeTableOfContentsPlaceHolder includes a `TableOfContentsPlaceHolder` block in the document
 // if the `toc` attribute is present
 func includeTableOfContentsPlaceHolder(doc types.Document) types.Document {
-	if t, found := doc.Attributes[types.AttrTableOfContents]; found {
+	if t, found := doc.Attributes.Get(types.AttrTableOfContents); found {
 		doc = doInsertTableOfContentsPlaceHolder(doc, t)
 	}
 	return doc
@@ -39,7 +39,8 @@ func doInsertTableOfContentsPlaceHolder(doc types.Document, location interface{}
 		} else if preambleIndex, ok := lookupPreamble(doc.Elements); ok {
 			doc.Elements = insertAt(doc.Elements, toc, preambleIndex)
 		}
-	// case "macro":
+	case types.TableOfContentsMacro:
+		// the ToC is rendered at the location of the `toc::[]` macro (if any)
 	default:
 		log.Warnf("invalid or unsupported value for 'toc' attribute: '%s'", location)
 	}
@@ -40,8 +40,8 @@ var _ = Describe("include table of contents", func() {
 
 	It("table of contents with default placement and no header with content", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: nil,
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: nil},
 			},
 			Elements: []interface{}{
 				preamble,
@@ -49,8 +49,8 @@ var _ = Describe("include table of contents", func() {
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: nil,
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: nil},
 			},
 			Elements: []interface{}{
 				tocPlaceHolder,
@@ -63,8 +63,8 @@ var _ = Describe("include table of contents", func() {
 
 	It("table of contents with default placement and a header with content", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: nil,
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: nil},
 			},
 			ElementReferences: types.ElementReferences{}, // can leave empty for this test
 			Elements: []interface{}{
@@ -82,8 +82,8 @@ var _ = Describe("include table of contents", func() {
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: nil,
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: nil},
 			},
 			ElementReferences: types.ElementReferences{}, // can leave empty for this test
 			Elements: []interface{}{
@@ -106,8 +106,8 @@ var _ = Describe("include table of contents", func() {
 
 	It("table of contents with default placement and a header without content", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: nil,
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: nil},
 			},
 			ElementReferences: types.ElementReferences{}, // can leave empty for this test
 			Elements: []interface{}{
@@ -124,8 +124,8 @@ var _ = Describe("include table of contents", func() {
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: nil,
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: nil},
 			},
 			ElementReferences: types.ElementReferences{}, // can leave empty for this test
 			Elements: []interface{}{
@@ -147,8 +147,8 @@ var _ = Describe("include table of contents", func() {
 
 	It("table of contents with preamble placement and no header with content", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: "preamble",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: "preamble"},
 			},
 			Elements: []interface{}{
 				preamble,
@@ -156,8 +156,8 @@ var _ = Describe("include table of contents", func() {
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: "preamble",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: "preamble"},
 			},
 			Elements: []interface{}{
 				preamble,
@@ -170,8 +170,8 @@ var _ = Describe("include table of contents", func() {
 
 	It("table of contents with preamble placement and header with content", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: "preamble",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: "preamble"},
 			},
 			Elements: []interface{}{
 				types.Section{
@@ -188,8 +188,8 @@ var _ = Describe("include table of contents", func() {
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: "preamble",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: "preamble"},
 			},
 			Elements: []interface{}{
 				types.Section{
@@ -211,8 +211,8 @@ var _ = Describe("include table of contents", func() {
 
 	It("table of contents with preamble placement and header without content", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: "preamble",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: "preamble"},
 			},
 			Elements: []interface{}{
 				types.Section{
@@ -228,8 +228,8 @@ var _ = Describe("include table of contents", func() {
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTableOfContents: "preamble",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTableOfContents, Value: "preamble"},
 			},
 			Elements: []interface{}{
 				types.Section{
@@ -5,13 +5,12 @@ import (
 )
 
 // includePreamble wraps all document elements before the first section in a `Preamble`,
-// unless the document has no section. Returns a new document with the changes.
+// unless the document has no header or no section. Returns a new document with the changes.
 func includePreamble(doc types.Document) types.Document {
+	// no preamble in a document without header
 	if header, ok := doc.Header(); ok {
 		header.Elements = doInsertPreamble(header.Elements)
 		doc.Elements[0] = header // need to update the header in the parent doc as we don't use pointers here.
-	} else {
-		doc.Elements = doInsertPreamble(doc.Elements)
 	}
 	return doc
 }
@@ -20,16 +19,21 @@ func doInsertPreamble(blocks []interface{}) []interface{} {
 	preamble := types.Preamble{
 		Elements: make([]interface{}, 0, len(blocks)),
 	}
+	content := false
 	for _, block := range blocks {
-		switch block.(type) {
-		case types.Section:
+		if s, ok := block.(types.Section); ok && !s.IsDiscrete() {
 			break
+		}
+		switch block.(type) {
+		case types.AttributeDeclaration, types.AttributeReset:
+			// not a content block per-se
 		default:
-			preamble.Elements = append(preamble.Elements, block)
+			content = true
 		}
+		preamble.Elements = append(preamble.Elements, block)
 	}
-	// no element in the preamble, or no section in the document, so no preamble to generate
-	if len(preamble.Elements) == 0 || len(preamble.Elements) == len(blocks) {
+	// no content in the preamble, or no section in the document, so no preamble to generate
+	if !content || len(preamble.Elements) == len(blocks) {
 		return blocks
 	}
 	// now, insert the preamble instead of the 'n' blocks that belong to the preamble
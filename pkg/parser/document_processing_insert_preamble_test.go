@@ -9,6 +9,10 @@ import (
 
 var _ = Describe("preambles", func() {
 
+	docTitle := []interface{}{
+		types.StringElement{Content: "foo"},
+	}
+
 	sectionATitle := []interface{}{
 		types.StringElement{Content: "Section A"},
 	}
@@ -19,8 +23,8 @@ var _ = Describe("preambles", func() {
 
 	It("doc without sections", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTitle: "foo",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTitle, Value: "foo"},
 			},
 			ElementReferences: types.ElementReferences{
 				"_section_a": sectionATitle,
@@ -45,8 +49,8 @@ var _ = Describe("preambles", func() {
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTitle: "foo",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTitle, Value: "foo"},
 			},
 			ElementReferences: types.ElementReferences{
 				"_section_a": sectionATitle,
@@ -73,14 +77,10 @@ var _ = Describe("preambles", func() {
 		Expect(includePreamble(source)).To(Equal(expected))
 	})
 
-	It("doc with 1-paragraph preamble", func() {
+	It("doc without header", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTitle: "foo",
-			},
 			ElementReferences: types.ElementReferences{
 				"_section_a": sectionATitle,
-				"_section_b": sectionBTitle,
 			},
 			Elements: []interface{}{
 				types.Paragraph{
@@ -90,43 +90,26 @@ var _ = Describe("preambles", func() {
 						},
 					},
 				},
-				types.BlankLine{},
 				types.Section{
 					Level: 1,
 					Title: sectionATitle,
 					Attributes: types.Attributes{
 						types.AttrID: "_section_a",
 					},
-
-					Elements: []interface{}{},
-				},
-				types.Section{
-					Level: 1,
-					Title: sectionBTitle,
-					Attributes: types.Attributes{
-						types.AttrID: "_section_b",
-					},
 					Elements: []interface{}{},
 				},
 			},
 		}
 		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTitle: "foo",
-			},
 			ElementReferences: types.ElementReferences{
 				"_section_a": sectionATitle,
-				"_section_b": sectionBTitle,
 			},
 			Elements: []interface{}{
-				types.Preamble{
-					Elements: []interface{}{
-						types.Paragraph{
-							Lines: [][]interface{}{
-								{types.StringElement{Content: "a short paragraph"}},
-							},
+				types.Paragraph{
+					Lines: [][]interface{}{
+						{
+							types.StringElement{Content: "a short paragraph"},
 						},
-						types.BlankLine{},
 					},
 				},
 				types.Section{
@@ -135,15 +118,6 @@ var _ = Describe("preambles", func() {
 					Attributes: types.Attributes{
 						types.AttrID: "_section_a",
 					},
-
-					Elements: []interface{}{},
-				},
-				types.Section{
-					Level: 1,
-					Title: sectionBTitle,
-					Attributes: types.Attributes{
-						types.AttrID: "_section_b",
-					},
 					Elements: []interface{}{},
 				},
 			},
@@ -151,92 +125,194 @@ var _ = Describe("preambles", func() {
 		Expect(includePreamble(source)).To(Equal(expected))
 	})
 
-	It("doc with 2-paragraph preamble", func() {
+	It("doc with 1-paragraph preamble", func() {
 		source := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTitle: "foo",
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTitle, Value: "foo"},
 			},
 			ElementReferences: types.ElementReferences{
 				"_section_a": sectionATitle,
 				"_section_b": sectionBTitle,
 			},
 			Elements: []interface{}{
-				types.Paragraph{
-					Lines: [][]interface{}{
-						{
-							types.StringElement{Content: "a short paragraph"},
+				types.Section{
+					Level: 0,
+					Title: docTitle,
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a short paragraph"},
+								},
+							},
 						},
-					},
-				},
-				types.BlankLine{},
-				types.Paragraph{
-					Lines: [][]interface{}{
-						{
-							types.StringElement{Content: "another short paragraph"},
+						types.BlankLine{},
+						types.Section{
+							Level: 1,
+							Title: sectionATitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
+
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Level: 1,
+							Title: sectionBTitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_b",
+							},
+							Elements: []interface{}{},
 						},
 					},
 				},
-				types.BlankLine{},
+			},
+		}
+		expected := types.Document{
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTitle, Value: "foo"},
+			},
+			ElementReferences: types.ElementReferences{
+				"_section_a": sectionATitle,
+				"_section_b": sectionBTitle,
+			},
+			Elements: []interface{}{
 				types.Section{
-					Level: 1,
-					Title: sectionATitle,
-					Attributes: types.Attributes{
-						types.AttrID: "_section_a",
-					},
+					Level: 0,
+					Title: docTitle,
+					Elements: []interface{}{
+						types.Preamble{
+							Elements: []interface{}{
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{types.StringElement{Content: "a short paragraph"}},
+									},
+								},
+								types.BlankLine{},
+							},
+						},
+						types.Section{
+							Level: 1,
+							Title: sectionATitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
 
-					Elements: []interface{}{},
-				},
-				types.Section{
-					Level: 1,
-					Title: sectionBTitle,
-					Attributes: types.Attributes{
-						types.AttrID: "_section_b",
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Level: 1,
+							Title: sectionBTitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_b",
+							},
+							Elements: []interface{}{},
+						},
 					},
-					Elements: []interface{}{},
 				},
 			},
 		}
-		expected := types.Document{
-			Attributes: types.Attributes{
-				types.AttrTitle: "foo",
+		Expect(includePreamble(source)).To(Equal(expected))
+	})
+
+	It("doc with 2-paragraph preamble", func() {
+		source := types.Document{
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTitle, Value: "foo"},
 			},
 			ElementReferences: types.ElementReferences{
 				"_section_a": sectionATitle,
 				"_section_b": sectionBTitle,
 			},
 			Elements: []interface{}{
-				types.Preamble{
+				types.Section{
+					Level: 0,
+					Title: docTitle,
 					Elements: []interface{}{
 						types.Paragraph{
 							Lines: [][]interface{}{
-								{types.StringElement{Content: "a short paragraph"}},
+								{
+									types.StringElement{Content: "a short paragraph"},
+								},
 							},
 						},
 						types.BlankLine{},
 						types.Paragraph{
 							Lines: [][]interface{}{
-								{types.StringElement{Content: "another short paragraph"}},
+								{
+									types.StringElement{Content: "another short paragraph"},
+								},
 							},
 						},
 						types.BlankLine{},
+						types.Section{
+							Level: 1,
+							Title: sectionATitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
+
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Level: 1,
+							Title: sectionBTitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_b",
+							},
+							Elements: []interface{}{},
+						},
 					},
 				},
+			},
+		}
+		expected := types.Document{
+			Attributes: types.DocumentAttributes{
+				{Name: types.AttrTitle, Value: "foo"},
+			},
+			ElementReferences: types.ElementReferences{
+				"_section_a": sectionATitle,
+				"_section_b": sectionBTitle,
+			},
+			Elements: []interface{}{
 				types.Section{
-					Level: 1,
-					Title: sectionATitle,
-					Attributes: types.Attributes{
-						types.AttrID: "_section_a",
-					},
+					Level: 0,
+					Title: docTitle,
+					Elements: []interface{}{
+						types.Preamble{
+							Elements: []interface{}{
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{types.StringElement{Content: "a short paragraph"}},
+									},
+								},
+								types.BlankLine{},
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{types.StringElement{Content: "another short paragraph"}},
+									},
+								},
+								types.BlankLine{},
+							},
+						},
+						types.Section{
+							Level: 1,
+							Title: sectionATitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
 
-					Elements: []interface{}{},
-				},
-				types.Section{
-					Level: 1,
-					Title: sectionBTitle,
-					Attributes: types.Attributes{
-						types.AttrID: "_section_b",
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Level: 1,
+							Title: sectionBTitle,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_b",
+							},
+							Elements: []interface{}{},
+						},
 					},
-					Elements: []interface{}{},
 				},
 			},
 		}
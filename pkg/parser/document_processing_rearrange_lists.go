@@ -1,12 +1,12 @@
 package parser
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -16,6 +16,7 @@ func rearrangeListItems(blocks []interface{}, withinDelimitedBlock bool) ([]inte
 	a := &listArranger{
 		blocks:               make([]interface{}, 0, len(blocks)),
 		lists:                make([]types.List, 0, len(blocks)),
+		labeledListMarkers:   map[*types.LabeledList]int{},
 		blanklineCounter:     0,
 		withinDelimitedBlock: withinDelimitedBlock,
 	}
@@ -44,7 +45,7 @@ func rearrangeListItems(blocks []interface{}, withinDelimitedBlock bool) ([]inte
 				a.appendPendingLists()
 			}
 			if err = a.appendListItem(block); err != nil {
-				return nil, errors.Wrapf(err, "unable to rearrange list items in delimited block")
+				return nil, fmt.Errorf("unable to rearrange list items in delimited block: %w", err)
 			}
 		case types.ContinuedListItemElement:
 			block.Offset = a.blanklineCounter
@@ -61,8 +62,11 @@ func rearrangeListItems(blocks []interface{}, withinDelimitedBlock bool) ([]inte
 }
 
 type listArranger struct {
-	blocks               []interface{}
-	lists                []types.List
+	blocks []interface{}
+	lists  []types.List
+	// the level of the separator (`::`, `:::`, etc.) used by the items of each labeled list,
+	// which may differ from the (normalized) level of the items in the list
+	labeledListMarkers   map[*types.LabeledList]int
 	blanklineCounter     int
 	withinDelimitedBlock bool
 }
@@ -201,15 +205,6 @@ func (a *listArranger) appendUnorderedListItem(item *types.UnorderedListItem) er
 	// log.Debugf("adding a new unordered list")
 	// also, force the current item level to (last seen level + 1)
 	item.Level = maxLevel + 1
-	// also, force the bullet-style based on the list on the level above (if it exists)
-	if len(a.lists) > 0 {
-		parentList := &(a.lists[len(a.lists)-1])
-		parentItem := (*parentList).LastItem()
-		// also, force the bullet style
-		if parentItem, ok := parentItem.(*types.UnorderedListItem); ok {
-			item.BulletStyle = item.BulletStyle.NextLevel(parentItem.BulletStyle)
-		}
-	}
 	a.appendList(types.NewUnorderedList(*item))
 	return nil
 }
@@ -234,8 +229,9 @@ func (a *listArranger) appendLabeledListItem(item types.LabeledListItem) error {
 			// assume we can't have empty lists
 			maxLevel++
 			// log.Debugf("  comparing with list item level %d vs %d", list.Items[0].Level, item.Level)
-			if list.Items[0].Level == item.Level {
+			if a.labeledListMarkers[list] == item.Level {
 				// log.Debugf("found a matching labeled list")
+				item.Level = list.Items[0].Level
 				a.pruneLists(i)
 				list.AddItem(item)
 				// log.Debugf("labeled list at level %d now has %d items", maxLevel, len(list.Items))
@@ -246,17 +242,20 @@ func (a *listArranger) appendLabeledListItem(item types.LabeledListItem) error {
 	// no match found: create a new list and if needed, adjust the level of the item
 	// log.Debugf("adding a new labeled list")
 	// also, force the current item level to (last seen level + 1)
+	marker := item.Level
 	item.Level = maxLevel + 1
-	a.appendList(types.NewLabeledList(item))
+	list := types.NewLabeledList(item)
+	a.labeledListMarkers[list] = marker
+	a.appendList(list)
 	return nil
 }
 
 // a labeled list item term may contain links, images, quoted text, footnotes, etc.
 func parseLabeledListItemTerm(term string) ([]interface{}, error) {
 	// result := []interface{}{}
-	elements, err := ParseReader("", strings.NewReader(term), Entrypoint("LabeledListItemTerm"))
+	elements, err := parsers.ParseReader("", strings.NewReader(term), Entrypoint("LabeledListItemTerm"))
 	if err != nil {
-		return []interface{}{}, errors.Wrap(err, "error while parsing content for inline links")
+		return []interface{}{}, fmt.Errorf("error while parsing content for inline links: %w", err)
 	}
 	return elements.([]interface{}), nil
 }
@@ -1,13 +1,20 @@
 package parser
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/types"
+	log "github.com/sirupsen/logrus"
 )
 
-// rearrangeSections moves elements into section to obtain a hierarchical document instead of a flat thing
-func rearrangeSections(blocks []interface{}) (types.Document, error) {
+// rearrangeSections moves elements into section to obtain a hierarchical document instead of a flat thing.
+// Sections whose level is out of sequence (eg: `== A` followed by `==== B`) are reported with a warning,
+// and their level is repaired if the `relax-section-levels` attribute is set.
+// Sections are also numbered if the `sectnums` attribute is set.
+// The given positions are the ones of the sections (including the document header) in the original source, to include in the warnings
+func rearrangeSections(blocks []interface{}, attrs types.Attributes, positions []types.SourcePosition) (types.Document, error) {
 
 	// use same logic as with list items:
 	// only append a child section to her parent section when
@@ -16,47 +23,88 @@ func rearrangeSections(blocks []interface{}) (types.Document, error) {
 	// log.Debugf("rearranging sections in %d blocks...", len(blocks))
 	tle := make([]interface{}, 0, len(blocks)) // top-level elements
 	sections := make([]types.Section, 0, 6)    // the path to the current section (eg: []{section-level0, section-level1, etc.})
+	levels := make([]int, 0, 6)                // the levels of the sections in the path, as they were in the source (ie, before being repaired)
+	count := 0                                 // the number of sections processed so far
 	elementRefs := types.ElementReferences{}
-	var previous *types.Section // the current "parent" section
+	appendices := 0
+	numbers, err := newSectionNumbers(attrs)
+	if err != nil {
+		return types.Document{}, err
+	}
 	for _, element := range blocks {
-		if e, ok := element.(types.Section); ok {
+		var position types.SourcePosition
+		if _, ok := element.(types.Section); ok {
+			if count < len(positions) {
+				position = positions[count]
+			}
+			count++
+		}
+		if e, ok := element.(types.Section); ok && e.IsDiscrete() {
+			// discrete headings are xref targets (unless `%notitle` is set), but they are not part of the
+			// section tree, hence they are added as regular elements
+			if !e.Attributes.HasOption(types.AttrNoTitle) {
+				if err := referenceSection(&e, elementRefs); err != nil {
+					return types.Document{}, err
+				}
+			}
+			element = e
+		}
+		if e, ok := element.(types.Section); ok && !e.IsDiscrete() {
 			// avoid duplicate IDs in sections
 			if err := referenceSection(&e, elementRefs); err != nil {
 				return types.Document{}, err
 			}
-			if previous == nil { // set first parent
-				// log.Debugf("setting section with title %v as a top-level element", e.Title)
-				sections = append(sections, e)
-			} else if e.Level > previous.Level { // add new level
-				// log.Debugf("adding section with title %v as the first section at level %d", e.Title, e.Level)
-				sections = append(sections, e)
-			} else { // replace at the deepest level
-				sections = pruneSections(sections, e.Level)
-				if len(sections) > 0 && sections[0].Level == e.Level {
-					// log.Debugf("moving section with title %v as a new top-level element", e.Title)
-					tle = append(tle, sections[0])
-					sections = make([]types.Section, 0, 6)
+			// appendices are lettered in the order in which they appear in the document
+			if e.IsAppendix() {
+				e.Attributes = e.Attributes.Set(types.AttrAppendixNumber, appendixLetter(appendices))
+				appendices++
+			}
+			// the parent is the last section in the path with a lower level in the source
+			level := e.Level
+			parent := len(levels) - 1
+			for parent >= 0 && levels[parent] >= level {
+				parent--
+			}
+			// check that no level was skipped since the parent section (or the document header)
+			expectedLevel := 1
+			if parent >= 0 {
+				expectedLevel = levels[parent] + 1
+			}
+			if level > expectedLevel {
+				log.Warnf("section title out of sequence: expected level %d, got level %d%s", expectedLevel, level, sectionPosition(e, position))
+			}
+			// when relaxed, the level is repaired relatively to the (possibly repaired) level of the parent,
+			// so that the sections which were siblings in the source remain siblings
+			if attrs.HasFlag(types.AttrRelaxSectionLevels) {
+				parentLevel := 0
+				if parent >= 0 {
+					parentLevel = sections[parent].Level
+				}
+				if e.Level > parentLevel+1 {
+					e.Level = parentLevel + 1
 				}
-				// log.Debugf("adding section with title %v as another section at level %d", e.Title, e.Level)
-				sections = append(sections, e)
 			}
-			previous = &e // pointer to new current parent
-		} else {
-			if previous == nil {
-				// log.Debugf("adding element of type %T as a top-level element", element)
-				tle = append(tle, element)
-			} else {
-				parentSection := &(sections[len(sections)-1])
-				// log.Debugf("adding element of type %T as a child of section with level %d", element, parentSection.Level)
-				(*parentSection).AddElement(element)
+			if number := numbers.next(e); number != "" {
+				e.Attributes = e.Attributes.Set(types.AttrSectionNumber, number)
 			}
+			// close all sections at the same or at a deeper level,
+			// regardless of the level of the first section in the document
+			sections, tle = pruneSections(sections, e.Level, tle)
+			sections = append(sections, e)
+			levels = append(levels[:len(sections)-1], level)
+		} else if len(sections) == 0 {
+			// log.Debugf("adding element of type %T as a top-level element", element)
+			tle = append(tle, element)
+		} else {
+			parentSection := &(sections[len(sections)-1])
+			// log.Debugf("adding element of type %T as a child of section with level %d", element, parentSection.Level)
+			(*parentSection).AddElement(element)
 		}
 	}
 	// process the remaining sections
-	sections = pruneSections(sections, 1)
-	if len(sections) > 0 {
-		tle = append(tle, sections[0])
-	}
+	_, tle = pruneSections(sections, -1, tle)
+	// also reference the other elements with an ID, and the inline anchors
+	referenceElements(tle, elementRefs)
 	if len(elementRefs) == 0 {
 		elementRefs = nil
 	}
@@ -66,6 +114,89 @@ func rearrangeSections(blocks []interface{}) (types.Document, error) {
 	}, nil
 }
 
+// appendixLetter returns the letter of the appendix at the given (0-based) index,
+// ie: `A` to `Z`, then `AA`, `AB`, etc. (bijective base-26)
+func appendixLetter(index int) string {
+	result := ""
+	for n := index + 1; n > 0; n = (n - 1) / 26 {
+		result = string(rune('A'+(n-1)%26)) + result
+	}
+	return result
+}
+
+// sectionNumbers the counters to number the sections, by level
+type sectionNumbers struct {
+	levels   int      // the number of levels to number
+	counters []int    // the counters of the current section and its parents, indexed by level (minus 1)
+	numbers  []string // the numbers of the current section and its parents (the letter in the case of an appendix)
+}
+
+// newSectionNumbers returns the counters to number the sections,
+// or `nil` if the `sectnums` attribute is not set
+func newSectionNumbers(attrs types.Attributes) (*sectionNumbers, error) {
+	if !attrs.HasFlag(types.AttrSectionNumbers) {
+		return nil, nil
+	}
+	levels := types.DefaultSectionNumberLevels
+	if l, found, err := attrs.GetAsString(types.AttrSectionNumberLevels); err != nil {
+		return nil, err
+	} else if found {
+		if levels, err = strconv.Atoi(l); err != nil {
+			log.Warnf("invalid value of the '%s' attribute: '%s', using the default value (%d)", types.AttrSectionNumberLevels, l, types.DefaultSectionNumberLevels)
+			levels = types.DefaultSectionNumberLevels
+		}
+	}
+	return &sectionNumbers{
+		levels: levels,
+	}, nil
+}
+
+// next returns the number of the given section (eg: `1.2` or `A.1` in an appendix), or an empty string if the section is not numbered,
+// ie, if it is a part (level 0), if it (or one of its parents) has a style (eg: `[glossary]`) or if it is deeper than the number of levels to number.
+// Appendices are not numbered as they already have a letter, but their subsections are.
+func (n *sectionNumbers) next(s types.Section) string {
+	if n == nil || s.Level < 1 {
+		return ""
+	}
+	for len(n.counters) < s.Level {
+		n.counters = append(n.counters, 0)
+		n.numbers = append(n.numbers, "0")
+	}
+	n.counters = n.counters[:s.Level]
+	n.numbers = n.numbers[:s.Level]
+	if number, found := s.Attributes[types.AttrAppendixNumber].(string); found {
+		n.numbers[s.Level-1] = number
+		return ""
+	}
+	if s.Attributes.Has(types.AttrStyle) {
+		n.numbers[s.Level-1] = "" // subsections are not numbered either
+		return ""
+	}
+	n.counters[s.Level-1]++
+	n.numbers[s.Level-1] = strconv.Itoa(n.counters[s.Level-1])
+	if s.Level > n.levels {
+		return ""
+	}
+	for _, number := range n.numbers {
+		if number == "" {
+			return ""
+		}
+	}
+	return strings.Join(n.numbers, ".")
+}
+
+// sectionPosition returns the position (if known) and the ID of the given section, to include in warnings
+func sectionPosition(s types.Section, position types.SourcePosition) string {
+	result := ""
+	if position.Line > 0 {
+		result = fmt.Sprintf(" at %s", position)
+	}
+	if id, found, _ := s.Attributes.GetAsString(types.AttrID); found {
+		result += fmt.Sprintf(" (section '%s')", id)
+	}
+	return result
+}
+
 func referenceSection(e *types.Section, elementRefs types.ElementReferences) error {
 	attrID, found, err := e.Attributes.GetAsString(types.AttrID)
 	if err != nil {
@@ -74,6 +205,18 @@ func referenceSection(e *types.Section, elementRefs types.ElementReferences) err
 	if !found {
 		return nil
 	}
+	// use the reftext (if specified) as the default label in cross references to this section
+	ref := e.Title
+	switch reftext := e.Attributes[types.AttrReftext].(type) {
+	case string:
+		ref = []interface{}{
+			types.StringElement{
+				Content: reftext,
+			},
+		}
+	case []interface{}:
+		ref = reftext
+	}
 	for i := 1; ; i++ {
 		var id string
 		if i == 1 {
@@ -82,32 +225,110 @@ func referenceSection(e *types.Section, elementRefs types.ElementReferences) err
 			id = attrID + "_" + strconv.Itoa(i)
 		}
 		if _, found := elementRefs[id]; !found {
-			elementRefs[id] = e.Title
+			elementRefs[id] = ref
 			// override the element id
 			e.Attributes.Set(types.AttrID, id)
 			break
 		}
 	}
-	elementRefs[attrID] = e.Title
+	elementRefs[attrID] = ref
 	return nil
 }
 
-func pruneSections(sections []types.Section, level int) []types.Section {
-	if len(sections) > 0 && level > 0 { // && level < len(sections) {
-		// log.Debugf("pruning the section path with %d level(s) of deep", len(sections))
-		// add the last list(s) as children of their parent, in reverse order,
-		// because we copy the value, not the pointers
-		cut := len(sections)
-		for i := len(sections) - 1; i > 0 && sections[i].Level >= level; i-- {
-			parentSection := &(sections[i-1])
-			// log.Debugf("appending section at depth %d (%v) to the last element of the parent section (%v)", i, sections[i].Title, parentSection.Title)
-			(*parentSection).AddElement(sections[i])
-			cut = i
+// referenceElements adds an entry in the given element references for each (non-section) block with an ID,
+// and for each inline anchor in the given elements and their nested elements.
+// The entry is the reftext of the element if available, otherwise its title, or `nil` if it has none
+// (in which case the ID is used as the label in the cross references to this element).
+// Entries which already exist (eg: for sections) are not overridden.
+func referenceElements(elements []interface{}, elementRefs types.ElementReferences) {
+	for _, element := range elements {
+		switch e := element.(type) {
+		case types.Section:
+			// section itself was already referenced
+			referenceElements(e.Title, elementRefs)
+			referenceElements(e.Elements, elementRefs)
+			continue
+		case types.Preamble:
+			referenceElements(e.Elements, elementRefs)
+		case types.InlineAnchor:
+			referenceElement(e.ID, e.RefText, nil, elementRefs)
+		case types.OrderedList:
+			for _, item := range e.Items {
+				referenceElements([]interface{}{item}, elementRefs)
+			}
+		case types.UnorderedList:
+			for _, item := range e.Items {
+				referenceElements([]interface{}{item}, elementRefs)
+			}
+		case types.LabeledList:
+			for _, item := range e.Items {
+				referenceElements(item.Term, elementRefs)
+				referenceElements([]interface{}{item}, elementRefs)
+			}
+		case types.Table:
+			if id, ok := e.Attributes[types.AttrID].(string); ok {
+				referenceElement(id, e.Attributes[types.AttrReftext], e.Attributes[types.AttrTitle], elementRefs)
+			}
+			for _, line := range append(append([]types.TableLine{e.Header}, e.Lines...), e.Footer) {
+				for _, cell := range line.Cells {
+					referenceElements(cell, elementRefs)
+				}
+			}
+		case types.InnerDocument:
+			referenceElements(e.Elements, elementRefs)
+		}
+		if e, ok := element.(types.WithAttributesToSubstitute); ok {
+			attrs := e.AttributesToSubstitute()
+			if id, ok := attrs[types.AttrID].(string); ok {
+				referenceElement(id, attrs[types.AttrReftext], attrs[types.AttrTitle], elementRefs)
+			}
+		}
+		if e, ok := element.(types.WithLineSubstitution); ok {
+			for _, line := range e.LinesToSubstitute() {
+				referenceElements(line, elementRefs)
+			}
+		}
+		if e, ok := element.(types.WithElementsToSubstitute); ok {
+			referenceElements(e.ElementsToSubstitute(), elementRefs)
+		}
+	}
+}
+
+func referenceElement(id string, reftext, title interface{}, elementRefs types.ElementReferences) {
+	if _, found := elementRefs[id]; found {
+		return
+	}
+	for _, ref := range []interface{}{reftext, title} {
+		switch ref := ref.(type) {
+		case string:
+			elementRefs[id] = []interface{}{
+				types.StringElement{
+					Content: ref,
+				},
+			}
+			return
+		case []interface{}:
+			elementRefs[id] = ref
+			return
+		}
+	}
+	elementRefs[id] = nil
+}
+
+// pruneSections closes all sections whose level is greater than or equal to the given level, by
+// appending each one of them to its parent section, or to the top-level elements if it has no parent.
+// Returns the remaining path of sections and the top-level elements.
+func pruneSections(sections []types.Section, level int, tle []interface{}) ([]types.Section, []interface{}) {
+	for len(sections) > 0 && sections[len(sections)-1].Level >= level {
+		last := sections[len(sections)-1]
+		sections = sections[:len(sections)-1]
+		if len(sections) > 0 {
+			// log.Debugf("appending section (%v) to the last element of the parent section (%v)", last.Title, sections[len(sections)-1].Title)
+			(&sections[len(sections)-1]).AddElement(last)
+		} else {
+			// log.Debugf("moving section with title %v as a new top-level element", last.Title)
+			tle = append(tle, last)
 		}
-		// also, prune the pointers to the remaining sublists
-		sections := sections[0:cut]
-		// log.Debugf("sections list has now %d top-level elements", len(sections))
-		return sections
 	}
-	return sections
+	return sections, tle
 }
@@ -3,6 +3,7 @@ package parser
 import (
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega" //nolint golint
 )
 
@@ -156,7 +157,7 @@ var _ = Describe("rearrange sections", func() {
 				},
 			},
 		}
-		Expect(rearrangeSections(actual)).To(Equal(expected))
+		Expect(rearrangeSections(actual, nil, nil)).To(Equal(expected))
 	})
 
 	It("section levels 1, 2, 3, 3", func() {
@@ -307,7 +308,7 @@ var _ = Describe("rearrange sections", func() {
 				},
 			},
 		}
-		Expect(rearrangeSections(actual)).To(Equal(expected))
+		Expect(rearrangeSections(actual, nil, nil)).To(Equal(expected))
 	})
 
 	It("section levels 1, 3, 4, 4", func() {
@@ -458,7 +459,21 @@ var _ = Describe("rearrange sections", func() {
 				},
 			},
 		}
-		Expect(rearrangeSections(actual)).To(Equal(expected))
+		Expect(rearrangeSections(actual, nil, nil)).To(Equal(expected))
 	})
 
 })
+
+var _ = DescribeTable("appendix letters",
+	func(index int, expected string) {
+		Expect(appendixLetter(index)).To(Equal(expected))
+	},
+	Entry("first", 0, "A"),
+	Entry("last single letter", 25, "Z"),
+	Entry("first double letter", 26, "AA"),
+	Entry("second double letter", 27, "AB"),
+	Entry("last double letter with A", 51, "AZ"),
+	Entry("first double letter with B", 52, "BA"),
+	Entry("last double letter", 701, "ZZ"),
+	Entry("first triple letter", 702, "AAA"),
+)
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// ErrStrictMode the error returned when the source document contains deprecated or ambiguous constructs
+// and the strict mode is enabled in the configuration
+var ErrStrictMode = errors.New("non-compliant content in strict mode")
+
+var (
+	// a list item or section title marker followed by a tab instead of a space
+	tabAfterMarker = regexp.MustCompile(`^[ \t]*(\*+|-|\.+|[0-9]+\.|[a-zA-Z]\.|[ivxIVX]+\)|<[0-9.]+>|=+|#+)\t`)
+	// a delimiter of a block (opening or closing)
+	blockDelimiter = regexp.MustCompile("^(/{4,}|={4,}|-{4,}|\\.{4,}|\\+{4,}|_{4,}|\\*{4,}|```[\\w-]*|--|\\|===)[ \\t]*$")
+	// a block attributes line with a single upper case style, as in `[NOTE]`
+	upperCaseStyle = regexp.MustCompile(`^\[([A-Z]+)(,.*)?\]$`)
+	// the lines which can precede a block delimiter, without a blank line in between
+	blockPrefix = regexp.MustCompile(`^(\[.*\]|\.[^ .\t].*|//.*|\+|:!?[\w{][^:]*!?:.*)$`)
+)
+
+// checkStrictMode checks that the given source does not contain deprecated or ambiguous constructs,
+// ie: tabs after list item or section title markers, delimited blocks which are not preceded by a blank line,
+// and admonitions with an unknown label.
+// The source is the one which results from the preprocessing of the document (with the given filename), and the problems
+// are reported with their position in the original source, given the positions of its lines.
+func checkStrictMode(source []byte, positions sourceMap, filename string) error {
+	problems := []string{}
+	delimiters := []string{} // the delimiters of the blocks in which the current line is
+	previous := ""
+	scanner := bufio.NewScanner(bytes.NewReader(source))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		verbatim := len(delimiters) > 0 && isVerbatimDelimiter(delimiters[len(delimiters)-1])
+		if d := blockDelimiter.FindString(line); d != "" {
+			d = strings.TrimSpace(d)
+			switch {
+			case len(delimiters) > 0 && (d == delimiters[len(delimiters)-1] || (strings.HasPrefix(d, "```") && delimiters[len(delimiters)-1] == "```")):
+				delimiters = delimiters[:len(delimiters)-1]
+			case verbatim:
+				// not a delimiter within a verbatim block
+			default:
+				if strings.TrimSpace(previous) != "" && !blockPrefix.MatchString(previous) {
+					problems = append(problems, fmt.Sprintf("%s: missing blank line before the delimited block", strictModePosition(positions.locate(n, filename))))
+				}
+				if strings.HasPrefix(d, "```") {
+					d = "```"
+				}
+				delimiters = append(delimiters, d)
+			}
+			previous = ""
+			continue
+		}
+		if !verbatim {
+			if tabAfterMarker.MatchString(line) {
+				problems = append(problems, fmt.Sprintf("%s: tab after the list item or section title marker", strictModePosition(positions.locate(n, filename))))
+			}
+			if m := upperCaseStyle.FindStringSubmatch(line); m != nil && !isAdmonitionLabel(m[1]) {
+				problems = append(problems, fmt.Sprintf("%s: unknown admonition label '%s'", strictModePosition(positions.locate(n, filename)), m[1]))
+			}
+		}
+		previous = line
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrStrictMode, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// strictModePosition returns the given position as `<filename>: line <line>`, or as `line <line>` if the filename is unknown
+func strictModePosition(p types.SourcePosition) string {
+	if p.Filename == "" {
+		return fmt.Sprintf("line %d", p.Line)
+	}
+	return fmt.Sprintf("%s: line %d", p.Filename, p.Line)
+}
+
+func isVerbatimDelimiter(d string) bool {
+	switch d[0] {
+	case '/', '-', '.', '+', '`':
+		return d != "--" // open blocks are not verbatim
+	default:
+		return false
+	}
+}
+
+func isAdmonitionLabel(label string) bool {
+	switch label {
+	case types.Tip, types.Note, types.Important, types.Warning, types.Caution:
+		return true
+	default:
+		return false
+	}
+}
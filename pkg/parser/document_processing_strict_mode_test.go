@@ -0,0 +1,113 @@
+package parser_test
+
+import (
+	"path/filepath"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("strict mode", func() {
+
+	It("should accept compliant content", func() {
+		source := `= Title
+
+.a title
+[source,go]
+----
+*	not a list item
+[DANGER]
+----
+
+* item
++
+====
+[NOTE]
+a note
+====
+
+[WARNING]
+--
+a warning
+--`
+		_, err := ParseDocument(source, configuration.WithStrict(true))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject tabs after markers", func() {
+		source := "==\tSection\n\n*\titem\n. item\n1.\titem"
+		_, err := ParseDocument(source, configuration.WithStrict(true))
+		Expect(err).To(MatchError(parser.ErrStrictMode))
+		Expect(err).To(MatchError("non-compliant content in strict mode: " +
+			"line 1: tab after the list item or section title marker; " +
+			"line 3: tab after the list item or section title marker; " +
+			"line 5: tab after the list item or section title marker"))
+	})
+
+	It("should reject delimited blocks without blank line before", func() {
+		source := `a paragraph
+----
+a listing
+----
+
+====
+an example
+****
+a sidebar
+****
+====`
+		_, err := ParseDocument(source, configuration.WithStrict(true))
+		Expect(err).To(MatchError("non-compliant content in strict mode: " +
+			"line 2: missing blank line before the delimited block; " +
+			"line 8: missing blank line before the delimited block"))
+	})
+
+	It("should reject unknown admonition labels", func() {
+		source := `[DANGER]
+do not do this
+
+[TIP]
+do this instead`
+		_, err := ParseDocument(source, configuration.WithStrict(true), configuration.WithFilename("test.adoc"))
+		Expect(err).To(MatchError("non-compliant content in strict mode: test.adoc: line 1: unknown admonition label 'DANGER'"))
+	})
+
+	It("should not check the content excluded by a conditional inclusion", func() {
+		source := `ifdef::foo[]
+[DANGER]
+do not do this
+endif::[]
+
+[CAUTION]
+do this instead
+
+[DANGER]
+do not do this either`
+		_, err := ParseDocument(source, configuration.WithStrict(true), configuration.WithFilename("test.adoc"))
+		Expect(err).To(MatchError("non-compliant content in strict mode: test.adoc: line 9: unknown admonition label 'DANGER'"))
+	})
+
+	It("should report the position of the problems in included files", func() {
+		source := `a paragraph
+
+include::../../test/includes/strict-mode.adoc[]`
+		filename, err := filepath.Abs("../../test/includes/strict-mode.adoc")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ParseDocument(source, configuration.WithStrict(true), configuration.WithFilename("test.adoc"))
+		Expect(err).To(MatchError("non-compliant content in strict mode: " + filename + ": line 3: unknown admonition label 'DANGER'"))
+	})
+
+	It("should accept non-compliant content when not in strict mode", func() {
+		source := `[DANGER]
+a paragraph
+----
+a listing
+----`
+		_, err := ParseDocument(source)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
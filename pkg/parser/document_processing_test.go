@@ -2,6 +2,7 @@ package parser_test
 
 import (
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
@@ -19,8 +20,8 @@ var _ = Describe("document processing", func() {
 foo
 ----`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					types.AttrSyntaxHighlighter: "pygments",
+				Attributes: types.DocumentAttributes{
+					{Name: types.AttrSyntaxHighlighter, Value: "pygments"},
 				},
 				Elements: []interface{}{
 					types.ListingBlock{
@@ -81,8 +82,8 @@ Preamble comes here
 				},
 			}
 			expected := types.Document{
-				Attributes: types.Attributes{
-					types.AttrTableOfContents: nil,
+				Attributes: types.DocumentAttributes{
+					{Name: types.AttrTableOfContents, Value: nil},
 				},
 				Elements: []interface{}{
 					types.Section{
@@ -184,27 +185,27 @@ eve - analyzes an image to determine if it's a picture of a life form
 			}
 
 			expected := types.Document{
-				Attributes: types.Attributes{
-					types.AttrDocType: "manpage",
-					types.AttrAuthors: []types.DocumentAuthor{
+				Attributes: types.DocumentAttributes{
+					{Name: "author", Value: "Andrew Stanton"},
+					{Name: "authorinitials", Value: "AS"},
+					{Name: types.AttrAuthors, Value: []types.DocumentAuthor{
 						{
 							FullName: "Andrew Stanton",
 						},
-					},
-					"firstname":      "Andrew",
-					"lastname":       "Stanton",
-					"author":         "Andrew Stanton",
-					"authorinitials": "AS",
-					types.AttrRevision: types.DocumentRevision{
+					}},
+					{Name: "firstname", Value: "Andrew"},
+					{Name: "lastname", Value: "Stanton"},
+					{Name: types.AttrRevision, Value: types.DocumentRevision{
 						Revnumber: "1.0.0",
-					},
-					"revnumber": "1.0.0",
+					}},
+					{Name: "revnumber", Value: "1.0.0"},
+					{Name: types.AttrDocType, Value: "manpage"},
 				},
 				Elements: []interface{}{
 					types.Section{
 						Level: 0,
 						Attributes: types.Attributes{
-							types.AttrID: "_eve_1",
+							types.AttrID: "_eve1",
 						},
 						Title: headerSectionTitle,
 						Elements: []interface{}{
@@ -238,7 +239,7 @@ eve - analyzes an image to determine if it's a picture of a life form
 					},
 				},
 				ElementReferences: types.ElementReferences{
-					"_eve_1":    headerSectionTitle,
+					"_eve1":     headerSectionTitle,
 					"_name":     nameSectionTitle,
 					"_synopsis": synopisSectionTitle,
 				},
@@ -251,4 +252,31 @@ eve - analyzes an image to determine if it's a picture of a life form
 		})
 
 	})
+
+	Context("input size", func() {
+
+		It("should parse document within the max input size", func() {
+			source := `some content`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.StringElement{
+									Content: "some content",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source, configuration.WithMaxInputSize(int64(len(source))))).To(MatchDocument(expected))
+		})
+
+		It("should not parse document exceeding the max input size", func() {
+			source := `some content`
+			_, err := ParseDocument(source, configuration.WithMaxInputSize(int64(len(source)-1)))
+			Expect(err).To(MatchError(parser.ErrInputTooLarge))
+		})
+	})
 })
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// the kinds of delimited blocks, indexed by the first character of their delimiter
+var delimitedBlockKinds = map[byte]string{
+	'/': "comment",
+	'=': "example",
+	'-': "listing",
+	'.': "literal",
+	'+': "passthrough",
+	'_': "quote",
+	'*': "sidebar",
+	'`': "fenced",
+	'|': "table",
+}
+
+// openDelimiter the opening delimiter of a delimited block, along with the line (in the preprocessed source) at which it was found
+type openDelimiter struct {
+	delimiter string
+	line      int
+}
+
+// unterminatedBlockWarnings returns a warning for each delimited block which was not closed before the end of the document
+// (as reported by the parser), in which case all the remaining content of the document was included in the block.
+// The warnings refer to the position of the opening delimiters in the original source, in the main document (identified by the given filename)
+// or in an included file.
+func unterminatedBlockWarnings(delimiters []openDelimiter, positions sourceMap, filename string) []types.SourceWarning {
+	if len(delimiters) == 0 {
+		return nil
+	}
+	// the inner blocks reach the end of the document first, but the warnings are reported in order of appearance
+	delimiters = append([]openDelimiter{}, delimiters...)
+	sort.SliceStable(delimiters, func(i, j int) bool {
+		return delimiters[i].line < delimiters[j].line
+	})
+	warnings := make([]types.SourceWarning, len(delimiters))
+	for i, d := range delimiters {
+		position := positions.locate(d.line, filename)
+		warnings[i] = types.SourceWarning{
+			Filename: position.Filename,
+			Line:     position.Line,
+			Message:  fmt.Sprintf("unterminated %s block: the '%s' delimiter at %s is not closed before the end of the document", delimitedBlockKinds[d.delimiter[0]], d.delimiter, position),
+		}
+	}
+	return warnings
+}
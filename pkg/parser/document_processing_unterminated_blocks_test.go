@@ -0,0 +1,129 @@
+package parser_test
+
+import (
+	"path/filepath"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("unterminated delimited blocks", func() {
+
+	It("should not report closed blocks", func() {
+		source := `= Title
+
+.a title
+[source,go]
+----
+====
+not an example
+----
+
+* item
++
+====
+[NOTE]
+--
+a note
+--
+====
+
+a paragraph
+
+....
+a literal block
+....
+
+++++++ and .....`
+		doc, err := ParseDocument(source)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doc.Warnings).To(BeEmpty())
+	})
+
+	It("should report nested unterminated blocks with their opening line", func() {
+		source := `a paragraph
+
+====
+an example
+
+****
+a sidebar
+
+////
+a comment`
+		doc, err := ParseDocument(source)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doc.Warnings).To(Equal([]types.SourceWarning{
+			{
+				Line:    3,
+				Message: "unterminated example block: the '====' delimiter at line 3 is not closed before the end of the document",
+			},
+			{
+				Line:    6,
+				Message: "unterminated sidebar block: the '****' delimiter at line 6 is not closed before the end of the document",
+			},
+			{
+				Line:    9,
+				Message: "unterminated comment block: the '////' delimiter at line 9 is not closed before the end of the document",
+			},
+		}))
+	})
+
+	It("should report unterminated block in included file", func() {
+		source := `a paragraph
+
+include::../../test/includes/unterminated-block.adoc[]
+
+another paragraph`
+		filename, err := filepath.Abs("../../test/includes/unterminated-block.adoc")
+		Expect(err).NotTo(HaveOccurred())
+		doc, err := ParseDocument(source, configuration.WithFilename("test.adoc"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doc.Warnings).To(Equal([]types.SourceWarning{
+			{
+				Filename: filename,
+				Line:     3,
+				Message:  "unterminated listing block: the '----' delimiter at " + filename + ":3 is not closed before the end of the document",
+			},
+		}))
+	})
+
+	It("should report unterminated block with its line in the document after conditional inclusions", func() {
+		source := `ifdef::foo[]
+----
+skipped
+----
+endif::[]
+|===
+| a cell`
+		doc, err := ParseDocument(source)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doc.Warnings).To(Equal([]types.SourceWarning{
+			{
+				Line:    6,
+				Message: "unterminated table block: the '|===' delimiter at line 6 is not closed before the end of the document",
+			},
+		}))
+	})
+
+	It("should report unterminated block with the filename of the document", func() {
+		source := `a paragraph
+
+[source,go]
+----
+package foo`
+		doc, err := ParseDocument(source, configuration.WithFilename("test.adoc"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doc.Warnings).To(Equal([]types.SourceWarning{
+			{
+				Filename: "test.adoc",
+				Line:     4,
+				Message:  "unterminated listing block: the '----' delimiter at test.adoc:4 is not closed before the end of the document",
+			},
+		}))
+	})
+})
@@ -120,6 +120,9 @@ a paragraph`
 					source := `[[img-foobar]]
 a paragraph`
 					expected := types.Document{
+						ElementReferences: types.ElementReferences{
+							"img-foobar": nil, // no reftext nor title
+						},
 						Elements: []interface{}{
 							types.Paragraph{
 								Attributes: types.Attributes{
@@ -142,6 +145,9 @@ a paragraph`
 					source := `[#img-foobar]
 a paragraph`
 					expected := types.Document{
+						ElementReferences: types.ElementReferences{
+							"img-foobar": nil, // no reftext nor title
+						},
 						Elements: []interface{}{
 							types.Paragraph{
 								Attributes: types.Attributes{
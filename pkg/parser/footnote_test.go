@@ -227,8 +227,8 @@ a paragraph with another footnote.footnote:[baz]`
 				},
 			}
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"idprefix": "id_",
+				Attributes: types.DocumentAttributes{
+					{Name: "idprefix", Value: "id_"},
 				},
 				ElementReferences: types.ElementReferences{
 					"id_title":     docTitle,
@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
@@ -22,9 +23,9 @@ author: Xavier
 
 first paragraph`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"title":  "a title", // TODO: convert `title` attribute from front-matter into `doctitle` here ?
-						"author": "Xavier",
+					Attributes: types.DocumentAttributes{
+						{Name: "author", Value: "Xavier"},
+						{Name: "title", Value: "a title"}, // TODO: convert `title` attribute from front-matter into `doctitle` here ?
 					},
 					Elements: []interface{}{
 						types.Paragraph{
@@ -57,6 +58,126 @@ first paragraph`
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
+
+			It("skipped front-matter", func() {
+				source := `---
+title: a title
+author: Xavier
+---
+
+first paragraph`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "first paragraph"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source, configuration.SkipFrontMatter())).To(MatchDocument(expected))
+			})
+		})
+
+		Context("toml front-matter", func() {
+
+			It("front-matter with simple attributes", func() {
+				source := `+++
+title = "a title" # the title
+draft = false
+weight = 1_000
+tags = ["go", 'asciidoc',]
+date = 2021-03-21
+
+[params]
+author = { name = "Xavier", email = "xavier@example.com" }
++++
+
+first paragraph`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: "date", Value: "2021-03-21"},
+						{Name: "draft", Value: false},
+						{Name: "params", Value: map[string]interface{}{
+							"author": map[string]interface{}{
+								"name":  "Xavier",
+								"email": "xavier@example.com",
+							},
+						}},
+						{Name: "tags", Value: []interface{}{"go", "asciidoc"}},
+						{Name: "title", Value: "a title"},
+						{Name: "weight", Value: 1000},
+					},
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "first paragraph"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source, configuration.ParseFrontMatter(configuration.TOMLFrontMatter))).To(MatchDocument(expected))
+			})
+
+			It("front-matter along with yaml front-matter", func() {
+				source := `+++
+title = "a title"
++++
+
+first paragraph`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: "title", Value: "a title"},
+					},
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "first paragraph"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source,
+					configuration.ParseFrontMatter(configuration.YAMLFrontMatter),
+					configuration.ParseFrontMatter(configuration.TOMLFrontMatter),
+				)).To(MatchDocument(expected))
+			})
+
+			It("skipped front-matter", func() {
+				source := `+++
+title = "a title"
++++
+
+first paragraph`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "first paragraph"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source, configuration.SkipFrontMatter())).To(MatchDocument(expected))
+			})
+
+			It("invalid front-matter", func() {
+				source := `+++
+title = "a title
++++
+
+first paragraph`
+				_, err := ParseDocument(source, configuration.ParseFrontMatter(configuration.TOMLFrontMatter))
+				Expect(err).To(HaveOccurred())
+			})
 		})
 	})
 })
@@ -0,0 +1,92 @@
+package parser_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("generic block macros", func() {
+
+	Context("final documents", func() {
+
+		It("with target and attributes", func() {
+			source := `:format: svg
+
+.A diagram
+[#diagram]
+plantuml::diagrams/sequence.puml[format={format},width=100]`
+			expected := types.Document{
+				Attributes: types.DocumentAttributes{
+					{Name: "format", Value: "svg"},
+				},
+				Elements: []interface{}{
+					types.GenericBlockMacro{
+						Name:   "plantuml",
+						Target: "diagrams/sequence.puml",
+						Attributes: types.Attributes{
+							types.AttrTitle: "A diagram",
+							types.AttrID:    "diagram",
+							"format":        "svg",
+							types.AttrWidth: "100",
+						},
+						RawText: "plantuml::diagrams/sequence.puml[format={format},width=100]",
+					},
+				},
+				ElementReferences: types.ElementReferences{
+					"diagram": []interface{}{
+						types.StringElement{Content: "A diagram"},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("with dashes in name and without target", func() {
+			source := `include-code::[]`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.GenericBlockMacro{
+						Name:    "include-code",
+						RawText: "include-code::[]",
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("not with built-in name", func() {
+			source := `tag::foo[]`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.StringElement{Content: "tag::foo[]"},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("not with trailing content", func() {
+			source := `std::vector[int] is a template`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.StringElement{Content: "std::vector[int] is a template"},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
+})
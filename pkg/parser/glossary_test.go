@@ -0,0 +1,168 @@
+package parser_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("glossaries", func() {
+
+	Context("draft documents", func() {
+
+		It("term macro", func() {
+			source := `a term:[mud] paragraph`
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.StringElement{Content: "a "},
+								types.GlossaryTerm{Term: "mud"},
+								types.StringElement{Content: " paragraph"},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+	})
+
+	Context("final documents", func() {
+
+		It("glossary section with entries", func() {
+			source := `see term:[mud]
+
+[glossary]
+== Glossary
+
+[glossary]
+mud:: wet dirt`
+			mudTerm := []interface{}{
+				types.StringElement{Content: "mud"},
+			}
+			glossaryTitle := []interface{}{
+				types.StringElement{Content: "Glossary"},
+			}
+			expected := types.Document{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.StringElement{Content: "see "},
+								types.GlossaryTerm{Term: "mud"},
+							},
+						},
+					},
+					types.Section{
+						Level: 1,
+						Attributes: types.Attributes{
+							types.AttrID:    "_glossary",
+							types.AttrStyle: types.Glossary,
+						},
+						Title: glossaryTitle,
+						Elements: []interface{}{
+							types.LabeledList{
+								Attributes: types.Attributes{
+									types.AttrStyle: types.Glossary,
+								},
+								Items: []types.LabeledListItem{
+									{
+										Attributes: types.Attributes{
+											types.AttrID: "_glossary_mud",
+										},
+										Level: 1,
+										Term:  mudTerm,
+										Elements: []interface{}{
+											types.Paragraph{
+												Lines: [][]interface{}{
+													{
+														types.StringElement{Content: "wet dirt"},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ElementReferences: types.ElementReferences{
+					"_glossary":     glossaryTitle,
+					"_glossary_mud": mudTerm,
+				},
+				Glossary: types.GlossaryEntries{
+					"mud": "_glossary_mud",
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("glossary links", func() {
+			source := `:glossary-links:
+
+Some *Mud* on the floor.
+
+[glossary]
+mud:: wet dirt`
+			mudTerm := []interface{}{
+				types.StringElement{Content: "mud"},
+			}
+			expected := types.Document{
+				Attributes: types.DocumentAttributes{
+					{Name: types.AttrGlossaryLinks, Value: nil},
+				},
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.StringElement{Content: "Some "},
+								types.QuotedText{
+									Kind: types.SingleQuoteBold,
+									Elements: []interface{}{
+										types.GlossaryTerm{Term: "Mud"},
+									},
+								},
+								types.StringElement{Content: " on the floor."},
+							},
+						},
+					},
+					types.LabeledList{
+						Attributes: types.Attributes{
+							types.AttrStyle: types.Glossary,
+						},
+						Items: []types.LabeledListItem{
+							{
+								Attributes: types.Attributes{
+									types.AttrID: "_glossary_mud",
+								},
+								Level: 1,
+								Term:  mudTerm,
+								Elements: []interface{}{
+									types.Paragraph{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{Content: "wet dirt"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				ElementReferences: types.ElementReferences{
+					"_glossary_mud": mudTerm,
+				},
+				Glossary: types.GlossaryEntries{
+					"mud": "_glossary_mud",
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
+})
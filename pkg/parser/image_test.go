@@ -147,6 +147,26 @@ image::images/foo.png[the foo.png image, 600, 400]`
 			}
 			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 		})
+		It("with link containing equal signs", func() {
+			source := `image::images/foo.png[the foo.png image, link=http://foo.bar/?a=1]`
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.ImageBlock{
+						Attributes: types.Attributes{
+							types.AttrInlineLink: "http://foo.bar/?a=1",
+							types.AttrImageAlt:   "the foo.png image",
+						},
+						Location: types.Location{
+							Path: []interface{}{
+								types.StringElement{Content: "images/foo.png"},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+
 		It("with roles", func() {
 			source := `[.role1.role2]
 image::images/foo.png[the foo.png image, 600, 400]`
@@ -172,6 +192,51 @@ image::images/foo.png[the foo.png image, 600, 400]`
 			Expect(result).To(MatchDraftDocument(expected))
 		})
 
+		It("with block attributes and empty alt", func() {
+			source := `.a title
+[.role1,float=left,align=center]
+image::images/foo.png[]`
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.ImageBlock{
+						Attributes: types.Attributes{
+							types.AttrTitle:      "a title",
+							types.AttrFloat:      "left",
+							types.AttrImageAlign: "center",
+							types.AttrRoles:      []interface{}{"role1"},
+						},
+						Location: types.Location{
+							Path: []interface{}{
+								types.StringElement{Content: "images/foo.png"},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+
+		It("with inline attributes overriding block attributes", func() {
+			source := `[float=left]
+image::images/foo.png[foo,float=right]`
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.ImageBlock{
+						Attributes: types.Attributes{
+							types.AttrImageAlt: "foo",
+							types.AttrFloat:    "right",
+						},
+						Location: types.Location{
+							Path: []interface{}{
+								types.StringElement{Content: "images/foo.png"},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+
 		It("with special characters", func() {
 			source := `image::http://example.com/foo.png?a=1&b=2[]`
 			expected := types.DraftDocument{
@@ -215,8 +280,8 @@ image::images/foo.png[the foo.png image, 600, 400]`
 			
 image::images/foo.png[{alt}]`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"alt": "the foo.png image",
+				Attributes: types.DocumentAttributes{
+					{Name: "alt", Value: "the foo.png image"},
 				},
 				Elements: []interface{}{
 					types.ImageBlock{
@@ -240,8 +305,8 @@ image::images/foo.png[{alt}]`
 
 image::foo.png[]`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"imagesdir": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "imagesdir", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.ImageBlock{
@@ -263,8 +328,8 @@ image::foo.png[]`
 
 image::{dir}/foo.png[]`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"dir": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "dir", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.ImageBlock{
@@ -285,8 +350,8 @@ image::{dir}/foo.png[]`
 
 image::foo.png[]`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"imagesdir": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "imagesdir", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.ImageBlock{
@@ -308,8 +373,8 @@ image::foo.png[]`
 
 image::{imagesdir}/foo.png[]`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"imagesdir": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "imagesdir", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.ImageBlock{
@@ -348,6 +413,32 @@ image::images/bar.png[]`
 			}
 			Expect(ParseDocument(source)).To(MatchDocument(expected))
 		})
+
+		It("lists of figures, tables and listings", func() {
+			source := `.Figures
+toc::figures[]
+
+toc::tables[]
+
+toc::listings[]`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.CaptionListPlaceHolder{
+						Kind: types.FiguresCaptionList,
+						Attributes: types.Attributes{
+							types.AttrTitle: "Figures",
+						},
+					},
+					types.CaptionListPlaceHolder{
+						Kind: types.TablesCaptionList,
+					},
+					types.CaptionListPlaceHolder{
+						Kind: types.ListingsCaptionList,
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
 	})
 
 	Context("errors", func() {
@@ -771,8 +862,8 @@ var _ = Describe("inline images", func() {
 
 an image:{dir}/foo.png[].`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"dir": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "dir", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.Paragraph{
@@ -801,8 +892,8 @@ an image:{dir}/foo.png[].`
 
 an image:foo.png[].`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"imagesdir": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "imagesdir", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.Paragraph{
@@ -832,8 +923,8 @@ an image:foo.png[].`
 
 an image:{imagesdir}/foo.png[].`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"imagesdir": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "imagesdir", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.Paragraph{
@@ -862,8 +953,8 @@ an image:{imagesdir}/foo.png[].`
 
 image::{path}/foo.png[]`
 			expected := types.Document{
-				Attributes: types.Attributes{
-					"path": "./path/to/images",
+				Attributes: types.DocumentAttributes{
+					{Name: "path", Value: "./path/to/images"},
 				},
 				Elements: []interface{}{
 					types.ImageBlock{
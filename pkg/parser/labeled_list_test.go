@@ -1602,6 +1602,134 @@ second term:: definition of the second term`
 			Expect(result).To(MatchDocument(expected))
 		})
 
+		It("with items separated by blank lines", func() {
+			source := `item 1:: description 1
+
+item 2:: description 2
+
+
+item 3:: description 3`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.LabeledList{
+						Items: []types.LabeledListItem{
+							{
+								Level: 1,
+								Term: []interface{}{
+									types.StringElement{
+										Content: "item 1",
+									},
+								},
+								Elements: []interface{}{
+									types.Paragraph{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{
+													Content: "description 1",
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Level: 1,
+								Term: []interface{}{
+									types.StringElement{
+										Content: "item 2",
+									},
+								},
+								Elements: []interface{}{
+									types.Paragraph{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{
+													Content: "description 2",
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Level: 1,
+								Term: []interface{}{
+									types.StringElement{
+										Content: "item 3",
+									},
+								},
+								Elements: []interface{}{
+									types.Paragraph{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{
+													Content: "description 3",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("with items using a nested separator and separated by a blank line", func() {
+			source := `item 1::: description 1
+
+item 2::: description 2`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.LabeledList{
+						Items: []types.LabeledListItem{
+							{
+								Level: 1,
+								Term: []interface{}{
+									types.StringElement{
+										Content: "item 1",
+									},
+								},
+								Elements: []interface{}{
+									types.Paragraph{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{
+													Content: "description 1",
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Level: 1,
+								Term: []interface{}{
+									types.StringElement{
+										Content: "item 2",
+									},
+								},
+								Elements: []interface{}{
+									types.Paragraph{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{
+													Content: "description 2",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
 		It("max level of labeled items - case 1", func() {
 			source := `.Labeled, max nesting
 level 1:: description 1
@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
@@ -320,6 +321,104 @@ next lines`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("with custom scheme", func() {
+				source := "a link to ssh://git@example.com[repo]"
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a link to "},
+									types.InlineLink{
+										Attributes: types.Attributes{
+											types.AttrInlineLinkText: "repo",
+										},
+										Location: types.Location{
+											Scheme: "ssh://",
+											Path: []interface{}{
+												types.StringElement{
+													Content: "git@example.com",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source, configuration.WithURLScheme("ssh://"))).To(MatchDocument(expected))
+			})
+
+			It("with unregistered custom scheme", func() {
+				source := "a link to ssh://git@example.com"
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a link to ssh://git@example.com"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("escaped with a backslash", func() {
+				source := `a link to \https://example.com[example]`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a link to "},
+									types.InlinePassthrough{
+										Kind: types.EscapedPassthrough,
+										Elements: []interface{}{
+											types.StringElement{Content: "https://example.com"},
+										},
+									},
+									types.StringElement{Content: "[example]"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("escaped with a backslash and quoted text in attributes", func() {
+				source := `a link to \https://example.com[*bold* text]`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a link to "},
+									types.InlinePassthrough{
+										Kind: types.EscapedPassthrough,
+										Elements: []interface{}{
+											types.StringElement{Content: "https://example.com"},
+										},
+									},
+									types.StringElement{Content: "["},
+									types.QuotedText{
+										Kind: types.SingleQuoteBold,
+										Elements: []interface{}{
+											types.StringElement{Content: "bold"},
+										},
+									},
+									types.StringElement{Content: " text]"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("with more text afterwards", func() {
 				source := `a link to https://example.com and more text`
 				expected := types.Document{
@@ -700,6 +799,52 @@ next lines`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("with quoted text in bold text", func() {
+				source := "see *https://example.com[the _docs_]*"
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "see "},
+									types.QuotedText{
+										Kind: types.SingleQuoteBold,
+										Elements: []interface{}{
+											types.InlineLink{
+												Attributes: types.Attributes{
+													types.AttrInlineLinkText: []interface{}{
+														types.StringElement{
+															Content: "the ",
+														},
+														types.QuotedText{
+															Kind: types.SingleQuoteItalic,
+															Elements: []interface{}{
+																types.StringElement{
+																	Content: "docs",
+																},
+															},
+														},
+													},
+												},
+												Location: types.Location{
+													Scheme: "https://",
+													Path: []interface{}{
+														types.StringElement{
+															Content: "example.com",
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("with special characters", func() {
 				source := "a link to https://foo*_.com"
 				expected := types.Document{
@@ -800,8 +945,9 @@ next lines`
 a link to {url}`
 
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"url": "https://foo2.bar", // overridden by second declaration
+						Attributes: types.DocumentAttributes{
+							{Name: "url", Value: "https://example.com"},
+							{Name: "url", Value: "https://foo2.bar"}, // overridden by second declaration
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -836,9 +982,9 @@ a link to {url}`
 a link to {scheme}://{path} and https://foo.com`
 
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"scheme": "https",
-							"path":   "example.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "scheme", Value: "https"},
+							{Name: "path", Value: "example.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -882,9 +1028,9 @@ a link to {scheme}://{path} and https://foo.com`
 a link to *{scheme}://{path}[] and https://foo.com[]*`
 
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"scheme": "https",
-							"path":   "example.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "scheme", Value: "https"},
+							{Name: "path", Value: "example.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -937,11 +1083,15 @@ a link to *{scheme}://{path}[] and https://foo.com[]*`
 a link to {scheme}://{path} and https://foo.com`
 
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"scheme": "https",
-							"path":   nil,
+						Attributes: types.DocumentAttributes{
+							{Name: "scheme", Value: "https"},
+							{Name: "path", Value: "example.com"},
+							{Name: "path", Value: types.UnsetAttribute{}, Body: true},
 						},
 						Elements: []interface{}{
+							types.AttributeReset{
+								Name: "path",
+							},
 							types.Paragraph{
 								Lines: [][]interface{}{
 									{
@@ -1005,9 +1155,9 @@ a link to {scheme}://{path} and https://foo.com`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"scheme": "https",
-							"path":   "example.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "scheme", Value: "https"},
+							{Name: "path", Value: "example.com"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_a_title_to_httpsexample_com_and_httpsfoo_com": title,
@@ -1057,9 +1207,9 @@ a link to {scheme}://{path} and https://foo.com`
 						},
 					}
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"scheme": "https",
-							"path":   "example.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "scheme", Value: "https"},
+							{Name: "path", Value: "example.com"},
 						},
 						ElementReferences: types.ElementReferences{
 							"_a_title_to_httpsexample_com_and_httpsfoo_com": title,
@@ -1385,11 +1535,15 @@ Test 2: link:/test/a%20b[with encoded space]`
 a link to {scheme}:{path}[] and https://foo.com`
 
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"scheme": "link",
-						"path":   nil,
+					Attributes: types.DocumentAttributes{
+						{Name: "scheme", Value: "link"},
+						{Name: "path", Value: "example.com"},
+						{Name: "path", Value: types.UnsetAttribute{}, Body: true},
 					},
 					Elements: []interface{}{
+						types.AttributeReset{
+							Name: "path",
+						},
 						types.Paragraph{
 							Lines: [][]interface{}{
 								{
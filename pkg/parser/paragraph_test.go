@@ -373,6 +373,87 @@ foo`
 				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 			})
 
+			It("interrupted by an unordered list item", func() {
+				source := `a paragraph
+* an item`
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a paragraph"},
+								},
+							},
+						},
+						types.UnorderedListItem{
+							Level:       1,
+							BulletStyle: types.OneAsterisk,
+							CheckStyle:  types.NoCheck,
+							Elements: []interface{}{
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{Content: "an item"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("interrupted by an ordered list item", func() {
+				source := `a paragraph
+. an item`
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a paragraph"},
+								},
+							},
+						},
+						types.OrderedListItem{
+							Level: 1,
+							Style: types.Arabic,
+							Elements: []interface{}{
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{Content: "an item"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("not interrupted by an invalid list item", func() {
+				source := `a paragraph
+*not an item`
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a paragraph"},
+								},
+								{
+									types.StringElement{Content: "*not an item"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
 			Context("with counters", func() {
 
 				It("default", func() {
@@ -1377,6 +1458,28 @@ I am a quote paragraph.`
 				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 			})
 
+			It("paragraph as a quote with named author and title", func() {
+				source := `[quote, attribution="john doe", citetitle="quote title"]
+I am a quote paragraph.`
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Attributes: types.Attributes{
+								types.AttrStyle:       types.Quote,
+								types.AttrQuoteAuthor: "john doe",
+								types.AttrQuoteTitle:  "quote title",
+							},
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "I am a quote paragraph."},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
 			It("paragraph as a quote with author, title and other attributes", func() {
 				source := `[[universal]]
 [quote, john doe, quote title]
@@ -1616,8 +1719,8 @@ image::foo.png[]`
 .a title
 a paragraph`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrIDPrefix: "bar_",
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrIDPrefix, Value: "bar_"},
 					},
 					Elements: []interface{}{
 						types.Paragraph{
@@ -1673,6 +1776,185 @@ a paragraph`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			Context("with shorthand titles", func() {
+
+				It("title starting with digits", func() {
+					source := `.2021 Roadmap
+foo`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.Paragraph{
+								Attributes: types.Attributes{
+									types.AttrTitle: "2021 Roadmap",
+								},
+								Lines: [][]interface{}{
+									{
+										types.StringElement{Content: "foo"},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+
+				It("title starting with punctuation", func() {
+					source := `.(draft) #1 notes
+foo`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.Paragraph{
+								Attributes: types.Attributes{
+									types.AttrTitle: "(draft) #1 notes",
+								},
+								Lines: [][]interface{}{
+									{
+										types.StringElement{Content: "foo"},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+
+				It("title starting with a single dot", func() {
+					source := `..hidden
+foo`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.Paragraph{
+								Attributes: types.Attributes{
+									types.AttrTitle: ".hidden",
+								},
+								Lines: [][]interface{}{
+									{
+										types.StringElement{Content: "foo"},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+
+				It("not a title when starting with 2 dots and a space", func() {
+					source := `.. foo`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.OrderedList{
+								Items: []types.OrderedListItem{
+									{
+										Level: 1,
+										Style: types.LowerAlpha,
+										Elements: []interface{}{
+											types.Paragraph{
+												Lines: [][]interface{}{
+													{
+														types.StringElement{Content: "foo"},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+
+				It("not a title when starting with a tab", func() {
+					source := ".\tfoo"
+					expected := types.Document{
+						Elements: []interface{}{
+							types.OrderedList{
+								Items: []types.OrderedListItem{
+									{
+										Level: 1,
+										Style: types.Arabic,
+										Elements: []interface{}{
+											types.Paragraph{
+												Lines: [][]interface{}{
+													{
+														types.StringElement{Content: "foo"},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+
+				It("not a title when only dots", func() {
+					source := `..
+foo`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.Paragraph{
+								Lines: [][]interface{}{
+									{
+										types.StringElement{Content: ".."},
+									},
+									{
+										types.StringElement{Content: "foo"},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+
+				It("not a literal block delimiter when more than 4 dots", func() {
+					source := `.....
+foo`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.Paragraph{
+								Lines: [][]interface{}{
+									{
+										types.StringElement{Content: "\u2026\u200b.."},
+									},
+									{
+										types.StringElement{Content: "foo"},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+
+				It("title before a literal block", func() {
+					source := `.2021 Roadmap
+....
+.....
+....`
+					expected := types.Document{
+						Elements: []interface{}{
+							types.LiteralBlock{
+								Attributes: types.Attributes{
+									types.AttrTitle:            "2021 Roadmap",
+									types.AttrStyle:            types.Literal,
+									types.AttrLiteralBlockType: types.LiteralBlockWithDelimiter,
+								},
+								Lines: [][]interface{}{
+									{
+										types.StringElement{Content: "....."},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+			})
+
 			Context("with custom substitutions", func() {
 
 				// using the same input for all substitution tests
@@ -1686,8 +1968,8 @@ another one using attribute substitution: {github-url}[]...
 				It("should apply the 'none' substitution", func() {
 					s := strings.ReplaceAll(source, "$SUBS", "none")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1712,8 +1994,8 @@ another one using attribute substitution: {github-url}[]...
 					// quoted text is parsed but inline link macro is not
 					s := strings.ReplaceAll(source, "$SUBS", "quotes")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1753,8 +2035,8 @@ another one using attribute substitution: {github-url}[]...
 					// quoted text is not parsed but inline link macro is
 					s := strings.ReplaceAll(source, "$SUBS", "macros")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1796,8 +2078,8 @@ another one using attribute substitution: {github-url}[]...
 					// quoted text is not parsed but inline link macro is
 					s := strings.ReplaceAll(source, "$SUBS", "attributes")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1822,8 +2104,8 @@ another one using attribute substitution: {github-url}[]...
 					// quoted text is not parsed but inline link macro is
 					s := strings.ReplaceAll(source, "$SUBS", "attributes,macros")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1870,8 +2152,8 @@ another one using attribute substitution: {github-url}[]...
 					// quoted text is not parsed but inline link macro is
 					s := strings.ReplaceAll(source, "$SUBS", "specialchars")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1899,8 +2181,8 @@ another one using attribute substitution: {github-url}[]...
 					// quoted text is not parsed but inline link macro is
 					s := strings.ReplaceAll(source, "$SUBS", "replacements")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1925,8 +2207,8 @@ another one using attribute substitution: {github-url}[]...
 					// quoted text and inline link macro are both parsed
 					s := strings.ReplaceAll(source, "$SUBS", "quotes,macros")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
@@ -1978,8 +2260,8 @@ another one using attribute substitution: {github-url}[]...
 					// (same as above, but with subs in reversed order)
 					s := strings.ReplaceAll(source, "$SUBS", "macros,quotes")
 					expected := types.Document{
-						Attributes: types.Attributes{
-							"github-url": "https://github.com",
+						Attributes: types.DocumentAttributes{
+							{Name: "github-url", Value: "https://github.com"},
 						},
 						Elements: []interface{}{
 							types.Paragraph{
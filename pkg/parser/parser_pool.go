@@ -0,0 +1,189 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+// Pool a pool of parsers which can be reused across calls, to reduce the memory allocations
+// when parsing a lot of (small) documents. A Pool is safe for concurrent use.
+type Pool struct {
+	parsers sync.Pool
+}
+
+// NewPool returns a new, empty Pool of parsers
+func NewPool() *Pool {
+	return &Pool{
+		parsers: sync.Pool{
+			New: func() interface{} {
+				return newPooledParser()
+			},
+		},
+	}
+}
+
+// the pool used by default within this package
+var parsers = NewPool()
+
+// MaxInputSize creates an Option to limit the number of bytes of data to parse (0 means no limit).
+// When parsing with `Pool.ParseReader`, an `ErrInputTooLarge` error is returned as soon as more data is read.
+// The generated `Parse` and `ParseReader` functions, which read all the data beforehand, fail without parsing it.
+func MaxInputSize(size int64) Option {
+	return func(p *parser) Option {
+		old, _ := p.cur.globalStore[maxInputSizeKey].(int64)
+		p.cur.globalStore[maxInputSizeKey] = size
+		if size > 0 && int64(len(p.data)) > size {
+			p.addErr(ErrInputTooLarge)
+			p.data = nil
+		}
+		return MaxInputSize(old)
+	}
+}
+
+// Parse parses the data from b using filename as information in the
+// error messages, with a parser taken from the pool.
+//
+// Deprecated: use ParseReader instead.
+func (p *Pool) Parse(filename string, b []byte, opts ...Option) (interface{}, error) {
+	return p.parse(filename, b, opts...)
+}
+
+// ParseReader parses the data from r using filename as information in the
+// error messages, with a parser taken from the pool.
+// The data is read in a single buffer, sized after the length of r when it is known
+// (eg: a file, or a bytes or strings reader), and not copied again before being parsed.
+// The number of bytes to read can be limited with the `MaxInputSize` option.
+func (p *Pool) ParseReader(filename string, r io.Reader, opts ...Option) (interface{}, error) {
+	pp := p.parsers.Get().(*pooledParser)
+	defer func() {
+		pp.release()
+		p.parsers.Put(pp)
+	}()
+	// options are applied before reading the data, since they may limit its size
+	parser := pp.reset(filename, nil, opts...)
+	if size, ok := parser.cur.globalStore[maxInputSizeKey].(int64); ok && size > 0 {
+		r = &limitedReader{
+			r:         r,
+			remaining: size,
+		}
+	}
+	b, err := readSource(r)
+	if err != nil {
+		return nil, err
+	}
+	parser.data = b
+	return parser.parse(g)
+}
+
+func (p *Pool) parse(filename string, b []byte, opts ...Option) (interface{}, error) {
+	pp := p.parsers.Get().(*pooledParser)
+	defer func() {
+		pp.release()
+		p.parsers.Put(pp)
+	}()
+	return pp.reset(filename, b, opts...).parse(g)
+}
+
+// readSource reads all the data from r. The parser needs the whole data to backtrack,
+// but the unread content of a `bytes.Buffer` is used as-is, and otherwise, the data is read in a buffer
+// which is allocated once if the length of r is known.
+func readSource(r io.Reader) ([]byte, error) {
+	if b, ok := r.(*bytes.Buffer); ok {
+		return b.Next(b.Len()), nil
+	}
+	// leave enough room to read the EOF without growing the buffer
+	buf := bytes.NewBuffer(make([]byte, 0, sizeHint(r)+bytes.MinRead))
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sizeHint returns the number of bytes to read from r, or 0 if it is unknown
+func sizeHint(r io.Reader) int {
+	switch r := r.(type) {
+	case interface{ Len() int }: // eg: bytes.Reader, strings.Reader
+		return r.Len()
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil || offset > info.Size() {
+			return 0
+		}
+		return int(info.Size() - offset)
+	case *limitedReader:
+		// read 1 extra byte at most, to detect if the limit was exceeded
+		if h := sizeHint(r.r); int64(h) <= r.remaining {
+			return h
+		}
+		return int(r.remaining + 1)
+	default:
+		return 0
+	}
+}
+
+// pooledParser a parser which retains its internal buffers and stores between 2 calls
+type pooledParser struct {
+	*parser
+	stats Stats
+}
+
+func newPooledParser() *pooledParser {
+	pp := &pooledParser{
+		stats: Stats{
+			ChoiceAltCnt: make(map[string]map[string]int),
+		},
+	}
+	pp.parser = newParser("", nil)
+	pp.parser.Stats = &pp.stats
+	return pp
+}
+
+// reset rebuilds the state of the parser with the generated constructor, so it can be used to parse the given data
+// with the given options, but retains the stores, stacks and stats allocated during the previous calls
+func (pp *pooledParser) reset(filename string, b []byte, opts ...Option) *parser {
+	p := pp.parser
+	state, globalStore := p.cur.state, p.cur.globalStore
+	vstack, rstack, recoveryStack := p.vstack[:0], p.rstack[:0], p.recoveryStack[:0]
+	maxFailExpected := p.maxFailExpected[:0]
+	*p = *newParser(filename, b)
+	p.cur.state, p.cur.globalStore = state, globalStore
+	p.vstack, p.rstack, p.recoveryStack = vstack, rstack, recoveryStack
+	p.maxFailExpected = maxFailExpected
+	p.Stats = &pp.stats
+	// the options are applied once the stores and stats are restored, since they may override them
+	// (eg: `GlobalStore` or `Statistics`)
+	p.setOptions(opts)
+	if p.maxExprCnt == 0 {
+		p.maxExprCnt = math.MaxUint64
+	}
+	return p
+}
+
+// release clears the stores and stats of the parser, so that it does not retain any
+// reference to the elements of the last parsed document
+func (pp *pooledParser) release() {
+	p := pp.parser
+	for k := range p.cur.state {
+		delete(p.cur.state, k)
+	}
+	for k, v := range p.cur.globalStore {
+		if s, ok := v.(*stack); ok && k == attributesKey {
+			// retain the stack, but not its elements
+			s.clear()
+			continue
+		}
+		delete(p.cur.globalStore, k)
+	}
+	pp.stats.ExprCnt = 0
+	for k := range pp.stats.ChoiceAltCnt {
+		delete(pp.stats.ChoiceAltCnt, k)
+	}
+	p.data = nil
+}
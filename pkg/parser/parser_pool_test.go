@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("parser pool", func() {
+
+	It("should parse with a pooled parser", func() {
+		// given
+		p := NewPool()
+		// when
+		result, err := p.Parse("", []byte("1..2"), Entrypoint("LineRanges"))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(types.LineRange{
+			StartLine: 1,
+			EndLine:   2,
+		}))
+	})
+
+	It("should parse with a reused parser and other options", func() {
+		// given
+		p := NewPool()
+		_, err := p.ParseReader("", strings.NewReader("1..2"), Entrypoint("LineRanges"))
+		Expect(err).NotTo(HaveOccurred())
+		// when
+		result, err := p.ParseReader("", strings.NewReader("*cookie*"), Entrypoint("QuotedTextSubs"))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := Parse("", []byte("*cookie*"), Entrypoint("QuotedTextSubs"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(expected))
+	})
+
+	It("should not retain errors of previous calls", func() {
+		// given
+		p := NewPool()
+		_, err1 := p.Parse("", []byte("foo"), Entrypoint("LineRanges"))
+		Expect(err1).To(HaveOccurred())
+		msg := err1.Error()
+		// when
+		_, err2 := p.Parse("", []byte("1..2"), Entrypoint("LineRanges"))
+		// then
+		Expect(err2).NotTo(HaveOccurred())
+		Expect(err1.Error()).To(Equal(msg))
+	})
+
+	It("should parse concurrently", func() {
+		// given
+		p := NewPool()
+		expected, err := Parse("", []byte("some *bold* and _italic_ content"), Entrypoint("QuotedTextSubs"))
+		Expect(err).NotTo(HaveOccurred())
+		wg := sync.WaitGroup{}
+		results := make([]interface{}, 20)
+		// when
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				r, err := p.Parse("", []byte("some *bold* and _italic_ content"), Entrypoint("QuotedTextSubs"))
+				Expect(err).NotTo(HaveOccurred())
+				results[i] = r
+			}(i)
+		}
+		wg.Wait()
+		// then
+		for _, r := range results {
+			Expect(r).To(Equal(expected))
+		}
+	})
+
+	It("should parse the whole content of a buffer", func() {
+		// given
+		p := NewPool()
+		buf := bytes.NewBufferString("1..2")
+		// when
+		result, err := p.ParseReader("", buf, Entrypoint("LineRanges"))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(types.LineRange{
+			StartLine: 1,
+			EndLine:   2,
+		}))
+		Expect(buf.Len()).To(Equal(0))
+	})
+
+	It("should parse the content of a file", func() {
+		// given
+		f, err := os.Open("../../test/includes/chapter-a.adoc")
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+		// when
+		result, err := NewPool().ParseReader(f.Name(), f, Entrypoint("RawSource"))
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := ParseFile("../../test/includes/chapter-a.adoc", Entrypoint("RawSource"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(expected))
+	})
+
+	Context("max input size", func() {
+
+		It("should parse content within the limit", func() {
+			// when
+			result, err := NewPool().ParseReader("", strings.NewReader("1..2"), Entrypoint("LineRanges"), MaxInputSize(4))
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(types.LineRange{
+				StartLine: 1,
+				EndLine:   2,
+			}))
+		})
+
+		It("should parse content without limit", func() {
+			// when
+			result, err := NewPool().ParseReader("", strings.NewReader("1..2"), Entrypoint("LineRanges"), MaxInputSize(0))
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(types.LineRange{
+				StartLine: 1,
+				EndLine:   2,
+			}))
+		})
+
+		It("should fail when content exceeds the limit", func() {
+			// when
+			_, err := NewPool().ParseReader("", strings.NewReader("1..2"), Entrypoint("LineRanges"), MaxInputSize(3))
+			// then
+			Expect(err).To(MatchError(ErrInputTooLarge))
+		})
+
+		It("should not retain the limit of previous calls", func() {
+			// given
+			p := NewPool()
+			_, err := p.ParseReader("", strings.NewReader("1..2"), Entrypoint("LineRanges"), MaxInputSize(3))
+			Expect(err).To(MatchError(ErrInputTooLarge))
+			// when
+			_, err = p.ParseReader("", strings.NewReader("1..2"), Entrypoint("LineRanges"))
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail when content exceeds the limit with the generated parser", func() {
+			// when
+			_, err := ParseReader("", strings.NewReader("1..2"), Entrypoint("LineRanges"), MaxInputSize(3))
+			// then
+			Expect(err).To(MatchError(ContainSubstring(ErrInputTooLarge.Error())))
+		})
+	})
+})
@@ -36,3 +36,11 @@ func (s *stack) get() interface{} {
 	}
 	return s.elements[s.index]
 }
+
+// clear removes all elements from the stack, but retains its capacity
+func (s *stack) clear() {
+	for i := range s.elements {
+		s.elements[i] = nil
+	}
+	s.index = -1
+}
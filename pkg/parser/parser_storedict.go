@@ -2,6 +2,7 @@ package parser
 
 import (
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
 )
 
 // extra methods on the generated parser's `storeDict` type
@@ -10,6 +11,32 @@ const attributesKey = "attributes"
 
 const usermacrosKey = "user_macros"
 
+const urlSchemesKey = "url_schemes"
+
+const sourceMapKey = "source_map"
+
+const sourcePositionsKey = "source_positions"
+
+const imagesdirKey = "imagesdir"
+
+const frontMatterKindsKey = "front_matter_kinds"
+
+const skipFrontMatterKey = "skip_front_matter"
+
+const delimitersKey = "delimiters"
+
+const unterminatedDelimitersKey = "unterminated_delimiters"
+
+const sectionLinesKey = "section_lines"
+
+const rawDocumentStateKey = "raw_document_state"
+
+const compatModeKey = "compat_mode"
+
+const hugoShortcodesKey = "hugo_shortcodes"
+
+const maxInputSizeKey = "max_input_size"
+
 func (c storeDict) pushAttributes(value interface{}) {
 	if s, ok := c[attributesKey].(*stack); ok {
 		s.push(value)
@@ -40,3 +67,125 @@ func (c storeDict) hasUserMacro(name string) bool {
 	}
 	return false
 }
+
+func (c storeDict) hasURLScheme(scheme string) bool {
+	if schemes, exists := c[urlSchemesKey].([]string); exists {
+		for _, s := range schemes {
+			if s == scheme {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// consumesFrontMatter returns true if the front matter of the given kind (`yaml` or `toml`) should be consumed
+// at the top of the document, ie, if it was configured to be parsed (only `yaml` by default) or if all front matters are skipped
+func (c storeDict) consumesFrontMatter(kind string) bool {
+	if c.skipsFrontMatter() {
+		return true
+	}
+	kinds, _ := c[frontMatterKindsKey].([]configuration.FrontMatterKind)
+	if len(kinds) == 0 {
+		return configuration.FrontMatterKind(kind) == configuration.YAMLFrontMatter
+	}
+	for _, k := range kinds {
+		if k == configuration.FrontMatterKind(kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipsFrontMatter returns true if the front matter should be consumed without setting its values as document attributes
+func (c storeDict) skipsFrontMatter() bool {
+	skip, ok := c[skipFrontMatterKey].(bool)
+	return ok && skip
+}
+
+// isCompatMode returns `true` if the legacy AsciiDoc syntax is supported (ie, the `compat-mode` attribute is set)
+func (c storeDict) isCompatMode() bool {
+	compat, ok := c[compatModeKey].(bool)
+	return ok && compat
+}
+
+// retainsHugoShortcodes returns `true` if the Hugo shortcodes should be retained as-is
+func (c storeDict) retainsHugoShortcodes() bool {
+	hugo, ok := c[hugoShortcodesKey].(bool)
+	return ok && hugo
+}
+
+// pushDelimiter records the given opening delimiter of a delimited block (eg: `======`) along with the line at which it was found,
+// so that the block is closed by a delimiter of the same length.
+// The delimiters are kept in the parser's state, which is restored when backtracking,
+// hence a new slice is set instead of modifying the current one.
+func (c storeDict) pushDelimiter(delimiter string, line int) {
+	delimiters, _ := c[delimitersKey].([]openDelimiter)
+	c[delimitersKey] = append(delimiters[:len(delimiters):len(delimiters)], openDelimiter{
+		delimiter: delimiter,
+		line:      line,
+	})
+}
+
+// popDelimiter discards the opening delimiter of the delimited block which was just closed
+func (c storeDict) popDelimiter() {
+	if delimiters, ok := c[delimitersKey].([]openDelimiter); ok && len(delimiters) > 0 {
+		c[delimitersKey] = delimiters[:len(delimiters)-1]
+	}
+}
+
+// popUnterminatedDelimiter discards the opening delimiter of the delimited block which reached the end of the document
+// without being closed, and records it as unterminated
+func (c storeDict) popUnterminatedDelimiter() {
+	if delimiters, ok := c[delimitersKey].([]openDelimiter); ok && len(delimiters) > 0 {
+		unterminated, _ := c[unterminatedDelimitersKey].([]openDelimiter)
+		c[unterminatedDelimitersKey] = append(unterminated[:len(unterminated):len(unterminated)], delimiters[len(delimiters)-1])
+		c[delimitersKey] = delimiters[:len(delimiters)-1]
+	}
+}
+
+// isCurrentDelimiter returns true if the given delimiter is the opening delimiter of the current delimited block
+func (c storeDict) isCurrentDelimiter(delimiter string) bool {
+	delimiters, ok := c[delimitersKey].([]openDelimiter)
+	return ok && len(delimiters) > 0 && delimiters[len(delimiters)-1].delimiter == delimiter
+}
+
+// pushSectionLine records the line at which a section (or the document header) starts
+func (c storeDict) pushSectionLine(line int) {
+	lines, _ := c[sectionLinesKey].([]int)
+	c[sectionLinesKey] = append(lines[:len(lines):len(lines)], line)
+}
+
+// rawDocumentState the information recorded in the parser's state while parsing a raw document
+type rawDocumentState struct {
+	unterminatedDelimiters []openDelimiter // the opening delimiters of the blocks which were not closed before the end of the document
+	sectionLines           []int           // the lines at which the sections start, in their order of appearance
+}
+
+// collectRawDocumentState copies the information recorded in the given (final) state of the parser
+// into the collector which was set in the global store (if any)
+func (c storeDict) collectRawDocumentState(state storeDict) {
+	if collector, ok := c[rawDocumentStateKey].(*rawDocumentState); ok {
+		collector.unterminatedDelimiters, _ = state[unterminatedDelimitersKey].([]openDelimiter)
+		collector.sectionLines, _ = state[sectionLinesKey].([]int)
+	}
+}
+
+// withSourceLine sets the position in the original source of the given line (of the preprocessed source)
+// as the `sourceline` attribute (and `sourcefile` attribute, if the block is in an included file) of the given block,
+// if the source map was enabled in the configuration
+func (c storeDict) withSourceLine(block interface{}, line int) interface{} {
+	if enabled, ok := c[sourceMapKey].(bool); !ok || !enabled {
+		return block
+	}
+	positions, _ := c[sourcePositionsKey].(sourceMap)
+	position := positions.position(line)
+	if b, ok := block.(types.WithAttributesToSubstitute); ok {
+		attrs := b.AttributesToSubstitute().Set(types.AttrSourceLine, position.Line)
+		if position.Filename != "" {
+			attrs = attrs.Set(types.AttrSourceFile, position.Filename)
+		}
+		return b.ReplaceAttributes(attrs)
+	}
+	return block
+}
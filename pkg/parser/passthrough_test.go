@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
@@ -61,8 +62,8 @@ var _ = Describe("passthroughs", func() {
 				
 +++ {hello}, world +++` // attribute susbsitution must not occur
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"hello": "HELLO",
+					Attributes: types.DocumentAttributes{
+						{Name: "hello", Value: "HELLO"},
 					},
 					Elements: []interface{}{
 						types.Paragraph{
@@ -589,7 +590,274 @@ var _ = Describe("passthroughs", func() {
 					Expect(ParseDocument(source)).To(MatchDocument(expected))
 				})
 			})
+
+			Context("passthrough macro with custom substitutions", func() {
+
+				It("passthrough macro with attributes substitution", func() {
+					source := `:name: value
+
+pass:a[{name} *hello*]`
+					expected := types.Document{
+						Attributes: types.DocumentAttributes{
+							{Name: "name", Value: "value"},
+						},
+						Elements: []interface{}{
+							types.Paragraph{
+								Lines: [][]interface{}{
+									{types.InlinePassthrough{
+										Kind: types.PassthroughMacro,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "value *hello*",
+											},
+										},
+									},
+									},
+								},
+							},
+						},
+					}
+					Expect(ParseDocument(source)).To(MatchDocument(expected))
+				})
+			})
+		})
+
+		Context("escaped inline elements", func() {
+
+			It("escaped attribute substitution", func() {
+				source := `:name: value
+
+\{name} and {name}`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: "name", Value: "value"},
+					},
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.InlinePassthrough{
+										Kind: types.EscapedPassthrough,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "{name}",
+											},
+										},
+									},
+									types.StringElement{
+										Content: " and value",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("escaped cross reference", func() {
+				source := `see \<<section>>`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "see ",
+									},
+									types.InlinePassthrough{
+										Kind: types.EscapedPassthrough,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "<<",
+											},
+										},
+									},
+									types.StringElement{
+										Content: "section",
+									},
+									types.SpecialCharacter{
+										Name: ">",
+									},
+									types.SpecialCharacter{
+										Name: ">",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("escaped singleplus passthrough within quoted text", func() {
+				source := `*\+hello+*`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.QuotedText{
+										Kind: types.SingleQuoteBold,
+										Elements: []interface{}{
+											types.InlinePassthrough{
+												Kind: types.EscapedPassthrough,
+												Elements: []interface{}{
+													types.StringElement{
+														Content: "+",
+													},
+												},
+											},
+											types.StringElement{
+												Content: "hello+",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("escaped singleplus passthrough with quoted text", func() {
+				source := `\+*hello*+`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.InlinePassthrough{
+										Kind: types.EscapedPassthrough,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "+",
+											},
+										},
+									},
+									types.QuotedText{
+										Kind: types.SingleQuoteBold,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "hello",
+											},
+										},
+									},
+									types.StringElement{
+										Content: "+",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("backslash without any inline element", func() {
+				source := `a \ b\c`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: `a \ b\c`,
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
 		})
 
+		Context("hugo shortcodes", func() {
+
+			It("shortcodes retained as-is", func() {
+				source := `a {{< figure src="/img/*cover*.png" >}} and {{% notice info %}}*bold*{{% /notice %}}`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "a ",
+									},
+									types.InlinePassthrough{
+										Kind: types.HugoShortcodePassthrough,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: `{{< figure src="/img/*cover*.png" >}}`,
+											},
+										},
+									},
+									types.StringElement{
+										Content: " and ",
+									},
+									types.InlinePassthrough{
+										Kind: types.HugoShortcodePassthrough,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "{{% notice info %}}",
+											},
+										},
+									},
+									types.QuotedText{
+										Kind: types.SingleQuoteBold,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "bold",
+											},
+										},
+									},
+									types.InlinePassthrough{
+										Kind: types.HugoShortcodePassthrough,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "{{% /notice %}}",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source, configuration.WithHugoShortcodes(true))).To(MatchDocument(expected))
+			})
+
+			It("shortcodes not retained by default", func() {
+				source := `a {{< ref "other.adoc" >}}`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{
+										Content: "a {{",
+									},
+									types.SpecialCharacter{
+										Name: "<",
+									},
+									types.StringElement{
+										Content: ` ref "other.adoc" `,
+									},
+									types.SpecialCharacter{
+										Name: ">",
+									},
+									types.StringElement{
+										Content: "}}",
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+		})
 	})
 })
@@ -48,6 +48,54 @@ var _ = Describe("quoted strings", func() {
 			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 		})
 
+		It("multiline single quoted string", func() {
+			source := "'`curly\nwas single`'"
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.QuotedString{
+									Kind: types.SingleQuote,
+									Elements: []interface{}{
+										types.StringElement{Content: "curly\nwas single"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+
+		It("multiline double quoted string with bold text", func() {
+			source := "\"`curly *was\ndouble*`\""
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.QuotedString{
+									Kind: types.DoubleQuote,
+									Elements: []interface{}{
+										types.StringElement{Content: "curly "},
+										types.QuotedText{
+											Kind: types.SingleQuoteBold,
+											Elements: []interface{}{
+												types.StringElement{Content: "was\ndouble"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+
 		It("interior ending space with single quoted string", func() {
 			source := "'`curly was single `'"
 			expected := types.DraftDocument{
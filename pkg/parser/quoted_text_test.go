@@ -554,6 +554,27 @@ var _ = Describe("quoted texts", func() {
 				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 			})
 
+			It("monospace text with single backticks in double quote", func() {
+				source := "``some `nested` content``"
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.QuotedText{
+										Kind: types.DoubleQuoteMonospace,
+										Elements: []interface{}{
+											types.StringElement{Content: "some `nested` content"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
 			It("monospace text with newline", func() {
 				source := "``some monospace\ncontent``"
 				expected := types.DraftDocument{
@@ -2596,6 +2617,50 @@ var _ = Describe("quoted texts", func() {
 
 		})
 
+		Context("adjacent punctuation", func() {
+
+			It("bold text in parentheses and followed by a comma", func() {
+				source := "a (*bold*), content"
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a ("},
+									types.QuotedText{
+										Kind: types.SingleQuoteBold,
+										Elements: []interface{}{
+											types.StringElement{
+												Content: "bold",
+											},
+										},
+									},
+									types.StringElement{Content: "), content"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("bold and italic text preceded by a colon or a semicolon", func() {
+				source := "a:*bold* and a;_italic_ content"
+				expected := types.DraftDocument{
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "a:*bold* and a;_italic_ content"},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+		})
+
 		Context("prevented substitution", func() {
 
 			Context("prevented bold text substitution", func() {
@@ -6270,4 +6335,67 @@ var _ = Describe("quoted texts", func() {
 			})
 		})
 	})
+
+	Context("compat mode", func() {
+
+		It("single quotes and plus signs with compat mode", func() {
+			source := `:compat-mode:
+
+'italic content', it's +monospace content+`
+			expected := types.Document{
+				Attributes: types.DocumentAttributes{
+					{Name: types.AttrCompatMode, Value: nil},
+				},
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.QuotedText{
+									Kind: types.SingleQuoteItalic,
+									Elements: []interface{}{
+										types.StringElement{Content: "italic content"},
+									},
+								},
+								types.StringElement{Content: ", it\u2019s "},
+								types.QuotedText{
+									Kind: types.SingleQuoteMonospace,
+									Elements: []interface{}{
+										types.InlinePassthrough{
+											Kind: types.SinglePlusPassthrough,
+											Elements: []interface{}{
+												types.StringElement{Content: "monospace content"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+
+		It("single quotes and plus signs without compat mode", func() {
+			source := `'regular content' +passthrough content+`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.Paragraph{
+						Lines: [][]interface{}{
+							{
+								types.StringElement{Content: "'regular content' "},
+								types.InlinePassthrough{
+									Kind: types.SinglePlusPassthrough,
+									Elements: []interface{}{
+										types.StringElement{Content: "passthrough content"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
+	})
 })
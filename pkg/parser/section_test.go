@@ -1,11 +1,15 @@
 package parser_test
 
 import (
+	"path/filepath"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
 	. "github.com/onsi/gomega" //nolint golint
+	log "github.com/sirupsen/logrus"
 )
 
 var _ = Describe("sections", func() {
@@ -765,6 +769,195 @@ a short preamble
 				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 			})
 
+			It("sections with relative level offsets", func() {
+				source := `:leveloffset: +1
+
+= section 1
+
+:leveloffset: -1
+
+== section 2`
+				expected := types.DraftDocument{
+					Attributes: types.Attributes{
+						types.AttrLevelOffset: "-1",
+					},
+					Elements: []interface{}{
+						types.AttributeDeclaration{
+							Name:  types.AttrLevelOffset,
+							Value: "+1",
+						},
+						types.BlankLine{},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_1",
+							},
+							Level: 1, // level is changed from `0` to `1` because of the `+1` offset
+							Title: []interface{}{
+								types.StringElement{Content: "section 1"},
+							},
+							Elements: []interface{}{},
+						},
+						types.BlankLine{},
+						types.AttributeDeclaration{
+							Name:  types.AttrLevelOffset,
+							Value: "-1",
+						},
+						types.BlankLine{},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_2",
+							},
+							Level: 1, // level is unchanged since both offsets cancel each other
+							Title: []interface{}{
+								types.StringElement{Content: "section 2"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("sections with absolute level offset", func() {
+				source := `:leveloffset: +1
+:leveloffset: 2
+
+== section 1`
+				expected := types.DraftDocument{
+					Attributes: types.Attributes{
+						types.AttrLevelOffset: "2",
+					},
+					Elements: []interface{}{
+						types.AttributeDeclaration{
+							Name:  types.AttrLevelOffset,
+							Value: "+1",
+						},
+						types.AttributeDeclaration{
+							Name:  types.AttrLevelOffset,
+							Value: "2",
+						},
+						types.BlankLine{},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_1",
+							},
+							Level: 3, // level is changed from `1` to `3` since the absolute offset replaces the relative one
+							Title: []interface{}{
+								types.StringElement{Content: "section 1"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("section with negative level offset applied on title", func() {
+				source := `:leveloffset: -2
+
+= a title`
+				expected := types.DraftDocument{
+					Attributes: types.Attributes{
+						types.AttrLevelOffset: "-2",
+					},
+					Elements: []interface{}{
+						types.AttributeDeclaration{
+							Name:  types.AttrLevelOffset,
+							Value: "-2",
+						},
+						types.BlankLine{},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_a_title",
+							},
+							Level: 0, // level is not lower than `0`
+							Title: []interface{}{
+								types.StringElement{Content: "a title"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("section with level offset beyond the maximum level", func() {
+				source := `:leveloffset: +3
+
+==== a section`
+				expected := types.DraftDocument{
+					Attributes: types.Attributes{
+						types.AttrLevelOffset: "+3",
+					},
+					Elements: []interface{}{
+						types.AttributeDeclaration{
+							Name:  types.AttrLevelOffset,
+							Value: "+3",
+						},
+						types.BlankLine{},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_a_section",
+							},
+							Level: 5, // level is not greater than `5`
+							Title: []interface{}{
+								types.StringElement{Content: "a section"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
+			It("sections with level offset reset", func() {
+				source := `:leveloffset: +1
+
+== section 1
+
+:leveloffset!:
+
+== section 2`
+				expected := types.DraftDocument{
+					Attributes: types.Attributes{
+						types.AttrLevelOffset: types.UnsetAttribute{},
+					},
+					Elements: []interface{}{
+						types.AttributeDeclaration{
+							Name:  types.AttrLevelOffset,
+							Value: "+1",
+						},
+						types.BlankLine{},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_1",
+							},
+							Level: 2,
+							Title: []interface{}{
+								types.StringElement{Content: "section 1"},
+							},
+							Elements: []interface{}{},
+						},
+						types.BlankLine{},
+						types.AttributeReset{
+							Name: types.AttrLevelOffset,
+						},
+						types.BlankLine{},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_2",
+							},
+							Level: 1, // level is restored since the offset was reset
+							Title: []interface{}{
+								types.StringElement{Content: "section 2"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+			})
+
 		})
 
 		Context("invalid sections", func() {
@@ -934,6 +1127,55 @@ Doc Writer <thedoc@asciidoctor.org>`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
+			It("discrete heading within section", func() {
+				source := `== a section
+
+[discrete]
+=== a discrete heading
+
+a paragraph`
+				sectionTitle := []interface{}{
+					types.StringElement{Content: "a section"},
+				}
+				headingTitle := []interface{}{
+					types.StringElement{Content: "a discrete heading"},
+				}
+				expected := types.Document{
+					ElementReferences: types.ElementReferences{
+						"_a_section":          sectionTitle,
+						"_a_discrete_heading": headingTitle,
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_a_section",
+							},
+							Level: 1,
+							Title: sectionTitle,
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID:    "_a_discrete_heading",
+										types.AttrStyle: types.Discrete,
+									},
+									Level:    2,
+									Title:    headingTitle,
+									Elements: []interface{}{},
+								},
+								types.Paragraph{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{Content: "a paragraph"},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("header and paragraph", func() {
 				source := `= a header
 
@@ -1599,37 +1841,62 @@ a paragraph`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
-			It("sections with same title", func() {
-				source := `== section 1
+			It("sections with custom IDs and reftexts", func() {
+				source := `[[custom_header,Custom Header]]
+== a header
 
-== section 1`
-				section1aTitle := []interface{}{
-					types.StringElement{Content: "section 1"},
-				}
-				section1bTitle := []interface{}{
-					types.StringElement{Content: "section 1"},
-				}
+[#other.a_role,reftext=Other Header]
+== another header
 
+== last header [[last, Last Header]]`
 				expected := types.Document{
 					ElementReferences: types.ElementReferences{
-						"_section_1":   section1aTitle,
-						"_section_1_2": section1bTitle,
+						"custom_header": []interface{}{
+							types.StringElement{Content: "Custom Header"},
+						},
+						"other": []interface{}{
+							types.StringElement{Content: "Other Header"},
+						},
+						"last": []interface{}{
+							types.StringElement{Content: "Last Header"},
+						},
 					},
 					Elements: []interface{}{
 						types.Section{
 							Attributes: types.Attributes{
-								types.AttrID: "_section_1",
+								types.AttrID:       "custom_header",
+								types.AttrCustomID: true,
+								types.AttrReftext:  "Custom Header",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "a header"},
 							},
-							Level:    1,
-							Title:    section1aTitle,
 							Elements: []interface{}{},
 						},
 						types.Section{
 							Attributes: types.Attributes{
-								types.AttrID: "_section_1_2",
+								types.AttrID:       "other",
+								types.AttrCustomID: true,
+								types.AttrRoles:    []interface{}{"a_role"},
+								types.AttrReftext:  "Other Header",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "another header"},
+							},
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:       "last",
+								types.AttrCustomID: true,
+								types.AttrReftext:  "Last Header",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "last header "},
 							},
-							Level:    1,
-							Title:    section1bTitle,
 							Elements: []interface{}{},
 						},
 					},
@@ -1637,48 +1904,237 @@ a paragraph`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 
-			It("section level 0 with nested section level 1 and custom ID prefix", func() {
-				source := `= a header
-:idprefix: custom_
+			It("sections with trailing anchors and roles in any order", func() {
+				source := `== first [[first]] [.role1]
 
-== section 1`
-				doctitle := []interface{}{
-					types.StringElement{Content: "a header"},
+== second [.role1.role2] [[second]]
+
+== third [[ignored]][#third.role1%option]
+
+== not [[a]] trailing [.role]`
+				firstTitle := []interface{}{
+					types.StringElement{Content: "first "},
 				}
-				section1Title := []interface{}{
-					types.StringElement{Content: "section 1"},
+				secondTitle := []interface{}{
+					types.StringElement{Content: "second "},
+				}
+				thirdTitle := []interface{}{
+					types.StringElement{Content: "third "},
+				}
+				notTrailingTitle := []interface{}{
+					types.StringElement{Content: "not "},
+					types.InlineAnchor{ID: "a"},
+					types.StringElement{Content: " trailing "},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrIDPrefix: "custom_",
-					},
 					ElementReferences: types.ElementReferences{
-						"custom_a_header":  doctitle,
-						"custom_section_1": section1Title,
+						"first":         firstTitle,
+						"second":        secondTitle,
+						"third":         thirdTitle,
+						"_not_trailing": notTrailingTitle,
+						"a":             nil,
 					},
 					Elements: []interface{}{
 						types.Section{
 							Attributes: types.Attributes{
-								types.AttrID: "custom_a_header",
+								types.AttrID:       "first",
+								types.AttrCustomID: true,
+								types.AttrRoles:    []interface{}{"role1"},
 							},
-							Level: 0,
-							Title: doctitle,
-							Elements: []interface{}{
-								types.Section{
-									Attributes: types.Attributes{
-										types.AttrID: "custom_section_1",
-									},
-									Level:    1,
-									Title:    section1Title,
-									Elements: []interface{}{},
-								},
+							Level:    1,
+							Title:    firstTitle,
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:       "second",
+								types.AttrCustomID: true,
+								types.AttrRoles:    []interface{}{"role1", "role2"},
 							},
+							Level:    1,
+							Title:    secondTitle,
+							Elements: []interface{}{},
 						},
-					},
-				}
-				Expect(ParseDocument(source)).To(MatchDocument(expected))
-			})
-
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:       "third",
+								types.AttrCustomID: true,
+								types.AttrRoles:    []interface{}{"role1"},
+								types.AttrOptions:  []interface{}{"option"},
+							},
+							Level:    1,
+							Title:    thirdTitle,
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:    "_not_trailing",
+								types.AttrRoles: []interface{}{"role"},
+							},
+							Level:    1,
+							Title:    notTrailingTitle,
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("headerless document with first section deeper than next sections", func() {
+				source := `intro
+
+=== Deep
+
+== A
+
+=== A.1
+
+== B`
+				deepTitle := []interface{}{
+					types.StringElement{Content: "Deep"},
+				}
+				aTitle := []interface{}{
+					types.StringElement{Content: "A"},
+				}
+				a1Title := []interface{}{
+					types.StringElement{Content: "A.1"},
+				}
+				bTitle := []interface{}{
+					types.StringElement{Content: "B"},
+				}
+				expected := types.Document{
+					ElementReferences: types.ElementReferences{
+						"_deep": deepTitle,
+						"_a":    aTitle,
+						"_a_1":  a1Title,
+						"_b":    bTitle,
+					},
+					Elements: []interface{}{
+						types.Paragraph{
+							Lines: [][]interface{}{
+								{
+									types.StringElement{Content: "intro"},
+								},
+							},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_deep",
+							},
+							Level:    2,
+							Title:    deepTitle,
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_a",
+							},
+							Level: 1,
+							Title: aTitle,
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "_a_1",
+									},
+									Level:    2,
+									Title:    a1Title,
+									Elements: []interface{}{},
+								},
+							},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_b",
+							},
+							Level:    1,
+							Title:    bTitle,
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("sections with same title", func() {
+				source := `== section 1
+
+== section 1`
+				section1aTitle := []interface{}{
+					types.StringElement{Content: "section 1"},
+				}
+				section1bTitle := []interface{}{
+					types.StringElement{Content: "section 1"},
+				}
+
+				expected := types.Document{
+					ElementReferences: types.ElementReferences{
+						"_section_1":   section1aTitle,
+						"_section_1_2": section1bTitle,
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_1",
+							},
+							Level:    1,
+							Title:    section1aTitle,
+							Elements: []interface{}{},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_1_2",
+							},
+							Level:    1,
+							Title:    section1bTitle,
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("section level 0 with nested section level 1 and custom ID prefix", func() {
+				source := `= a header
+:idprefix: custom_
+
+== section 1`
+				doctitle := []interface{}{
+					types.StringElement{Content: "a header"},
+				}
+				section1Title := []interface{}{
+					types.StringElement{Content: "section 1"},
+				}
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrIDPrefix, Value: "custom_"},
+					},
+					ElementReferences: types.ElementReferences{
+						"custom_a_header":  doctitle,
+						"custom_section_1": section1Title,
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "custom_a_header",
+							},
+							Level: 0,
+							Title: doctitle,
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "custom_section_1",
+									},
+									Level:    1,
+									Title:    section1Title,
+									Elements: []interface{}{},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
 			It("section level 0 with nested sections level 1 and custom ID prefixes - with idprefix as doc attribute", func() {
 				source := `= a header
 :idprefix: custom1a_
@@ -1698,8 +2154,9 @@ a paragraph`
 					types.StringElement{Content: "section 1b"},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						types.AttrIDPrefix: "custom1b_", // overridden by second occurrence of attribute declaration
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrIDPrefix, Value: "custom1a_"},
+						{Name: types.AttrIDPrefix, Value: "custom1b_", Body: true}, // the second occurrence of attribute declaration is in the body
 					},
 					ElementReferences: types.ElementReferences{
 						"custom1a_a_header":   doctitle,
@@ -1718,9 +2175,14 @@ a paragraph`
 									Attributes: types.Attributes{
 										types.AttrID: "custom1a_section_1a",
 									},
-									Level:    1,
-									Title:    section1aTitle,
-									Elements: []interface{}{},
+									Level: 1,
+									Title: section1aTitle,
+									Elements: []interface{}{
+										types.AttributeDeclaration{
+											Name:  types.AttrIDPrefix,
+											Value: "custom1b_",
+										},
+									},
 								},
 								types.Section{
 									Attributes: types.Attributes{
@@ -1757,8 +2219,9 @@ a paragraph`
 					types.StringElement{Content: "section 1b"},
 				}
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"idprefix": "custom1b_", // overridden by second attribute declaration
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrIDPrefix, Value: "custom1a_", Body: true},
+						{Name: types.AttrIDPrefix, Value: "custom1b_", Body: true},
 					},
 					ElementReferences: types.ElementReferences{
 						"_a_header":           doctitle,
@@ -1773,13 +2236,22 @@ a paragraph`
 							Level: 0,
 							Title: doctitle,
 							Elements: []interface{}{
+								types.AttributeDeclaration{
+									Name:  types.AttrIDPrefix,
+									Value: "custom1a_",
+								},
 								types.Section{
 									Attributes: types.Attributes{
 										types.AttrID: "custom1a_section_1a",
 									},
-									Level:    1,
-									Title:    section1aTitle,
-									Elements: []interface{}{},
+									Level: 1,
+									Title: section1aTitle,
+									Elements: []interface{}{
+										types.AttributeDeclaration{
+											Name:  types.AttrIDPrefix,
+											Value: "custom1b_",
+										},
+									},
 								},
 								types.Section{
 									Attributes: types.Attributes{
@@ -1857,8 +2329,8 @@ a short preamble
 
 == Section 1`
 				expected := types.Document{
-					Attributes: types.Attributes{
-						"toc": nil,
+					Attributes: types.DocumentAttributes{
+						{Name: "toc", Value: nil},
 					},
 					ElementReferences: types.ElementReferences{
 						"_a_title": []interface{}{
@@ -1908,6 +2380,483 @@ a short preamble
 			})
 		})
 
+		Context("sections out of sequence", func() {
+
+			It("should warn when a level is skipped", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `== Section A
+
+==== Section B`
+				expected := types.Document{
+					ElementReferences: types.ElementReferences{
+						"_section_a": []interface{}{
+							types.StringElement{Content: "Section A"},
+						},
+						"_section_b": []interface{}{
+							types.StringElement{Content: "Section B"},
+						},
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section A"},
+							},
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "_section_b",
+									},
+									Level: 3,
+									Title: []interface{}{
+										types.StringElement{Content: "Section B"},
+									},
+									Elements: []interface{}{},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+				Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "section title out of sequence: expected level 2, got level 3 at line 3 (section '_section_b')"))
+			})
+
+			It("should warn when the first section is not at level 1", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `= Title
+
+=== Section A`
+				_, err := ParseDocument(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "section title out of sequence: expected level 1, got level 2 at line 3 (section '_section_a')"))
+			})
+
+			It("should not warn when levels are in sequence", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `= Title
+
+== Section A
+
+=== Section A.1
+
+== Section B`
+				_, err := ParseDocument(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).ToNot(ContainAnyMessageWithLevels(log.WarnLevel))
+			})
+
+			It("should repair levels in relaxed mode", func() {
+				source := `:relax-section-levels:
+
+== Section A
+
+==== Section B
+
+===== Section C
+
+== Section D`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrRelaxSectionLevels, Value: nil},
+					},
+					ElementReferences: types.ElementReferences{
+						"_section_a": []interface{}{
+							types.StringElement{Content: "Section A"},
+						},
+						"_section_b": []interface{}{
+							types.StringElement{Content: "Section B"},
+						},
+						"_section_c": []interface{}{
+							types.StringElement{Content: "Section C"},
+						},
+						"_section_d": []interface{}{
+							types.StringElement{Content: "Section D"},
+						},
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section A"},
+							},
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "_section_b",
+									},
+									Level: 2,
+									Title: []interface{}{
+										types.StringElement{Content: "Section B"},
+									},
+									Elements: []interface{}{
+										types.Section{
+											Attributes: types.Attributes{
+												types.AttrID: "_section_c",
+											},
+											Level: 3,
+											Title: []interface{}{
+												types.StringElement{Content: "Section C"},
+											},
+											Elements: []interface{}{},
+										},
+									},
+								},
+							},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_d",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section D"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("should repair levels relatively to the parent section in relaxed mode", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `:relax-section-levels:
+
+== Section A
+
+==== Section B
+
+==== Section C`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrRelaxSectionLevels, Value: nil},
+					},
+					ElementReferences: types.ElementReferences{
+						"_section_a": []interface{}{
+							types.StringElement{Content: "Section A"},
+						},
+						"_section_b": []interface{}{
+							types.StringElement{Content: "Section B"},
+						},
+						"_section_c": []interface{}{
+							types.StringElement{Content: "Section C"},
+						},
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section A"},
+							},
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "_section_b",
+									},
+									Level: 2,
+									Title: []interface{}{
+										types.StringElement{Content: "Section B"},
+									},
+									Elements: []interface{}{},
+								},
+								types.Section{ // sibling of section B, as in the source
+									Attributes: types.Attributes{
+										types.AttrID: "_section_c",
+									},
+									Level: 2,
+									Title: []interface{}{
+										types.StringElement{Content: "Section C"},
+									},
+									Elements: []interface{}{},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+				Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "section title out of sequence: expected level 2, got level 3 at line 5 (section '_section_b')"))
+				// section C follows its sibling
+				Expect(logs).NotTo(ContainMessageWithLevel(log.WarnLevel, "section title out of sequence: expected level 2, got level 3 at line 7 (section '_section_c')"))
+			})
+
+			It("should not warn about a section following a nested section", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `== Section A
+
+=== Section A.1
+
+=== Section A.2`
+				_, err := ParseDocument(source)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).ToNot(ContainAnyMessageWithLevels(log.WarnLevel))
+			})
+
+			It("should warn with the position of the section in the included file", func() {
+				logs, reset := ConfigureLogger(log.WarnLevel)
+				defer reset()
+				source := `== Section A
+
+include::../../test/includes/chapter-a.adoc[leveloffset=+3]`
+				filename, err := filepath.Abs("../../test/includes/chapter-a.adoc")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = ParseDocument(source, configuration.WithFilename("test.adoc"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "section title out of sequence: expected level 2, got level 3 at "+filename+":1 (section '_chapter_a')"))
+			})
+		})
+
+		Context("numbered sections", func() {
+
+			It("should number sections up to the default level", func() {
+				source := `:sectnums:
+
+== Section A
+
+=== Section A.1
+
+==== Section A.1.1
+
+===== Section A.1.1.1
+
+== Section B`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrSectionNumbers, Value: nil},
+					},
+					ElementReferences: types.ElementReferences{
+						"_section_a": []interface{}{
+							types.StringElement{Content: "Section A"},
+						},
+						"_section_a_1": []interface{}{
+							types.StringElement{Content: "Section A.1"},
+						},
+						"_section_a_1_1": []interface{}{
+							types.StringElement{Content: "Section A.1.1"},
+						},
+						"_section_a_1_1_1": []interface{}{
+							types.StringElement{Content: "Section A.1.1.1"},
+						},
+						"_section_b": []interface{}{
+							types.StringElement{Content: "Section B"},
+						},
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:            "_section_a",
+								types.AttrSectionNumber: "1",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section A"},
+							},
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID:            "_section_a_1",
+										types.AttrSectionNumber: "1.1",
+									},
+									Level: 2,
+									Title: []interface{}{
+										types.StringElement{Content: "Section A.1"},
+									},
+									Elements: []interface{}{
+										types.Section{
+											Attributes: types.Attributes{
+												types.AttrID:            "_section_a_1_1",
+												types.AttrSectionNumber: "1.1.1",
+											},
+											Level: 3,
+											Title: []interface{}{
+												types.StringElement{Content: "Section A.1.1"},
+											},
+											Elements: []interface{}{
+												types.Section{
+													Attributes: types.Attributes{
+														types.AttrID: "_section_a_1_1_1",
+													},
+													Level: 4,
+													Title: []interface{}{
+														types.StringElement{Content: "Section A.1.1.1"},
+													},
+													Elements: []interface{}{},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:            "_section_b",
+								types.AttrSectionNumber: "2",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section B"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("should number sections up to the custom level, except special sections and appendices", func() {
+				source := `:sectnums:
+:sectnumlevels: 1
+
+== Section A
+
+=== Section A.1
+
+[glossary]
+== Glossary
+
+=== Section in glossary
+
+[appendix]
+== Appendix
+
+=== Section in appendix`
+				expected := types.Document{
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrSectionNumbers, Value: nil},
+						{Name: types.AttrSectionNumberLevels, Value: "1"},
+					},
+					ElementReferences: types.ElementReferences{
+						"_section_a": []interface{}{
+							types.StringElement{Content: "Section A"},
+						},
+						"_section_a_1": []interface{}{
+							types.StringElement{Content: "Section A.1"},
+						},
+						"_glossary": []interface{}{
+							types.StringElement{Content: "Glossary"},
+						},
+						"_section_in_glossary": []interface{}{
+							types.StringElement{Content: "Section in glossary"},
+						},
+						"_appendix": []interface{}{
+							types.StringElement{Content: "Appendix"},
+						},
+						"_section_in_appendix": []interface{}{
+							types.StringElement{Content: "Section in appendix"},
+						},
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:            "_section_a",
+								types.AttrSectionNumber: "1",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section A"},
+							},
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "_section_a_1",
+									},
+									Level: 2,
+									Title: []interface{}{
+										types.StringElement{Content: "Section A.1"},
+									},
+									Elements: []interface{}{},
+								},
+							},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:    "_glossary",
+								types.AttrStyle: types.Glossary,
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Glossary"},
+							},
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "_section_in_glossary",
+									},
+									Level: 2,
+									Title: []interface{}{
+										types.StringElement{Content: "Section in glossary"},
+									},
+									Elements: []interface{}{},
+								},
+							},
+						},
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID:             "_appendix",
+								types.AttrStyle:          types.Appendix,
+								types.AttrAppendixNumber: "A",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Appendix"},
+							},
+							Elements: []interface{}{
+								types.Section{
+									Attributes: types.Attributes{
+										types.AttrID: "_section_in_appendix",
+									},
+									Level: 2,
+									Title: []interface{}{
+										types.StringElement{Content: "Section in appendix"},
+									},
+									Elements: []interface{}{},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+
+			It("should not number sections by default", func() {
+				source := `== Section A`
+				expected := types.Document{
+					ElementReferences: types.ElementReferences{
+						"_section_a": []interface{}{
+							types.StringElement{Content: "Section A"},
+						},
+					},
+					Elements: []interface{}{
+						types.Section{
+							Attributes: types.Attributes{
+								types.AttrID: "_section_a",
+							},
+							Level: 1,
+							Title: []interface{}{
+								types.StringElement{Content: "Section A"},
+							},
+							Elements: []interface{}{},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+		})
+
 		Context("invalid sections", func() {
 
 			It("header invalid - too many spaces", func() {
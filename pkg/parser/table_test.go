@@ -402,4 +402,209 @@ var _ = Describe("tables", func() {
 		}
 		Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
 	})
+
+	It("with header and footer options", func() {
+		source := "[%header%footer]\n|===\n|h1|h2\n|a|b\n|f1|f2\n|==="
+		expected := types.DraftDocument{
+			Elements: []interface{}{
+				types.Table{
+					Attributes: types.Attributes{
+						types.AttrOptions: []interface{}{"header", "footer"},
+					},
+					Columns: []types.TableColumn{
+						{Width: "50", HAlign: "left", VAlign: "top"},
+						{Width: "50", HAlign: "left", VAlign: "top"},
+					},
+					Header: types.TableLine{
+						Cells: [][]interface{}{
+							{types.StringElement{Content: "h1"}},
+							{types.StringElement{Content: "h2"}},
+						},
+					},
+					Lines: []types.TableLine{
+						{
+							Cells: [][]interface{}{
+								{types.StringElement{Content: "a"}},
+								{types.StringElement{Content: "b"}},
+							},
+						},
+					},
+					Footer: types.TableLine{
+						Cells: [][]interface{}{
+							{types.StringElement{Content: "f1"}},
+							{types.StringElement{Content: "f2"}},
+						},
+					},
+				},
+			},
+		}
+		Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+	})
+
+	It("with noheader option", func() {
+		source := "[%noheader]\n|===\n|a|b\n\n|c|d\n|==="
+		expected := types.DraftDocument{
+			Elements: []interface{}{
+				types.Table{
+					Attributes: types.Attributes{
+						types.AttrOptions: []interface{}{"noheader"},
+					},
+					Columns: []types.TableColumn{
+						{Width: "50", HAlign: "left", VAlign: "top"},
+						{Width: "50", HAlign: "left", VAlign: "top"},
+					},
+					Lines: []types.TableLine{
+						{
+							Cells: [][]interface{}{
+								{types.StringElement{Content: "a"}},
+								{types.StringElement{Content: "b"}},
+							},
+						},
+						{
+							Cells: [][]interface{}{
+								{types.StringElement{Content: "c"}},
+								{types.StringElement{Content: "d"}},
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+	})
+
+	Context("asciidoc cells", func() {
+
+		It("with asciidoc style on column", func() {
+			source := "[cols=\"1,2a\"]\n|===\n|foo|* item\n|==="
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.Table{
+						Attributes: types.Attributes{
+							types.AttrCols: "1,2a",
+						},
+						Columns: []types.TableColumn{
+							{Width: "33.3333", HAlign: "left", VAlign: "top"},
+							{Width: "66.6667", HAlign: "left", VAlign: "top", Style: "a"},
+						},
+						Lines: []types.TableLine{
+							{
+								Cells: [][]interface{}{
+									{types.StringElement{Content: "foo"}},
+									{
+										types.InnerDocument{
+											Elements: []interface{}{
+												types.UnorderedList{
+													Items: []types.UnorderedListItem{
+														{
+															Level:       1,
+															BulletStyle: types.OneAsterisk,
+															CheckStyle:  types.NoCheck,
+															Elements: []interface{}{
+																types.Paragraph{
+																	Lines: [][]interface{}{
+																		{types.StringElement{Content: "item"}},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+
+		It("with asciidoc style on cell spanning multiple lines", func() {
+			source := "|===\n|foo a|\nfirst paragraph\n\nsecond paragraph\n\n|bar|baz\n|==="
+			expected := types.DraftDocument{
+				Elements: []interface{}{
+					types.Table{
+						Columns: []types.TableColumn{
+							{Width: "50", HAlign: "left", VAlign: "top"},
+							{Width: "50", HAlign: "left", VAlign: "top"},
+						},
+						Lines: []types.TableLine{
+							{
+								Cells: [][]interface{}{
+									{types.StringElement{Content: "foo"}},
+									{
+										types.InnerDocument{
+											Elements: []interface{}{
+												types.Paragraph{
+													Lines: [][]interface{}{
+														{types.StringElement{Content: "first paragraph"}},
+													},
+												},
+												types.Paragraph{
+													Lines: [][]interface{}{
+														{types.StringElement{Content: "second paragraph"}},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Cells: [][]interface{}{
+									{types.StringElement{Content: "bar"}},
+									{types.StringElement{Content: "baz"}},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+
+		It("with asciidoc style on cell", func() {
+			source := ":name: John\n\n|===\n|foo a|{name}\n|==="
+			expected := types.DraftDocument{
+				Attributes: types.Attributes{
+					"name": "John",
+				},
+				Elements: []interface{}{
+					types.AttributeDeclaration{
+						Name:  "name",
+						Value: "John",
+					},
+					types.BlankLine{},
+					types.Table{
+						Columns: []types.TableColumn{
+							{Width: "50", HAlign: "left", VAlign: "top"},
+							{Width: "50", HAlign: "left", VAlign: "top"},
+						},
+						Lines: []types.TableLine{
+							{
+								Cells: [][]interface{}{
+									{types.StringElement{Content: "foo"}},
+									{
+										types.InnerDocument{
+											Elements: []interface{}{
+												types.Paragraph{
+													Lines: [][]interface{}{
+														{types.StringElement{Content: "John"}},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDraftDocument(source)).To(MatchDraftDocument(expected))
+		})
+	})
 })
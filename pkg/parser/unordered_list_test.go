@@ -2529,15 +2529,12 @@ on 2 lines, too.`
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
-		})
 
-		Context("invalid content", func() {
-			It("unordered list with items on 2 levels - bad numbering", func() {
+			It("unordered list with mixed markers", func() {
 				source := `* item 1
-					*** item 1.1
-					*** item 1.1.1
-					** item 1.2
-					* item 2`
+- item 1.1
+** item 1.1.1
+* item 2`
 				expected := types.Document{
 					Elements: []interface{}{
 						types.UnorderedList{
@@ -2558,7 +2555,7 @@ on 2 lines, too.`
 											Items: []types.UnorderedListItem{
 												{
 													Level:       2,
-													BulletStyle: types.TwoAsterisks,
+													BulletStyle: types.Dash,
 													CheckStyle:  types.NoCheck,
 													Elements: []interface{}{
 														types.Paragraph{
@@ -2572,7 +2569,7 @@ on 2 lines, too.`
 															Items: []types.UnorderedListItem{
 																{
 																	Level:       3,
-																	BulletStyle: types.ThreeAsterisks,
+																	BulletStyle: types.TwoAsterisks,
 																	CheckStyle:  types.NoCheck,
 																	Elements: []interface{}{
 																		types.Paragraph{
@@ -2588,15 +2585,99 @@ on 2 lines, too.`
 														},
 													},
 												},
+											},
+										},
+									},
+								},
+								{
+									Level:       1,
+									BulletStyle: types.OneAsterisk,
+									CheckStyle:  types.NoCheck,
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{Content: "item 2"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
+		})
+
+		Context("invalid content", func() {
+			It("unordered list with items on 2 levels - bad numbering", func() {
+				source := `* item 1
+					*** item 1.1
+					*** item 1.1.1
+					** item 1.2
+					* item 2`
+				// markers are matched as-is: `***` is the marker of the second level, `**` the marker of the third level
+				expected := types.Document{
+					Elements: []interface{}{
+						types.UnorderedList{
+							Items: []types.UnorderedListItem{
+								{
+									Level:       1,
+									BulletStyle: types.OneAsterisk,
+									CheckStyle:  types.NoCheck,
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{Content: "item 1"},
+												},
+											},
+										},
+										types.UnorderedList{
+											Items: []types.UnorderedListItem{
 												{
 													Level:       2,
-													BulletStyle: types.TwoAsterisks,
+													BulletStyle: types.ThreeAsterisks,
 													CheckStyle:  types.NoCheck,
 													Elements: []interface{}{
 														types.Paragraph{
 															Lines: [][]interface{}{
 																{
-																	types.StringElement{Content: "item 1.2"},
+																	types.StringElement{Content: "item 1.1"},
+																},
+															},
+														},
+													},
+												},
+												{
+													Level:       2,
+													BulletStyle: types.ThreeAsterisks,
+													CheckStyle:  types.NoCheck,
+													Elements: []interface{}{
+														types.Paragraph{
+															Lines: [][]interface{}{
+																{
+																	types.StringElement{Content: "item 1.1.1"},
+																},
+															},
+														},
+														types.UnorderedList{
+															Items: []types.UnorderedListItem{
+																{
+																	Level:       3,
+																	BulletStyle: types.TwoAsterisks,
+																	CheckStyle:  types.NoCheck,
+																	Elements: []interface{}{
+																		types.Paragraph{
+																			Lines: [][]interface{}{
+																				{
+																					types.StringElement{Content: "item 1.2"},
+																				},
+																			},
+																		},
+																	},
 																},
 															},
 														},
@@ -2945,6 +3026,86 @@ another delimited block
 				}
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
+
+			It("unordered list with indented listing and fenced blocks", func() {
+				source := `* foo
+  ----
+  a listing block
+    with indentation
+  ----
+* bar
++
+    ` + "```" + `
+    a fenced block
+      with indentation
+    ` + "```" + `
+`
+				expected := types.Document{
+					Elements: []interface{}{
+						types.UnorderedList{
+							Items: []types.UnorderedListItem{
+								{
+									Level:       1,
+									BulletStyle: types.OneAsterisk,
+									CheckStyle:  types.NoCheck,
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{Content: "foo"},
+												},
+											},
+										},
+										types.ListingBlock{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{
+														Content: "a listing block",
+													},
+												},
+												{
+													types.StringElement{
+														Content: "  with indentation",
+													},
+												},
+											},
+										},
+									},
+								},
+								{
+									Level:       1,
+									BulletStyle: types.OneAsterisk,
+									CheckStyle:  types.NoCheck,
+									Elements: []interface{}{
+										types.Paragraph{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{Content: "bar"},
+												},
+											},
+										},
+										types.FencedBlock{
+											Lines: [][]interface{}{
+												{
+													types.StringElement{
+														Content: "a fenced block",
+													},
+												},
+												{
+													types.StringElement{
+														Content: "  with indentation",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(ParseDocument(source)).To(MatchDocument(expected))
+			})
 		})
 
 		Context("attach to ancestor", func() {
@@ -3100,5 +3261,64 @@ paragraph attached to parent list item`
 				Expect(ParseDocument(source)).To(MatchDocument(expected))
 			})
 		})
+
+		It("unordered list item with continued admonition, image and listing", func() {
+			source := `* item
++
+NOTE: a note
++
+image::foo.png[]
++
+----
+a listing
+----`
+			expected := types.Document{
+				Elements: []interface{}{
+					types.UnorderedList{
+						Items: []types.UnorderedListItem{
+							{
+								Level:       1,
+								BulletStyle: types.OneAsterisk,
+								CheckStyle:  types.NoCheck,
+								Elements: []interface{}{
+									types.Paragraph{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{Content: "item"},
+											},
+										},
+									},
+									types.Paragraph{
+										Attributes: types.Attributes{
+											types.AttrStyle: types.Note,
+										},
+										Lines: [][]interface{}{
+											{
+												types.StringElement{Content: "a note"},
+											},
+										},
+									},
+									types.ImageBlock{
+										Location: types.Location{
+											Path: []interface{}{
+												types.StringElement{Content: "foo.png"},
+											},
+										},
+									},
+									types.ListingBlock{
+										Lines: [][]interface{}{
+											{
+												types.StringElement{Content: "a listing"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(ParseDocument(source)).To(MatchDocument(expected))
+		})
 	})
 })
@@ -215,14 +215,10 @@ var _ = Describe("user macros", func() {
 				source := "git::[]"
 				expected := types.Document{
 					Elements: []interface{}{
-						types.Paragraph{
-							Lines: [][]interface{}{
-								{
-									types.StringElement{
-										Content: "git::[]",
-									},
-								},
-							},
+						types.GenericBlockMacro{
+							Name:    "git",
+							Target:  "",
+							RawText: "git::[]",
 						},
 					},
 				}
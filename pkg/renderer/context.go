@@ -1,6 +1,10 @@
 package renderer
 
 import (
+	"path"
+	"path/filepath"
+	"strings"
+
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 )
@@ -10,7 +14,8 @@ import (
 type Context struct {
 	Config configuration.Configuration
 	// TableOfContents exists even if the document did not specify the `:toc:` attribute.
-	// It will take into account the configured `:toclevels:` attribute value.
+	// It contains all sections: the `:toclevels:` attribute (or the `levels` attribute of the `toc::[]` macro)
+	// is applied when the ToC is rendered.
 	TableOfContents      types.TableOfContents
 	WithinDelimitedBlock bool
 	EncodeSpecialChars   bool
@@ -19,21 +24,38 @@ type Context struct {
 	Attributes           types.Attributes
 	Footnotes            []types.Footnote
 	ElementReferences    types.ElementReferences
+	Glossary             types.GlossaryEntries
 	HasHeader            bool
 	UseUnicode           bool
+	// CaptionLists the lists of figures, tables and listings, indexed by their kind.
+	// Contains only the lists which are rendered somewhere in the document (eg: with a `toc::figures[]` macro)
+	CaptionLists map[string][]types.CaptionListEntry
+	assets       []string
+	inlineCache  *inlineCache
 }
 
 // NewContext returns a new rendering context for the given document.
+// The document attributes are copied (with the attribute overrides of the given configuration),
+// so that the same document can be rendered multiple times (even concurrently) with different contexts.
 func NewContext(doc types.Document, config configuration.Configuration) *Context {
 	_, hasHeader := doc.Header()
+	var cache *inlineCache
+	if config.InlineCache {
+		cache = newInlineCache()
+	}
 	return &Context{
-		Config:             config,
-		counters:           make(map[string]int),
-		Attributes:         doc.Attributes,
+		Config:   config,
+		counters: make(map[string]int),
+		Attributes: types.AttributesWithOverrides{
+			Content:   doc.Attributes.Header(),
+			Overrides: config.AttributeOverrides,
+		}.All(),
 		ElementReferences:  doc.ElementReferences,
 		Footnotes:          doc.Footnotes,
+		Glossary:           doc.Glossary,
 		HasHeader:          hasHeader,
 		EncodeSpecialChars: true,
+		inlineCache:        cache,
 	}
 }
 
@@ -58,6 +80,19 @@ func (ctx *Context) GetAndIncrementExampleBlockCounter() int {
 	return ctx.getAndIncrementCounter(exampleBlockCounter)
 }
 
+const listingBlockCounter = "listingBlockCounter"
+
+// GetAndIncrementListingBlockCounter returns the current value for the listing block counter after internally incrementing it.
+func (ctx *Context) GetAndIncrementListingBlockCounter() int {
+	return ctx.getAndIncrementCounter(listingBlockCounter)
+}
+
+// GetAndIncrementCaptionListCounter returns the current value for the counter of the elements
+// of the given caption list kind (eg: `figures`) after internally incrementing it.
+func (ctx *Context) GetAndIncrementCaptionListCounter(kind string) int {
+	return ctx.getAndIncrementCounter("captionList:" + kind)
+}
+
 // getAndIncrementCounter returns the current value for the  counter after internally incrementing it.
 func (ctx *Context) getAndIncrementCounter(name string) int {
 	if _, found := ctx.counters[name]; !found {
@@ -67,3 +102,23 @@ func (ctx *Context) getAndIncrementCounter(name string) int {
 	ctx.counters[name]++
 	return ctx.counters[name]
 }
+
+// AddAsset records the given URL of a file referenced in the rendered document (eg: an image),
+// if it is a relative path to a local file
+func (ctx *Context) AddAsset(url string) {
+	if url == "" || strings.Contains(url, "://") || strings.HasPrefix(url, "data:") || path.IsAbs(url) || filepath.IsAbs(url) {
+		return
+	}
+	for _, a := range ctx.assets {
+		if a == url {
+			return
+		}
+	}
+	ctx.assets = append(ctx.assets, url)
+}
+
+// Assets returns the relative paths to the local files referenced in the rendered document, in the order in which they were
+// referenced first
+func (ctx *Context) Assets() []string {
+	return ctx.assets
+}
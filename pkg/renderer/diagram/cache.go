@@ -0,0 +1,93 @@
+package diagram
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// DefaultCacheSize the maximum number of images retained by a caching renderer
+const DefaultCacheSize = 100
+
+// Renderer the interface of the renderers of diagrams
+type Renderer interface {
+	RenderDiagram(kind, format, source string) ([]byte, error)
+}
+
+// NewCachingRenderer returns a diagram renderer which keeps the images rendered by the given renderer,
+// indexed by the hash of their kind, format and source, so that a diagram is rendered only once.
+// At most `size` images are retained: the least recently used image is discarded when the cache is full.
+func NewCachingRenderer(r Renderer, size int) *CachingRenderer {
+	return &CachingRenderer{
+		renderer: r,
+		size:     size,
+		images:   map[string]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+// CachingRenderer a diagram renderer with a cache of the rendered images
+type CachingRenderer struct {
+	renderer Renderer
+	size     int
+	mu       sync.Mutex
+	images   map[string]*list.Element // the elements of the `lru` list, indexed by key
+	lru      *list.List               // the cached images, from the most recently used to the least recently used
+}
+
+type cachedImage struct {
+	key   string
+	image []byte
+}
+
+// RenderDiagram returns the cached image of the given diagram, or renders it with the underlying renderer.
+// The lock is not held while the underlying renderer is called, so that the diagrams can be rendered concurrently
+// (the same diagram may then be rendered more than once, but only the last image is retained)
+func (r *CachingRenderer) RenderDiagram(kind, format, source string) ([]byte, error) {
+	h := sha256.Sum256([]byte(kind + "\x00" + format + "\x00" + source))
+	key := hex.EncodeToString(h[:])
+	if image, found := r.get(key); found {
+		return image, nil
+	}
+	image, err := r.renderer.RenderDiagram(kind, format, source)
+	if err != nil {
+		return nil, err
+	}
+	r.put(key, image)
+	return image, nil
+}
+
+// Len returns the number of cached images
+func (r *CachingRenderer) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+func (r *CachingRenderer) get(key string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, found := r.images[key]
+	if !found {
+		return nil, false
+	}
+	r.lru.MoveToFront(e)
+	return e.Value.(cachedImage).image, true
+}
+
+func (r *CachingRenderer) put(key string, image []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, found := r.images[key]; found {
+		e.Value = cachedImage{key: key, image: image}
+		r.lru.MoveToFront(e)
+		return
+	}
+	r.images[key] = r.lru.PushFront(cachedImage{key: key, image: image})
+	for r.lru.Len() > r.size {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.images, oldest.Value.(cachedImage).key)
+	}
+}
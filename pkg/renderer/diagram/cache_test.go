@@ -0,0 +1,76 @@
+package diagram_test
+
+import (
+	"sync"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/diagram"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+// rendererMock a diagram renderer which counts its calls, and which waits until
+// the given `release` channel is closed (if set) before returning
+type rendererMock struct {
+	mu      sync.Mutex
+	calls   int
+	release chan struct{}
+}
+
+func (r *rendererMock) RenderDiagram(kind, format, source string) ([]byte, error) {
+	r.mu.Lock()
+	r.calls++
+	r.mu.Unlock()
+	if r.release != nil {
+		<-r.release
+	}
+	return []byte(kind + "/" + format + "/" + source), nil
+}
+
+var _ = Describe("caching diagram renderer", func() {
+
+	It("should render the same diagram only once", func() {
+		r := &rendererMock{}
+		c := diagram.NewCachingRenderer(r, 10)
+		Expect(c.RenderDiagram("plantuml", "svg", "a -> b")).To(Equal([]byte("plantuml/svg/a -> b")))
+		Expect(c.RenderDiagram("plantuml", "svg", "a -> b")).To(Equal([]byte("plantuml/svg/a -> b")))
+		Expect(c.RenderDiagram("plantuml", "png", "a -> b")).To(Equal([]byte("plantuml/png/a -> b")))
+		Expect(r.calls).To(Equal(2))
+		Expect(c.Len()).To(Equal(2))
+	})
+
+	It("should discard the least recently used diagram", func() {
+		r := &rendererMock{}
+		c := diagram.NewCachingRenderer(r, 2)
+		_, _ = c.RenderDiagram("plantuml", "svg", "a")
+		_, _ = c.RenderDiagram("plantuml", "svg", "b")
+		_, _ = c.RenderDiagram("plantuml", "svg", "a") // hit: `b` becomes the least recently used
+		_, _ = c.RenderDiagram("plantuml", "svg", "c") // discards `b`
+		Expect(c.Len()).To(Equal(2))
+		Expect(r.calls).To(Equal(3))
+		_, _ = c.RenderDiagram("plantuml", "svg", "a") // hit
+		Expect(r.calls).To(Equal(3))
+		_, _ = c.RenderDiagram("plantuml", "svg", "b") // miss
+		Expect(r.calls).To(Equal(4))
+	})
+
+	It("should not hold the lock while rendering", func() {
+		slow := &rendererMock{release: make(chan struct{})}
+		c := diagram.NewCachingRenderer(slow, 10)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = c.RenderDiagram("plantuml", "svg", "slow")
+		}()
+		Eventually(func() int {
+			slow.mu.Lock()
+			defer slow.mu.Unlock()
+			return slow.calls
+		}).Should(Equal(1))
+		// the cache can still be read while the slow diagram is being rendered
+		Expect(c.Len()).To(Equal(0))
+		close(slow.release)
+		Eventually(done).Should(BeClosed())
+		Expect(c.Len()).To(Equal(1))
+	})
+})
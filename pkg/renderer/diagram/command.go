@@ -0,0 +1,46 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// NewCommandRenderer returns a diagram renderer which writes the source of the diagrams on the standard input of
+// the given command and reads the resulting image on its standard output.
+// The `{format}` placeholders in the arguments are replaced with the requested image format
+// (eg: `plantuml -t{format} -pipe` or `dot -T{format}`)
+func NewCommandRenderer(name string, args ...string) CommandRenderer {
+	return CommandRenderer{
+		name: name,
+		args: args,
+	}
+}
+
+// CommandRenderer a diagram renderer which calls an external command
+type CommandRenderer struct {
+	name string
+	args []string
+}
+
+// RenderDiagram renders the given source of a diagram of the given kind in an image with the given format
+func (r CommandRenderer) RenderDiagram(kind, format, source string) ([]byte, error) {
+	args := make([]string, len(r.args))
+	for i, arg := range r.args {
+		args[i] = strings.ReplaceAll(arg, "{format}", format)
+	}
+	cmd := exec.Command(r.name, args...)
+	cmd.Stdin = strings.NewReader(source)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("unable to render %s diagram with '%s': %w: %s", kind, r.name, err, msg)
+		}
+		return nil, fmt.Errorf("unable to render %s diagram with '%s': %w", kind, r.name, err)
+	}
+	return stdout.Bytes(), nil
+}
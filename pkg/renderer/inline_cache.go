@@ -0,0 +1,57 @@
+package renderer
+
+// inlineCache the rendered content of the inline elements, indexed by a hash of the element and of the rendering context
+type inlineCache struct {
+	entries map[string]string
+	hits    int
+	misses  int
+}
+
+func newInlineCache() *inlineCache {
+	return &inlineCache{
+		entries: map[string]string{},
+	}
+}
+
+// InlineCacheEnabled returns `true` if the rendered content of the inline elements can be cached
+func (ctx *Context) InlineCacheEnabled() bool {
+	return ctx.inlineCache != nil
+}
+
+// CachedInline returns the rendered content of the inline element with the given key (+ `true`),
+// or an empty string (+ `false`) if the element was not rendered yet or if the cache is disabled
+func (ctx *Context) CachedInline(key string) (string, bool) {
+	if ctx.inlineCache == nil {
+		return "", false
+	}
+	if result, found := ctx.inlineCache.entries[key]; found {
+		ctx.inlineCache.hits++
+		return result, true
+	}
+	ctx.inlineCache.misses++
+	return "", false
+}
+
+// CacheInline retains the rendered content of the inline element with the given key (if the cache is enabled)
+func (ctx *Context) CacheInline(key, result string) {
+	if ctx.inlineCache == nil {
+		return
+	}
+	ctx.inlineCache.entries[key] = result
+}
+
+// ClearInlineCache discards the rendered content of all inline elements (eg: when a document attribute changed)
+func (ctx *Context) ClearInlineCache() {
+	if ctx.inlineCache == nil {
+		return
+	}
+	ctx.inlineCache.entries = map[string]string{}
+}
+
+// InlineCacheStats returns the number of hits and misses in the cache of rendered inline elements
+func (ctx *Context) InlineCacheStats() (int, int) {
+	if ctx.inlineCache == nil {
+		return 0, 0
+	}
+	return ctx.inlineCache.hits, ctx.inlineCache.misses
+}
@@ -0,0 +1,41 @@
+package renderer_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("inline cache", func() {
+
+	It("should retain rendered content and count hits and misses", func() {
+		ctx := renderer.NewContext(types.Document{}, configuration.NewConfiguration(configuration.WithInlineCache(true)))
+		Expect(ctx.InlineCacheEnabled()).To(BeTrue())
+		_, found := ctx.CachedInline("foo")
+		Expect(found).To(BeFalse())
+		ctx.CacheInline("foo", "<b>foo</b>")
+		result, found := ctx.CachedInline("foo")
+		Expect(found).To(BeTrue())
+		Expect(result).To(Equal("<b>foo</b>"))
+		ctx.ClearInlineCache()
+		_, found = ctx.CachedInline("foo")
+		Expect(found).To(BeFalse())
+		hits, misses := ctx.InlineCacheStats()
+		Expect(hits).To(Equal(1))
+		Expect(misses).To(Equal(2))
+	})
+
+	It("should not retain rendered content when disabled", func() {
+		ctx := renderer.NewContext(types.Document{}, configuration.NewConfiguration())
+		Expect(ctx.InlineCacheEnabled()).To(BeFalse())
+		ctx.CacheInline("foo", "<b>foo</b>")
+		_, found := ctx.CachedInline("foo")
+		Expect(found).To(BeFalse())
+		hits, misses := ctx.InlineCacheStats()
+		Expect(hits).To(Equal(0))
+		Expect(misses).To(Equal(0))
+	})
+})
@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NormalizeHTML returns a normalized version of the given HTML content, so that
+// the output of different converters (eg: libasciidoc and asciidoctor) can be compared
+// without being affected by differences that do not change the rendering of the document:
+// - leading and trailing spaces on each line, and blank lines, are removed
+// - void elements are written without their trailing slash (eg: `<br/>` and `<br />` become `<br>`)
+// - numeric character references are replaced by the actual character (except for `<`, `>`, `&`, `"` and `'`)
+// - named character references for quotes are replaced by their numeric counterpart
+// - volatile elements such as the `generator` metadata, the stylesheets and the `last updated` footer are removed
+func NormalizeHTML(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	for _, e := range volatileElements {
+		content = e.ReplaceAllString(content, "")
+	}
+	content = selfClosingTag.ReplaceAllString(content, "$1>")
+	content = numericCharRef.ReplaceAllStringFunc(content, decodeNumericCharRef)
+	content = namedQuoteRefs.Replace(content)
+	lines := strings.Split(content, "\n")
+	result := make([]string, 0, len(lines))
+	for _, l := range lines {
+		if l = strings.TrimSpace(l); l != "" {
+			result = append(result, l)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+var volatileElements = []*regexp.Regexp{
+	regexp.MustCompile(`<meta name="generator"[^>]*>`),
+	regexp.MustCompile(`<link rel="stylesheet"[^>]*>`),
+	regexp.MustCompile(`(?s)<style>.*?</style>`),
+	regexp.MustCompile(`(?s)<div id="footer">\s*<div id="footer-text">.*?</div>\s*</div>`),
+}
+
+var selfClosingTag = regexp.MustCompile(`(<(?:area|base|br|col|embed|hr|img|input|link|meta|param|source|track|wbr)\b[^>]*?)\s*/>`)
+
+var numericCharRef = regexp.MustCompile(`&#(?:[0-9]+|[xX][0-9a-fA-F]+);`)
+
+var namedQuoteRefs = strings.NewReplacer(
+	"&quot;", "&#34;",
+	"&apos;", "&#39;",
+)
+
+func decodeNumericCharRef(ref string) string {
+	var code int64
+	var err error
+	if ref[2] == 'x' || ref[2] == 'X' {
+		code, err = strconv.ParseInt(ref[3:len(ref)-1], 16, 32)
+	} else {
+		code, err = strconv.ParseInt(ref[2:len(ref)-1], 10, 32)
+	}
+	if err != nil {
+		return ref
+	}
+	switch code {
+	case '<', '>', '&', '"', '\'':
+		// keep as-is, since these characters must remain escaped
+		return "&#" + strconv.FormatInt(code, 10) + ";"
+	default:
+		return string(rune(code))
+	}
+}
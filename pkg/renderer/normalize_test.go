@@ -0,0 +1,54 @@
+package renderer_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+
+	. "github.com/onsi/ginkgo"                  //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
+)
+
+var _ = Describe("html normalization", func() {
+
+	DescribeTable("normalize",
+		func(content, expected string) {
+			Expect(renderer.NormalizeHTML(content)).To(Equal(expected))
+		},
+		Entry("blank lines and indentation",
+			"<div class=\"paragraph\">\n\n  <p>foo</p>  \n</div>\n",
+			"<div class=\"paragraph\">\n<p>foo</p>\n</div>"),
+		Entry("windows line endings",
+			"<div>\r\n<p>foo</p>\r\n</div>\r\n",
+			"<div>\n<p>foo</p>\n</div>"),
+		Entry("void elements",
+			`<p>foo<br/>bar<br />baz<br></p><img src="foo.png" alt="foo"/>`,
+			`<p>foo<br>bar<br>baz<br></p><img src="foo.png" alt="foo">`),
+		Entry("numeric character references",
+			`<p>it&#8217;s &#x2026; &#169; &#60;&#38;&#62;</p>`,
+			`<p>it’s … © &#60;&#38;&#62;</p>`),
+		Entry("quote character references",
+			`<p>&quot;foo&quot; &apos;bar&apos; &#x27;baz&#x22;</p>`,
+			`<p>&#34;foo&#34; &#39;bar&#39; &#39;baz&#34;</p>`),
+		Entry("volatile elements",
+			`<head>
+<meta charset="UTF-8">
+<meta name="generator" content="Asciidoctor 2.0.10">
+<link rel="stylesheet" href="https://fonts.googleapis.com/css?family=Open+Sans">
+<style>
+body { color: red; }
+</style>
+</head>
+<body>
+<div id="footer">
+<div id="footer-text">
+Last updated 2020-01-01 00:00:00 +0100
+</div>
+</div>
+</body>`,
+			`<head>
+<meta charset="UTF-8">
+</head>
+<body>
+</body>`),
+	)
+})
@@ -0,0 +1,52 @@
+package sgml
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// sanitizeAnchorID returns the given ID in which the whitespaces (which are not allowed in an HTML `id` attribute)
+// are replaced with underscores
+func sanitizeAnchorID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, id)
+}
+
+// escapeAnchorID returns the given ID sanitized and escaped, to be used as the value of an `id` attribute
+func escapeAnchorID(id string) string {
+	return template.HTMLEscapeString(sanitizeAnchorID(id))
+}
+
+// escapeAnchorHref returns the given ID sanitized and percent-encoded, to be used as the fragment of
+// an `href` attribute (eg: `<a href="#{{ .Href }}">`) which refers to the element with this ID
+func escapeAnchorHref(id string) string {
+	id = sanitizeAnchorID(id)
+	result := &strings.Builder{}
+	for i := 0; i < len(id); i++ {
+		if b := id[i]; isFragmentChar(b) {
+			result.WriteByte(b)
+		} else {
+			fmt.Fprintf(result, "%%%02X", b)
+		}
+	}
+	return template.HTMLEscapeString(result.String())
+}
+
+// isFragmentChar returns `true` if the given byte can be used as-is in the fragment of a URL
+// (the non-ASCII characters of the UTF-8 encoded IDs are kept as-is, as in IRIs)
+func isFragmentChar(b byte) bool {
+	switch {
+	case b >= 0x80:
+		return true
+	case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9':
+		return true
+	default:
+		return strings.IndexByte("-._~!$&'()*+,;=:@/?", b) >= 0
+	}
+}
@@ -0,0 +1,41 @@
+package sgml
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// renderAttributeDeclaration sets the attribute in the context, so that it applies to the subsequent elements only.
+// Nothing is rendered.
+func (r *sgmlRenderer) renderAttributeDeclaration(ctx *renderer.Context, a types.AttributeDeclaration) (string, error) {
+	if isLockedAttribute(ctx, a.Name) {
+		return "", nil
+	}
+	ctx.Attributes = ctx.Attributes.Set(a.Name, a.Value)
+	ctx.ClearInlineCache()
+	return "", nil
+}
+
+// renderAttributeReset unsets the attribute in the context, so that the subsequent elements only are affected.
+// Nothing is rendered.
+func (r *sgmlRenderer) renderAttributeReset(ctx *renderer.Context, a types.AttributeReset) (string, error) {
+	if isLockedAttribute(ctx, a.Name) {
+		return "", nil
+	}
+	ctx.Attributes = ctx.Attributes.Set(a.Name, types.UnsetAttribute{})
+	ctx.ClearInlineCache()
+	return "", nil
+}
+
+// isLockedAttribute returns `true` if the attribute with the given name cannot be changed in the document body,
+// i.e., if it can only be set in the header, or if it is overridden (or reset) in the configuration
+func isLockedAttribute(ctx *renderer.Context, name string) bool {
+	if types.IsHeaderOnlyAttribute(name) {
+		return true
+	}
+	if _, found := ctx.Config.AttributeOverrides[name]; found {
+		return true
+	}
+	_, found := ctx.Config.AttributeOverrides["!"+name]
+	return found
+}
@@ -1,12 +1,12 @@
 package sgml
 
 import (
+	"fmt"
 	"io"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderCalloutList(ctx *renderer.Context, l types.CalloutList) (string, error) {
@@ -17,16 +17,16 @@ func (r *sgmlRenderer) renderCalloutList(ctx *renderer.Context, l types.CalloutL
 
 		err := r.renderCalloutListItem(ctx, content, item)
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render callout list item")
+			return "", fmt.Errorf("unable to render callout list item: %w", err)
 		}
 	}
 	roles, err := r.renderElementRoles(ctx, l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 	title, err := r.renderElementTitle(l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.calloutList.Execute(result, struct {
@@ -45,7 +45,7 @@ func (r *sgmlRenderer) renderCalloutList(ctx *renderer.Context, l types.CalloutL
 		Items:   l.Items,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list")
+		return "", fmt.Errorf("unable to render callout list: %w", err)
 	}
 	return result.String(), nil
 }
@@ -54,7 +54,7 @@ func (r *sgmlRenderer) renderCalloutListItem(ctx *renderer.Context, w io.Writer,
 
 	content, err := r.renderListElements(ctx, item.Elements)
 	if err != nil {
-		return errors.Wrap(err, "unable to render callout list item content")
+		return fmt.Errorf("unable to render callout list item content: %w", err)
 	}
 	err = r.calloutListItem.Execute(w, struct {
 		Context *renderer.Context
@@ -66,7 +66,7 @@ func (r *sgmlRenderer) renderCalloutListItem(ctx *renderer.Context, w io.Writer,
 		Content: string(content),
 	})
 	if err != nil {
-		return errors.Wrap(err, "unable to render callout list")
+		return fmt.Errorf("unable to render callout list: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,260 @@
+package sgml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// default titles of the lists of figures, tables and listings
+var defaultCaptionListTitles = map[string]string{
+	types.FiguresCaptionList:  "List of Figures",
+	types.TablesCaptionList:   "List of Tables",
+	types.ListingsCaptionList: "List of Listings",
+}
+
+// prefixes of the IDs generated for the figures, tables and listings without a custom ID, when they are listed
+var captionListIDPrefixes = map[string]string{
+	types.FiguresCaptionList:  "_figure_",
+	types.TablesCaptionList:   "_table_",
+	types.ListingsCaptionList: "_listing_",
+}
+
+func (r *sgmlRenderer) renderCaptionList(ctx *renderer.Context, p types.CaptionListPlaceHolder) (string, error) {
+	log.Debugf("rendering list of %s...", p.Kind)
+	entries := ctx.CaptionLists[p.Kind]
+	if len(entries) == 0 {
+		// nothing to render
+		return "", nil
+	}
+	content := &strings.Builder{}
+	for _, entry := range entries {
+		entry.ID = escapeAnchorHref(entry.ID)
+		err := r.captionListEntry.Execute(content, entry)
+		if err != nil {
+			return "", fmt.Errorf("error while rendering list of %s: %w", p.Kind, err)
+		}
+	}
+	title, err := r.renderElementTitle(p.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("error while rendering list of %s: %w", p.Kind, err)
+	}
+	if title == "" {
+		title = defaultCaptionListTitles[p.Kind]
+	}
+	result := &strings.Builder{}
+	err = r.captionList.Execute(result, struct {
+		Kind    string
+		Title   string
+		Content string
+	}{
+		Kind:    p.Kind,
+		Title:   title,
+		Content: content.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error while rendering list of %s: %w", p.Kind, err)
+	}
+	return result.String(), nil
+}
+
+// renderCaption returns the caption (eg: `Figure 1. `) of an element with a title, along with its number,
+// which is obtained with the given `next` func when the caption contains the counter with the given name.
+func renderCaption(ctx *renderer.Context, attrs types.Attributes, captionAttr, defaultCaption, counter string, next func() int) (string, int, error) {
+	c, found, err := attrs.GetAsString(types.AttrCaption)
+	if err != nil {
+		return "", 0, err
+	} else if !found {
+		if c = ctx.Attributes.GetAsStringWithDefault(captionAttr, defaultCaption); c != "" {
+			// We always append the number, unless the caption is disabled.
+			// This is for asciidoctor compatibility.
+			c += " {counter:" + counter + "}. "
+		}
+	}
+	number := 0
+	// TODO: Replace this hack when we have attribute substitution fully working
+	if ref := "{counter:" + counter + "}"; strings.Contains(c, ref) {
+		number = next()
+		c = strings.ReplaceAll(c, ref, strconv.Itoa(number))
+	}
+	return c, number, nil
+}
+
+// captionListID returns the ID of a figure, a table or a listing with the given ID, which may be generated
+// if the element is included in a list of figures, tables or listings
+func captionListID(ctx *renderer.Context, kind, id string) string {
+	entries, found := ctx.CaptionLists[kind]
+	if !found {
+		return id
+	}
+	if i := ctx.GetAndIncrementCaptionListCounter(kind); id == "" && i <= len(entries) {
+		return entries[i-1].ID
+	}
+	return id
+}
+
+// newCaptionLists returns the lists of figures, tables and/or listings of the given document,
+// if the document contains a macro to render them (eg: `toc::figures[]`)
+func (r *sgmlRenderer) newCaptionLists(ctx *renderer.Context, doc types.Document) (map[string][]types.CaptionListEntry, error) {
+	c := &captionListsCollector{
+		kinds:   map[string]bool{},
+		lists:   map[string][]types.CaptionListEntry{},
+		numbers: map[string]int{},
+	}
+	if err := r.collectCaptionListEntries(ctx, c, doc.Elements); err != nil {
+		return nil, err
+	}
+	if len(c.kinds) == 0 {
+		return nil, nil
+	}
+	result := make(map[string][]types.CaptionListEntry, len(c.kinds))
+	for kind := range c.kinds {
+		result[kind] = c.lists[kind]
+	}
+	return result, nil
+}
+
+type captionListsCollector struct {
+	kinds   map[string]bool // the kinds of lists to render
+	lists   map[string][]types.CaptionListEntry
+	numbers map[string]int // the numbers used in the captions
+}
+
+func (c *captionListsCollector) next(kind string) func() int {
+	return func() int {
+		c.numbers[kind]++
+		return c.numbers[kind]
+	}
+}
+
+func (c *captionListsCollector) add(kind, id, caption, title string) {
+	if id == "" {
+		id = captionListIDPrefixes[kind] + strconv.Itoa(len(c.lists[kind])+1)
+	}
+	c.lists[kind] = append(c.lists[kind], types.CaptionListEntry{
+		ID:      id,
+		Caption: caption,
+		Title:   title,
+	})
+}
+
+// collectCaptionListEntries visits the given elements (in the order in which they are rendered)
+// to collect the figures, tables and listings, using the same numbering as in their captions
+func (r *sgmlRenderer) collectCaptionListEntries(ctx *renderer.Context, c *captionListsCollector, elements []interface{}) error {
+	for _, element := range elements {
+		if skip, err := r.isSkippedByRoles(ctx, element); err != nil {
+			return err
+		} else if skip {
+			continue
+		}
+		var err error
+		switch e := element.(type) {
+		case types.CaptionListPlaceHolder:
+			c.kinds[e.Kind] = true
+		case types.ImageBlock:
+			var title string
+			if title, err = r.renderElementTitle(e.Attributes); err == nil && title != "" {
+				var caption string
+				if caption, _, err = renderCaption(ctx, e.Attributes, types.AttrFigureCaption, "Figure", "figure-number", c.next(types.FiguresCaptionList)); err == nil {
+					c.add(types.FiguresCaptionList, e.Attributes.GetAsStringWithDefault(types.AttrID, ""), caption, title)
+				}
+			}
+		case types.Table:
+			if e.Attributes.Has(types.AttrTitle) {
+				var title, caption string
+				if title, err = r.renderElementTitle(e.Attributes); err == nil {
+					if caption, _, err = renderCaption(ctx, e.Attributes, types.AttrTableCaption, "Table", "table-number", c.next(types.TablesCaptionList)); err == nil {
+						c.add(types.TablesCaptionList, e.Attributes.GetAsStringWithDefault(types.AttrID, ""), caption, title)
+					}
+				}
+			}
+		case types.ListingBlock:
+			err = r.collectListingCaptionListEntry(ctx, c, e)
+		case types.Paragraph:
+			// source paragraphs are rendered as listing blocks
+			if e.Attributes[types.AttrStyle] == types.Source {
+				err = r.collectListingCaptionListEntry(ctx, c, types.ListingBlock{Attributes: e.Attributes})
+			}
+		case types.Section:
+			err = r.collectCaptionListEntries(ctx, c, e.Elements)
+		case types.Preamble:
+			err = r.collectCaptionListEntries(ctx, c, e.Elements)
+		case types.ExampleBlock:
+			err = r.collectCaptionListEntries(ctx, c, e.Elements)
+		case types.QuoteBlock:
+			err = r.collectCaptionListEntries(ctx, c, e.Elements)
+		case types.SidebarBlock:
+			err = r.collectCaptionListEntries(ctx, c, e.Elements)
+		case types.OrderedList:
+			for _, item := range e.Items {
+				if err = r.collectCaptionListEntries(ctx, c, item.Elements); err != nil {
+					break
+				}
+			}
+		case types.UnorderedList:
+			for _, item := range e.Items {
+				if err = r.collectCaptionListEntries(ctx, c, item.Elements); err != nil {
+					break
+				}
+			}
+		case types.LabeledList:
+			for _, item := range e.Items {
+				if err = r.collectCaptionListEntries(ctx, c, item.Elements); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *sgmlRenderer) collectListingCaptionListEntry(ctx *renderer.Context, c *captionListsCollector, b types.ListingBlock) error {
+	if !hasListingCaption(ctx, b) {
+		return nil
+	}
+	title, err := r.renderElementTitle(b.Attributes)
+	if err != nil || title == "" {
+		return err
+	}
+	caption, _, err := renderCaption(ctx, b.Attributes, types.AttrListingCaption, "", "listing-number", c.next(types.ListingsCaptionList))
+	if err != nil {
+		return err
+	}
+	c.add(types.ListingsCaptionList, b.Attributes.GetAsStringWithDefault(types.AttrID, ""), caption, title)
+	return nil
+}
+
+// hasListingCaption returns `true` if the given listing block is rendered with a caption (when it has a title),
+// ie, if it is neither collapsible (its title is then rendered in the collapsible block) nor a diagram
+func hasListingCaption(ctx *renderer.Context, b types.ListingBlock) bool {
+	if b.Attributes.HasOption(types.OptionCollapsible) {
+		return false
+	}
+	if style, found, _ := b.Attributes.GetAsString(types.AttrStyle); found {
+		if _, found := ctx.Config.Diagrams[style]; found {
+			return false
+		}
+	}
+	return true
+}
+
+// renderListingCaption returns the caption (eg: `Listing 1. `, if the `listing-caption` attribute is set)
+// and the ID of the given listing block with the given title
+func (r *sgmlRenderer) renderListingCaption(ctx *renderer.Context, b types.ListingBlock, title string) (string, string, error) {
+	id := r.renderElementID(b.Attributes)
+	if title == "" {
+		return "", id, nil
+	}
+	caption, _, err := renderCaption(ctx, b.Attributes, types.AttrListingCaption, "", "listing-number", ctx.GetAndIncrementListingBlockCounter)
+	if err != nil {
+		return "", "", err
+	}
+	return caption, captionListID(ctx, types.ListingsCaptionList, id), nil
+}
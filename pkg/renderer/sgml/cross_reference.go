@@ -1,12 +1,13 @@
 package sgml
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderInternalCrossReference(ctx *renderer.Context, xref types.InternalCrossReference) (string, error) {
@@ -15,19 +16,24 @@ func (r *sgmlRenderer) renderInternalCrossReference(ctx *renderer.Context, xref
 	var label string
 	xrefID, ok := xref.ID.(string)
 	if !ok {
-		return "", errors.Errorf("unable to process internal cross reference: invalid ID: '%v'", xref.ID)
+		return "", fmt.Errorf("unable to process internal cross reference: invalid ID: '%v'", xref.ID)
 	}
+	// the label falls back to the reftext or title of the target element (resolved by the parser),
+	// then to its ID if it has none, or to the ID within brackets if there is no such target in the document
 	if xrefLabel, ok := xref.Label.(string); ok {
 		label = xrefLabel
 	} else if target, found := ctx.ElementReferences[xrefID]; found {
-		if t, ok := target.([]interface{}); ok {
+		switch t := target.(type) {
+		case []interface{}:
 			renderedContent, err := r.renderElement(ctx, t)
 			if err != nil {
-				return "", errors.Wrap(err, "error while rendering internal cross reference")
+				return "", fmt.Errorf("error while rendering internal cross reference: %w", err)
 			}
 			label = renderedContent
-		} else {
-			return "", errors.Errorf("unable to process internal cross reference to element of type %T", target)
+		case nil:
+			label = xrefID
+		default:
+			return "", fmt.Errorf("unable to process internal cross reference to element of type %T", target)
 		}
 	} else {
 		label = "[" + xrefID + "]"
@@ -36,11 +42,20 @@ func (r *sgmlRenderer) renderInternalCrossReference(ctx *renderer.Context, xref
 		Href  string
 		Label string
 	}{
-		Href:  xrefID,
+		Href:  escapeAnchorHref(xrefID),
 		Label: label,
 	})
 	if err != nil {
-		return "", errors.Wrapf(err, "unable to render internal cross reference")
+		return "", fmt.Errorf("unable to render internal cross reference: %w", err)
+	}
+	return result.String(), nil
+}
+
+func (r *sgmlRenderer) renderInlineAnchor(a types.InlineAnchor) (string, error) {
+	result := &strings.Builder{}
+	a.ID = escapeAnchorID(a.ID)
+	if err := r.inlineAnchor.Execute(result, a); err != nil {
+		return "", fmt.Errorf("unable to render inline anchor: %w", err)
 	}
 	return result.String(), nil
 }
@@ -55,27 +70,40 @@ func (r *sgmlRenderer) renderExternalCrossReference(ctx *renderer.Context, xref
 		label = l
 	case []interface{}:
 		if label, err = r.renderInlineElements(ctx, l); err != nil {
-			return "", errors.Wrap(err, "unable to render external cross reference")
+			return "", fmt.Errorf("unable to render external cross reference: %w", err)
 		}
 	default:
-		return "", errors.Errorf("unable to render external cross reference label of type '%T'", xref.Label)
+		return "", fmt.Errorf("unable to render external cross reference label of type '%T'", xref.Label)
+	}
+	class, err := r.renderInlineRoles(ctx, xref.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("unable to render external cross reference: %w", err)
 	}
 	err = r.externalCrossReference.Execute(result, struct {
 		Href  string
 		Label string
+		Class string
 	}{
-		Href:  getCrossReferenceLocation(xref),
+		Href:  rewriteURL(ctx, configuration.LinkURL, getCrossReferenceLocation(ctx, xref)),
 		Label: label,
+		Class: class,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render external cross reference")
+		return "", fmt.Errorf("unable to render external cross reference: %w", err)
 	}
 	return result.String(), nil
 }
 
-func getCrossReferenceLocation(xref types.ExternalCrossReference) string {
+func getCrossReferenceLocation(ctx *renderer.Context, xref types.ExternalCrossReference) string {
 	loc := xref.Location.Stringify()
-	ext := filepath.Ext(xref.Location.Stringify())
+	ext := filepath.Ext(loc)
 	// log.Debugf("ext of '%s': '%s'", loc, ext)
-	return loc[:len(loc)-len(ext)] + ".html" // TODO output extension
+	return loc[:len(loc)-len(ext)] + getRelFileSuffix(ctx)
+}
+
+// getRelFileSuffix returns the file extension to use in links to other documents,
+// based on the `relfilesuffix` and `outfilesuffix` attributes (`.html` by default)
+func getRelFileSuffix(ctx *renderer.Context) string {
+	return ctx.Attributes.GetAsStringWithDefault(types.AttrRelFileSuffix,
+		ctx.Attributes.GetAsStringWithDefault(types.AttrOutFileSuffix, ".html"))
 }
@@ -1,11 +1,11 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -20,18 +20,17 @@ func (r *sgmlRenderer) renderAdmonitionBlock(ctx *renderer.Context, b types.Exam
 		return "", err
 	}
 	result := &strings.Builder{}
-	blocks := discardBlankLines(b.Elements)
-	content, err := r.renderElements(ctx, blocks)
+	content, err := r.renderElements(ctx, b.Elements)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render admonition block content")
+		return "", fmt.Errorf("unable to render admonition block content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.admonitionBlock.Execute(result, struct {
@@ -62,24 +61,24 @@ func (r *sgmlRenderer) renderAdmonitionParagraph(ctx *renderer.Context, p types.
 		return "", err
 	}
 	if !ok {
-		return "", errors.Errorf("failed to render admonition with unknown kind: %T", p.Attributes[types.AttrStyle])
+		return "", fmt.Errorf("failed to render admonition with unknown kind: %T", p.Attributes[types.AttrStyle])
 	}
 	kind = strings.ToLower(kind)
 	icon, err := r.renderIcon(ctx, types.Icon{Class: kind, Attributes: p.Attributes}, true)
 	if err != nil {
 		return "", err
 	}
-	content, err := r.renderLines(ctx, p.Lines)
+	content, err := r.renderLines(ctx, p.Lines, r.withHardBreaks(hasHardBreaks(ctx, p.Attributes)))
 	if err != nil {
 		return "", err
 	}
 	roles, err := r.renderElementRoles(ctx, p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	title, err := r.renderElementTitle(p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.admonitionParagraph.Execute(result, struct {
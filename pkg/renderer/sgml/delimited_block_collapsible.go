@@ -0,0 +1,64 @@
+package sgml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// the default summary of a collapsible block without title
+const defaultCollapsibleBlockTitle = "Details"
+
+// renderCollapsibleBlock renders the given content in a collapsible block, whose summary is the title of the block
+// with the given attributes. The ID and roles of the block are set on the collapsible block itself, hence the content
+// must be rendered without them (see `collapsibleContentAttributes`)
+func (r *sgmlRenderer) renderCollapsibleBlock(ctx *renderer.Context, attrs types.Attributes, content string) (string, error) {
+	roles, err := r.renderElementRoles(ctx, attrs)
+	if err != nil {
+		return "", fmt.Errorf("unable to render collapsible block roles: %w", err)
+	}
+	title, err := r.renderElementTitle(attrs)
+	if err != nil {
+		return "", fmt.Errorf("unable to render collapsible block title: %w", err)
+	}
+	if title == "" {
+		title = defaultCollapsibleBlockTitle
+	}
+	result := &strings.Builder{}
+	err = r.collapsibleBlock.Execute(result, struct {
+		ID      string
+		Title   string
+		Roles   string
+		Open    bool
+		Content string
+	}{
+		ID:      r.renderElementID(attrs),
+		Title:   title,
+		Roles:   roles,
+		Open:    attrs.HasOption(types.OptionOpen),
+		Content: content,
+	})
+	return result.String(), err
+}
+
+// collapsibleContentAttributes returns a copy of the given attributes of a collapsible block, without the ID, roles,
+// title and options which apply to the collapsible block itself, and which can be used to render its content
+func collapsibleContentAttributes(attrs types.Attributes) types.Attributes {
+	result := attrs.Clone()
+	delete(result, types.AttrID)
+	delete(result, types.AttrCustomID)
+	delete(result, types.AttrRoles)
+	delete(result, types.AttrTitle)
+	if options, ok := attrs[types.AttrOptions].([]interface{}); ok {
+		opts := make([]interface{}, 0, len(options))
+		for _, opt := range options {
+			if opt != types.OptionCollapsible && opt != types.OptionOpen {
+				opts = append(opts, opt)
+			}
+		}
+		result[types.AttrOptions] = opts
+	}
+	return result
+}
@@ -1,12 +1,12 @@
 package sgml
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -21,15 +21,19 @@ func (r *sgmlRenderer) renderExampleBlock(ctx *renderer.Context, b types.Example
 	number := 0
 	content, err := r.renderElements(ctx, b.Elements)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render example block content")
+		return "", fmt.Errorf("unable to render example block content: %w", err)
+	}
+	if b.Attributes.HasOption(types.OptionCollapsible) {
+		// the content is rendered in the collapsible block, without the surrounding example block
+		return r.renderCollapsibleBlock(ctx, b.Attributes, content)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	c, ok, err := b.Attributes.GetAsString(types.AttrCaption)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	if !ok {
 		c = ctx.Attributes.GetAsStringWithDefault(types.AttrExampleCaption, "Example")
@@ -44,7 +48,7 @@ func (r *sgmlRenderer) renderExampleBlock(ctx *renderer.Context, b types.Example
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 	caption.WriteString(c)
 	err = r.exampleBlock.Execute(result, struct {
@@ -72,15 +76,15 @@ func (r *sgmlRenderer) renderExampleParagraph(ctx *renderer.Context, p types.Par
 	result := &strings.Builder{}
 	content, err := r.renderLines(ctx, p.Lines)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render quote paragraph lines")
+		return "", fmt.Errorf("unable to render quote paragraph lines: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	title, err := r.renderElementTitle(p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 	err = r.exampleBlock.Execute(result, struct {
 		Context       *renderer.Context
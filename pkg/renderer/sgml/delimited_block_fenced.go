@@ -1,11 +1,11 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderFencedBlock(ctx *renderer.Context, b types.FencedBlock) (string, error) {
@@ -15,18 +15,18 @@ func (r *sgmlRenderer) renderFencedBlock(ctx *renderer.Context, b types.FencedBl
 	}()
 	ctx.WithinDelimitedBlock = true
 	result := &strings.Builder{}
-	lines := discardEmptyLines(b.Lines)
-	content, err := r.renderLines(ctx, lines)
+	// the content is rendered as-is, including the leading and trailing blank lines
+	content, err := r.renderLines(ctx, b.Lines, r.withVerbatimLines())
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block roles")
+		return "", fmt.Errorf("unable to render fenced block roles: %w", err)
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.fencedBlock.Execute(result, struct {
@@ -1,47 +1,71 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
+// renderListingBlock renders the given listing block, with its style (`source` or a diagram kind) if any.
+// If the block has the `collapsible` option, then the styled block is wrapped in a collapsible block
+// (which takes the ID, roles and title of the listing block)
 func (r *sgmlRenderer) renderListingBlock(ctx *renderer.Context, b types.ListingBlock) (string, error) {
+	if b.Attributes.HasOption(types.OptionCollapsible) {
+		content, err := r.renderListingBlock(ctx, types.ListingBlock{
+			Attributes: collapsibleContentAttributes(b.Attributes),
+			Lines:      b.Lines,
+		})
+		if err != nil {
+			return "", err
+		}
+		return r.renderCollapsibleBlock(ctx, b.Attributes, content)
+	}
 	if k, found := b.Attributes[types.AttrStyle]; found && k == types.Source {
 		return r.renderSourceBlock(ctx, b)
 	}
+	if style, found, _ := b.Attributes.GetAsString(types.AttrStyle); found {
+		if d, found := ctx.Config.Diagrams[style]; found {
+			return r.renderDiagramBlock(ctx, style, d, b)
+		}
+	}
 	previousWithinDelimitedBlock := ctx.WithinDelimitedBlock
 	defer func() {
 		ctx.WithinDelimitedBlock = previousWithinDelimitedBlock
 	}()
 	ctx.WithinDelimitedBlock = true
 	result := &strings.Builder{}
-	lines := discardEmptyLines(b.Lines)
-	content, err := r.renderLines(ctx, lines)
+	// the content is rendered as-is, including the leading and trailing blank lines
+	content, err := r.renderLines(ctx, b.Lines, r.withVerbatimLines())
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render listing block content")
+		return "", fmt.Errorf("unable to render listing block content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render listing block roles")
+		return "", fmt.Errorf("unable to render listing block roles: %w", err)
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
+	}
+	caption, id, err := r.renderListingCaption(ctx, b, title)
+	if err != nil {
+		return "", fmt.Errorf("unable to render listing block caption: %w", err)
 	}
 
 	err = r.listingBlock.Execute(result, struct {
 		Context *renderer.Context
 		ID      string
 		Title   string
+		Caption string
 		Roles   string
 		Content string
 	}{
 		Context: ctx,
-		ID:      r.renderElementID(b.Attributes),
+		ID:      id,
 		Title:   title,
+		Caption: caption,
 		Roles:   roles,
 		Content: content,
 	})
@@ -52,15 +76,15 @@ func (r *sgmlRenderer) renderListingParagraph(ctx *renderer.Context, p types.Par
 	result := &strings.Builder{}
 	content, err := r.renderLines(ctx, p.Lines)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render listing block content")
+		return "", fmt.Errorf("unable to render listing block content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render listing block roles")
+		return "", fmt.Errorf("unable to render listing block roles: %w", err)
 	}
 	title, err := r.renderElementTitle(p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.listingBlock.Execute(result, struct {
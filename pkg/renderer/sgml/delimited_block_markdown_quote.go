@@ -1,30 +1,30 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderMarkdownQuoteBlock(ctx *renderer.Context, b types.MarkdownQuoteBlock) (string, error) {
 	result := &strings.Builder{}
 	content, err := r.renderLines(ctx, b.Lines)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render example block content")
+		return "", fmt.Errorf("unable to render example block content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	attribution, err := markdownQuoteBlockAttribution(b)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.markdownQuoteBlock.Execute(result, struct {
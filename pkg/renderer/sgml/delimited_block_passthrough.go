@@ -1,11 +1,11 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderPassthroughBlock(ctx *renderer.Context, b types.PassthroughBlock) (string, error) {
@@ -18,11 +18,11 @@ func (r *sgmlRenderer) renderPassthroughBlock(ctx *renderer.Context, b types.Pas
 	ctx.WithinDelimitedBlock = true
 	content, err := r.renderLines(ctx, lines)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render passthrough")
+		return "", fmt.Errorf("unable to render passthrough: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	err = r.passthroughBlock.Execute(result, struct {
 		Context *renderer.Context
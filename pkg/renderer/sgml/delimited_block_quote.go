@@ -1,11 +1,11 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -13,19 +13,19 @@ func (r *sgmlRenderer) renderQuoteBlock(ctx *renderer.Context, b types.QuoteBloc
 	result := &strings.Builder{}
 	content, err := r.renderElements(ctx, b.Elements)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render example block content")
+		return "", fmt.Errorf("unable to render example block content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	attribution, err := quoteBlockAttribution(b)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render fenced block content")
+		return "", fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.quoteBlock.Execute(result, struct {
@@ -52,21 +52,26 @@ func (r *sgmlRenderer) renderQuoteParagraph(ctx *renderer.Context, p types.Parag
 
 	content, err := r.renderLines(ctx, p.Lines)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render quote paragraph lines")
+		return "", fmt.Errorf("unable to render quote paragraph lines: %w", err)
 	}
 	attribution, err := paragraphAttribution(p)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render quote paragraph lines")
+		return "", fmt.Errorf("unable to render quote paragraph lines: %w", err)
 	}
 	title, err := r.renderElementTitle(p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render quote paragraph title: %w", err)
+	}
+	roles, err := r.renderElementRoles(ctx, p.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("unable to render quote paragraph roles: %w", err)
 	}
 
 	err = r.quoteParagraph.Execute(result, struct {
 		Context     *renderer.Context
 		ID          string
 		Title       string
+		Roles       string
 		Attribution Attribution
 		Content     string
 		Lines       [][]interface{}
@@ -74,6 +79,7 @@ func (r *sgmlRenderer) renderQuoteParagraph(ctx *renderer.Context, p types.Parag
 		Context:     ctx,
 		ID:          r.renderElementID(p.Attributes),
 		Title:       title,
+		Roles:       roles,
 		Attribution: attribution,
 		Content:     string(content),
 		Lines:       p.Lines,
@@ -11,7 +11,6 @@ import (
 	"github.com/alecthomas/chroma/styles"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -19,16 +18,16 @@ func (r *sgmlRenderer) renderSourceBlock(ctx *renderer.Context, b types.ListingB
 	// first, render the content
 	content, highlighter, language, err := r.renderSourceLines(ctx, b)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render source block content")
+		return "", fmt.Errorf("unable to render source block content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render source block roles")
+		return "", fmt.Errorf("unable to render source block roles: %w", err)
 	}
 	var nowrap bool
 	if options, ok := b.Attributes[types.AttrOptions].([]interface{}); ok {
 		for _, opt := range options {
-			if opt == "nowrap" {
+			if opt == types.OptionNoWrap {
 				nowrap = true
 				break
 			}
@@ -36,21 +35,27 @@ func (r *sgmlRenderer) renderSourceBlock(ctx *renderer.Context, b types.ListingB
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
+	}
+	caption, id, err := r.renderListingCaption(ctx, b, title)
+	if err != nil {
+		return "", fmt.Errorf("unable to render source block caption: %w", err)
 	}
 
 	result := &bytes.Buffer{}
 	err = r.sourceBlock.Execute(result, struct {
 		ID                string
 		Title             string
+		Caption           string
 		Roles             string
 		Language          string
 		Nowrap            bool
 		SyntaxHighlighter string
 		Content           string
 	}{
-		ID:                r.renderElementID(b.Attributes),
+		ID:                id,
 		Title:             title,
+		Caption:           caption,
 		SyntaxHighlighter: highlighter,
 		Roles:             roles,
 		Language:          language,
@@ -66,7 +71,7 @@ func (r *sgmlRenderer) renderSourceParagraph(ctx *renderer.Context, p types.Para
 	copy(lines, p.Lines)
 	attributes := p.Attributes
 	attributes[types.AttrStyle] = types.Source
-	return r.renderSourceBlock(ctx, types.ListingBlock{
+	return r.renderListingBlock(ctx, types.ListingBlock{
 		Attributes: attributes,
 		Lines:      lines,
 	})
@@ -79,7 +84,8 @@ func (r *sgmlRenderer) renderSourceLines(ctx *renderer.Context, b types.ListingB
 	}()
 	ctx.WithinDelimitedBlock = true
 
-	lines := discardEmptyLines(b.Lines)
+	// the content is rendered as-is, including the leading and trailing blank lines
+	lines := b.Lines
 	highlighter, _, err := ctx.Attributes.GetAsString(types.AttrSyntaxHighlighter)
 	if err != nil {
 		return "", "", "", err
@@ -88,6 +94,8 @@ func (r *sgmlRenderer) renderSourceLines(ctx *renderer.Context, b types.ListingB
 	if err != nil {
 		return "", "", "", err
 	}
+	// line-based rendering when some lines are highlighted
+	highlightedLines, lineBased := b.Attributes[types.AttrHighlight].(types.LineRanges)
 	if found && (highlighter == "chroma" || highlighter == "pygments") {
 		ctx.EncodeSpecialChars = false
 		defer func() {
@@ -118,7 +126,7 @@ func (r *sgmlRenderer) renderSourceLines(ctx *renderer.Context, b types.ListingB
 		result := &strings.Builder{}
 		for i, line := range lines {
 			// extra option: line numbers
-			if b.Attributes.Has(types.AttrLineNums) {
+			if b.Attributes.HasFlag(types.AttrLineNums) {
 				options = append(options, html.WithLineNumbers(true), html.BaseLineNumber(i+1))
 			}
 
@@ -134,15 +142,21 @@ func (r *sgmlRenderer) renderSourceLines(ctx *renderer.Context, b types.ListingB
 			if err = html.New(options...).Format(highlightedLineBuf, style, iterator); err != nil {
 				return "", "", "", err
 			}
-			result.WriteString(highlightedLineBuf.String())
 			// append callouts at the end of the highlighted line
 			for _, callout := range callouts {
 				renderedCallout, err := r.renderCalloutRef(callout)
 				if err != nil {
 					return "", "", "", err
 				}
-				result.WriteString(renderedCallout)
+				highlightedLineBuf.WriteString(renderedCallout)
+			}
+			renderedLine = highlightedLineBuf.String()
+			if lineBased {
+				if renderedLine, err = r.renderSourceLineWrapper(renderedLine, highlightedLines.Match(i+1)); err != nil {
+					return "", "", "", err
+				}
 			}
+			result.WriteString(renderedLine)
 			if i < len(lines)-1 {
 				result.WriteRune('\n')
 			}
@@ -154,7 +168,14 @@ func (r *sgmlRenderer) renderSourceLines(ctx *renderer.Context, b types.ListingB
 		}
 		return result.String(), highlighter, language, nil
 	}
-	result, err := r.renderLines(ctx, lines)
+	if lineBased {
+		result, err := r.renderHighlightedLines(ctx, lines, highlightedLines)
+		if err != nil {
+			return "", "", "", err
+		}
+		return result, highlighter, language, nil
+	}
+	result, err := r.renderLines(ctx, lines, r.withVerbatimLines())
 	if err != nil {
 		if err != nil {
 			return "", "", "", err
@@ -163,6 +184,47 @@ func (r *sgmlRenderer) renderSourceLines(ctx *renderer.Context, b types.ListingB
 	return result, highlighter, language, nil
 }
 
+// renderHighlightedLines renders each line in its own `span` element, with an extra `highlight` class
+// on the lines matching the given ranges. Unlike `renderLines`, the whitespaces of each line are
+// preserved as-is.
+func (r *sgmlRenderer) renderHighlightedLines(ctx *renderer.Context, lines [][]interface{}, highlightedLines types.LineRanges) (string, error) {
+	result := &strings.Builder{}
+	for i, line := range lines {
+		buf := &strings.Builder{}
+		for _, e := range line {
+			s, err := r.renderElement(ctx, e)
+			if err != nil {
+				return "", err
+			}
+			buf.WriteString(s)
+		}
+		renderedLine, err := r.renderSourceLineWrapper(buf.String(), highlightedLines.Match(i+1))
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(renderedLine)
+		if i < len(lines)-1 {
+			result.WriteRune('\n')
+		}
+	}
+	return result.String(), nil
+}
+
+func (r *sgmlRenderer) renderSourceLineWrapper(content string, highlighted bool) (string, error) {
+	result := &strings.Builder{}
+	err := r.sourceLine.Execute(result, struct {
+		Content     string
+		Highlighted bool
+	}{
+		Content:     content,
+		Highlighted: highlighted,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to render source line: %w", err)
+	}
+	return result.String(), nil
+}
+
 func (r *sgmlRenderer) renderSourceLine(ctx *renderer.Context, line interface{}) (string, []types.Callout, error) {
 	elements, ok := line.([]interface{})
 	if !ok {
@@ -191,7 +253,7 @@ func (r *sgmlRenderer) renderCalloutRef(co types.Callout) (string, error) {
 	result := &strings.Builder{}
 	err := r.calloutRef.Execute(result, co)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout number")
+		return "", fmt.Errorf("unable to render callout number: %w", err)
 	}
 	return result.String(), nil
 }
@@ -1,11 +1,11 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -13,7 +13,7 @@ func (r *sgmlRenderer) renderVerseBlock(ctx *renderer.Context, b types.VerseBloc
 	result := &strings.Builder{}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render verser block")
+		return "", fmt.Errorf("unable to render verser block: %w", err)
 	}
 	previousWithinDelimitedBlock := ctx.WithinDelimitedBlock
 	defer func() {
@@ -22,15 +22,15 @@ func (r *sgmlRenderer) renderVerseBlock(ctx *renderer.Context, b types.VerseBloc
 	ctx.WithinDelimitedBlock = true
 	content, err := r.renderLines(ctx, discardEmptyLines(b.Lines))
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render verse block")
+		return "", fmt.Errorf("unable to render verse block: %w", err)
 	}
 	attribution, err := verseBlockAttribution(b)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render verse block")
+		return "", fmt.Errorf("unable to render verse block: %w", err)
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.verseBlock.Execute(result, struct {
@@ -57,21 +57,26 @@ func (r *sgmlRenderer) renderVerseParagraph(ctx *renderer.Context, p types.Parag
 
 	content, err := r.renderLines(ctx, p.Lines, r.withPlainText())
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render verse paragraph lines")
+		return "", fmt.Errorf("unable to render verse paragraph lines: %w", err)
 	}
 	attribution, err := paragraphAttribution(p)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render verse block")
+		return "", fmt.Errorf("unable to render verse block: %w", err)
 	}
 	title, err := r.renderElementTitle(p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render verse paragraph title: %w", err)
+	}
+	roles, err := r.renderElementRoles(ctx, p.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("unable to render verse paragraph roles: %w", err)
 	}
 
 	err = r.verseParagraph.Execute(result, struct {
 		Context     *renderer.Context
 		ID          string
 		Title       string
+		Roles       string
 		Attribution Attribution
 		Content     string
 		Lines       [][]interface{}
@@ -79,6 +84,7 @@ func (r *sgmlRenderer) renderVerseParagraph(ctx *renderer.Context, p types.Parag
 		Context:     ctx,
 		ID:          r.renderElementID(p.Attributes),
 		Title:       title,
+		Roles:       roles,
 		Attribution: attribution,
 		Content:     string(content),
 		Lines:       p.Lines,
@@ -0,0 +1,57 @@
+package sgml
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+const (
+	// the attribute to specify the format of the image of a diagram
+	diagramFormat = "format"
+	// the attribute to specify the name of the image of a diagram (used as the default `alt` text)
+	diagramTarget = "target"
+)
+
+var diagramMediaTypes = map[string]string{
+	"svg": "image/svg+xml",
+	"png": "image/png",
+}
+
+// renderDiagramBlock renders the given listing block with the diagram renderer registered for its style,
+// as an image block in which the resulting image is embedded
+func (r *sgmlRenderer) renderDiagramBlock(ctx *renderer.Context, kind string, d configuration.DiagramRenderer, b types.ListingBlock) (string, error) {
+	format := b.Attributes.GetAsStringWithDefault(diagramFormat, "svg")
+	mediaType, found := diagramMediaTypes[format]
+	if !found {
+		return "", fmt.Errorf("unable to render %s diagram: unsupported format '%s'", kind, format)
+	}
+	source, err := b.RawText()
+	if err != nil {
+		return "", fmt.Errorf("unable to render %s diagram: %w", kind, err)
+	}
+	image, err := d.RenderDiagram(kind, format, source)
+	if err != nil {
+		return "", fmt.Errorf("unable to render %s diagram: %w", kind, err)
+	}
+	attrs := b.Attributes.Clone()
+	if !attrs.Has(types.AttrImageAlt) {
+		attrs = attrs.Set(types.AttrImageAlt, attrs.GetAsStringWithDefault(diagramTarget, kind))
+	}
+	delete(attrs, types.AttrStyle)
+	delete(attrs, diagramFormat)
+	delete(attrs, diagramTarget)
+	return r.renderImageBlock(ctx, types.ImageBlock{
+		Location: types.Location{
+			Path: []interface{}{
+				types.StringElement{
+					Content: "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(image),
+				},
+			},
+		},
+		Attributes: attrs,
+	})
+}
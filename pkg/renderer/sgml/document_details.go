@@ -2,33 +2,33 @@ package sgml
 
 import (
 	"bytes"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderDocumentDetails(ctx *renderer.Context) (*string, error) {
 	if ctx.Attributes.Has(types.AttrAuthors) {
 		authors, err := r.renderDocumentAuthorsDetails(ctx)
 		if err != nil {
-			return nil, errors.Wrap(err, "error while rendering the document details")
+			return nil, fmt.Errorf("error while rendering the document details: %w", err)
 		}
 		documentDetailsBuff := &bytes.Buffer{}
-		revLabel := ctx.Attributes.GetAsStringWithDefault("version-label", "version")
-		revNumber, _, err := ctx.Attributes.GetAsString("revnumber")
+		revLabel := ctx.Attributes.GetAsStringWithDefault(types.AttrVersionLabel, "version")
+		revNumber, _, err := ctx.Attributes.GetAsString(types.AttrRevNumber)
 		if err != nil {
-			return nil, errors.Wrap(err, "error while rendering the document details")
+			return nil, fmt.Errorf("error while rendering the document details: %w", err)
 		}
-		revDate, _, err := ctx.Attributes.GetAsString("revdate")
+		revDate, _, err := ctx.Attributes.GetAsString(types.AttrRevDate)
 		if err != nil {
-			return nil, errors.Wrap(err, "error while rendering the document details")
+			return nil, fmt.Errorf("error while rendering the document details: %w", err)
 		}
-		revRemark, _, err := ctx.Attributes.GetAsString("revremark")
+		revRemark, _, err := ctx.Attributes.GetAsString(types.AttrRevRemark)
 		if err != nil {
-			return nil, errors.Wrap(err, "error while rendering the document details")
+			return nil, fmt.Errorf("error while rendering the document details: %w", err)
 		}
 		err = r.documentDetails.Execute(documentDetailsBuff, struct {
 			Authors   string
@@ -44,7 +44,7 @@ func (r *sgmlRenderer) renderDocumentDetails(ctx *renderer.Context) (*string, er
 			RevRemark: revRemark,
 		})
 		if err != nil {
-			return nil, errors.Wrap(err, "error while rendering the document details")
+			return nil, fmt.Errorf("error while rendering the document details: %w", err)
 		}
 		documentDetails := string(documentDetailsBuff.String()) //nolint: gosec
 		return &documentDetails, nil
@@ -70,14 +70,14 @@ func (r *sgmlRenderer) renderDocumentAuthorsDetails(ctx *renderer.Context) (*str
 		}
 		// having at least one author is the minimal requirement for document details
 		if author, ok, err := ctx.Attributes.GetAsString(authorKey); err != nil {
-			return nil, errors.Wrap(err, "error while rendering the document details")
+			return nil, fmt.Errorf("error while rendering the document details: %w", err)
 		} else if ok {
 			if i > 1 {
 				authorsDetailsBuff.WriteString("\n")
 			}
 			email, _, err := ctx.Attributes.GetAsString(emailKey)
 			if err != nil {
-				return nil, errors.Wrap(err, "error while rendering the document details")
+				return nil, fmt.Errorf("error while rendering the document details: %w", err)
 			}
 			err = r.documentAuthorDetails.Execute(authorsDetailsBuff, struct {
 				Index string
@@ -89,7 +89,7 @@ func (r *sgmlRenderer) renderDocumentAuthorsDetails(ctx *renderer.Context) (*str
 				Email: email,
 			})
 			if err != nil {
-				return nil, errors.Wrap(err, "error while rendering the document author")
+				return nil, fmt.Errorf("error while rendering the document author: %w", err)
 			}
 			// if there were authors before, need to insert a `\n`
 			i++
@@ -22,30 +22,39 @@ func (r *sgmlRenderer) renderElementRoles(ctx *Context, attrs types.Attributes)
 	return "", nil
 }
 
+// renderInlineRoles returns the classes of the inline element (link, image, icon, cross reference, etc.) with the
+// given attributes: the given extra classes (if not empty), followed by the roles of the element
+func (r *sgmlRenderer) renderInlineRoles(ctx *Context, attrs types.Attributes, extras ...string) (string, error) {
+	result := make([]string, 0, len(extras)+1)
+	for _, e := range extras {
+		if e != "" {
+			result = append(result, e)
+		}
+	}
+	roles, err := r.renderElementRoles(ctx, attrs)
+	if err != nil {
+		return "", err
+	}
+	if roles != "" {
+		result = append(result, roles)
+	}
+	return strings.Join(result, " "), nil
+}
+
 // Image roles add float and alignment attributes -- we turn these into roles.
 func (r *sgmlRenderer) renderImageRoles(ctx *Context, attrs types.Attributes) (string, error) {
-	var result []string
-	if val, ok, err := attrs.GetAsString(types.AttrFloat); err != nil {
+	float, _, err := attrs.GetAsString(types.AttrFloat)
+	if err != nil {
 		return "", err
-	} else if ok {
-		result = append(result, val)
 	}
-	if val, ok, err := attrs.GetAsString(types.AttrImageAlign); err != nil {
+	align, _, err := attrs.GetAsString(types.AttrImageAlign)
+	if err != nil {
 		return "", err
-	} else if ok {
-		result = append(result, "text-"+val)
 	}
-	if roles, ok := attrs[types.AttrRoles].([]interface{}); ok {
-		for _, e := range roles {
-			s, err := r.renderElementRole(ctx, e)
-			if err != nil {
-				return "", err
-			}
-			result = append(result, s)
-		}
+	if align != "" {
+		align = "text-" + align
 	}
-	// log.Debugf("rendered image roles: '%s'", result)
-	return strings.Join(result, " "), nil
+	return r.renderInlineRoles(ctx, attrs, float, align)
 }
 
 func (r *sgmlRenderer) renderElementRole(ctx *Context, role interface{}) (string, error) {
@@ -68,3 +77,45 @@ func (r *sgmlRenderer) renderElementRole(ctx *Context, role interface{}) (string
 	// log.Debugf("rendered role: '%s'", result.String())
 	return result.String(), nil
 }
+
+// isSkippedByRoles returns `true` if the given element should not be rendered, because one of its roles
+// is excluded, or because none of its roles is included (elements without role are always rendered)
+func (r *sgmlRenderer) isSkippedByRoles(ctx *Context, element interface{}) (bool, error) {
+	if len(ctx.Config.ExcludedRoles) == 0 && len(ctx.Config.IncludedRoles) == 0 {
+		return false, nil
+	}
+	var attrs types.Attributes
+	switch e := element.(type) {
+	case types.DocumentElement:
+		attrs = e.GetAttributes()
+	case types.WithAttributesToSubstitute:
+		attrs = e.AttributesToSubstitute()
+	default:
+		return false, nil
+	}
+	roles, ok := attrs[types.AttrRoles].([]interface{})
+	if !ok || len(roles) == 0 {
+		return false, nil
+	}
+	included := len(ctx.Config.IncludedRoles) == 0
+	for _, e := range roles {
+		role, err := r.renderElementRole(ctx, e)
+		if err != nil {
+			return false, err
+		}
+		if containsRole(ctx.Config.ExcludedRoles, role) {
+			return true, nil
+		}
+		included = included || containsRole(ctx.Config.IncludedRoles, role)
+	}
+	return !included, nil
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,17 @@
 package sgml
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-
-	"github.com/pkg/errors"
 )
 
+// ErrUnsupportedElement the error returned when an element of an unknown type is rendered
+var ErrUnsupportedElement = errors.New("unsupported type of element")
+
 func (r *sgmlRenderer) renderElements(ctx *renderer.Context, elements []interface{}) (string, error) {
 	// log.Debugf("rendering %d elements(s)...", len(elements))
 	buff := &strings.Builder{}
@@ -23,10 +26,18 @@ func (r *sgmlRenderer) renderElements(ctx *renderer.Context, elements []interfac
 		}
 	}
 	for _, element := range elements {
+		if skip, err := r.isSkippedByRoles(ctx, element); err != nil {
+			return "", err
+		} else if skip {
+			continue
+		}
 		renderedElement, err := r.renderElement(ctx, element)
 		if err != nil {
 			return "", err // no need to wrap the error here
 		}
+		if ctx.Config.SourceMap {
+			renderedElement = withSourceLine(element, renderedElement)
+		}
 		// insert new line if there's already some content (except for BlankLine)
 		_, isVerbatimLine := element.(types.VerbatimLine)
 		if buff.Len() > 0 && isVerbatimLine {
@@ -37,12 +48,54 @@ func (r *sgmlRenderer) renderElements(ctx *renderer.Context, elements []interfac
 	return buff.String(), nil
 }
 
+// withSourceLine inserts a `data-sourceline` attribute in the first tag of the rendered element,
+// if the element has a `sourceline` attribute
+func withSourceLine(element interface{}, rendered string) string {
+	var attrs types.Attributes
+	switch e := element.(type) {
+	case types.DocumentElement:
+		attrs = e.GetAttributes()
+	case types.WithAttributesToSubstitute:
+		attrs = e.AttributesToSubstitute()
+	default:
+		return rendered
+	}
+	line, found := attrs[types.AttrSourceLine].(int)
+	if !found {
+		return rendered
+	}
+	start := strings.Index(rendered, "<")
+	if start < 0 {
+		return rendered
+	}
+	end := strings.Index(rendered[start:], ">")
+	if end < 0 {
+		return rendered
+	}
+	end += start
+	// insert before the closing `/>` of a self-closing tag
+	if strings.HasSuffix(rendered[:end], "/") {
+		end = len(strings.TrimRight(rendered[:end-1], " "))
+	}
+	// `data-*` attributes come last (see `HTMLAttributes`), so the new attribute can be appended to the existing ones
+	attr, err := HTMLAttributes("data-sourceline", line)
+	if err != nil {
+		return rendered
+	}
+	return rendered[:end] + attr + rendered[end:]
+}
+
 // renderListElements is similar to the `renderElements` func above,
 // but it sets the `withinList` context flag to true for the first element only
 func (r *sgmlRenderer) renderListElements(ctx *renderer.Context, elements []interface{}) (string, error) {
 	// log.Debugf("rendering list with %d element(s)...", len(elements))
 	buff := &strings.Builder{}
 	for i, element := range elements {
+		if skip, err := r.isSkippedByRoles(ctx, element); err != nil {
+			return "", fmt.Errorf("unable to render a list block: %w", err)
+		} else if skip {
+			continue
+		}
 		if i == 0 {
 			ctx.WithinList++
 		}
@@ -51,7 +104,7 @@ func (r *sgmlRenderer) renderListElements(ctx *renderer.Context, elements []inte
 			ctx.WithinList--
 		}
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render a list block")
+			return "", fmt.Errorf("unable to render a list block: %w", err)
 		}
 		buff.WriteString(renderedElement)
 	}
@@ -65,7 +118,9 @@ func (r *sgmlRenderer) renderElement(ctx *renderer.Context, element interface{})
 	case []interface{}:
 		return r.renderElements(ctx, e)
 	case types.TableOfContentsPlaceHolder:
-		return r.renderTableOfContents(ctx, ctx.TableOfContents)
+		return r.renderTableOfContents(ctx, e)
+	case types.CaptionListPlaceHolder:
+		return r.renderCaptionList(ctx, e)
 	case types.Section:
 		return r.renderSection(ctx, e)
 	case types.Preamble:
@@ -86,18 +141,26 @@ func (r *sgmlRenderer) renderElement(ctx *renderer.Context, element interface{})
 		return r.renderParagraph(ctx, e)
 	case types.InternalCrossReference:
 		return r.renderInternalCrossReference(ctx, e)
+	case types.InlineAnchor:
+		return r.renderInlineAnchor(e)
 	case types.ExternalCrossReference:
 		return r.renderExternalCrossReference(ctx, e)
 	case types.QuotedText:
-		return r.renderQuotedText(ctx, e)
+		return r.renderCachedInlineElement(ctx, e, func() (string, error) {
+			return r.renderQuotedText(ctx, e)
+		})
 	case types.InlinePassthrough:
 		return r.renderInlinePassthrough(ctx, e)
 	case types.ImageBlock:
 		return r.renderImageBlock(ctx, e)
 	case types.Icon:
-		return r.renderInlineIcon(ctx, e)
+		return r.renderCachedInlineElement(ctx, e, func() (string, error) {
+			return r.renderInlineIcon(ctx, e)
+		})
 	case types.InlineImage:
-		return r.renderInlineImage(ctx, e)
+		return r.renderCachedInlineElement(ctx, e, func() (string, error) {
+			return r.renderInlineImage(ctx, e)
+		})
 	case types.ExampleBlock:
 		return r.renderExampleBlock(ctx, e)
 	case types.QuoteBlock:
@@ -119,7 +182,9 @@ func (r *sgmlRenderer) renderElement(ctx *renderer.Context, element interface{})
 	case types.LiteralBlock:
 		return r.renderLiteralBlock(ctx, e)
 	case types.InlineLink:
-		return r.renderLink(ctx, e)
+		return r.renderCachedInlineElement(ctx, e, func() (string, error) {
+			return r.renderLink(ctx, e)
+		})
 	case types.StringElement:
 		return r.renderStringElement(ctx, e)
 	case types.FootnoteReference:
@@ -128,10 +193,14 @@ func (r *sgmlRenderer) renderElement(ctx *renderer.Context, element interface{})
 		return r.renderLineBreak()
 	case types.UserMacro:
 		return r.renderUserMacro(ctx, e)
+	case types.GenericBlockMacro:
+		return r.renderGenericBlockMacro(ctx, e)
 	case types.IndexTerm:
 		return r.renderIndexTerm(ctx, e)
 	case types.ConcealedIndexTerm:
 		return r.renderConcealedIndexTerm(e)
+	case types.GlossaryTerm:
+		return r.renderGlossaryTerm(ctx, e)
 	case types.VerbatimLine:
 		return r.renderVerbatimLine(e)
 	case types.QuotedString:
@@ -142,8 +211,12 @@ func (r *sgmlRenderer) renderElement(ctx *renderer.Context, element interface{})
 		return r.renderSpecialCharacter(ctx, e)
 	case types.PredefinedAttribute:
 		return r.renderPredefinedAttribute(e)
+	case types.AttributeDeclaration:
+		return r.renderAttributeDeclaration(ctx, e)
+	case types.AttributeReset:
+		return r.renderAttributeReset(ctx, e)
 	default:
-		return "", errors.Errorf("unsupported type of element: %T", element)
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedElement, element)
 	}
 }
 
@@ -160,7 +233,10 @@ func (r *sgmlRenderer) renderPlainText(ctx *renderer.Context, element interface{
 	case types.InlineImage:
 		return element.Attributes.GetAsStringWithDefault(types.AttrImageAlt, ""), nil
 	case types.InlineLink:
-		if alt, ok := element.Attributes[types.AttrInlineLinkText].([]interface{}); ok {
+		switch alt := element.Attributes[types.AttrInlineLinkText].(type) {
+		case string:
+			return alt, nil
+		case []interface{}:
 			return r.renderPlainText(ctx, alt)
 		}
 		return element.Location.Stringify(), nil
@@ -168,6 +244,14 @@ func (r *sgmlRenderer) renderPlainText(ctx *renderer.Context, element interface{
 		return "\n\n", nil
 	case types.StringElement:
 		return element.Content, nil
+	case types.SpecialCharacter:
+		return r.renderSpecialCharacter(ctx, element)
+	case types.GlossaryTerm:
+		return element.Term, nil
+	case types.PredefinedAttribute:
+		return predefinedAttribute(element.Name), nil
+	case types.InlinePassthrough:
+		return r.renderInlinePassthrough(ctx, element)
 	case types.QuotedString:
 		return r.renderQuotedStringPlain(ctx, element)
 	case types.Paragraph:
@@ -176,6 +260,6 @@ func (r *sgmlRenderer) renderPlainText(ctx *renderer.Context, element interface{
 		// footnotes are rendered in HTML so they can appear as such in the table of contents
 		return r.renderFootnoteReferencePlainText(element)
 	default:
-		return "", errors.Errorf("unable to render plain string for element of type '%T'", element)
+		return "", fmt.Errorf("unable to render plain string for element of type '%T'", element)
 	}
 }
@@ -7,7 +7,6 @@ import (
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderFootnoteReference(note types.FootnoteReference) (string, error) {
@@ -22,7 +21,7 @@ func (r *sgmlRenderer) renderFootnoteReference(note types.FootnoteReference) (st
 			Ref: note.Ref,
 		})
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render footnote")
+			return "", fmt.Errorf("unable to render footnote: %w", err)
 		}
 	} else if note.Duplicate {
 		// valid case for a footnote with content, with our without an explicit reference
@@ -34,7 +33,7 @@ func (r *sgmlRenderer) renderFootnoteReference(note types.FootnoteReference) (st
 			Ref: note.Ref,
 		})
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render footnote")
+			return "", fmt.Errorf("unable to render footnote: %w", err)
 		}
 	} else {
 		// invalid footnote
@@ -44,7 +43,7 @@ func (r *sgmlRenderer) renderFootnoteReference(note types.FootnoteReference) (st
 			Ref: note.Ref,
 		})
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render missing footnote")
+			return "", fmt.Errorf("unable to render missing footnote: %w", err)
 		}
 	}
 	return result.String(), nil
@@ -62,7 +61,7 @@ func (r *sgmlRenderer) renderFootnoteReferencePlainText(note types.FootnoteRefer
 			Class: "footnote",
 		})
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render footnote")
+			return "", fmt.Errorf("unable to render footnote: %w", err)
 		}
 	} else {
 		return "", fmt.Errorf("unable to render missing footnote")
@@ -80,7 +79,7 @@ func (r *sgmlRenderer) renderFootnotes(ctx *renderer.Context, notes []types.Foot
 
 	for _, item := range notes {
 		if err := r.renderFootnoteItem(ctx, content, item); err != nil {
-			return "", errors.Wrap(err, "failed to render footnote item")
+			return "", fmt.Errorf("failed to render footnote item: %w", err)
 		}
 	}
 
@@ -94,7 +93,7 @@ func (r *sgmlRenderer) renderFootnotes(ctx *renderer.Context, notes []types.Foot
 		Footnotes: notes,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render footnotes")
+		return "", fmt.Errorf("failed to render footnotes: %w", err)
 	}
 	return result.String(), nil
 }
@@ -103,7 +102,7 @@ func (r *sgmlRenderer) renderFootnoteItem(ctx *renderer.Context, w io.Writer, it
 
 	content, err := r.renderInlineElements(ctx, item.Elements)
 	if err != nil {
-		return errors.Wrapf(err, "unable to render foot note content")
+		return fmt.Errorf("unable to render foot note content: %w", err)
 	}
 	content = strings.TrimSpace(content)
 
@@ -0,0 +1,51 @@
+package sgml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// renderGenericBlockMacro renders the given macro with the block macro processor registered under its name,
+// or as a paragraph containing the raw text of the macro (with a warning) if there is no such processor
+func (r *sgmlRenderer) renderGenericBlockMacro(ctx *renderer.Context, m types.GenericBlockMacro) (string, error) {
+	if process, found := ctx.Config.BlockMacros[m.Name]; found {
+		result, err := process(m.Name, m.Target, m.Attributes)
+		if err != nil {
+			return "", fmt.Errorf("unable to render block macro '%s': %w", m.Name, err)
+		}
+		if !strings.HasSuffix(result, "\n") {
+			result += "\n"
+		}
+		return result, nil
+	}
+	log.Warnf("no processor registered for block macro '%s'", m.Name)
+	p, _ := types.NewParagraph([]interface{}{
+		withSpecialCharacters(m.RawText),
+	}, nil)
+	return r.renderParagraph(ctx, p)
+}
+
+// withSpecialCharacters splits the given text around its special characters (`<`, `>` and `&`),
+// so that they are escaped when rendered, as if the `specialcharacters` substitution was applied
+func withSpecialCharacters(text string) []interface{} {
+	result := []interface{}{}
+	for {
+		i := strings.IndexAny(text, "<>&")
+		if i < 0 {
+			break
+		}
+		if i > 0 {
+			result = append(result, types.StringElement{Content: text[:i]})
+		}
+		result = append(result, types.SpecialCharacter{Name: text[i : i+1]})
+		text = text[i+1:]
+	}
+	if text != "" {
+		result = append(result, types.StringElement{Content: text})
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package sgml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// renderGlossaryTerm renders the given term as a link to its entry in the glossary,
+// or as plain text (with a warning) if there is no such entry in the document
+func (r *sgmlRenderer) renderGlossaryTerm(ctx *renderer.Context, t types.GlossaryTerm) (string, error) {
+	label, err := r.renderStringElement(ctx, types.StringElement{Content: t.Term})
+	if err != nil {
+		return "", fmt.Errorf("unable to render glossary term: %w", err)
+	}
+	id, found := ctx.Glossary.Lookup(t.Term)
+	if !found {
+		log.Warnf("unknown glossary term '%s'", t.Term)
+		return label, nil
+	}
+	result := &strings.Builder{}
+	err = r.internalCrossReference.Execute(result, struct {
+		Href  string
+		Label string
+	}{
+		Href:  id,
+		Label: label,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to render glossary term: %w", err)
+	}
+	return result.String(), nil
+}
@@ -2,9 +2,7 @@ package html5
 
 // initializes the sgml
 const (
-	calloutListTmpl = `<div` +
-		`{{ if .ID }} id="{{ .ID }}"{{ end }} ` +
-		"class=\"colist arabic{{ if .Roles }} {{ .Roles }}{{ end}}\">\n" +
+	calloutListTmpl = `<div{{ attributes "id" .ID "class" (classes "colist arabic" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<ol>\n" +
 		"{{ .Content }}" +
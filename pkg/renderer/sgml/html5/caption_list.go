@@ -0,0 +1,10 @@
+package html5
+
+const (
+	captionListTmpl = `<div{{ attributes "class" (print "toc list-of-" .Kind) }}>` + "\n" +
+		"<div class=\"title\">{{ .Title }}</div>\n" +
+		"<ul>\n{{ .Content }}</ul>\n" +
+		"</div>\n"
+
+	captionListEntryTmpl = "<li><a href=\"#{{ .ID }}\">{{ .Caption }}{{ .Title }}</a></li>\n"
+)
@@ -2,5 +2,6 @@ package html5
 
 const (
 	internalCrossReferenceTmpl = `<a href="#{{ .Href }}">{{ .Label }}</a>`
-	externalCrossReferenceTmpl = `<a href="{{ .Href }}">{{ .Label }}</a>`
+	externalCrossReferenceTmpl = `<a href="{{ .Href }}"{{ attributes "class" .Class }}>{{ .Label }}</a>`
+	inlineAnchorTmpl           = `<a id="{{ .ID }}"></a>`
 )
@@ -46,6 +46,149 @@ with some content linked to <<thetitle,a label to the title>>!`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("cross reference with custom id and reftext", func() {
+			source := `[[thetitle,the reftext]]
+== a title
+
+with some content linked to <<thetitle>>!`
+			expected := `<div class="sect1">
+<h2 id="thetitle">a title</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>with some content linked to <a href="#thetitle">the reftext</a>!</p>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("cross reference with custom id and reftext in block attributes", func() {
+			source := `[#thetitle.a_role,reftext=the reftext]
+== a title
+
+with some content linked to <<thetitle>>!`
+			expected := `<div class="sect1 a_role">
+<h2 id="thetitle">a title</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>with some content linked to <a href="#thetitle">the reftext</a>!</p>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("cross reference with trailing custom id and reftext", func() {
+			source := `== a title [[thetitle,the reftext]]
+
+with some content linked to <<thetitle>>!`
+			expected := `<div class="sect1">
+<h2 id="thetitle">a title</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>with some content linked to <a href="#thetitle">the reftext</a>!</p>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("cross references to inline anchors", func() {
+			source := `a paragraph with an [[first]]anchor and [[second,the second anchor]]another one.
+
+see <<first>> and <<second>>`
+			expected := `<div class="paragraph">
+<p>a paragraph with an <a id="first"></a>anchor and <a id="second"></a>another one.</p>
+</div>
+<div class="paragraph">
+<p>see <a href="#first">first</a> and <a href="#second">the second anchor</a></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("cross references to blocks with reftext, title or ID only", func() {
+			source := `[#with-reftext,reftext=the table]
+.A table
+|===
+| a
+|===
+
+[#with-title]
+.A listing
+----
+some code
+----
+
+[[id-only]]
+a paragraph
+
+see <<with-reftext>>, <<with-title>> and <<id-only>>`
+			expected := `<table id="with-reftext" class="tableblock frame-all grid-all stretch">
+<caption class="title">Table 1. A table</caption>
+<colgroup>
+<col style="width: 100%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+</tr>
+</tbody>
+</table>
+<div id="with-title" class="listingblock">
+<div class="title">A listing</div>
+<div class="content">
+<pre>some code</pre>
+</div>
+</div>
+<div id="id-only" class="paragraph">
+<p>a paragraph</p>
+</div>
+<div class="paragraph">
+<p>see <a href="#with-reftext">the table</a>, <a href="#with-title">A listing</a> and <a href="#id-only">id-only</a></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("cross reference to section with reftext taking precedence over title", func() {
+			source := `[reftext="Reference"]
+== a title
+
+see <<_a_title>>`
+			expected := `<div class="sect1">
+<h2 id="_a_title">a title</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>see <a href="#_a_title">Reference</a></p>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("cross references to custom ids with special characters", func() {
+
+			source := `[[the "title"]]
+== a title
+
+with some content linked to <<the "title">>, to [[an"anchor]]<<an"anchor>> and to <<a<b,an invalid reference>>!`
+			expected := `<div class="sect1">
+<h2 id="the_&#34;title&#34;">a title</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>with some content linked to <a href="#the_%22title%22">a title</a>, to <a id="an&#34;anchor"></a><a href="#an%22anchor">an"anchor</a> and to &lt;&lt;a&lt;b,an invalid reference&gt;&gt;!</p>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("invalid section reference", func() {
 
 			source := `[[thetitle]]
@@ -82,6 +225,26 @@ some content linked to xref:{foo}.adoc[another_doc()]!`
 			expected := `<div class="paragraph">
 <p>some content linked to <a href="foo-doc.html">another_doc()</a>!</p>
 </div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("external cross reference to other doc with custom relfilesuffix", func() {
+			source := `:relfilesuffix: .xhtml
+
+some content linked to xref:another-doc.adoc[another doc]!`
+			expected := `<div class="paragraph">
+<p>some content linked to <a href="another-doc.xhtml">another doc</a>!</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("external cross reference to other doc with label and roles", func() {
+			source := `some content linked to xref:another-doc.adoc[another doc,role="external doc"]!`
+			expected := `<div class="paragraph">
+<p>some content linked to <a href="another-doc.html" class="external doc">another doc</a>!</p>
+</div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
@@ -1,8 +1,7 @@
 package html5
 
 const (
-	admonitionBlockTmpl = `<div {{ if .ID }}id="{{ .ID}}" {{ end }}` +
-		"class=\"admonitionblock {{ .Kind }}{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	admonitionBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "admonitionblock" .Kind .Roles) }}>` + "\n" +
 		"<table>\n" +
 		"<tr>\n" +
 		"<td class=\"icon\">\n{{ .Icon }}\n</td>\n" +
@@ -0,0 +1,10 @@
+package html5
+
+const (
+	collapsibleBlockTmpl = `<details{{ attributes "id" .ID "class" .Roles }}` + "{{ if .Open }} open{{ end }}>\n" +
+		"<summary class=\"title\">{{ .Title }}</summary>\n" +
+		"<div class=\"content\">\n" +
+		"{{ .Content }}" +
+		"</div>\n" +
+		"</details>\n"
+)
@@ -154,6 +154,80 @@ foo
 </div>
 </div>
 </div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("example block with nested example block", func() {
+			source := `====
+outer
+
+.nested
+======
+inner
+======
+
+after
+====`
+			expected := `<div class="exampleblock">
+<div class="content">
+<div class="paragraph">
+<p>outer</p>
+</div>
+<div class="exampleblock">
+<div class="title">Example 1. nested</div>
+<div class="content">
+<div class="paragraph">
+<p>inner</p>
+</div>
+</div>
+</div>
+<div class="paragraph">
+<p>after</p>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+		It("collapsible example block with ID and title", func() {
+			source := `[#details%collapsible]
+.Click to reveal
+====
+hidden content
+====`
+			expected := `<details id="details">
+<summary class="title">Click to reveal</summary>
+<div class="content">
+<div class="paragraph">
+<p>hidden content</p>
+</div>
+</div>
+</details>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("admonition example block with collapsible option", func() {
+			// the admonition style takes precedence over the collapsible option
+			source := `[NOTE%collapsible]
+====
+a note
+====`
+			expected := `<div class="admonitionblock note">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Note</div>
+</td>
+<td class="content">
+<div class="paragraph">
+<p>a note</p>
+</div>
+</td>
+</tr>
+</table>
+</div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
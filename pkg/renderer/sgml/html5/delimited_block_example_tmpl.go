@@ -1,8 +1,7 @@
 package html5
 
 const (
-	exampleBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"exampleblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	exampleBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "exampleblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Caption }}{{ .Title }}</div>\n{{ end }}" +
 		"<div class=\"content\">\n" +
 		"{{ .Content }}" +
@@ -15,9 +15,13 @@ var _ = Describe("delimited blocks", func() {
 			source := "```\n\nsome source code \n\nhere  \n\n\n\n```"
 			expected := `<div class="listingblock">
 <div class="content">
-<pre class="highlight"><code>some source code
+<pre class="highlight"><code>
+some source code 
+
+here  
+
 
-here</code></pre>
+</code></pre>
 </div>
 </div>
 `
@@ -28,7 +32,9 @@ here</code></pre>
 			source := "```\n\n\n\n```"
 			expected := `<div class="listingblock">
 <div class="content">
-<pre class="highlight"><code></code></pre>
+<pre class="highlight"><code>
+
+</code></pre>
 </div>
 </div>
 `
@@ -42,7 +48,10 @@ here</code></pre>
 <div class="content">
 <pre class="highlight"><code>some source code
 
-here</code></pre>
+here
+
+
+</code></pre>
 </div>
 </div>
 `
@@ -59,7 +68,8 @@ here</code></pre>
 <div class="content">
 <pre class="highlight"><code>a http://website.com
 and more text on the
-next lines</code></pre>
+next lines
+</code></pre>
 </div>
 </div>
 `
@@ -1,8 +1,7 @@
 package html5
 
 const (
-	fencedBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"listingblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	fencedBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "listingblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<div class=\"content\">\n" +
 		"<pre class=\"highlight\"><code>{{ .Content }}</code></pre>\n" +
@@ -34,7 +34,37 @@ here
 <div class="content">
 <pre>some source code
 
-here</pre>
+here
+</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with content preserved as-is", func() {
+			source := "----\n\n  indented  \n\tcode\t\n\n----"
+			expected := "<div class=\"listingblock\">\n" +
+				"<div class=\"content\">\n" +
+				"<pre>\n  indented  \n\tcode\t\n</pre>\n" +
+				"</div>\n" +
+				"</div>\n"
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with ASCII table", func() {
+			source := `------
++-----+-----+
+| foo | bar |
++-----+-----+
+----
+------`
+			expected := `<div class="listingblock">
+<div class="content">
+<pre>+-----+-----+
+| foo | bar |
++-----+-----+
+----</pre>
 </div>
 </div>
 `
@@ -67,7 +97,8 @@ some source code
 			expected := `<div id="id-for-listing-block" class="listingblock">
 <div class="title">listing block title</div>
 <div class="content">
-<pre>some source code</pre>
+<pre>some source code
+</pre>
 </div>
 </div>
 `
@@ -108,6 +139,29 @@ import <1>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("with single callout and title on callout list", func() {
+			source := `----
+import <1>
+----
+.Callouts
+<1> an import`
+			expected := `<div class="listingblock">
+<div class="content">
+<pre>import <b class="conum">(1)</b></pre>
+</div>
+</div>
+<div class="colist arabic">
+<div class="title">Callouts</div>
+<ol>
+<li>
+<p>an import</p>
+</li>
+</ol>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("with multiple callouts and blankline between calloutitems", func() {
 			source := `----
 import <1>
@@ -193,6 +247,81 @@ import <a>
 		})
 	})
 
+	Context("with custom substitutions", func() {
+
+		It("should substitute attributes in delimited block", func() {
+			source := `:version: 1.2.3
+
+[subs="attributes"]
+----
+go get example.com/module@{version} <1>
+----`
+			expected := `<div class="listingblock">
+<div class="content">
+<pre>go get example.com/module@1.2.3 <1></pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should substitute attributes in source block", func() {
+			source := `:version: 1.2.3
+
+[source,go,subs="attributes+"]
+----
+const version = "{version}"
+----`
+			expected := `<div class="listingblock">
+<div class="content">
+<pre class="highlight"><code class="language-go" data-lang="go">const version = "1.2.3"</code></pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should substitute attributes in delimited block attached to a list item", func() {
+			source := `:version: 1.2.3
+
+* install the module:
++
+[subs="attributes+"]
+----
+go get example.com/module@{version}
+----`
+			expected := `<div class="ulist">
+<ul>
+<li>
+<p>install the module:</p>
+<div class="listingblock">
+<div class="content">
+<pre>go get example.com/module@1.2.3</pre>
+</div>
+</div>
+</li>
+</ul>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should not substitute attributes by default", func() {
+			source := `:version: 1.2.3
+
+----
+go get example.com/module@{version}
+----`
+			expected := `<div class="listingblock">
+<div class="content">
+<pre>go get example.com/module@{version}</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
 	Context("paragraph blocks", func() {
 
 		It("with single plaintext line", func() {
@@ -1,9 +1,8 @@
 package html5
 
 const (
-	listingBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"listingblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
-		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
+	listingBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "listingblock" .Roles) }}>` + "\n" +
+		"{{ if .Title }}<div class=\"title\">{{ .Caption }}{{ .Title }}</div>\n{{ end }}" +
 		"<div class=\"content\">\n" +
 		"<pre>{{ .Content }}</pre>\n" +
 		"</div>\n" +
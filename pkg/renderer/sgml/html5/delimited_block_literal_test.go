@@ -68,6 +68,68 @@ lines.</pre>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("literal block from paragraph with tab on each line", func() {
+			source := "\tsome literal content\n\t  on 2 lines."
+			expected := `<div class="literalblock">
+<div class="content">
+<pre>some literal content
+  on 2 lines.</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("literal block from paragraph with mixed spaces and tabs", func() {
+			source := "  some literal content\n\ton 2 lines."
+			expected := `<div class="literalblock">
+<div class="content">
+<pre>some literal content
+  on 2 lines.</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("literal block from paragraph with mixed spaces and tabs and custom tab size", func() {
+			source := "[tabsize=2]\n  some literal content\n\t\ton 2 lines."
+			expected := `<div class="literalblock">
+<div class="content">
+<pre>some literal content
+  on 2 lines.</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("literal block in list item continuation followed by a list item", func() {
+			source := `* item 1
++
+  some literal content
+  on 2 lines.
+* item 2`
+			expected := `<div class="ulist">
+<ul>
+<li>
+<p>item 1</p>
+<div class="literalblock">
+<div class="content">
+<pre>some literal content
+on 2 lines.</pre>
+</div>
+</div>
+</li>
+<li>
+<p>item 2</p>
+</li>
+</ul>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("mixing literal block with attributes followed by a paragraph ", func() {
 			source := `.title
 [#ID]
@@ -106,6 +168,20 @@ a normal paragraph.`
 <div class="paragraph">
 <p>a normal paragraph.</p>
 </div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("literal block with ID and roles", func() {
+			source := `[#ID.role1.role2]
+....
+some literal content
+....`
+			expected := `<div id="ID" class="literalblock role1 role2">
+<div class="content">
+<pre>some literal content</pre>
+</div>
+</div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
@@ -158,6 +234,47 @@ on two lines.</pre>
 		})
 	})
 
+	Context("paragraphs with normal style", func() {
+
+		It("indented paragraph with normal style", func() {
+			source := `[normal]
+  some *normal* content
+    on two lines.
+
+ a literal paragraph.`
+			expected := `<div class="paragraph">
+<p>some <strong>normal</strong> content
+  on two lines.</p>
+</div>
+<div class="literalblock">
+<div class="content">
+<pre>a literal paragraph.</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("indented paragraph with normal style in a list item continuation", func() {
+			source := `* an item
++
+[normal]
+  some *normal* content`
+			expected := `<div class="ulist">
+<ul>
+<li>
+<p>an item</p>
+<div class="paragraph">
+<p>some <strong>normal</strong> content</p>
+</div>
+</li>
+</ul>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
 	Context("with custom substitutions", func() {
 
 		// testing custom substitutions on a literal block only
@@ -1,8 +1,7 @@
 package html5
 
 const (
-	literalBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"literalblock\"{{ if .Roles }} {{ .Roles }}{{ end }}>\n" +
+	literalBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "literalblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<div class=\"content\">\n" +
 		"<pre>{{ .Content }}</pre>\n" +
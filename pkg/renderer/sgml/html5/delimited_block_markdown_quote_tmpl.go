@@ -1,8 +1,7 @@
 package html5
 
 const (
-	markdownQuoteBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"quoteblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	markdownQuoteBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "quoteblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<blockquote>\n" +
 		"{{ if .Content }}<div class=\"paragraph\">\n" +
@@ -16,6 +16,26 @@ var _ = Describe("quote blocks", func() {
 ____
 some *quote* content
 
+____`
+			expected := `<div class="quoteblock">
+<blockquote>
+<div class="paragraph">
+<p>some <strong>quote</strong> content</p>
+</div>
+</blockquote>
+<div class="attribution">
+&#8212; john doe<br>
+<cite>quote title</cite>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("single-line quote with named author and title", func() {
+			source := `[quote, attribution="john doe", citetitle="quote title"]
+____
+some *quote* content
 ____`
 			expected := `<div class="quoteblock">
 <blockquote>
@@ -109,7 +129,7 @@ ____`
 </div>
 <div class="listingblock">
 <div class="content">
-<pre>* quote</pre>
+<pre>* quote </pre>
 </div>
 </div>
 <div class="ulist">
@@ -1,8 +1,7 @@
 package html5
 
 const (
-	sidebarBlockTmpl = "<div {{ if .ID }}id=\"{{ .ID }}\" {{ end }}" +
-		"class=\"sidebarblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	sidebarBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "sidebarblock" .Roles) }}>` + "\n" +
 		"<div class=\"content\">\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"{{ .Content }}" +
@@ -33,6 +33,16 @@ end</code></pre>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("with content preserved as-is", func() {
+			source := "[source,go]\n----\n\nfunc main() {  \n}\n\n----"
+			expected := "<div class=\"listingblock\">\n" +
+				"<div class=\"content\">\n" +
+				"<pre class=\"highlight\"><code class=\"language-go\" data-lang=\"go\">\nfunc main() {  \n}\n</code></pre>\n" +
+				"</div>\n" +
+				"</div>\n"
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("with title, source and languages attributes", func() {
 			source := `[source,ruby]
 .Source block title
@@ -51,7 +61,8 @@ end
 
 get '/hi' do
   "Hello World!"
-end</code></pre>
+end
+</code></pre>
 </div>
 </div>
 `
@@ -76,7 +87,8 @@ end
 
 get '/hi' do
   "Hello World!"
-end</code></pre>
+end
+</code></pre>
 </div>
 </div>
 `
@@ -237,6 +249,63 @@ const Cookie = "cookie"
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("with collapsible and nowrap options, and with id, role and title", func() {
+			source := `[source%collapsible%nowrap,go,id=cookie,role=fancy]
+.The cookie
+----
+const Cookie = "cookie"
+----`
+			expected := `<details id="cookie" class="fancy">
+<summary class="title">The cookie</summary>
+<div class="content">
+<div class="listingblock">
+<div class="content">
+<pre class="highlight nowrap"><code class="language-go" data-lang="go">const Cookie = "cookie"</code></pre>
+</div>
+</div>
+</div>
+</details>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with collapsible and open options, without title", func() {
+			source := `[source%collapsible%open,go]
+----
+const Cookie = "cookie"
+----`
+			expected := `<details open>
+<summary class="title">Details</summary>
+<div class="content">
+<div class="listingblock">
+<div class="content">
+<pre class="highlight"><code class="language-go" data-lang="go">const Cookie = "cookie"</code></pre>
+</div>
+</div>
+</div>
+</details>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with highlighted lines", func() {
+			source := `[source,go,highlight=2..3]
+----
+type Foo struct{
+    Field  string  
+}
+----`
+			expected := `<div class="listingblock">
+<div class="content">
+<pre class="highlight"><code class="language-go" data-lang="go"><span class="line">type Foo struct{</span>
+<span class="line highlight">    Field  string  </span>
+<span class="line highlight">}</span></code></pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		Context("with syntax highlighting", func() {
 
 			It("with callouts and syntax highlighting", func() {
@@ -439,6 +508,26 @@ const Cookie = "cookie"
 <pre class="pygments highlight nowrap"><code data-lang="go"><span style="color:#069;font-weight:bold">const</span> Cookie = <span style="color:#c30">&#34;cookie&#34;</span></code></pre>
 </div>
 </div>
+`
+				Expect(RenderHTML(source)).To(MatchHTML(expected))
+			})
+
+			It("with highlighted lines", func() {
+				source := `:source-highlighter: pygments
+
+[source,go,highlight=1;3]
+----
+type Foo struct{
+    Field string
+}
+----`
+				expected := `<div class="listingblock">
+<div class="content">
+<pre class="pygments highlight"><code data-lang="go"><span class="line highlight"><span class="tok-kd">type</span> <span class="tok-nx">Foo</span> <span class="tok-kd">struct</span><span class="tok-p">{</span></span>
+<span class="line">    <span class="tok-nx">Field</span> <span class="tok-kt">string</span></span>
+<span class="line highlight"><span class="tok-p">}</span></span></code></pre>
+</div>
+</div>
 `
 				Expect(RenderHTML(source)).To(MatchHTML(expected))
 			})
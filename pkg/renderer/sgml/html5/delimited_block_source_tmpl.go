@@ -1,18 +1,18 @@
 package html5
 
 const (
-	sourceBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"listingblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
-		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
+	sourceBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "listingblock" .Roles) }}>` + "\n" +
+		"{{ if .Title }}<div class=\"title\">{{ .Caption }}{{ .Title }}</div>\n{{ end }}" +
 		"<div class=\"content\">\n" +
 		"<pre class=\"" +
 		`{{ if .SyntaxHighlighter }}{{ .SyntaxHighlighter }} {{ end }}` +
 		`highlight` +
 		`{{ if .Nowrap }} nowrap{{ end }}` + // space before the `nowrap` option as it's the last value in the 'class' attribute
 		`">` +
-		`<code{{ if .Language }}{{ if not .SyntaxHighlighter }} class="language-{{ .Language}}"{{ end }} ` +
-		`data-lang="{{ .Language}}"{{ end }}>` +
+		`<code{{ if .Language }}{{ attributes "class" (and (not .SyntaxHighlighter) (print "language-" .Language)) "data-lang" .Language }}{{ end }}>` +
 		"{{ .Content }}</code></pre>\n" +
 		"</div>\n" +
 		"</div>\n"
+
+	sourceLineTmpl = `<span class="line{{ if .Highlighted }} highlight{{ end }}">{{ .Content }}</span>`
 )
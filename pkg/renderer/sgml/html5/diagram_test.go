@@ -0,0 +1,104 @@
+package html5_test
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+type diagramRendererMock struct {
+	calls int
+}
+
+func (r *diagramRendererMock) RenderDiagram(kind, format, source string) ([]byte, error) {
+	r.calls++
+	return []byte(fmt.Sprintf("%s/%s:%s", kind, format, source)), nil
+}
+
+var _ = Describe("diagram blocks", func() {
+
+	It("with registered renderer", func() {
+		source := `.Sequence
+[plantuml#seq, target=sequence, format=png]
+----
+Alice -> Bob: hello <1>
+----`
+		expected := `<div id="seq" class="imageblock">
+<div class="content">
+<img src="data:image/png;base64,` + base64.StdEncoding.EncodeToString([]byte("plantuml/png:Alice -> Bob: hello <1>")) + `" alt="sequence">
+</div>
+<div class="title">Figure 1. Sequence</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithDiagramRenderer("plantuml", &diagramRendererMock{}))).To(MatchHTML(expected))
+	})
+
+	It("with cached diagrams", func() {
+		source := `[plantuml]
+----
+Alice -> Bob: hello
+----
+
+[plantuml]
+----
+Alice -> Bob: hello
+----`
+		image := base64.StdEncoding.EncodeToString([]byte("plantuml/svg:Alice -> Bob: hello"))
+		expected := `<div class="imageblock">
+<div class="content">
+<img src="data:image/svg+xml;base64,` + image + `" alt="plantuml">
+</div>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="data:image/svg+xml;base64,` + image + `" alt="plantuml">
+</div>
+</div>
+`
+		d := &diagramRendererMock{}
+		Expect(RenderHTML(source, configuration.WithDiagramRenderer("plantuml", d))).To(MatchHTML(expected))
+		Expect(d.calls).To(Equal(1))
+	})
+
+	It("with command", func() {
+		source := `[graphviz]
+----
+digraph { a -> b }
+----`
+		expected := `<div class="imageblock">
+<div class="content">
+<img src="data:image/svg+xml;base64,` + base64.StdEncoding.EncodeToString([]byte("digraph { a -> b }")) + `" alt="graphviz">
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithDiagramCommand("graphviz", "cat"))).To(MatchHTML(expected))
+	})
+
+	It("with unsupported format", func() {
+		source := `[plantuml, format=pdf]
+----
+Alice -> Bob: hello
+----`
+		_, err := RenderHTML(source, configuration.WithDiagramRenderer("plantuml", &diagramRendererMock{}))
+		Expect(err).To(MatchError(ContainSubstring("unable to render plantuml diagram: unsupported format 'pdf'")))
+	})
+
+	It("without registered renderer", func() {
+		source := `[plantuml]
+----
+Alice -> Bob: hello
+----`
+		expected := `<div class="listingblock">
+<div class="content">
+<pre>Alice -&gt; Bob: hello</pre>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
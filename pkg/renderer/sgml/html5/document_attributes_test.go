@@ -0,0 +1,161 @@
+package html5_test
+
+import (
+	"fmt"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("document attributes in body", func() {
+
+	It("should apply imagesdir to subsequent images only", func() {
+		source := `image::a.png[]
+
+:imagesdir: ./assets
+
+image::b.png[]
+
+an image:c.png[]`
+		expected := `<div class="imageblock">
+<div class="content">
+<img src="a.png" alt="a">
+</div>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="./assets/b.png" alt="b">
+</div>
+</div>
+<div class="paragraph">
+<p>an <span class="image"><img src="./assets/c.png" alt="c"></span></p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("should apply hide-uri-scheme to subsequent links only", func() {
+		source := `a link to https://example.com
+
+:hide-uri-scheme:
+
+another link to https://example.com
+
+:hide-uri-scheme!:
+
+a last link to https://example.com`
+		expected := `<div class="paragraph">
+<p>a link to <a href="https://example.com" class="bare">https://example.com</a></p>
+</div>
+<div class="paragraph">
+<p>another link to <a href="https://example.com" class="bare">example.com</a></p>
+</div>
+<div class="paragraph">
+<p>a last link to <a href="https://example.com" class="bare">https://example.com</a></p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("should apply figure-caption reset to subsequent images only", func() {
+		source := `= Title
+
+.A caption
+image::a.png[]
+
+== Section
+
+:figure-caption!:
+
+.Another caption
+image::b.png[]`
+		expected := `<div id="preamble">
+<div class="sectionbody">
+<div class="imageblock">
+<div class="content">
+<img src="a.png" alt="a">
+</div>
+<div class="title">Figure 1. A caption</div>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_section">Section</h2>
+<div class="sectionbody">
+<div class="imageblock">
+<div class="content">
+<img src="b.png" alt="b">
+</div>
+<div class="title">Another caption</div>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("should not change attributes overridden in the configuration", func() {
+		source := `:hide-uri-scheme!:
+
+a link to https://example.com`
+		expected := `<div class="paragraph">
+<p>a link to <a href="https://example.com" class="bare">example.com</a></p>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithAttribute("hide-uri-scheme", ""))).To(MatchHTML(expected))
+	})
+
+	Context("missing attributes", func() {
+
+		source := `:attribute-missing: %s
+
+first line with {missing}
+second line with *{missing}*
+third line`
+
+		It("should keep the references by default", func() {
+			expected := `<div class="paragraph">
+<p>first line with {missing}
+second line with <strong>{missing}</strong>
+third line</p>
+</div>
+`
+			Expect(RenderHTML(fmt.Sprintf(source, "warn"))).To(MatchHTML(expected))
+			Expect(RenderHTML(fmt.Sprintf(source, "skip"))).To(MatchHTML(expected))
+		})
+
+		It("should drop the references", func() {
+			expected := `<div class="paragraph">
+<p>first line with
+second line with <strong></strong>
+third line</p>
+</div>
+`
+			Expect(RenderHTML(fmt.Sprintf(source, "drop"))).To(MatchHTML(expected))
+		})
+
+		It("should drop the lines", func() {
+			expected := `<div class="paragraph">
+<p>third line</p>
+</div>
+`
+			Expect(RenderHTML(fmt.Sprintf(source, "drop-line"))).To(MatchHTML(expected))
+		})
+
+		It("should drop the references in titles", func() {
+			source := `:attribute-missing: drop-line
+
+.a title{missing}
+some content`
+			expected := `<div class="paragraph">
+<div class="title">a title</div>
+<p>some content</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+})
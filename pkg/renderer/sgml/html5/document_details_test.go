@@ -20,6 +20,58 @@ var _ = Describe("document details", func() {
 Xavier <xavier@example.org>
 v1.0, March 22, 2020: Containment
 
+{author} wrote this doc on {revdate}.
+`
+			expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<meta name="author" content="Xavier">
+<title>Document Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Document Title</h1>
+<div class="details">
+<span id="author" class="author">Xavier</span><br>
+<span id="email" class="email"><a href="mailto:xavier@example.org">xavier@example.org</a></span><br>
+<span id="revnumber">version 1.0,</span>
+<span id="revdate">March 22, 2020</span>
+<br><span id="revremark">Containment</span>
+</div>
+</div>
+<div id="content">
+<div class="paragraph">
+<p>Xavier wrote this doc on March 22, 2020.</p>
+</div>
+</div>
+<div id="footer">
+<div id="footer-text">
+Version 1.0<br>
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+			now := time.Now()
+			Expect(RenderHTML(source, configuration.WithHeaderFooter(true), configuration.WithLastUpdated(now))).To(MatchHTMLTemplate(expected, now))
+		})
+
+		It("header with comments between author, revision and attributes", func() {
+			source := `= Document Title
+// the author
+Xavier <xavier@example.org>
+// the revision
+v1.0, March 22, 2020: Containment
+////
+the attributes
+////
+:author-note: a note
+
 {author} wrote this doc on {revdate}.
 `
 			expected := `<!DOCTYPE html>
@@ -95,6 +147,47 @@ Last updated {{.LastUpdated}}
 </div>
 </body>
 </html>
+`
+			now := time.Now()
+			Expect(RenderHTML(source, configuration.WithHeaderFooter(true), configuration.WithLastUpdated(now))).
+				To(MatchHTMLTemplate(expected, now))
+		})
+
+		It("header with revision and localized labels", func() {
+			source := `= Titre du document
+John Doe
+v1.0, 2020-01-01
+:version-label: Version du document
+:last-update-label: Dernière mise à jour`
+			expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<meta name="author" content="John Doe">
+<title>Titre du document</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Titre du document</h1>
+<div class="details">
+<span id="author" class="author">John Doe</span><br>
+<span id="revnumber">Version du document 1.0,</span>
+<span id="revdate">2020-01-01</span>
+</div>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Version du document 1.0<br>
+Dernière mise à jour {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
 `
 			now := time.Now()
 			Expect(RenderHTML(source, configuration.WithHeaderFooter(true), configuration.WithLastUpdated(now))).
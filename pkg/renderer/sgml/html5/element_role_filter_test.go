@@ -0,0 +1,179 @@
+package html5_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("element role filters", func() {
+
+	source := `= Title
+:toc:
+
+== Public section
+
+a public paragraph
+
+[.internal-only]
+an internal paragraph
+
+[.internal-only]
+== Internal section
+
+internal content
+
+== Other section
+
+[.public]
+====
+example
+====
+
+* item
++
+[.internal-only]
+internal detail`
+
+	It("without filter", func() {
+		expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_public_section">Public section</a></li>
+<li><a href="#_internal_section">Internal section</a></li>
+<li><a href="#_other_section">Other section</a></li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="_public_section">Public section</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>a public paragraph</p>
+</div>
+<div class="paragraph internal-only">
+<p>an internal paragraph</p>
+</div>
+</div>
+</div>
+<div class="sect1 internal-only">
+<h2 id="_internal_section">Internal section</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>internal content</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_other_section">Other section</h2>
+<div class="sectionbody">
+<div class="exampleblock public">
+<div class="content">
+<div class="paragraph">
+<p>example</p>
+</div>
+</div>
+</div>
+<div class="ulist">
+<ul>
+<li>
+<p>item</p>
+<div class="paragraph internal-only">
+<p>internal detail</p>
+</div>
+</li>
+</ul>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("with excluded role", func() {
+		expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_public_section">Public section</a></li>
+<li><a href="#_other_section">Other section</a></li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="_public_section">Public section</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>a public paragraph</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_other_section">Other section</h2>
+<div class="sectionbody">
+<div class="exampleblock public">
+<div class="content">
+<div class="paragraph">
+<p>example</p>
+</div>
+</div>
+</div>
+<div class="ulist">
+<ul>
+<li>
+<p>item</p>
+</li>
+</ul>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithExcludedRoles("internal-only"))).To(MatchHTML(expected))
+	})
+
+	It("with included role", func() {
+		expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_public_section">Public section</a></li>
+<li><a href="#_internal_section">Internal section</a></li>
+<li><a href="#_other_section">Other section</a></li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="_public_section">Public section</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>a public paragraph</p>
+</div>
+<div class="paragraph internal-only">
+<p>an internal paragraph</p>
+</div>
+</div>
+</div>
+<div class="sect1 internal-only">
+<h2 id="_internal_section">Internal section</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>internal content</p>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_other_section">Other section</h2>
+<div class="sectionbody">
+<div class="ulist">
+<ul>
+<li>
+<p>item</p>
+<div class="paragraph internal-only">
+<p>internal detail</p>
+</div>
+</li>
+</ul>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithIncludedRoles("internal-only"))).To(MatchHTML(expected))
+	})
+})
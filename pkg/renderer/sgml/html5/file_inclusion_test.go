@@ -338,6 +338,23 @@ a third paragraph`
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
 
+	It("should show unresolved directive when file is missing", func() {
+		logs, reset := ConfigureLogger(log.ErrorLevel)
+		defer reset()
+		source := `a paragraph
+
+include::../../../../test/includes/unknown.adoc[]`
+		expected := `<div class="paragraph">
+<p>a paragraph</p>
+</div>
+<div class="paragraph">
+<p>Unresolved directive in test.adoc - include::../../../../test/includes/unknown.adoc[]</p>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithFilename("test.adoc"), configuration.WithShowUnresolvedDirectives(true))).To(MatchHTML(expected))
+		Expect(logs).To(ContainMessageWithLevel(log.ErrorLevel, "unresolved directive in test.adoc - include::../../../../test/includes/unknown.adoc[]"))
+	})
+
 	Context("in delimited blocks", func() {
 
 		Context("adoc file inclusion in delimited blocks", func() {
@@ -957,3 +974,41 @@ last line of grandchild</pre>
 	})
 
 })
+
+var _ = Describe("conditional inclusions", func() {
+
+	It("should include content with attribute negation", func() {
+		source := `:foo:
+
+ifdef::foo+bar[]
+foo and bar
+endif::foo+bar[]
+
+ifndef::foo+bar[]
+not foo and bar
+endif::foo+bar[]
+
+ifdef::foo,bar[foo or bar]`
+		expected := `<div class="paragraph">
+<p>not foo and bar</p>
+</div>
+<div class="paragraph">
+<p>foo or bar</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("should include content with evaluated expression", func() {
+		source := `:sectnumlevels: 4
+
+ifeval::[{sectnumlevels} >= 3]
+deep sections
+endif::[]`
+		expected := `<div class="paragraph">
+<p>deep sections</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
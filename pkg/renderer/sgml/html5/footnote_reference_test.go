@@ -16,7 +16,7 @@ var _ = Describe("footnotes", func() {
 </div>
 <div id="footnotes">
 <hr>
-<div class="footnote" id="_footnotedef_1">
+<div id="_footnotedef_1" class="footnote">
 <a href="#_footnoteref_1">1</a>. a note for foo
 </div>
 </div>
@@ -31,7 +31,7 @@ var _ = Describe("footnotes", func() {
 </div>
 <div id="footnotes">
 <hr>
-<div class="footnote" id="_footnotedef_1">
+<div id="_footnotedef_1" class="footnote">
 <a href="#_footnoteref_1">1</a>. some <strong>rich</strong> <a href="https://foo.com">content</a>
 </div>
 </div>
@@ -46,17 +46,17 @@ A bold statement!footnote:disclaimer[Opinions are my own.]
 Another outrageous statement.footnote:disclaimer[]`
 		expected := `<div class="paragraph">
 <p>A statement.<sup class="footnote">[<a id="_footnoteref_1" class="footnote" href="#_footnotedef_1" title="View footnote.">1</a>]</sup>
-A bold statement!<sup class="footnote" id="_footnote_disclaimer">[<a id="_footnoteref_2" class="footnote" href="#_footnotedef_2" title="View footnote.">2</a>]</sup></p>
+A bold statement!<sup id="_footnote_disclaimer" class="footnote">[<a id="_footnoteref_2" class="footnote" href="#_footnotedef_2" title="View footnote.">2</a>]</sup></p>
 </div>
 <div class="paragraph">
 <p>Another outrageous statement.<sup class="footnoteref">[<a class="footnote" href="#_footnotedef_2" title="View footnote.">2</a>]</sup></p>
 </div>
 <div id="footnotes">
 <hr>
-<div class="footnote" id="_footnotedef_1">
+<div id="_footnotedef_1" class="footnote">
 <a href="#_footnoteref_1">1</a>. a regular footnote.
 </div>
-<div class="footnote" id="_footnotedef_2">
+<div id="_footnotedef_2" class="footnote">
 <a href="#_footnoteref_2">2</a>. Opinions are my own.
 </div>
 </div>
@@ -92,13 +92,13 @@ a paragraph with another footnote:[baz]`
 </div>
 <div id="footnotes">
 <hr>
-<div class="footnote" id="_footnotedef_1">
+<div id="_footnotedef_1" class="footnote">
 <a href="#_footnoteref_1">1</a>. foo
 </div>
-<div class="footnote" id="_footnotedef_2">
+<div id="_footnotedef_2" class="footnote">
 <a href="#_footnoteref_2">2</a>. bar
 </div>
-<div class="footnote" id="_footnotedef_3">
+<div id="_footnotedef_3" class="footnote">
 <a href="#_footnoteref_3">3</a>. baz
 </div>
 </div>
@@ -0,0 +1,60 @@
+package html5_test
+
+import (
+	"fmt"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+	log "github.com/sirupsen/logrus"
+)
+
+var _ = Describe("generic block macros", func() {
+
+	diagram := func(name, target string, attributes map[string]interface{}) (string, error) {
+		return fmt.Sprintf("<div class=\"%s\" id=\"%s\">\n<img src=\"%s.%s\">\n</div>", name, attributes["id"], target, attributes["format"]), nil
+	}
+
+	It("with registered processor", func() {
+		source := `:format: svg
+
+[#seq]
+plantuml::sequence[format={format}]`
+		expected := `<div class="plantuml" id="seq">
+<img src="sequence.svg">
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithBlockMacroProcessor("plantuml", diagram))).To(MatchHTML(expected))
+	})
+
+	It("with failing processor", func() {
+		source := `plantuml::sequence[]`
+		_, err := RenderHTML(source, configuration.WithBlockMacroProcessor("plantuml", func(string, string, map[string]interface{}) (string, error) {
+			return "", fmt.Errorf("mock error")
+		}))
+		Expect(err).To(MatchError(ContainSubstring("unable to render block macro 'plantuml': mock error")))
+	})
+
+	It("without registered processor", func() {
+		logs, reset := ConfigureLogger(log.WarnLevel)
+		defer reset()
+		source := `plantuml::sequence[format=svg]`
+		expected := `<div class="paragraph">
+<p>plantuml::sequence[format=svg]</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+		Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "no processor registered for block macro 'plantuml'"))
+	})
+
+	It("without registered processor and with special characters", func() {
+		source := `foo::bar[<script>alert(1)</script> & more]`
+		expected := `<div class="paragraph">
+<p>foo::bar[&lt;script&gt;alert(1)&lt;/script&gt; &amp; more]</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
@@ -0,0 +1,106 @@
+package html5_test
+
+import (
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+	log "github.com/sirupsen/logrus"
+)
+
+var _ = Describe("glossaries", func() {
+
+	It("glossary section with term references", func() {
+		source := `A term:[Mud] puddle.
+
+[glossary]
+== Glossary
+
+[glossary]
+mud:: wet dirt
+rock:: big stone`
+		expected := `<div class="paragraph">
+<p>A <a href="#_glossary_mud">Mud</a> puddle.</p>
+</div>
+<div class="sect1">
+<h2 id="_glossary">Glossary</h2>
+<div class="sectionbody">
+<div class="dlist glossary">
+<dl>
+<dt id="_glossary_mud">mud</dt>
+<dd>
+<p>wet dirt</p>
+</dd>
+<dt id="_glossary_rock">rock</dt>
+<dd>
+<p>big stone</p>
+</dd>
+</dl>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("unknown term", func() {
+		logs, reset := ConfigureLogger(log.WarnLevel)
+		defer reset()
+		source := `A term:[dust] puddle.`
+		expected := `<div class="paragraph">
+<p>A dust puddle.</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+		Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "unknown glossary term 'dust'"))
+	})
+
+	It("glossary links", func() {
+		source := `:glossary-links:
+
+Some _mud_ and more mud on the floor.
+
+[glossary]
+mud:: wet dirt`
+		expected := `<div class="paragraph">
+<p>Some <em><a href="#_glossary_mud">mud</a></em> and more <a href="#_glossary_mud">mud</a> on the floor.</p>
+</div>
+<div class="dlist glossary">
+<dl>
+<dt id="_glossary_mud">mud</dt>
+<dd>
+<p>wet dirt</p>
+</dd>
+</dl>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("glossary links with terms ending with non-word characters", func() {
+		source := `:glossary-links:
+
+Use C++ here.
+
+Not C++x there.
+
+[glossary]
+C++:: a language`
+		expected := `<div class="paragraph">
+<p>Use <a href="#_glossary_c">C++</a> here.</p>
+</div>
+<div class="paragraph">
+<p>Not C++x there.</p>
+</div>
+<div class="dlist glossary">
+<dl>
+<dt id="_glossary_c">C++</dt>
+<dd>
+<p>a language</p>
+</dd>
+</dl>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/html5"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
@@ -46,6 +48,71 @@ Last updated {{.LastUpdated}}
 			To(MatchHTMLTemplate(expected, now))
 	})
 
+	It("with attribute reference, special character and link", func() {
+		source := `= The {project} & https://example.com[_Example_ Site] Title
+:project: Foo`
+		expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<title>The Foo &amp; Example Site Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>The Foo &amp; <a href="https://example.com"><em>Example</em> Site</a> Title</h1>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
+	It("with title attribute", func() {
+		source := `= The _Document_ *Title*
+:project: Foo
+:title: Browser <Title> of {project}`
+		expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<title>Browser &lt;Title&gt; of Foo</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>The <em>Document</em> <strong>Title</strong></h1>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
 	It("with role", func() {
 		source := `[.my_role]
 = My Title`
@@ -60,7 +127,7 @@ Last updated {{.LastUpdated}}
 <title>My Title</title>
 </head>
 <body class="article my_role">
-<div id="header">
+<div id="header" class="my_role">
 <h1>My Title</h1>
 </div>
 <div id="content">
@@ -94,7 +161,7 @@ Last updated {{.LastUpdated}}
 <title>My Title</title>
 </head>
 <body id="anchor" class="article role1 role2">
-<div id="header">
+<div id="header" class="role1 role2">
 <h1>My Title</h1>
 </div>
 <div id="content">
@@ -114,6 +181,103 @@ Last updated {{.LastUpdated}}
 			To(MatchHTMLTemplate(expected, now))
 	})
 
+	It("with subtitle and default separator", func() {
+		source := `= Main Title: Sub: *Title*
+:title-separator:`
+		expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<title>Main Title: Sub: Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Main Title: Sub</h1>
+<div class="subtitle"><strong>Title</strong></div>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
+	It("with subtitle and custom separator", func() {
+		source := `= Main Title: Sub -- Title
+:title-separator: --`
+		expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<title>Main Title: Sub` + "\u2009\u2014\u2009" + `Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Main Title: Sub</h1>
+<div class="subtitle">Title</div>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
+	It("without title separator", func() {
+		source := `= Main Title: Sub Title`
+		expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<title>Main Title: Sub Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Main Title: Sub Title</h1>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
 	Context("without title", func() {
 
 		now := time.Now()
@@ -162,6 +326,39 @@ Last updated {{.LastUpdated}}
 				configuration.WithLastUpdated(now),
 			)).To(MatchHTMLTemplate(expected, now))
 		})
+
+		It("with title attribute", func() {
+			expected := `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta http-equiv="X-UA-Compatible" content="IE=edge">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<meta name="generator" content="libasciidoc">
+<title>Custom Title</title>
+</head>
+<body class="article">
+<div id="content">
+<div class="paragraph">
+<p>a paragraph</p>
+</div>
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+			Expect(RenderHTML(source,
+				configuration.WithHeaderFooter(true),
+				configuration.WithLastUpdated(now),
+				configuration.WithAttributes(map[string]string{
+					types.AttrTitle: "Custom Title",
+				}),
+			)).To(MatchHTMLTemplate(expected, now))
+		})
 	})
 	It("should include adoc file without leveloffset from relative file", func() {
 		source := "include::../../../../../test/includes/grandchild-include.adoc[]" // with filename `tmp/foo.adoc`, we are virtually in a subfolder
@@ -503,3 +700,57 @@ Free use of this software is granted under the terms of the MIT License.</p>
 			To(MatchHTMLTemplate(expected, now))
 	})
 })
+
+var _ = Describe("element rendering", func() {
+
+	It("should render paragraph", func() {
+		// given
+		ctx := renderer.NewContext(types.Document{}, configuration.NewConfiguration())
+		element := types.Paragraph{
+			Lines: [][]interface{}{
+				{
+					types.StringElement{Content: "some "},
+					types.QuotedText{
+						Kind: types.SingleQuoteBold,
+						Elements: []interface{}{
+							types.StringElement{Content: "bold"},
+						},
+					},
+					types.StringElement{Content: " content"},
+				},
+				{
+					types.StringElement{Content: "on two lines"},
+				},
+			},
+		}
+		// when
+		result, err := html5.RenderElement(ctx, element)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(`<div class="paragraph">
+<p>some <strong>bold</strong> content
+on two lines</p>
+</div>
+`))
+	})
+
+	It("should render inline elements", func() {
+		// given
+		ctx := renderer.NewContext(types.Document{}, configuration.NewConfiguration())
+		elements := []interface{}{
+			types.StringElement{Content: "some "},
+			types.QuotedText{
+				Kind: types.SingleQuoteItalic,
+				Elements: []interface{}{
+					types.StringElement{Content: "italic"},
+				},
+			},
+			types.LineBreak{},
+		}
+		// when
+		result, err := html5.RenderElement(ctx, elements)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("some <em>italic</em><br>"))
+	})
+})
@@ -1,6 +1,7 @@
 package html5_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
@@ -40,6 +41,15 @@ var _ = Describe("icons", func() {
 				Expect(RenderHTML(source)).To(MatchHTML(expected))
 			})
 
+			It("inline icon roles", func() {
+				source := `icon:caution[role=red,role=big]`
+				expected := `<div class="paragraph">
+<p><span class="icon red big">[caution]</span></p>
+</div>
+`
+				Expect(RenderHTML(source)).To(MatchHTML(expected))
+			})
+
 			It("inline icon link", func() {
 				source := `
 icon:amazon[link="https://amazon.com"]`
@@ -334,5 +344,24 @@ here *we go icon:stop[]*`
 			})
 
 		})
+
+		Context("with inline cache", func() {
+
+			It("repeated inline icons", func() {
+				source := `:icons: font
+
+icon:stop[] and icon:stop[]
+
+icon:stop[] and *icon:stop[]*`
+				expected := `<div class="paragraph">
+<p><span class="icon"><i class="fa fa-stop"></i></span> and <span class="icon"><i class="fa fa-stop"></i></span></p>
+</div>
+<div class="paragraph">
+<p><span class="icon"><i class="fa fa-stop"></i></span> and <strong><span class="icon"><i class="fa fa-stop"></i></span></strong></p>
+</div>
+`
+				Expect(RenderHTML(source, configuration.WithInlineCache(true))).To(MatchHTML(expected))
+			})
+		})
 	})
 })
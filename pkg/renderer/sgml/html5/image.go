@@ -1,13 +1,13 @@
 package html5
 
 const (
-	blockImageTmpl = `<div{{ if .ID }} id="{{ .ID }}"{{ end }} class="imageblock{{ if .Roles }} {{ .Roles }}{{ end }}">
+	blockImageTmpl = `<div{{ attributes "id" .ID "class" (classes "imageblock" .Roles) }}>
 <div class="content">
-{{ if ne .Href "" }}<a class="image" href="{{ .Href }}">{{ end }}<img src="{{ .Path }}" alt="{{ .Alt }}"{{ if .Width }} width="{{ .Width }}"{{ end }}{{ if .Height }} height="{{ .Height }}"{{ end }}>{{ if ne .Href "" }}</a>{{ end }}
+{{ if ne .Href "" }}<a class="image" href="{{ .Href }}">{{ end }}<img src="{{ .Path }}" alt="{{ .Alt }}"{{ if .Width }} width="{{ .Width }}"{{ end }}{{ if .Height }} height="{{ .Height }}"{{ end }}{{ if .Srcset }} srcset="{{ .Srcset }}"{{ end }}{{ if .Sizes }} sizes="{{ .Sizes }}"{{ end }}{{ if .Loading }} loading="{{ .Loading }}"{{ end }}>{{ if ne .Href "" }}</a>{{ end }}
 </div>{{ if .Title }}
 <div class="title">{{ .Caption }}{{ .Title }}</div>
 {{ else }}
 {{ end }}</div>
 `
-	inlineImageTmpl = `<span class="image{{ if .Roles }} {{ .Roles }}{{ end }}">{{ if ne .Href "" }}<a class="image" href="{{ .Href }}">{{ end }}<img src="{{ .Path }}" alt="{{ .Alt }}"{{ if .Width }} width="{{ .Width }}"{{ end }}{{ if .Height }} height="{{ .Height }}"{{ end }}{{ if .Title }} title="{{ .Title }}"{{ end }}>{{ if ne .Href "" }}</a>{{ end }}</span>`
+	inlineImageTmpl = `<span{{ attributes "class" (classes "image" .Roles) }}>{{ if ne .Href "" }}<a class="image" href="{{ .Href }}"{{ if .Window }} target="{{ .Window }}"{{ end }}>{{ end }}<img src="{{ .Path }}" alt="{{ .Alt }}"{{ if .Width }} width="{{ .Width }}"{{ end }}{{ if .Height }} height="{{ .Height }}"{{ end }}{{ if .Srcset }} srcset="{{ .Srcset }}"{{ end }}{{ if .Sizes }} sizes="{{ .Sizes }}"{{ end }}{{ if .Loading }} loading="{{ .Loading }}"{{ end }}{{ if .Title }} title="{{ .Title }}"{{ end }}>{{ if ne .Href "" }}</a>{{ end }}</span>`
 )
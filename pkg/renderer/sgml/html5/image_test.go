@@ -1,6 +1,14 @@
 package html5_test
 
 import (
+	"image"
+	"image/gif"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
@@ -23,6 +31,34 @@ var _ = Describe("images", func() {
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("with link containing a query string", func() {
+			source := "image::images/foo.png[link=https://example.com/?a=1&b=2]"
+			expected := `<div class="imageblock">
+<div class="content">
+<a class="image" href="https://example.com/?a=1&amp;b=2"><img src="images/foo.png" alt="foo"></a>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with URL rewriter", func() {
+
+			source := "image::images/foo.png[link=https://example.com]"
+			expected := `<div class="imageblock">
+<div class="content">
+<a class="image" href="https://example.com"><img src="https://cdn.example.com/images/foo.png" alt="foo"></a>
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithURLRewriter(func(kind, url string) string {
+				if kind != configuration.ImageURL {
+					return url
+				}
+				return "https://cdn.example.com/" + url
+			}))).To(MatchHTML(expected))
+		})
+
 		It("with alt", func() {
 
 			source := "image::foo.png[foo image]"
@@ -59,6 +95,20 @@ var _ = Describe("images", func() {
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("with title, float and align in block attributes", func() {
+			source := `.a title
+[.role1,float=right,align=center]
+image::foo.png[]`
+			expected := `<div class="imageblock right text-center role1">
+<div class="content">
+<img src="foo.png" alt="foo">
+</div>
+<div class="title">Figure 1. a title</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("with quoted text in attribute alt", func() {
 			// alt text is rendered as-is, even if it's rich
 			source := `image::images/foo.png[*alt text*, 600, 400]`
@@ -124,6 +174,28 @@ image::foo.png[foo image, 600, 400]`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("with srcset, sizes and lazy option", func() {
+			source := `image::foo.png[foo image, opts=lazy, srcset="foo-320.png 320w, foo-640.png 640w", sizes="(max-width: 600px) 320px, 640px"]`
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="foo.png" alt="foo image" srcset="foo-320.png 320w, foo-640.png 640w" sizes="(max-width: 600px) 320px, 640px" loading="lazy">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with loading attribute", func() {
+			source := `image::foo.png[foo image, 600, 400, loading=eager]`
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="foo.png" alt="foo image" width="600" height="400" loading="eager">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("with title, alt and dimensions", func() {
 			source := `[#img-foobar]
 .A title to foobar
@@ -218,6 +290,17 @@ image::appa.png[]`
 				Expect(RenderHTML(source)).To(MatchHTML(expected))
 			})
 
+			It("with URL rewriter", func() {
+				source := "image:app.png[]"
+				expected := `<div class="paragraph">
+<p><span class="image"><img src="app.png?v=1" alt="app"></span></p>
+</div>
+`
+				Expect(RenderHTML(source, configuration.WithURLRewriter(func(kind, url string) string {
+					return url + "?v=1"
+				}))).To(MatchHTML(expected))
+			})
+
 			It("with alt", func() {
 				source := "image:foo.png[foo image]"
 				expected := `<div class="paragraph">
@@ -245,6 +328,42 @@ image::appa.png[]`
 				Expect(RenderHTML(source)).To(MatchHTML(expected))
 			})
 
+			It("with link, window, title, dimensions and role", func() {
+				source := `image:foo.png[foo image, 60, 40, title="the foo image", link=https://foo.bar/?a=1&b=2, window=_blank, role=middle]`
+				expected := `<div class="paragraph">
+<p><span class="image middle"><a class="image" href="https://foo.bar/?a=1&amp;b=2" target="_blank"><img src="foo.png" alt="foo image" width="60" height="40" title="the foo image"></a></span></p>
+</div>
+`
+				Expect(RenderHTML(source)).To(MatchHTML(expected))
+			})
+
+			It("with srcset and loading attribute", func() {
+				source := `image:foo.png[foo image, srcset="foo-1x.png 1x, foo-2x.png 2x", loading=lazy]`
+				expected := `<div class="paragraph">
+<p><span class="image"><img src="foo.png" alt="foo image" srcset="foo-1x.png 1x, foo-2x.png 2x" loading="lazy"></span></p>
+</div>
+`
+				Expect(RenderHTML(source)).To(MatchHTML(expected))
+			})
+
+			It("with lazy option among other options", func() {
+				source := `image:foo.png[foo image, opts="interactive,lazy"]`
+				expected := `<div class="paragraph">
+<p><span class="image"><img src="foo.png" alt="foo image" loading="lazy"></span></p>
+</div>
+`
+				Expect(RenderHTML(source)).To(MatchHTML(expected))
+			})
+
+			It("with named dimensions and float", func() {
+				source := "image:foo.png[width=60, height=40, float=right]"
+				expected := `<div class="paragraph">
+<p><span class="image right"><img src="foo.png" alt="foo" width="60" height="40"></span></p>
+</div>
+`
+				Expect(RenderHTML(source)).To(MatchHTML(expected))
+			})
+
 			It("paragraph with inline image with alt and dimensions", func() {
 				source := "a foo image:foo.png[foo image, 600, 400] bar"
 				expected := `<div class="paragraph">
@@ -352,4 +471,112 @@ image::file:///bar/foo.png[]`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 	})
+
+	Context("with inline cache", func() {
+
+		It("repeated inline images with attribute changed in the document body", func() {
+			source := `image:foo.png[] and image:foo.png[]
+
+:imagesdir: images
+
+image:foo.png[]`
+			expected := `<div class="paragraph">
+<p><span class="image"><img src="foo.png" alt="foo"></span> and <span class="image"><img src="foo.png" alt="foo"></span></p>
+</div>
+<div class="paragraph">
+<p><span class="image"><img src="images/foo.png" alt="foo"></span></p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithInlineCache(true))).To(MatchHTML(expected))
+		})
+	})
+
+	Context("with auto image dimensions", func() {
+
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "libasciidoc")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.MkdirAll(filepath.Join(dir, "images"), 0755)).To(Succeed())
+			f, err := os.Create(filepath.Join(dir, "images", "foo.png"))
+			Expect(err).ToNot(HaveOccurred())
+			defer f.Close()
+			Expect(png.Encode(f, image.NewRGBA(image.Rect(0, 0, 40, 30)))).To(Succeed())
+			g, err := os.Create(filepath.Join(dir, "images", "bar.gif"))
+			Expect(err).ToNot(HaveOccurred())
+			defer g.Close()
+			Expect(gif.Encode(g, image.NewRGBA(image.Rect(0, 0, 12, 8)), nil)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("block and inline images without dimensions", func() {
+			source := `:imagesdir: images
+
+image::foo.png[]
+
+an image:bar.gif[] icon`
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="images/foo.png" alt="foo" width="40" height="30">
+</div>
+</div>
+<div class="paragraph">
+<p>an <span class="image"><img src="images/bar.gif" alt="bar" width="12" height="8"></span> icon</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithFilename(filepath.Join(dir, "test.adoc")), configuration.WithAutoImageDimensions(true))).To(MatchHTML(expected))
+		})
+
+		It("block image with width", func() {
+			source := `image::images/foo.png[foo,20]`
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="images/foo.png" alt="foo" width="20">
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithFilename(filepath.Join(dir, "test.adoc")), configuration.WithAutoImageDimensions(true))).To(MatchHTML(expected))
+		})
+
+		It("missing, remote and outside images", func() {
+			source := `image::images/missing.png[]
+
+image::https://example.com/foo.png[]
+
+image::../images/foo.png[]`
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="images/missing.png" alt="missing">
+</div>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="https://example.com/foo.png" alt="foo">
+</div>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="../images/foo.png" alt="foo">
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithFilename(filepath.Join(dir, "sub", "test.adoc")), configuration.WithAutoImageDimensions(true))).To(MatchHTML(expected))
+		})
+
+		It("block image without setting", func() {
+			source := `image::images/foo.png[]`
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="images/foo.png" alt="foo">
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithFilename(filepath.Join(dir, "test.adoc")))).To(MatchHTML(expected))
+		})
+	})
 })
@@ -1,18 +1,14 @@
 package html5
 
 const (
-	labeledListTmpl = `<div` +
-		`{{ if .ID }} id="{{ .ID }}"{{ end }}` +
-		" class=\"dlist{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	labeledListTmpl = `<div{{ attributes "id" .ID "class" (classes "dlist" .Style .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<dl>\n{{ .Content }}</dl>\n</div>\n"
 
-	labeledListItemTmpl = "<dt class=\"hdlist1\">{{ .Term }}</dt>\n" +
+	labeledListItemTmpl = `<dt{{ attributes "id" .ID "class" (and (not .Glossary) "hdlist1") }}>{{ .Term }}</dt>` + "\n" +
 		"{{ if .Content }}<dd>\n{{ .Content }}</dd>\n{{ end }}"
 
-	labeledListHorizontalTmpl = `<div` +
-		`{{ if .ID }} id="{{ .ID }}"{{ end }} ` +
-		"class=\"hdlist{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	labeledListHorizontalTmpl = `<div{{ attributes "id" .ID "class" (classes "hdlist" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<table>\n{{ .Content }}</table>\n</div>\n"
 
@@ -22,9 +18,7 @@ const (
 		"{{ .Term }}\n" +
 		"{{ if .Content }}</td>\n<td class=\"hdlist2\">\n{{ .Content }}</td>\n</tr>\n{{ end }}"
 
-	qAndAListTmpl = "<div{{ if .ID }} id=\"{{ .ID }}\"{{ end }} " +
-		"class=\"qlist qanda{{ if .Roles }} {{ .Roles }}{{ end }}\"" +
-		">\n" +
+	qAndAListTmpl = `<div{{ attributes "id" .ID "class" (classes "qlist qanda" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<ol>\n{{ .Content }}</ol>\n</div>\n"
 
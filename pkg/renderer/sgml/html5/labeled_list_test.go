@@ -194,6 +194,25 @@ second term:: definition of the second term`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("labeled list with nested separators and items separated by blank lines", func() {
+			source := `item 1::: description 1
+
+item 2::: description 2`
+			expected := `<div class="dlist">
+<dl>
+<dt class="hdlist1">item 1</dt>
+<dd>
+<p>description 1</p>
+</dd>
+<dt class="hdlist1">item 2</dt>
+<dd>
+<p>description 2</p>
+</dd>
+</dl>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
 	})
 
 	Context("horizontal layout", func() {
@@ -519,6 +538,29 @@ What is the answer to the Ultimate Question?:: 42`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("q and a with items separated by blank lines", func() {
+			source := `[qanda]
+What is libasciidoc?::
+	An implementation of the AsciiDoc processor in Golang.
+
+What is the answer to the Ultimate Question?:: 42`
+
+			expected := `<div class="qlist qanda">
+<ol>
+<li>
+<p><em>What is libasciidoc?</em></p>
+<p>An implementation of the AsciiDoc processor in Golang.</p>
+</li>
+<li>
+<p><em>What is the answer to the Ultimate Question?</em></p>
+<p>42</p>
+</li>
+</ol>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("q and a with roles, title", func() {
 			source := `.Q&A
 [qanda#quiz.role1.role2]
@@ -1,5 +1,5 @@
 package html5
 
 const (
-	linkTmpl = `<a href="{{ .URL }}"{{if .Class}} class="{{ .Class }}"{{ end }}{{if .Target}} target="{{ .Target }}"{{ end }}>{{ .Text }}</a>`
+	linkTmpl = `<a href="{{ .URL }}"{{ attributes "class" .Class "target" .Target }}>{{ .Text }}</a>`
 )
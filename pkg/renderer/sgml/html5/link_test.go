@@ -1,6 +1,7 @@
 package html5_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
@@ -21,6 +22,49 @@ var _ = Describe("links", func() {
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("without text and with hidden URI scheme", func() {
+			source := `:hide-uri-scheme:
+
+a link to https://foo.com[] and to mailto:foo@example.com[] and to https://bar.com[bar].`
+			expected := `<div class="paragraph">
+<p>a link to <a href="https://foo.com" class="bare">foo.com</a> and to <a href="mailto:foo@example.com" class="bare">foo@example.com</a> and to <a href="https://bar.com">bar</a>.</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with custom scheme", func() {
+			source := "a link to ssh://git@example.com[repo]."
+			expected := `<div class="paragraph">
+<p>a link to <a href="ssh://git@example.com">repo</a>.</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithURLScheme("ssh://"))).To(MatchHTML(expected))
+		})
+
+		It("with URL rewriter", func() {
+			source := "a link to https://foo.com[] and https://foo.com/bar[bar]."
+			expected := `<div class="paragraph">
+<p>a link to <a href="https://foo.com?ref=doc" class="bare">https://foo.com</a> and <a href="https://foo.com/bar?ref=doc">bar</a>.</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithURLRewriter(func(kind, url string) string {
+				if kind != configuration.LinkURL {
+					return url
+				}
+				return url + "?ref=doc"
+			}))).To(MatchHTML(expected))
+		})
+
+		It("escaped with a backslash", func() {
+			source := `a link to \https://example.com[].`
+			expected := `<div class="paragraph">
+<p>a link to https://example.com[].</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("with quoted text", func() {
 			source := "https://foo.com[_a_ *b* `c`]"
 			expected := `<div class="paragraph">
@@ -30,6 +74,33 @@ var _ = Describe("links", func() {
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("in bold text with quoted text", func() {
+			source := "*see https://foo.com[the _docs_]*"
+			expected := `<div class="paragraph">
+<p><strong>see <a href="https://foo.com">the <em>docs</em></a></strong></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("in italic text with bold text", func() {
+			source := "_see https://foo.com[**bold** docs] now_"
+			expected := `<div class="paragraph">
+<p><em>see <a href="https://foo.com"><strong>bold</strong> docs</a> now</em></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link in italic text with bold text", func() {
+			source := "_see link:foo.html[*docs*] now_"
+			expected := `<div class="paragraph">
+<p><em>see <a href="foo.html"><strong>docs</strong></a> now</em></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("with unquoted text having comma", func() {
 			source := "https://foo.com[A, B, and C]"
 			// here, `B` and `and C` are considered as other positional args,
@@ -182,7 +253,7 @@ a link to {scheme}://{path} and https://foo.baz`
 		It("relative link to doc without text", func() {
 			source := "a link to link:foo.adoc[]."
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc" class="bare">foo.adoc</a>.</p>
+<p>a link to <a href="foo.html" class="bare">foo.adoc</a>.</p>
 </div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
@@ -191,7 +262,7 @@ a link to {scheme}://{path} and https://foo.baz`
 		It("relative link to doc with text", func() {
 			source := "a link to link:foo.adoc[foo doc]."
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc">foo doc</a>.</p>
+<p>a link to <a href="foo.html">foo doc</a>.</p>
 </div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
@@ -200,7 +271,7 @@ a link to {scheme}://{path} and https://foo.baz`
 		It("relative link with text having comma", func() {
 			source := "a link to link:foo.adoc[A, B, and C]"
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc">A</a></p>
+<p>a link to <a href="foo.html">A</a></p>
 </div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
@@ -212,7 +283,48 @@ a link to {scheme}://{path} and https://foo.baz`
 			// TODO: expect `target=b` and `role= 'and C'` attributes
 			source := "a link to link:foo.adoc['A, B, and C']"
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc">A, B, and C</a></p>
+<p>a link to <a href="foo.html">A, B, and C</a></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to doc with fragment", func() {
+			source := "a link to link:../guide/index.adoc#install[Guide]."
+			expected := `<div class="paragraph">
+<p>a link to <a href="../guide/index.html#install">Guide</a>.</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to doc with custom outfilesuffix", func() {
+			source := `:outfilesuffix: .xhtml
+
+a link to link:foo.adoc[foo doc].`
+			expected := `<div class="paragraph">
+<p>a link to <a href="foo.xhtml">foo doc</a>.</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to doc with custom relfilesuffix", func() {
+			source := `:outfilesuffix: .xhtml
+:relfilesuffix: /
+
+a link to link:foo.adoc[foo doc].`
+			expected := `<div class="paragraph">
+<p>a link to <a href="foo/">foo doc</a>.</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to other file", func() {
+			source := "a link to link:foo.pdf[foo doc]."
+			expected := `<div class="paragraph">
+<p>a link to <a href="foo.pdf">foo doc</a>.</p>
 </div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
@@ -1,15 +1,9 @@
 package html5
 
 const (
-	orderedListTmpl = `<div{{ if .ID }} id="{{ .ID }}"{{ end }}` +
-		` class="olist {{ .Style }}` +
-		`{{ if .Roles }} {{ .Roles }}{{ end }}"` +
-		">\n" +
+	orderedListTmpl = `<div{{ attributes "id" .ID "class" (classes "olist" .Style .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
-		`<ol` +
-		` class="{{ .Style }}"` +
-		`{{ if .ListStyle }} type="{{ .ListStyle }}"{{ end }}` +
-		`{{ if .Start }} start="{{ .Start }}"{{ end }}` +
+		`<ol{{ attributes "class" .Style "type" .ListStyle "start" .Start }}` +
 		`{{ if .Reversed }} reversed{{ end }}` +
 		">\n{{ .Content }}</ol>\n</div>\n"
 
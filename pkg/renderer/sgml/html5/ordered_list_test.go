@@ -515,6 +515,49 @@ paragraph attached to child list item`
 </li>
 </ol>
 </div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("ordered list item with continued admonition, image and listing", func() {
+			source := `. item
++
+NOTE: a note
++
+image::foo.png[]
++
+----
+a listing
+----`
+			expected := `<div class="olist arabic">
+<ol class="arabic">
+<li>
+<p>item</p>
+<div class="admonitionblock note">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Note</div>
+</td>
+<td class="content">
+a note
+</td>
+</tr>
+</table>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="foo.png" alt="foo">
+</div>
+</div>
+<div class="listingblock">
+<div class="content">
+<pre>a listing</pre>
+</div>
+</div>
+</li>
+</ol>
+</div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
@@ -0,0 +1,84 @@
+package html5_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("output formats", func() {
+
+	source := `a *bold*
+_italic_   content +
+on 2 lines
+
+----
+some   code
+  indented
+----
+
+* item  1
+* item 2`
+
+	It("should render with pretty format", func() {
+		expected := `<div class="paragraph">
+<p>a <strong>bold</strong>
+<em>italic</em>   content<br>
+on 2 lines</p>
+</div>
+<div class="listingblock">
+<div class="content">
+<pre>some   code
+  indented</pre>
+</div>
+</div>
+<div class="ulist">
+<ul>
+<li>
+<p>item  1</p>
+</li>
+<li>
+<p>item 2</p>
+</li>
+</ul>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithOutputFormat(configuration.PrettyOutput))).To(MatchHTML(expected))
+	})
+
+	It("should render with compact format", func() {
+		expected := `<div class="paragraph"><p>a <strong>bold</strong> <em>italic</em>   content<br>on 2 lines</p></div>` +
+			`<div class="listingblock"><div class="content"><pre>some   code
+  indented</pre></div></div>` +
+			`<div class="ulist"><ul><li><p>item  1</p></li><li><p>item 2</p></li></ul></div>`
+		Expect(RenderHTML(source, configuration.WithOutputFormat(configuration.CompactOutput))).To(MatchHTML(expected))
+	})
+
+	It("should render with minified format", func() {
+		expected := `<div class="paragraph"><p>a <strong>bold</strong> <em>italic</em> content<br>on 2 lines</p></div>` +
+			`<div class="listingblock"><div class="content"><pre>some   code
+  indented</pre></div></div>` +
+			`<div class="ulist"><ul><li><p>item 1</p></li><li><p>item 2</p></li></ul></div>`
+		Expect(RenderHTML(source, configuration.WithOutputFormat(configuration.MinifiedOutput))).To(MatchHTML(expected))
+	})
+
+	It("should replace the newlines between text lines and inline elements with a space in compact format", func() {
+		source := `some
+content
+*bold*
+text
+_italic_`
+		expected := `<div class="paragraph"><p>some content <strong>bold</strong> text <em>italic</em></p></div>`
+		Expect(RenderHTML(source, configuration.WithOutputFormat(configuration.CompactOutput))).To(MatchHTML(expected))
+	})
+
+	It("should not collapse the whitespaces in quoted attribute values", func() {
+		source := `[[anchor]]
+.A  title
+image::foo.png[alt="some   alt > text"]`
+		expected := `<div id="anchor" class="imageblock"><div class="content"><img src="foo.png" alt="some   alt > text"></div><div class="title">Figure 1. A title</div></div>`
+		Expect(RenderHTML(source, configuration.WithOutputFormat(configuration.MinifiedOutput))).To(MatchHTML(expected))
+	})
+})
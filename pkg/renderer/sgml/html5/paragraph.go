@@ -1,15 +1,13 @@
 package html5
 
 const (
-	paragraphTmpl = "<div{{ if .ID }} id=\"{{ .ID }}\"{{ end }}" +
-		" class=\"paragraph{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	paragraphTmpl = `<div{{ attributes "id" .ID "class" (classes "paragraph" .Roles) }}>` + "\n" +
 		"{{ if .Title  }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<p>{{ .Content }}</p>\n" +
 		"</div>\n"
 
 	admonitionParagraphTmpl = `{{ if .Content }}` +
-		"<div {{ if .ID }}id=\"{{ .ID }}\" {{ end }}class=\"admonitionblock {{ .Kind }}" +
-		"{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+		`<div{{ attributes "id" .ID "class" (classes "admonitionblock" .Kind .Roles) }}>` + "\n" +
 		"<table>\n<tr>\n<td class=\"icon\">\n" +
 		"{{ if .Icon }}{{ .Icon }}{{ end }}\n" +
 		"</td>\n" +
@@ -20,14 +18,14 @@ const (
 
 	delimitedBlockParagraphTmpl = "<p>{{ .CheckStyle }}{{ .Content }}</p>\n"
 
-	verseParagraphTmpl = "<div {{ if .ID }}id=\"{{ .ID }}\" {{ end }}class=\"verseblock\">\n" +
+	verseParagraphTmpl = `<div{{ attributes "id" .ID "class" (classes "verseblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<pre class=\"content\">{{ .Content }}</pre>\n" +
 		"{{ if .Attribution.First }}<div class=\"attribution\">\n&#8212; {{ .Attribution.First }}" +
 		"{{ if .Attribution.Second }}<br>\n<cite>{{ .Attribution.Second }}</cite>\n{{ else }}\n{{ end }}" +
 		"</div>\n{{ end }}</div>\n"
 
-	quoteParagraphTmpl = "<div {{ if .ID }}id=\"{{ .ID }}\" {{ end }}class=\"quoteblock\">\n" +
+	quoteParagraphTmpl = `<div{{ attributes "id" .ID "class" (classes "quoteblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<blockquote>\n" +
 		"{{ .Content }}\n" +
@@ -1,6 +1,7 @@
 package html5_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
@@ -62,6 +63,27 @@ and here another paragraph
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("paragraph followed by a list without blank line", func() {
+			source := `a paragraph
+* an item
+* another item`
+			expected := `<div class="paragraph">
+<p>a paragraph</p>
+</div>
+<div class="ulist">
+<ul>
+<li>
+<p>an item</p>
+</li>
+<li>
+<p>another item</p>
+</li>
+</ul>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("paragraph with single quotes", func() {
 			source := `a 'subsection' paragraph.`
 			expected := `<div class="paragraph">
@@ -100,6 +122,55 @@ some content`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("paragraph with predefined attributes in quoted text", func() {
+			source := "*{asterisk}bold{asterisk}* `mono{two-semicolons}` ^{caret}^"
+			expected := `<div class="paragraph">
+<p><strong>*bold*</strong> <code>mono;;</code> <sup>^</sup></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("paragraph with predefined attributes of special characters", func() {
+			source := "a {lt}b{gt} {amp} c"
+			expected := `<div class="paragraph">
+<p>a &lt;b&gt; &amp; c</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("paragraph with predefined attribute overridden in document", func() {
+			source := `:cpp: C plus plus
+
+hello {cpp} world`
+			expected := `<div class="paragraph">
+<p>hello C plus plus world</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("paragraph with predefined attribute overridden in configuration", func() {
+			source := "hello {cpp} world"
+			expected := `<div class="paragraph">
+<p>hello C plus plus world</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithAttribute("cpp", "C plus plus"))).To(MatchHTML(expected))
+		})
+
+		It("paragraph with predefined attributes in title", func() {
+			source := `.a {startsb}title{endsb} with {apos}{lt}{gt}{apos}
+some content`
+			expected := `<div class="paragraph">
+<div class="title">a [title] with &#39;&lt;&gt;&#39;</div>
+<p>some content</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("with custom title attribute - explicit and unquoted", func() {
 			source := `:title: cookies
 			
@@ -291,6 +362,54 @@ warning!
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("multiline admonition paragraph with hardbreaks", func() {
+			source := `[%hardbreaks]
+TIP: this is a multiline
+tip!`
+			expected := `<div class="admonitionblock tip">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Tip</div>
+</td>
+<td class="content">
+this is a multiline<br>
+tip!
+</td>
+</tr>
+</table>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("multiline admonition paragraph with line break and followed by a paragraph", func() {
+			source := `NOTE: this is a multiline +
+note
+with 3 lines
+   
+and this is another paragraph`
+			expected := `<div class="admonitionblock note">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Note</div>
+</td>
+<td class="content">
+this is a multiline<br>
+note
+with 3 lines
+</td>
+</tr>
+</table>
+</div>
+<div class="paragraph">
+<p>and this is another paragraph</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("admonition note paragraph with id and title", func() {
 			source := `[[foo]]
 .bar
@@ -430,6 +549,19 @@ I am a verse paragraph.`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("paragraph as a verse with named author and role", func() {
+			source := `[verse.poem, attribution="john doe"]
+I am a verse paragraph.`
+			expected := `<div class="verseblock poem">
+<pre class="content">I am a verse paragraph.</pre>
+<div class="attribution">
+&#8212; john doe
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("paragraph as a verse with empty title", func() {
 			source := `[verse, john doe, ]
 I am a verse paragraph.`
@@ -528,6 +660,22 @@ I am a quote paragraph.
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("paragraph as a quote with named author and title, and role", func() {
+			source := `[quote.fancy, attribution="Albert Einstein", citetitle="Why Socialism?"]
+Man is, at one and the same time, a solitary being and a social being.`
+			expected := `<div class="quoteblock fancy">
+<blockquote>
+Man is, at one and the same time, a solitary being and a social being.
+</blockquote>
+<div class="attribution">
+&#8212; Albert Einstein<br>
+<cite>Why Socialism?</cite>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("paragraph as a quote with empty title", func() {
 			source := `[quote, john doe, ]
 I am a quote paragraph.`
@@ -1,10 +1,12 @@
 package html5_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
 	. "github.com/onsi/gomega" //nolint golint
+	log "github.com/sirupsen/logrus"
 )
 
 var _ = Describe("passthroughs", func() {
@@ -175,4 +177,300 @@ world</p>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 	})
+
+	Context("passthrough macro with custom substitutions", func() {
+
+		It("should apply attributes substitution only", func() {
+			source := `:name: value
+
+pass:a[{name} *not bold*]`
+			expected := `<div class="paragraph">
+<p>value *not bold*</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should apply quotes and attributes substitutions", func() {
+			source := `:name: value
+
+pass:q,a[<u>*{name}*</u>]`
+			expected := `<div class="paragraph">
+<p><u><strong>value</strong></u></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should apply special characters substitution only", func() {
+			source := `:name: value
+
+pass:c[<b>{name}</b>]`
+			expected := `<div class="paragraph">
+<p>&lt;b&gt;{name}&lt;/b&gt;</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should not alter content in quoted text", func() {
+			source := `:name: value
+
+*bold pass:a[{name} *x*] text*`
+			expected := `<div class="paragraph">
+<p><strong>bold value *x* text</strong></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should ignore unsupported substitutions", func() {
+			logs, reset := ConfigureLogger(log.WarnLevel)
+			defer reset()
+			source := `pass:z[*z*]`
+			expected := `<div class="paragraph">
+<p>*z*</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+			Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "unable to apply the substitutions of the passthrough macro: unsupported substitution: 'z'"))
+		})
+	})
+
+	Context("escaped inline elements", func() {
+
+		It("escaped attribute substitution", func() {
+			source := `:name: value
+
+\{name} and {name}`
+			expected := `<div class="paragraph">
+<p>{name} and value</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped counter substitution", func() {
+			source := `\{counter:idx}`
+			expected := `<div class="paragraph">
+<p>{counter:idx}</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped internal cross reference", func() {
+			source := `see \<<section>>`
+			expected := `<div class="paragraph">
+<p>see &lt;&lt;section&gt;&gt;</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped external cross reference", func() {
+			source := `see \xref:other.adoc[other]`
+			expected := `<div class="paragraph">
+<p>see xref:other.adoc[other]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped relative link", func() {
+			source := `\link:foo.adoc[foo]`
+			expected := `<div class="paragraph">
+<p>link:foo.adoc[foo]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped external link", func() {
+			source := `\https://example.com[example]`
+			expected := `<div class="paragraph">
+<p>https://example.com[example]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped inline image", func() {
+			source := `\image:foo.png[]`
+			expected := `<div class="paragraph">
+<p>image:foo.png[]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped inline icon", func() {
+			source := `\icon:tip[]`
+			expected := `<div class="paragraph">
+<p>icon:tip[]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped footnote", func() {
+			source := `foo\footnote:[bar]`
+			expected := `<div class="paragraph">
+<p>foofootnote:[bar]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped inline anchor", func() {
+			source := `\[[id]]`
+			expected := `<div class="paragraph">
+<p>[[id]]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped index term", func() {
+			source := `\((foo))`
+			expected := `<div class="paragraph">
+<p>((foo))</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped singleplus passthrough", func() {
+			source := `\+*hello*+`
+			expected := `<div class="paragraph">
+<p>+<strong>hello</strong>+</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped link with quoted text", func() {
+			source := `\https://example.com[*bold* text]`
+			expected := `<div class="paragraph">
+<p>https://example.com[<strong>bold</strong> text]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped tripleplus passthrough", func() {
+			source := `\+++<u>hello</u>+++`
+			expected := `<div class="paragraph">
+<p>+++&lt;u&gt;hello&lt;/u&gt;+++</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped passthrough macro", func() {
+			source := `\pass:[<u>hello</u>]`
+			expected := `<div class="paragraph">
+<p>pass:[&lt;u&gt;hello&lt;/u&gt;]</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped replacements", func() {
+			source := `\(C) \(TM) \(R) foo\... foo\--bar`
+			expected := `<div class="paragraph">
+<p>(C) (TM) (R) foo... foo--bar</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped quoted texts", func() {
+			source := "\\*bold* \\_italic_ \\`mono` \\#marked# \\^sup^ \\~sub~"
+			expected := "<div class=\"paragraph\">\n" +
+				"<p>*bold* _italic_ `mono` #marked# ^sup^ ~sub~</p>\n" +
+				"</div>\n"
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped element within quoted text", func() {
+			source := `*\{name}*`
+			expected := `<div class="paragraph">
+<p><strong>{name}</strong></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("escaped element in section title", func() {
+			source := `== Title \{name}`
+			expected := `<div class="sect1">
+<h2 id="_title_name">Title {name}</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("hugo shortcodes", func() {
+
+		It("shortcodes in paragraph, list item, table cell and section title", func() {
+			source := `== Section {{< param "name" >}}
+
+a paragraph with {{< figure src="/img/*cover*.png" title="a_b_c" >}} and {{% notice note %}}*bold*{{% /notice %}}
+
+* item {{< ref "other.adoc" >}}
+
+|===
+| cell {{< icon "x_y" >}}
+|===`
+			expected := `<div class="sect1">
+<h2 id="_section_param_name">Section {{< param "name" >}}</h2>
+<div class="sectionbody">
+<div class="paragraph">
+<p>a paragraph with {{< figure src="/img/*cover*.png" title="a_b_c" >}} and {{% notice note %}}<strong>bold</strong>{{% /notice %}}</p>
+</div>
+<div class="ulist">
+<ul>
+<li>
+<p>item {{< ref "other.adoc" >}}</p>
+</li>
+</ul>
+</div>
+<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 100%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">cell {{< icon "x_y" >}}</p></td>
+</tr>
+</tbody>
+</table>
+</div>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithHugoShortcodes(true))).To(MatchHTML(expected))
+		})
+
+		It("shortcodes escaped by default", func() {
+			source := `a paragraph with {{< figure src="/img/*cover*.png" >}}`
+			expected := `<div class="paragraph">
+<p>a paragraph with {{&lt; figure src="/img/<strong>cover</strong>.png" &gt;}}</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("incomplete shortcodes", func() {
+			source := `a paragraph with {{< figure and {{% notice`
+			expected := `<div class="paragraph">
+<p>a paragraph with {{&lt; figure and {{% notice</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithHugoShortcodes(true))).To(MatchHTML(expected))
+		})
+	})
 })
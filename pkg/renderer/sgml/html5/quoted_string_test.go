@@ -20,6 +20,16 @@ var _ = Describe("quoted strings", func() {
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("multiline double quoted string", func() {
+			source := "a \"`curly\nwas double`\" string"
+			expected := `<div class="paragraph">
+<p>a &#8220;curly
+was double&#8221; string</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("simple single quoted string", func() {
 			source := "'`curly was single`'"
 			expected := `<div class="paragraph">
@@ -1,10 +1,10 @@
 package html5
 
 const (
-	boldTextTmpl        = `<strong{{ if .ID }} id="{{ .ID }}"{{ end }}{{ if .Roles }} class="{{ .Roles }}"{{ end }}>{{ .Content }}</strong>`
-	italicTextTmpl      = `<em{{ if .ID }} id="{{ .ID }}"{{ end }}{{ if .Roles }} class="{{ .Roles }}"{{ end }}>{{ .Content }}</em>`
-	monospaceTextTmpl   = `<code{{ if .ID }} id="{{ .ID }}"{{ end }}{{ if .Roles }} class="{{ .Roles }}"{{ end }}>{{ .Content }}</code>`
-	subscriptTextTmpl   = `<sub{{ if .ID }} id="{{ .ID }}"{{ end }}{{ if .Roles }} class="{{ .Roles }}"{{ end }}>{{ .Content }}</sub>`
-	superscriptTextTmpl = `<sup{{ if .ID }} id="{{ .ID }}"{{ end }}{{ if .Roles }} class="{{ .Roles }}"{{ end }}>{{ .Content }}</sup>`
-	markedTextTmpl      = `{{ if .Roles }}<span{{ else }}<mark{{ end }}{{ if .ID }} id="{{ .ID }}"{{ end }}{{ if .Roles }} class="{{ .Roles }}"{{ end }}>{{ .Content }}{{ if .Roles }}</span>{{ else }}</mark>{{ end }}`
+	boldTextTmpl        = `<strong{{ attributes "id" .ID "class" .Roles }}>{{ .Content }}</strong>`
+	italicTextTmpl      = `<em{{ attributes "id" .ID "class" .Roles }}>{{ .Content }}</em>`
+	monospaceTextTmpl   = `<code{{ attributes "id" .ID "class" .Roles }}>{{ .Content }}</code>`
+	subscriptTextTmpl   = `<sub{{ attributes "id" .ID "class" .Roles }}>{{ .Content }}</sub>`
+	superscriptTextTmpl = `<sup{{ attributes "id" .ID "class" .Roles }}>{{ .Content }}</sup>`
+	markedTextTmpl      = `{{ if .Roles }}<span{{ else }}<mark{{ end }}{{ attributes "id" .ID "class" .Roles }}>{{ .Content }}{{ if .Roles }}</span>{{ else }}</mark>{{ end }}`
 )
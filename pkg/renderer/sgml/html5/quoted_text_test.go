@@ -1,9 +1,12 @@
 package html5_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega" //nolint golint
 )
 
@@ -193,6 +196,23 @@ content</code>.</p>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("double monospace content with single backticks", func() {
+			source := "use ``the `code` value`` or ``a`b``."
+			expected := "<div class=\"paragraph\">\n" +
+				"<p>use <code>the `code` value</code> or <code>a`b</code>.</p>\n" +
+				"</div>\n"
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("monospace content followed by punctuation", func() {
+			source := "some `code`. some `code`, some `code`; some `code`? some `code`! (some `code`) some `code`..."
+			expected := `<div class="paragraph">
+<p>some <code>code</code>. some <code>code</code>, some <code>code</code>; some <code>code</code>? some <code>code</code>! (some <code>code</code>) some <code>code</code>&#8230;&#8203;</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("double monospace content across paragraph", func() {
 			source := "some ``monospace\n\ncontent``."
 			expected := "<div class=\"paragraph\">\n" +
@@ -809,4 +829,76 @@ b</code></p>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 	})
+
+	Context("compat mode", func() {
+
+		It("single quotes as emphasis and plus signs as monospace passthrough", func() {
+			source := `:compat-mode:
+
+it's 'italic *and bold*' with +<monospace>+ content`
+			expected := `<div class="paragraph">
+<p>it&#8217;s <em>italic <strong>and bold</strong></em> with <code>&lt;monospace&gt;</code> content</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("compat mode set in the configuration", func() {
+			source := `'italic' content`
+			expected := `<div class="paragraph">
+<p><em>italic</em> content</p>
+</div>
+`
+			Expect(RenderHTML(source, configuration.WithAttribute(types.AttrCompatMode, ""))).To(MatchHTML(expected))
+		})
+
+		It("single quotes and plus signs without compat mode", func() {
+			source := `'regular' +<passthrough>+ content`
+			expected := `<div class="paragraph">
+<p>'regular' &lt;passthrough&gt; content</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("adjacent punctuation", func() {
+
+		DescribeTable("constrained quoted text",
+			func(source, expected string) {
+				Expect(RenderHTML(source)).To(MatchHTML("<div class=\"paragraph\">\n<p>" + expected + "</p>\n</div>\n"))
+			},
+			Entry("in parentheses", "(*bold*) (_italic_) (`mono`) (#marked#)",
+				"(<strong>bold</strong>) (<em>italic</em>) (<code>mono</code>) (<mark>marked</mark>)"),
+			Entry("in brackets and braces", "a [*bold*] {_italic_}",
+				"a [<strong>bold</strong>] {<em>italic</em>}"),
+			Entry("in typographic quotes", "“*bold*” ‘_italic_’",
+				"&#8220;<strong>bold</strong>&#8221; &#8216;<em>italic</em>&#8217;"),
+			Entry("in double quotes", `"*bold*"`,
+				`"<strong>bold</strong>"`),
+			Entry("followed by punctuation", "*bold*, _italic_; `mono`: #marked#. *bold*! _italic_?",
+				"<strong>bold</strong>, <em>italic</em>; <code>mono</code>: <mark>marked</mark>. <strong>bold</strong>! <em>italic</em>?"),
+			Entry("surrounded by dashes and slashes", "-*bold*- /_italic_/",
+				"-<strong>bold</strong>- /<em>italic</em>/"),
+			Entry("preceded by a colon", "a:*bold* a:_italic_ a:`mono` a:#marked#",
+				"a:*bold* a:_italic_ a:`mono` a:#marked#"),
+			Entry("preceded by a semicolon", "a;*bold* a;_italic_",
+				"a;*bold* a;_italic_"),
+			Entry("preceded by a closing brace", "a}*bold* a}_italic_",
+				"a}*bold* a}_italic_"),
+			Entry("preceded by a character", "a*bold* a_italic_",
+				"a*bold* a_italic_"),
+			Entry("followed by a character", "*bold*a _italic_a",
+				"*bold*a _italic_a"),
+		)
+
+		It("unconstrained quoted text preceded by a colon", func() {
+			source := "a:**bold** a;__italic__"
+			expected := `<div class="paragraph">
+<p>a:<strong>bold</strong> a;<em>italic</em></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
 })
@@ -7,7 +7,7 @@ const (
 		`{{ .Content }}` +
 		"{{ if .Wrapper }}</div>\n</div>\n{{ end }}"
 
-	sectionContentTmpl = "<div class=\"sect{{ .Level }}{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	sectionContentTmpl = `<div{{ attributes "class" (classes (print "sect" .Level) .Roles) }}>` + "\n" +
 		"{{ .Header }}" +
 		"{{ if eq .Level 1 }}<div class=\"sectionbody\">\n{{ end }}" +
 		"{{ .Content }}" +
@@ -15,4 +15,6 @@ const (
 		"</div>\n"
 
 	sectionHeaderTmpl = "<h{{ .LevelPlusOne }} id=\"{{ .ID }}\">{{ .Content }}</h{{ .LevelPlusOne }}>\n"
+
+	discreteHeadingTmpl = `<h{{ .LevelPlusOne }}{{ attributes "id" .ID "class" (classes "discrete" .Roles) }}>{{ .Content }}</h{{ .LevelPlusOne }}>` + "\n"
 )
@@ -1,6 +1,10 @@
 package html5_test
 
 import (
+	"fmt"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
@@ -45,6 +49,17 @@ var _ = Describe("sections", func() {
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("section level 1 with relative level offset", func() {
+			source := `:leveloffset: +1
+
+== section 1`
+			expected := `<div class="sect2">
+<h3 id="_section_1">section 1</h3>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("section level 1 alone with roles and id", func() {
 			source := "[.role1#anchor.role2]\n== a title with *bold* content"
 			// top-level section is not rendered per-say,
@@ -58,6 +73,17 @@ var _ = Describe("sections", func() {
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("section level 1 with trailing anchor and roles", func() {
+			source := "== a title with *bold* content [.role1] [[anchor]] [.role2]"
+			expected := `<div class="sect1 role1 role2">
+<h2 id="anchor">a title with <strong>bold</strong> content</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("section level 2 alone", func() {
 			source := "=== a title"
 			// top-level section is not rendered per-say,
@@ -132,6 +158,17 @@ var _ = Describe("sections", func() {
 <div class="sectionbody">
 </div>
 </div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("section with predefined attribute in title", func() {
+			source := `== a {startsb}section{endsb} title`
+			expected := `<div class="sect1">
+<h2 id="_a_section_title">a [section] title</h2>
+<div class="sectionbody">
+</div>
+</div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
@@ -284,6 +321,130 @@ Listing block content is commonly used to preserve code input.</pre>
 </div>
 </div>
 </div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("sections with first section deeper than next sections", func() {
+			source := `=== Deep
+
+deep content
+
+== A
+
+=== A.1
+
+== B`
+			expected := `<div class="sect2">
+<h3 id="_deep">Deep</h3>
+<div class="paragraph">
+<p>deep content</p>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_a">A</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_a_1">A.1</h3>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_b">B</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("appendices", func() {
+
+		It("should render appendix with default caption", func() {
+			source := `[appendix]
+== Additional content`
+			expected := `<div class="sect1">
+<h2 id="_additional_content">Appendix A: Additional content</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should render appendix without caption", func() {
+			source := `:appendix-caption!:
+
+[appendix]
+== Additional content`
+			expected := `<div class="sect1">
+<h2 id="_additional_content">A. Additional content</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("discrete headings", func() {
+
+		It("should render discrete heading in section", func() {
+			source := `== section 1
+
+[discrete]
+=== a discrete heading
+
+content here`
+			expected := `<div class="sect1">
+<h2 id="_section_1">section 1</h2>
+<div class="sectionbody">
+<h3 id="_a_discrete_heading" class="discrete">a discrete heading</h3>
+<div class="paragraph">
+<p>content here</p>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should render float heading with role", func() {
+			source := `[float.role1]
+== a floating heading
+
+content here`
+			expected := `<h2 id="_a_floating_heading" class="discrete role1">a floating heading</h2>
+<div class="paragraph">
+<p>content here</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should render discrete heading as cross reference target", func() {
+			source := `[discrete]
+== a discrete heading
+
+see <<_a_discrete_heading>>`
+			expected := `<h2 id="_a_discrete_heading" class="discrete">a discrete heading</h2>
+<div class="paragraph">
+<p>see <a href="#_a_discrete_heading">a discrete heading</a></p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("should not render discrete heading with notitle option as cross reference target", func() {
+			source := `[discrete%notitle]
+== a discrete heading
+
+see <<_a_discrete_heading>>`
+			expected := `<h2 id="_a_discrete_heading" class="discrete">a discrete heading</h2>
+<div class="paragraph">
+<p>see <a href="#_a_discrete_heading">[_a_discrete_heading]</a></p>
+</div>
 `
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
@@ -344,4 +505,31 @@ here</p>
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 		})
 	})
+
+	Context("section title decorators", func() {
+
+		It("sections with anchors and edit links", func() {
+			source := `== Section A
+
+=== Section A.1
+
+[discrete]
+== Discrete heading`
+			expected := `<div class="sect1">
+<h2 id="_section_a"><a class="anchor" href="#_section_a"></a>Section A<span class="edit">level 1</span></h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_section_a_1"><a class="anchor" href="#_section_a_1"></a>Section A.1<span class="edit">level 2</span></h3>
+<h2 id="_discrete_heading" class="discrete">Discrete heading</h2>
+</div>
+</div>
+</div>
+`
+			decorator := func(s types.Section) (string, string) {
+				id := s.Attributes.GetAsStringWithDefault(types.AttrID, "")
+				return fmt.Sprintf(`<a class="anchor" href="#%s"></a>`, id), fmt.Sprintf(`<span class="edit">level %d</span>`, s.Level)
+			}
+			Expect(RenderHTML(source, configuration.WithSectionTitleDecorator(decorator))).To(MatchHTML(expected))
+		})
+	})
 })
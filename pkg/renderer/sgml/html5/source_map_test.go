@@ -0,0 +1,114 @@
+package html5_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	. "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("source map", func() {
+
+	It("with top-level blocks", func() {
+		source := `a paragraph
+
+[source,go]
+----
+package foo
+----
+
+== a section
+
+* an item
+* another item
+
+image::foo.png[]`
+		expected := `<div class="paragraph" data-sourceline="1">
+<p>a paragraph</p>
+</div>
+<div class="listingblock" data-sourceline="4">
+<div class="content">
+<pre class="highlight"><code class="language-go" data-lang="go">package foo</code></pre>
+</div>
+</div>
+<div class="sect1" data-sourceline="8">
+<h2 id="_a_section">a section</h2>
+<div class="sectionbody">
+<div class="ulist" data-sourceline="10">
+<ul>
+<li>
+<p>an item</p>
+</li>
+<li>
+<p>another item</p>
+</li>
+</ul>
+</div>
+<div class="imageblock" data-sourceline="13">
+<div class="content">
+<img src="foo.png" alt="foo">
+</div>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithSourceMap(true))).To(MatchHTML(expected))
+	})
+
+	It("with nested blocks", func() {
+		source := `====
+a paragraph
+
+another paragraph
+====`
+		expected := `<div class="exampleblock" data-sourceline="1">
+<div class="content">
+<div class="paragraph">
+<p>a paragraph</p>
+</div>
+<div class="paragraph">
+<p>another paragraph</p>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithSourceMap(true))).To(MatchHTML(expected))
+	})
+
+	It("with file inclusions and conditional inclusions", func() {
+		source := `ifdef::foo[]
+a skipped paragraph
+endif::[]
+a paragraph
+
+include::../../../../test/includes/chapter-a.adoc[leveloffset=+1]
+
+another paragraph`
+		expected := `<div class="paragraph" data-sourceline="4">
+<p>a paragraph</p>
+</div>
+<div class="sect1" data-sourceline="1">
+<h2 id="_chapter_a">Chapter A</h2>
+<div class="sectionbody">
+<div class="paragraph" data-sourceline="3">
+<p>content</p>
+</div>
+<div class="paragraph" data-sourceline="8">
+<p>another paragraph</p>
+</div>
+</div>
+</div>
+`
+		Expect(RenderHTML(source, configuration.WithSourceMap(true))).To(MatchHTML(expected))
+	})
+
+	It("disabled by default", func() {
+		source := `a paragraph`
+		expected := `<div class="paragraph">
+<p>a paragraph</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+})
@@ -72,6 +72,63 @@ var _ = Describe("strings", func() {
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
 
+	It("text with em dash between words", func() {
+		source := `foo--bar`
+		expected := `<div class="paragraph">
+<p>foo&#8212;&#8203;bar</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("text with em dash between spaces", func() {
+		source := "foo -- bar\n-- baz --"
+		expected := `<div class="paragraph">
+<p>foo&#8201;&#8212;&#8201;bar
+&#8201;&#8212;&#8201;baz&#8201;&#8212;&#8201;</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("text with escaped em dash", func() {
+		source := `foo\--bar`
+		expected := `<div class="paragraph">
+<p>foo--bar</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("text with em dash no match", func() {
+		source := `foo--- bar --baz`
+		expected := `<div class="paragraph">
+<p>foo--- bar --baz</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("text with curved quotes and apostrophes", func() {
+		source := "it's \"`double`\" and '`single`'"
+		expected := `<div class="paragraph">
+<p>it&#8217;s &#8220;double&#8221; and &#8216;single&#8217;</p>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("listing block with em dash and apostrophe", func() {
+		source := "----\nit's foo--bar -- baz\n----"
+		expected := `<div class="listingblock">
+<div class="content">
+<pre>it's foo--bar -- baz</pre>
+</div>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
 	It("title with registered", func() {
 		// We will often want to use these symbols in headers.
 		source := `== Registered(R)`
@@ -98,7 +155,7 @@ var _ = Describe("strings", func() {
 	It("title with explicit apostrophe", func() {
 		source := "== It`'s A Wonderful Life"
 		expected := "<div class=\"sect1\">\n" +
-			"<h2 id=\"_it_s_a_wonderful_life\">It&#8217;s A Wonderful Life</h2>\n" +
+			"<h2 id=\"_its_a_wonderful_life\">It&#8217;s A Wonderful Life</h2>\n" +
 			"<div class=\"sectionbody\">\n" +
 			"</div>\n" +
 			"</div>\n"
@@ -108,7 +165,7 @@ var _ = Describe("strings", func() {
 	It("title with explicit apostrophe (unicode)", func() {
 		source := ":unicode:\n\n== It`'s A Wonderful Life"
 		expected := "<div class=\"sect1\">\n" +
-			"<h2 id=\"_it_s_a_wonderful_life\">It\u2019s A Wonderful Life</h2>\n" +
+			"<h2 id=\"_its_a_wonderful_life\">It\u2019s A Wonderful Life</h2>\n" +
 			"<div class=\"sectionbody\">\n" +
 			"</div>\n" +
 			"</div>\n"
@@ -118,7 +175,7 @@ var _ = Describe("strings", func() {
 	It("title with implicit apostrophe", func() {
 		source := "== It's A Wonderful Life"
 		expected := "<div class=\"sect1\">\n" +
-			"<h2 id=\"_it_s_a_wonderful_life\">It&#8217;s A Wonderful Life</h2>\n" +
+			"<h2 id=\"_its_a_wonderful_life\">It&#8217;s A Wonderful Life</h2>\n" +
 			"<div class=\"sectionbody\">\n" +
 			"</div>\n" +
 			"</div>\n"
@@ -128,7 +185,7 @@ var _ = Describe("strings", func() {
 	It("title with implicit apostrophe (unicode)", func() {
 		source := ":unicode:\n\n== It's A Wonderful Life"
 		expected := "<div class=\"sect1\">\n" +
-			"<h2 id=\"_it_s_a_wonderful_life\">It\u2019s A Wonderful Life</h2>\n" +
+			"<h2 id=\"_its_a_wonderful_life\">It\u2019s A Wonderful Life</h2>\n" +
 			"<div class=\"sectionbody\">\n" +
 			"</div>\n" +
 			"</div>\n"
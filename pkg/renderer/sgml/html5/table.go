@@ -1,33 +1,33 @@
 package html5
 
 const (
-	tableTmpl = "<table class=\"tableblock" +
-		" frame-{{ .Frame }} grid-{{ .Grid }}" +
-		"{{ if .Stripes }} stripes-{{ .Stripes }}{{ end }}" +
-		"{{ if .Fit }} {{ .Fit }}{{ end }}" +
-		"{{ if .Float }} {{ .Float }}{{ end }}" +
-		"{{ if .Roles }} {{ .Roles }}{{ end }}\"" +
-		"{{ if .Width }} style=\"width: {{ .Width }}%;\"{{ end }}" +
-		">\n" +
+	tableTmpl = `<table{{ attributes "id" .ID ` +
+		`"class" (classes "tableblock" (print "frame-" .Frame) (print "grid-" .Grid) (and .Stripes (print "stripes-" .Stripes)) .Fit .Float .Roles) ` +
+		`"style" (and .Width (printf "width: %d%%;" .Width)) }}>` + "\n" +
 		"{{ if .Title }}<caption class=\"title\">{{ .Caption }}{{ .Title }}</caption>\n{{ end }}" +
-		"{{ if .Body }}" +
+		"{{ if or .Header .Body .Footer }}" +
 		"<colgroup>\n" +
 		"{{ range $i, $w := .Columns }}<col" +
-		"{{ if $w.Width }} style=\"width: {{ $w.Width }}%;\"{{ end }}" +
+		`{{ attributes "style" (and $w.Width (printf "width: %s%%;" $w.Width)) }}` +
 		">\n{{ end}}" +
 		"</colgroup>\n" +
+		"{{ end }}" +
 		"{{ .Header }}" +
 		"{{ .Body }}" +
-		"{{ end }}" +
+		"{{ .Footer }}" +
 		"</table>\n"
 
 	tableBodyTmpl = "{{ if .Content }}<tbody>\n{{ .Content }}</tbody>\n{{ end }}"
 
+	tableFooterTmpl = "{{ if .Content }}<tfoot>\n{{ .Content }}</tfoot>\n{{ end }}"
+
 	tableHeaderTmpl = "{{ if .Content }}<thead>\n<tr>\n{{ .Content }}</tr>\n</thead>\n{{ end }}"
 
 	tableRowTmpl = "<tr>\n{{ .Content }}</tr>\n"
 
 	tableHeaderCellTmpl = "<th class=\"tableblock halign-{{ .HAlign }} valign-{{ .VAlign }}\">{{ .Content }}</th>\n"
 
-	tableCellTmpl = "<td class=\"tableblock halign-{{ .HAlign }} valign-{{ .VAlign }}\"><p class=\"tableblock\">{{ .Content }}</p></td>\n"
+	tableCellTmpl = "<td class=\"tableblock halign-{{ .HAlign }} valign-{{ .VAlign }}\">" +
+		"{{ if .AsciiDoc }}<div class=\"content\">{{ .Content }}</div>" +
+		"{{ else }}<p class=\"tableblock\">{{ .Content }}</p>{{ end }}</td>\n"
 )
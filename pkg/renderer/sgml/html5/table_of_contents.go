@@ -2,11 +2,11 @@ package html5
 
 const (
 	tocRootTmpl = "<div id=\"toc\" class=\"toc\">\n" +
-		"<div id=\"toctitle\">Table of Contents</div>\n" +
-		"{{ . }}" +
+		"<div id=\"toctitle\">{{ .Title }}</div>\n" +
+		"{{ .Content }}" +
 		"</div>\n"
 
-	tocSectionTmpl = "<ul class=\"sectlevel{{ .Level }}\">\n{{ .Content }}</ul>\n"
+	tocSectionTmpl = `<ul{{ attributes "class" (print "sectlevel" .Level) }}>` + "\n{{ .Content }}</ul>\n"
 
 	tocEntryTmpl = "<li><a href=\"#{{ .ID }}\">{{ .Title }}</a>" +
 		"{{ if .Content }}\n{{ .Content }}{{ end }}</li>\n"
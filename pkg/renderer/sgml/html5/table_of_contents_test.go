@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/ginkgo" //nolint golint
 	. "github.com/onsi/gomega" //nolint golint
+	log "github.com/sirupsen/logrus"
 )
 
 var _ = Describe("document toc", func() {
@@ -189,6 +190,616 @@ level 1 sections not exists.`
 			Expect(RenderHTML(source)).To(MatchHTML(expected))
 
 		})
+
+		It("toc reset in header", func() {
+			source := `= A title
+:toc:
+:toc!:
+
+== Section A`
+			expected := `<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("toc set in body", func() {
+			source := `= A title
+
+:toc:
+
+== Section A`
+			expected := `<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("toc with discrete headings", func() {
+			source := `= A title
+:toc:
+
+== Section A
+
+[discrete]
+=== Heading not in ToC
+
+[discrete%toc]
+=== Heading in ToC`
+
+			expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_section_a">Section A</a>
+<ul class="sectlevel2">
+<li><a href="#_heading_in_toc">Heading in ToC</a></li>
+</ul>
+</li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+<h3 id="_heading_not_in_toc" class="discrete">Heading not in ToC</h3>
+<h3 id="_heading_in_toc" class="discrete">Heading in ToC</h3>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("toc with numbered sections", func() {
+			// the sections are numbered up to `sectnumlevels`, regardless of the levels displayed in the ToC
+			source := `= A title
+:toc:
+:toclevels: 1
+:sectnums:
+:sectnumlevels: 2
+
+== Section A
+
+=== Section A.1
+
+==== Section A.1.1
+
+[appendix]
+== Appendix
+
+=== Section in appendix`
+
+			expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_section_a">1. Section A</a></li>
+<li><a href="#_appendix">Appendix A: Appendix</a></li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="_section_a">1. Section A</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_section_a_1">1.1. Section A.1</h3>
+<div class="sect3">
+<h4 id="_section_a_1_1">Section A.1.1</h4>
+</div>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_appendix">Appendix A: Appendix</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_section_in_appendix">A.1. Section in appendix</h3>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("numbered sections with invalid sectnumlevels", func() {
+			logs, reset := ConfigureLogger(log.WarnLevel)
+			defer reset()
+			source := `= A title
+:sectnums:
+:sectnumlevels: two
+
+== Section A
+
+=== Section A.1
+
+==== Section A.1.1
+
+===== Section A.1.1.1`
+
+			expected := `<div class="sect1">
+<h2 id="_section_a">1. Section A</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_section_a_1">1.1. Section A.1</h3>
+<div class="sect3">
+<h4 id="_section_a_1_1">1.1.1. Section A.1.1</h4>
+<div class="sect4">
+<h5 id="_section_a_1_1_1">Section A.1.1.1</h5>
+</div>
+</div>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+			Expect(logs).To(ContainMessageWithLevel(log.WarnLevel, "invalid value of the 'sectnumlevels' attribute: 'two', using the default value (3)"))
+		})
+
+		It("toc with custom ids with special characters", func() {
+			source := `= A title
+:toc:
+
+[[section a]]
+== Section A
+
+[#section<b>]
+== Section B`
+
+			expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#section_a">Section A</a></li>
+<li><a href="#section%3Cb%3E">Section B</a></li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="section_a">Section A</h2>
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="section&lt;b&gt;">Section B</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("toc with custom title and appendices", func() {
+			source := `= A title
+:toc:
+:toc-title: Sommaire
+:appendix-caption: Annexe
+
+== Section A
+
+[appendix]
+== First Appendix
+
+[appendix]
+== Second Appendix`
+
+			expected := `<div id="toc" class="toc">
+<div id="toctitle">Sommaire</div>
+<ul class="sectlevel1">
+<li><a href="#_section_a">Section A</a></li>
+<li><a href="#_first_appendix">Annexe A: First Appendix</a></li>
+<li><a href="#_second_appendix">Annexe B: Second Appendix</a></li>
+</ul>
+</div>
+<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="_first_appendix">Annexe A: First Appendix</h2>
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="_second_appendix">Annexe B: Second Appendix</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("document with toc macro", func() {
+
+		It("toc macro with default title and levels", func() {
+			source := `= A title
+:toc: macro
+
+a preamble
+
+toc::[]
+
+== Section A
+
+=== Section A.a`
+
+			expected := `<div id="preamble">
+<div class="sectionbody">
+<div class="paragraph">
+<p>a preamble</p>
+</div>
+<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_section_a">Section A</a>
+<ul class="sectlevel2">
+<li><a href="#_section_a_a">Section A.a</a></li>
+</ul>
+</li>
+</ul>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_section_a_a">Section A.a</h3>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("toc macro with custom title and levels", func() {
+			source := `= A title
+:toc: macro
+:toc-title: Sommaire
+
+toc::[title="Contents", levels=1]
+
+== Section A
+
+=== Section A.a`
+
+			expected := `<div id="preamble">
+<div class="sectionbody">
+<div id="toc" class="toc">
+<div id="toctitle">Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_section_a">Section A</a></li>
+</ul>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_section_a_a">Section A.a</h3>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("toc macro with block title and levels beyond toclevels", func() {
+			source := `= A title
+:toc: macro
+:toclevels: 1
+
+.Summary
+toc::[levels=3]
+
+== Section A
+
+=== Section A.a
+
+==== Section A.a.a`
+
+			expected := `<div id="preamble">
+<div class="sectionbody">
+<div id="toc" class="toc">
+<div id="toctitle">Summary</div>
+<ul class="sectlevel1">
+<li><a href="#_section_a">Section A</a>
+<ul class="sectlevel2">
+<li><a href="#_section_a_a">Section A.a</a>
+<ul class="sectlevel3">
+<li><a href="#_section_a_a_a">Section A.a.a</a></li>
+</ul>
+</li>
+</ul>
+</li>
+</ul>
+</div>
+</div>
+</div>
+<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+<div class="sect2">
+<h3 id="_section_a_a">Section A.a</h3>
+<div class="sect3">
+<h4 id="_section_a_a_a">Section A.a.a</h4>
+</div>
+</div>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("toc macro ignored when toc is not set to macro", func() {
+			source := `= A title
+:toc:
+
+toc::[title="Contents"]
+
+== Section A`
+
+			expected := `<div id="toc" class="toc">
+<div id="toctitle">Table of Contents</div>
+<ul class="sectlevel1">
+<li><a href="#_section_a">Section A</a></li>
+</ul>
+</div>
+<div id="preamble">
+<div class="sectionbody">
+</div>
+</div>
+<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
+	Context("document with lists of figures, tables and listings", func() {
+
+		It("list of figures with default title", func() {
+			source := `toc::figures[]
+
+.A cookie
+image::cookie.png[]
+
+[#another-cookie]
+.Another cookie
+image::another-cookie.png[]
+
+image::untitled-cookie.png[]`
+
+			expected := `<div class="toc list-of-figures">
+<div class="title">List of Figures</div>
+<ul>
+<li><a href="#_figure_1">Figure 1. A cookie</a></li>
+<li><a href="#another-cookie">Figure 2. Another cookie</a></li>
+</ul>
+</div>
+<div id="_figure_1" class="imageblock">
+<div class="content">
+<img src="cookie.png" alt="cookie">
+</div>
+<div class="title">Figure 1. A cookie</div>
+</div>
+<div id="another-cookie" class="imageblock">
+<div class="content">
+<img src="another-cookie.png" alt="another-cookie">
+</div>
+<div class="title">Figure 2. Another cookie</div>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="untitled-cookie.png" alt="untitled-cookie">
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("list of tables with custom title and caption", func() {
+			source := `:table-caption: Tableau
+
+== Section A
+
+.Tables
+toc::tables[]
+
+.First table
+|===
+| a
+|===
+
+[caption="Custom table: "]
+.Second table
+|===
+| b
+|===`
+
+			expected := `<div class="sect1">
+<h2 id="_section_a">Section A</h2>
+<div class="sectionbody">
+<div class="toc list-of-tables">
+<div class="title">Tables</div>
+<ul>
+<li><a href="#_table_1">Tableau 1. First table</a></li>
+<li><a href="#_table_2">Custom table: Second table</a></li>
+</ul>
+</div>
+<table id="_table_1" class="tableblock frame-all grid-all stretch">
+<caption class="title">Tableau 1. First table</caption>
+<colgroup>
+<col style="width: 100%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+</tr>
+</tbody>
+</table>
+<table id="_table_2" class="tableblock frame-all grid-all stretch">
+<caption class="title">Custom table: Second table</caption>
+<colgroup>
+<col style="width: 100%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">b</p></td>
+</tr>
+</tbody>
+</table>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("list of tables with custom id with special characters", func() {
+			source := `.A "table"
+[#table<1>]
+|===
+| foo
+|===
+
+toc::tables[]`
+
+			expected := `<table id="table&lt;1&gt;" class="tableblock frame-all grid-all stretch">
+<caption class="title">Table 1. A &#34;table&#34;</caption>
+<colgroup>
+<col style="width: 100%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">foo</p></td>
+</tr>
+</tbody>
+</table>
+<div class="toc list-of-tables">
+<div class="title">List of Tables</div>
+<ul>
+<li><a href="#table%3C1%3E">Table 1. A &#34;table&#34;</a></li>
+</ul>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("list of listings with caption", func() {
+			source := `:listing-caption: Listing
+
+toc::listings[]
+
+.A listing
+----
+some content
+----
+
+[source,go]
+[#main]
+.The main function
+----
+func main() {}
+----
+
+[source]
+.A source paragraph
+some source
+
+----
+untitled listing
+----`
+
+			expected := `<div class="toc list-of-listings">
+<div class="title">List of Listings</div>
+<ul>
+<li><a href="#_listing_1">Listing 1. A listing</a></li>
+<li><a href="#main">Listing 2. The main function</a></li>
+<li><a href="#_listing_3">Listing 3. A source paragraph</a></li>
+</ul>
+</div>
+<div id="_listing_1" class="listingblock">
+<div class="title">Listing 1. A listing</div>
+<div class="content">
+<pre>some content</pre>
+</div>
+</div>
+<div id="main" class="listingblock">
+<div class="title">Listing 2. The main function</div>
+<div class="content">
+<pre class="highlight"><code class="language-go" data-lang="go">func main() {}</code></pre>
+</div>
+</div>
+<div id="_listing_3" class="listingblock">
+<div class="title">Listing 3. A source paragraph</div>
+<div class="content">
+<pre class="highlight"><code>some source</code></pre>
+</div>
+</div>
+<div class="listingblock">
+<div class="content">
+<pre>untitled listing</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("list of listings without caption", func() {
+			source := `.Listings
+toc::listings[]
+
+.A listing
+----
+some content
+----`
+
+			expected := `<div class="toc list-of-listings">
+<div class="title">Listings</div>
+<ul>
+<li><a href="#_listing_1">A listing</a></li>
+</ul>
+</div>
+<div id="_listing_1" class="listingblock">
+<div class="title">A listing</div>
+<div class="content">
+<pre>some content</pre>
+</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("no generated IDs without list", func() {
+			source := `.A cookie
+image::cookie.png[]`
+
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="cookie.png" alt="cookie">
+</div>
+<div class="title">Figure 1. A cookie</div>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("empty list of figures", func() {
+			source := `toc::figures[]
+
+a paragraph`
+
+			expected := `<div class="paragraph">
+<p>a paragraph</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
 	})
 })
 
@@ -198,7 +809,7 @@ var _ = Describe("table of contents initialization", func() {
 
 		It("should return empty table of contents when doc has no section", func() {
 			actual := types.Document{
-				Attributes:        types.Attributes{},
+				Attributes:        types.DocumentAttributes{},
 				ElementReferences: types.ElementReferences{},
 				Footnotes:         []types.Footnote{},
 				Elements: []interface{}{
@@ -251,7 +862,7 @@ var _ = Describe("table of contents initialization", func() {
 			types.StringElement{Content: "Section B"},
 		}
 		document := types.Document{
-			Attributes: types.Attributes{},
+			Attributes: types.DocumentAttributes{},
 			ElementReferences: types.ElementReferences{
 				"_a_header":    doctitle,
 				"_section_a":   sectionATitle,
@@ -344,7 +955,7 @@ var _ = Describe("table of contents initialization", func() {
 		}
 
 		It("should return table of contents with section level 1,2,3,2 with default level", func() {
-			delete(document.Attributes, types.AttrTableOfContentsLevels)
+			document.Attributes = types.DocumentAttributes{}
 			expected := types.TableOfContents{
 				Sections: []types.ToCSection{
 					{
@@ -373,7 +984,7 @@ var _ = Describe("table of contents initialization", func() {
 		})
 
 		It("should return table of contents with section level 1,2,3,2 with custom level", func() {
-			document.Attributes[types.AttrTableOfContentsLevels] = "4" // must be a string
+			document.Attributes = document.Attributes.Set(types.AttrTableOfContentsLevels, "4") // must be a string
 			expected := types.TableOfContents{
 				Sections: []types.ToCSection{
 					{
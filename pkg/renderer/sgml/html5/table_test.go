@@ -321,6 +321,110 @@ var _ = Describe("tables", func() {
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
 
+	It("stripes from document attribute", func() {
+		source := ":table-stripes: odd\n\n|===\n|==="
+		expected := `<table class="tableblock frame-all grid-all stripes-odd stretch">
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("stripes overriding document attribute", func() {
+		source := ":table-stripes: odd\n\n[stripes=even]\n|===\n|==="
+		expected := `<table class="tableblock frame-all grid-all stripes-even stretch">
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table with header and footer options", func() {
+		source := "[options=\"header,footer\"]\n|===\n|h1|h2\n|a|b\n|f1|f2\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">h1</th>
+<th class="tableblock halign-left valign-top">h2</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">b</p></td>
+</tr>
+</tbody>
+<tfoot>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">f1</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">f2</p></td>
+</tr>
+</tfoot>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table with header only", func() {
+		source := "[%header]\n|===\n|h1|h2\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">h1</th>
+<th class="tableblock halign-left valign-top">h2</th>
+</tr>
+</thead>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table with footer only", func() {
+		source := "[%footer]\n|===\n|f1|f2\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<tfoot>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">f1</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">f2</p></td>
+</tr>
+</tfoot>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table with noheader option", func() {
+		source := "[%noheader]\n|===\n|a|b\n\n|c|d\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">b</p></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">c</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">d</p></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
 	It("table with cols relative widths", func() {
 		source := "[cols=\"3,2,5\"]\n|===\n|one|two|three\n|==="
 		expected := `<table class="tableblock frame-all grid-all stretch">
@@ -341,6 +445,62 @@ var _ = Describe("tables", func() {
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
 
+	It("autowidth (options attribute)", func() {
+		source := "[options=\"autowidth\"]\n|===\n|one|two\n|==="
+		expected := `<table class="tableblock frame-all grid-all fit-content">
+<colgroup>
+<col>
+<col>
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">one</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">two</p></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table with truncated cols relative widths", func() {
+		source := "[cols=\"2,1\"]\n|===\n|one|two\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 66.6666%;">
+<col style="width: 33.3334%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">one</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">two</p></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table with implicit equal cols widths", func() {
+		source := "|===\n|one|two|three\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 33.3333%;">
+<col style="width: 33.3333%;">
+<col style="width: 33.3334%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">one</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">two</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">three</p></td>
+</tr>
+</tbody>
+</table>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
 	It("table with cols relative widths and header", func() {
 		source := "[cols=\"3,2,5\"]\n|===\n|h1|h2|h3\n\n|one|two|three\n|==="
 		expected := `<table class="tableblock frame-all grid-all stretch">
@@ -490,5 +650,107 @@ var _ = Describe("tables", func() {
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
 
+	Context("asciidoc cells", func() {
+
+		It("with list in cell of asciidoc column", func() {
+			source := "[cols=\"1,2a\"]\n|===\n|foo|* item\n|==="
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 33.3333%;">
+<col style="width: 66.6667%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">foo</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="ulist">
+<ul>
+<li>
+<p>item</p>
+</li>
+</ul>
+</div></div></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with multiple blocks in cell spanning multiple lines", func() {
+			source := `|===
+|foo a|
+* item 1
+* item 2
+
+another paragraph
+|bar |baz
+|===`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">foo</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="ulist">
+<ul>
+<li>
+<p>item 1</p>
+</li>
+<li>
+<p>item 2</p>
+</li>
+</ul>
+</div>
+<div class="paragraph">
+<p>another paragraph</p>
+</div></div></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">bar</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">baz</p></td>
+</tr>
+</tbody>
+</table>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with inherited and isolated attributes", func() {
+			source := `:name: John
+
+|===
+|foo a|:name: Jane
+|bar a|{name}
+|===
+
+{name}`
+			expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;">
+<col style="width: 50%;">
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">foo</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"></div></td>
+</tr>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">bar</p></td>
+<td class="tableblock halign-left valign-top"><div class="content"><div class="paragraph">
+<p>John</p>
+</div></div></td>
+</tr>
+</tbody>
+</table>
+<div class="paragraph">
+<p>John</p>
+</div>
+`
+			Expect(RenderHTML(source)).To(MatchHTML(expected))
+		})
+	})
+
 	// TODO: Verify styles -- it's verified in the parser for now, but we still need to implement styles.
 })
@@ -19,7 +19,11 @@ var templates = sgml.Templates{
 	CalloutList:               calloutListTmpl,
 	CalloutListItem:           calloutListItemTmpl,
 	CalloutRef:                calloutRefTmpl,
+	CaptionList:               captionListTmpl,
+	CaptionListEntry:          captionListEntryTmpl,
+	CollapsibleBlock:          collapsibleBlockTmpl,
 	DelimitedBlockParagraph:   delimitedBlockParagraphTmpl,
+	DiscreteHeading:           discreteHeadingTmpl,
 	DocumentDetails:           documentDetailsTmpl,
 	DocumentAuthorDetails:     documentAuthorDetailsTmpl,
 	ExternalCrossReference:    externalCrossReferenceTmpl,
@@ -33,6 +37,7 @@ var templates = sgml.Templates{
 	IconFont:                  iconFontTmpl,
 	IconImage:                 iconImageTmpl,
 	IconText:                  iconTextTmpl,
+	InlineAnchor:              inlineAnchorTmpl,
 	InlineIcon:                inlineIconTmpl,
 	InlineImage:               inlineImageTmpl,
 	InternalCrossReference:    internalCrossReferenceTmpl,
@@ -65,6 +70,7 @@ var templates = sgml.Templates{
 	SectionHeader:             sectionHeaderTmpl,
 	SidebarBlock:              sidebarBlockTmpl,
 	SourceBlock:               sourceBlockTmpl,
+	SourceLine:                sourceLineTmpl,
 	SpecialCharacter:          specialCharacterTmpl,
 	StringElement:             stringTmpl,
 	SubscriptText:             subscriptTextTmpl,
@@ -72,6 +78,7 @@ var templates = sgml.Templates{
 	Table:                     tableTmpl,
 	TableBody:                 tableBodyTmpl,
 	TableCell:                 tableCellTmpl,
+	TableFooter:               tableFooterTmpl,
 	TableHeader:               tableHeaderTmpl,
 	TableHeaderCell:           tableHeaderCellTmpl,
 	TableRow:                  tableRowTmpl,
@@ -99,6 +106,12 @@ func Render(ctx *renderer.Context, doc types.Document, output io.Writer) (types.
 	return defaultRenderer.Render(ctx, doc, output)
 }
 
+// RenderElement renders a single block or inline element in HTML5, using a default instance
+// of the renderer, with default templates.
+func RenderElement(ctx *renderer.Context, element interface{}) (string, error) {
+	return defaultRenderer.(sgml.ElementRenderer).RenderElement(ctx, element)
+}
+
 // Templates returns the default Templates use for HTML5.  It may be useful
 // for derived implementations.
 func Templates() sgml.Templates {
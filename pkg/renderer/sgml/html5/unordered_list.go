@@ -1,13 +1,9 @@
 package html5
 
 const (
-	unorderedListTmpl = `<div{{ if .ID }} id="{{ .ID }}"{{ end }}` +
-		` class="ulist{{ if .Checklist }} checklist{{ end }}` +
-		`{{ if .Style }} {{ .Style }}{{ end }}` +
-		`{{ if .Roles }} {{ .Roles }}{{ end }}"` +
-		">\n" +
+	unorderedListTmpl = `<div{{ attributes "id" .ID "class" (classes "ulist" (and .Checklist "checklist") .Style .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
-		"<ul{{ if .Checklist }} class=\"checklist\"{{ else if .Style }} class=\"{{ .Style }}\"{{ end }}>\n" +
+		`<ul{{ attributes "class" (or (and .Checklist "checklist") .Style) }}>` + "\n" +
 		"{{ .Content }}</ul>\n</div>\n"
 
 	unorderedListItemTmpl = "<li>\n{{ .Content }}</li>\n"
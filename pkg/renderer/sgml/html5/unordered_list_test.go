@@ -56,6 +56,43 @@ and a standalone paragraph`
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
 
+	It("unordered list with mixed markers", func() {
+		source := `* item 1
+- item 1.1
+** item 1.1.1
+- item 1.2
+* item 2`
+		expected := `<div class="ulist">
+<ul>
+<li>
+<p>item 1</p>
+<div class="ulist">
+<ul>
+<li>
+<p>item 1.1</p>
+<div class="ulist">
+<ul>
+<li>
+<p>item 1.1.1</p>
+</li>
+</ul>
+</div>
+</li>
+<li>
+<p>item 1.2</p>
+</li>
+</ul>
+</div>
+</li>
+<li>
+<p>item 2</p>
+</li>
+</ul>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
 	It("simple unordered list with id, title and role", func() {
 		source := `.mytitle
 [#foo]
@@ -285,6 +322,88 @@ another delimited block
 <pre>another delimited block</pre>
 </div>
 </div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("unordered list with indented listing and fenced blocks", func() {
+		source := `* foo
+  ----
+  a listing block
+    with indentation
+  ----
+* bar
++
+    ` + "```" + `
+    a fenced block
+      with indentation
+    ` + "```" + `
+`
+		expected := `<div class="ulist">
+<ul>
+<li>
+<p>foo</p>
+<div class="listingblock">
+<div class="content">
+<pre>a listing block
+  with indentation</pre>
+</div>
+</div>
+</li>
+<li>
+<p>bar</p>
+<div class="listingblock">
+<div class="content">
+<pre class="highlight"><code>a fenced block
+  with indentation</code></pre>
+</div>
+</div>
+</li>
+</ul>
+</div>
+`
+		Expect(RenderHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("unordered list item with continued admonition, image and listing", func() {
+		source := `* item
++
+NOTE: a note
++
+image::foo.png[]
++
+----
+a listing
+----`
+		expected := `<div class="ulist">
+<ul>
+<li>
+<p>item</p>
+<div class="admonitionblock note">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Note</div>
+</td>
+<td class="content">
+a note
+</td>
+</tr>
+</table>
+</div>
+<div class="imageblock">
+<div class="content">
+<img src="foo.png" alt="foo">
+</div>
+</div>
+<div class="listingblock">
+<div class="content">
+<pre>a listing</pre>
+</div>
+</div>
+</li>
+</ul>
+</div>
 `
 		Expect(RenderHTML(source)).To(MatchHTML(expected))
 	})
@@ -0,0 +1,94 @@
+package sgml
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// HTMLAttributes writes the given HTML attributes (as a sequence of name/value pairs) in a stable order:
+//
+// 1. `id`
+// 2. `class`
+// 3. `style`
+// 4. the other attributes, in the given order
+// 5. the `data-*` attributes, sorted by name
+//
+// Each attribute is written with a leading space (eg: ` id="foo" class="bar"`), so the result can be inserted
+// right after the tag name in the templates. Attributes with a zero value (eg: `nil`, an empty string or `0`) are skipped,
+// and the values are written as-is (ie, they are expected to be already escaped).
+func HTMLAttributes(nameValues ...interface{}) (string, error) {
+	if len(nameValues)%2 != 0 {
+		return "", fmt.Errorf("odd number of arguments: %d", len(nameValues))
+	}
+	attrs := make([]htmlAttribute, 0, len(nameValues)/2)
+	for i := 0; i < len(nameValues); i += 2 {
+		name, ok := nameValues[i].(string)
+		if !ok {
+			return "", fmt.Errorf("invalid attribute name: '%v' (%T)", nameValues[i], nameValues[i])
+		}
+		if v := reflect.ValueOf(nameValues[i+1]); !v.IsValid() || v.IsZero() {
+			continue
+		}
+		attrs = append(attrs, htmlAttribute{
+			name:  name,
+			value: fmt.Sprintf("%v", nameValues[i+1]),
+		})
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		ri, rj := attrs[i].rank(), attrs[j].rank()
+		if ri != rj {
+			return ri < rj
+		}
+		if ri == dataAttributeRank {
+			return attrs[i].name < attrs[j].name
+		}
+		return false // keep the given order
+	})
+	result := &strings.Builder{}
+	for _, attr := range attrs {
+		result.WriteString(" ")
+		result.WriteString(attr.name)
+		result.WriteString(`="`)
+		result.WriteString(attr.value)
+		result.WriteString(`"`)
+	}
+	return result.String(), nil
+}
+
+type htmlAttribute struct {
+	name  string
+	value string
+}
+
+const dataAttributeRank = 4
+
+func (a htmlAttribute) rank() int {
+	switch {
+	case a.name == "id":
+		return 0
+	case a.name == "class":
+		return 1
+	case a.name == "style":
+		return 2
+	case strings.HasPrefix(a.name, "data-"):
+		return dataAttributeRank
+	default:
+		return 3
+	}
+}
+
+// HTMLClasses joins the given class names (or groups of class names) with a single space, skipping the ones with a zero value.
+// It is meant to be used along with `HTMLAttributes` in the templates, to compute the value of the `class` attribute
+// (eg: `{{ attributes "class" (classes "paragraph" .Roles) }}`)
+func HTMLClasses(classes ...interface{}) string {
+	result := make([]string, 0, len(classes))
+	for _, c := range classes {
+		if v := reflect.ValueOf(c); !v.IsValid() || v.IsZero() {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%v", c))
+	}
+	return strings.Join(result, " ")
+}
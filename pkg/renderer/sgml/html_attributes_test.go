@@ -0,0 +1,47 @@
+package sgml_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml"
+
+	. "github.com/onsi/ginkgo"                  //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
+)
+
+var _ = DescribeTable("html attributes",
+	func(nameValues []interface{}, expected string) {
+		Expect(sgml.HTMLAttributes(nameValues...)).To(Equal(expected))
+	},
+	Entry("none", []interface{}{}, ""),
+	Entry("id and class", []interface{}{"id", "foo", "class", "bar"}, ` id="foo" class="bar"`),
+	Entry("class before id", []interface{}{"class", "bar", "id", "foo"}, ` id="foo" class="bar"`),
+	Entry("data attributes last, sorted by name",
+		[]interface{}{"data-lang", "go", "style", "width: 50%;", "data-foo", 1, "target", "_blank", "class", "bar"},
+		` class="bar" style="width: 50%;" target="_blank" data-foo="1" data-lang="go"`),
+	Entry("other attributes in the given order",
+		[]interface{}{"width", 10, "height", 20, "title", "cookie"},
+		` width="10" height="20" title="cookie"`),
+	Entry("skip empty values", []interface{}{"id", "", "class", nil, "start", 0, "reversed", false, "data-lang", "go"}, ` data-lang="go"`),
+)
+
+var _ = Describe("html attributes errors", func() {
+
+	It("should fail with odd number of arguments", func() {
+		_, err := sgml.HTMLAttributes("id", "foo", "class")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail with invalid attribute name", func() {
+		_, err := sgml.HTMLAttributes(1, "foo")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = DescribeTable("html classes",
+	func(classes []interface{}, expected string) {
+		Expect(sgml.HTMLClasses(classes...)).To(Equal(expected))
+	},
+	Entry("none", []interface{}{}, ""),
+	Entry("single", []interface{}{"paragraph"}, "paragraph"),
+	Entry("multiple with empty values", []interface{}{"ulist", false, "", "checklist", nil, "role1 role2"}, "ulist checklist role1 role2"),
+)
@@ -1,13 +1,13 @@
 package sgml
 
 import (
-	fmt "fmt"
+	"fmt"
 	"path"
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderInlineIcon(ctx *renderer.Context, icon types.Icon) (string, error) {
@@ -20,6 +20,10 @@ func (r *sgmlRenderer) renderInlineIcon(ctx *renderer.Context, icon types.Icon)
 	if err != nil {
 		return "", err
 	}
+	roles, err := r.renderInlineRoles(ctx, icon.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("unable to render inline icon: %w", err)
+	}
 	err = r.inlineIcon.Execute(result, struct {
 		Class  string
 		Role   string
@@ -31,13 +35,13 @@ func (r *sgmlRenderer) renderInlineIcon(ctx *renderer.Context, icon types.Icon)
 		Class:  icon.Class,
 		Icon:   iconStr,
 		ID:     r.renderElementID(icon.Attributes),
-		Link:   icon.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""),
+		Link:   rewriteURL(ctx, configuration.LinkURL, icon.Attributes.GetAsStringWithDefault(types.AttrInlineLink, "")),
 		Window: icon.Attributes.GetAsStringWithDefault(types.AttrImageWindow, ""),
-		Role:   icon.Attributes.GetAsStringWithDefault(types.AttrRoles, ""),
+		Role:   roles,
 	})
 
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render inline image")
+		return "", fmt.Errorf("unable to render inline image: %w", err)
 	}
 	return result.String(), nil
 }
@@ -51,16 +55,16 @@ var defaultIconClasses = map[string]string{
 }
 
 func (r *sgmlRenderer) renderIcon(ctx *renderer.Context, icon types.Icon, admonition bool) (string, error) {
-	icons := ctx.Attributes.GetAsStringWithDefault("icons", "text")
+	icons := ctx.Attributes.GetAsStringWithDefault(types.AttrIcons, types.IconsText)
 	var template *textTemplate
 	font := false
 	switch icons {
-	case "font":
+	case types.IconsFont:
 		font = true
 		template = r.iconFont
-	case "text":
+	case types.IconsText:
 		template = r.iconText
-	case "image", "":
+	case types.IconsImage, "":
 		template = r.iconImage
 	default:
 		return "", fmt.Errorf("unsupported icon type %s", icons)
@@ -109,9 +113,9 @@ func (r *sgmlRenderer) renderIcon(ctx *renderer.Context, icon types.Icon, admoni
 		Size:       icon.Attributes.GetAsStringWithDefault(types.AttrIconSize, ""),
 		Rotate:     icon.Attributes.GetAsStringWithDefault(types.AttrIconRotate, ""),
 		Flip:       icon.Attributes.GetAsStringWithDefault(types.AttrIconFlip, ""),
-		Link:       icon.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""),
+		Link:       rewriteURL(ctx, configuration.LinkURL, icon.Attributes.GetAsStringWithDefault(types.AttrInlineLink, "")),
 		Window:     icon.Attributes.GetAsStringWithDefault(types.AttrImageWindow, ""),
-		Path:       renderIconPath(ctx, icon.Class),
+		Path:       rewriteURL(ctx, configuration.IconURL, renderIconPath(ctx, icon.Class)),
 		Admonition: admonition,
 	})
 	return string(s.String()), err
@@ -119,10 +123,10 @@ func (r *sgmlRenderer) renderIcon(ctx *renderer.Context, icon types.Icon, admoni
 
 func renderIconPath(ctx *renderer.Context, name string) string {
 	// Icon files by default are in {imagesdir}/icons, where {imagesdir} defaults to "./images"
-	dir := ctx.Attributes.GetAsStringWithDefault("iconsdir",
-		path.Join(ctx.Attributes.GetAsStringWithDefault("imagesdir", "./images"), "icons"))
+	dir := ctx.Attributes.GetAsStringWithDefault(types.AttrIconsDir,
+		path.Join(ctx.Attributes.GetAsStringWithDefault(types.AttrImagesDir, "./images"), "icons"))
 	// TODO: perform attribute substitutions here!
-	ext := ctx.Attributes.GetAsStringWithDefault("icontype", "png")
+	ext := ctx.Attributes.GetAsStringWithDefault(types.AttrIconType, "png")
 
 	return path.Join(dir, name+"."+ext)
 }
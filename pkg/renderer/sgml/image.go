@@ -1,21 +1,21 @@
 package sgml
 
 import (
+	"fmt"
 	"net/url"
 	"path/filepath"
-	"strconv"
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderImageBlock(ctx *renderer.Context, img types.ImageBlock) (string, error) {
 	result := &strings.Builder{}
 	title, err := r.renderElementTitle(img.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	}
 
 	// Matching asciidoctor behavior, we increment the counter if we have a title,
@@ -23,33 +23,26 @@ func (r *sgmlRenderer) renderImageBlock(ctx *renderer.Context, img types.ImageBl
 	// if mixed custom and stock captioning is used.
 	caption := &strings.Builder{}
 	number := 0
+	id := r.renderElementID(img.Attributes)
 	if title != "" {
-		c, found, err := img.Attributes.GetAsString(types.AttrCaption)
+		c, n, err := renderCaption(ctx, img.Attributes, types.AttrFigureCaption, "Figure", "figure-number", ctx.GetAndIncrementImageCounter)
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render image")
-		} else if !found {
-			if c = ctx.Attributes.GetAsStringWithDefault(types.AttrFigureCaption, "Figure"); c != "" {
-				// We always append the figure number, unless the caption is disabled.
-				// This is for asciidoctor compatibility.
-				c += " {counter:figure-number}. "
-			}
-		}
-		if strings.Contains(c, "{counter:figure-number}") {
-			// TODO: Replace this hack when we have attribute substitution fully working
-			number = ctx.GetAndIncrementImageCounter()
-			c = strings.ReplaceAll(c, "{counter:figure-number}", strconv.Itoa(number))
+			return "", fmt.Errorf("unable to render image: %w", err)
 		}
+		number = n
+		id = captionListID(ctx, types.FiguresCaptionList, id)
 		caption.WriteString(c)
 	}
 	roles, err := r.renderImageRoles(ctx, img.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	}
 	path := img.Location.Stringify()
 	alt, err := r.renderImageAlt(img.Attributes, path)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	}
+	width, height := renderImageDimensions(ctx, img.Attributes, path)
 	err = r.blockImage.Execute(result, struct {
 		ID          string
 		Title       string
@@ -60,22 +53,28 @@ func (r *sgmlRenderer) renderImageBlock(ctx *renderer.Context, img types.ImageBl
 		Alt         string
 		Width       string
 		Height      string
+		Srcset      string
+		Sizes       string
+		Loading     string
 		Path        string
 	}{
-		ID:          r.renderElementID(img.Attributes),
+		ID:          id,
 		Title:       title,
 		ImageNumber: number,
 		Caption:     caption.String(),
 		Roles:       roles,
-		Href:        img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""),
+		Href:        EscapeString(rewriteURL(ctx, configuration.LinkURL, img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""))),
 		Alt:         alt,
-		Width:       img.Attributes.GetAsStringWithDefault(types.AttrWidth, ""),
-		Height:      img.Attributes.GetAsStringWithDefault(types.AttrHeight, ""),
-		Path:        path,
+		Width:       width,
+		Height:      height,
+		Srcset:      img.Attributes.GetAsStringWithDefault(types.AttrImageSrcset, ""),
+		Sizes:       img.Attributes.GetAsStringWithDefault(types.AttrImageSizes, ""),
+		Loading:     renderImageLoading(img.Attributes),
+		Path:        rewriteURL(ctx, configuration.ImageURL, path),
 	})
 
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	}
 	return result.String(), nil
 }
@@ -84,38 +83,47 @@ func (r *sgmlRenderer) renderInlineImage(ctx *Context, img types.InlineImage) (s
 	result := &strings.Builder{}
 	roles, err := r.renderImageRoles(ctx, img.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	}
 	path := img.Location.Stringify()
 	alt, err := r.renderImageAlt(img.Attributes, path)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	}
+	width, height := renderImageDimensions(ctx, img.Attributes, path)
 	title, err := r.renderElementTitle(img.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.inlineImage.Execute(result, struct {
-		Roles  string
-		Title  string
-		Href   string
-		Alt    string
-		Width  string
-		Height string
-		Path   string
+		Roles   string
+		Title   string
+		Href    string
+		Window  string
+		Alt     string
+		Width   string
+		Height  string
+		Srcset  string
+		Sizes   string
+		Loading string
+		Path    string
 	}{
-		Title:  title,
-		Roles:  roles,
-		Href:   img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""),
-		Alt:    alt,
-		Width:  img.Attributes.GetAsStringWithDefault(types.AttrWidth, ""),
-		Height: img.Attributes.GetAsStringWithDefault(types.AttrHeight, ""),
-		Path:   path,
+		Title:   title,
+		Roles:   roles,
+		Href:    EscapeString(rewriteURL(ctx, configuration.LinkURL, img.Attributes.GetAsStringWithDefault(types.AttrInlineLink, ""))),
+		Window:  img.Attributes.GetAsStringWithDefault(types.AttrImageWindow, ""),
+		Alt:     alt,
+		Width:   width,
+		Height:  height,
+		Srcset:  img.Attributes.GetAsStringWithDefault(types.AttrImageSrcset, ""),
+		Sizes:   img.Attributes.GetAsStringWithDefault(types.AttrImageSizes, ""),
+		Loading: renderImageLoading(img.Attributes),
+		Path:    rewriteURL(ctx, configuration.ImageURL, path),
 	})
 
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render inline image")
+		return "", fmt.Errorf("unable to render inline image: %w", err)
 	}
 	// log.Debugf("rendered inline image: %s", result.Bytes())
 	return result.String(), nil
@@ -123,14 +131,26 @@ func (r *sgmlRenderer) renderInlineImage(ctx *Context, img types.InlineImage) (s
 
 func (r *sgmlRenderer) renderImageAlt(attrs types.Attributes, path string) (string, error) {
 	if alt, found, err := attrs.GetAsString(types.AttrImageAlt); err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	} else if found {
 		return alt, nil
 	}
 	u, err := url.Parse(path)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render image")
+		return "", fmt.Errorf("unable to render image: %w", err)
 	}
 	// return base path without its extension
 	return strings.TrimSuffix(filepath.Base(u.Path), filepath.Ext(u.Path)), nil
 }
+
+// renderImageLoading returns the value of the `loading` attribute of the image,
+// which can also be set with the `lazy` option
+func renderImageLoading(attrs types.Attributes) string {
+	if loading, found, _ := attrs.GetAsString(types.AttrImageLoading); found {
+		return loading
+	}
+	if attrs.HasOption(types.OptionLazy) {
+		return types.OptionLazy
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package sgml
+
+import (
+	"image"
+	_ "image/gif"  // register the GIF decoder
+	_ "image/jpeg" // register the JPEG decoder
+	_ "image/png"  // register the PNG decoder
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// renderImageDimensions returns the `width` and `height` attributes of the given image. If none of them is set
+// and the `AutoImageDimensions` setting is enabled, then the dimensions are read in the header of the image file.
+// Only the images which are located in the directory of the document (or in its subdirectories) are read,
+// as in the `safe` mode of Asciidoctor.
+func renderImageDimensions(ctx *renderer.Context, attrs types.Attributes, path string) (string, string) {
+	width := attrs.GetAsStringWithDefault(types.AttrWidth, "")
+	height := attrs.GetAsStringWithDefault(types.AttrHeight, "")
+	if width != "" || height != "" || !ctx.Config.AutoImageDimensions {
+		return width, height
+	}
+	filename, ok := localImagePath(ctx, path)
+	if !ok {
+		return width, height
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Warnf("unable to read the dimensions of image '%s': %v", path, err)
+		return width, height
+	}
+	defer f.Close()
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		log.Warnf("unable to read the dimensions of image '%s': %v", path, err)
+		return width, height
+	}
+	return strconv.Itoa(config.Width), strconv.Itoa(config.Height)
+}
+
+// localImagePath returns the path of the file of the given image, relative to the directory of the document,
+// or `false` if the image is remote, embedded or outside of the directory of the document
+func localImagePath(ctx *renderer.Context, path string) (string, bool) {
+	if path == "" || strings.Contains(path, "://") || strings.HasPrefix(path, "data:") || filepath.IsAbs(path) {
+		return "", false
+	}
+	path = filepath.Clean(filepath.FromSlash(path))
+	if path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.Join(filepath.Dir(ctx.Config.Filename), path), true
+}
@@ -0,0 +1,29 @@
+package sgml
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+)
+
+// renderCachedInlineElement returns the content of the given inline element as it was rendered earlier
+// in the same context, or renders it with the given func and retains the result (when the inline cache is enabled)
+func (r *sgmlRenderer) renderCachedInlineElement(ctx *renderer.Context, element interface{}, render func() (string, error)) (string, error) {
+	if !ctx.InlineCacheEnabled() {
+		return render()
+	}
+	// the key also includes the context flags which affect the rendering of the element
+	h := sha256.Sum256([]byte(fmt.Sprintf("%t|%t|%d|%#v", ctx.EncodeSpecialChars, ctx.WithinDelimitedBlock, ctx.WithinList, element)))
+	key := hex.EncodeToString(h[:])
+	if result, found := ctx.CachedInline(key); found {
+		return result, nil
+	}
+	result, err := render()
+	if err != nil {
+		return "", err
+	}
+	ctx.CacheInline(key, result)
+	return result, nil
+}
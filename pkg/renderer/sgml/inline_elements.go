@@ -8,7 +8,8 @@ import (
 )
 
 type linesRenderer struct {
-	render renderFunc
+	render             renderFunc
+	trimTrailingSpaces bool
 }
 
 type renderLinesOption func(config *linesRenderer)
@@ -19,13 +20,21 @@ func (r *sgmlRenderer) withVerbatim() renderLinesOption {
 	}
 }
 
+// withTrailingSpaces keeps the trailing spaces of the line (eg: in verbatim blocks)
+func (r *sgmlRenderer) withTrailingSpaces() renderLinesOption {
+	return func(config *linesRenderer) {
+		config.trimTrailingSpaces = false
+	}
+}
+
 func (r *sgmlRenderer) renderInlineElements(ctx *renderer.Context, elements []interface{}, options ...renderLinesOption) (string, error) {
 	if len(elements) == 0 {
 		return "", nil
 	}
 	// log.Debugf("rendering line with %d element(s)...", len(elements))
 	lr := linesRenderer{
-		render: r.renderElement,
+		render:             r.renderElement,
+		trimTrailingSpaces: true,
 	}
 	for _, apply := range options {
 		apply(&lr)
@@ -37,7 +46,7 @@ func (r *sgmlRenderer) renderInlineElements(ctx *renderer.Context, elements []in
 		if err != nil {
 			return "", err
 		}
-		if i == len(elements)-1 {
+		if i == len(elements)-1 && lr.trimTrailingSpaces {
 			if _, ok := element.(types.StringElement); ok { // TODO: only for StringElement? or for any kind of element?
 				// trim trailing spaces before returning the line
 				buf.WriteString(strings.TrimRight(string(renderedElement), " "))
@@ -1,34 +1,34 @@
 package sgml
 
 import (
+	"fmt"
 	"io"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderLabeledList(ctx *renderer.Context, l types.LabeledList) (string, error) {
 	tmpl, itemTmpl, err := r.getLabeledListTmpl(l)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render labeled list")
+		return "", fmt.Errorf("unable to render labeled list: %w", err)
 	}
 
 	content := &strings.Builder{}
 	cont := false
 	for _, item := range l.Items {
-		if cont, err = r.renderLabeledListItem(ctx, itemTmpl, content, cont, item); err != nil {
-			return "", errors.Wrap(err, "unable to render unordered list")
+		if cont, err = r.renderLabeledListItem(ctx, itemTmpl, content, cont, item, l.Attributes.GetAsStringWithDefault(types.AttrStyle, "")); err != nil {
+			return "", fmt.Errorf("unable to render unordered list: %w", err)
 		}
 	}
 	roles, err := r.renderElementRoles(ctx, l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render labeled list roles")
+		return "", fmt.Errorf("unable to render labeled list roles: %w", err)
 	}
 	title, err := r.renderElementTitle(l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 	result := &strings.Builder{}
 	// here we must preserve the HTML tags
@@ -37,6 +37,7 @@ func (r *sgmlRenderer) renderLabeledList(ctx *renderer.Context, l types.LabeledL
 		ID      string
 		Title   string
 		Roles   string
+		Style   string
 		Content string
 		Items   []types.LabeledListItem
 	}{
@@ -44,11 +45,12 @@ func (r *sgmlRenderer) renderLabeledList(ctx *renderer.Context, l types.LabeledL
 		ID:      r.renderElementID(l.Attributes),
 		Title:   title,
 		Roles:   roles,
+		Style:   l.Attributes.GetAsStringWithDefault(types.AttrStyle, ""),
 		Content: string(content.String()),
 		Items:   l.Items,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render labeled list")
+		return "", fmt.Errorf("unable to render labeled list: %w", err)
 	}
 	return result.String(), nil
 }
@@ -60,31 +62,37 @@ func (r *sgmlRenderer) getLabeledListTmpl(l types.LabeledList) (*textTemplate, *
 			return r.qAndAList, r.qAndAListItem, nil
 		case "horizontal":
 			return r.labeledListHorizontal, r.labeledListHorizontalItem, nil
+		case types.Glossary:
+			return r.labeledList, r.labeledListItem, nil
 		default:
-			return nil, nil, errors.Errorf("unsupported labeled list layout: %s", layout)
+			return nil, nil, fmt.Errorf("unsupported labeled list layout: %s", layout)
 		}
 	}
 	return r.labeledList, r.labeledListItem, nil
 }
 
-func (r *sgmlRenderer) renderLabeledListItem(ctx *renderer.Context, tmpl *textTemplate, w io.Writer, continuation bool, item types.LabeledListItem) (bool, error) {
+func (r *sgmlRenderer) renderLabeledListItem(ctx *renderer.Context, tmpl *textTemplate, w io.Writer, continuation bool, item types.LabeledListItem, style string) (bool, error) {
 
 	term, err := r.renderInlineElements(ctx, item.Term)
 	if err != nil {
-		return false, errors.Wrap(err, "unable to render labeled list term")
+		return false, fmt.Errorf("unable to render labeled list term: %w", err)
 	}
 	content, err := r.renderListElements(ctx, item.Elements)
 	if err != nil {
-		return false, errors.Wrap(err, "unable to render labeled list content")
+		return false, fmt.Errorf("unable to render labeled list content: %w", err)
 	}
 	err = tmpl.Execute(w, struct {
 		Context      *renderer.Context
+		ID           string
 		Term         string
 		Content      string
 		Continuation bool
+		Glossary     bool
 	}{
 		Context:      ctx,
+		ID:           r.renderElementID(item.Attributes),
 		Term:         string(term),
+		Glossary:     style == types.Glossary,
 		Continuation: continuation,
 		Content:      string(content),
 	})
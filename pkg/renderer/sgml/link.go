@@ -1,23 +1,24 @@
 package sgml
 
 import (
+	"fmt"
 	"html"
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderLink(ctx *renderer.Context, l types.InlineLink) (string, error) { //nolint: unparam
 	result := &strings.Builder{}
 	location := l.Location.Stringify()
-	text := ""
-	class := ""
-	roles, err := r.renderElementRoles(ctx, l.Attributes)
-	if err != nil {
-		return "", errors.Wrap(err, "unable to render link")
+	href := location
+	if l.Location.Scheme == "" {
+		href = getRelativeDocumentLocation(ctx, location)
 	}
+	text := ""
+	bare := "" // extra class for the links without text
 	// TODO; support `mailto:` positional attributes
 	if t, exists := l.Attributes[types.AttrInlineLinkText]; exists {
 		switch t := t.(type) {
@@ -26,17 +27,20 @@ func (r *sgmlRenderer) renderLink(ctx *renderer.Context, l types.InlineLink) (st
 		case []interface{}:
 			var err error
 			if text, err = r.renderInlineElements(ctx, t); err != nil {
-				return "", errors.Wrap(err, "unable to render link")
+				return "", fmt.Errorf("unable to render link: %w", err)
 			}
 		}
-		class = roles // can be empty (and it's fine)
 	} else {
-		text = html.EscapeString(location)
-		if len(roles) > 0 {
-			class = "bare " + roles
-		} else {
-			class = "bare"
+		text = location
+		if ctx.Attributes.HasFlag(types.AttrHideURIScheme) {
+			text = strings.TrimPrefix(text, l.Location.Scheme)
 		}
+		text = html.EscapeString(text)
+		bare = "bare"
+	}
+	class, err := r.renderInlineRoles(ctx, l.Attributes, bare)
+	if err != nil {
+		return "", fmt.Errorf("unable to render link: %w", err)
 	}
 	err = r.link.Execute(result, struct {
 		URL    string
@@ -44,14 +48,38 @@ func (r *sgmlRenderer) renderLink(ctx *renderer.Context, l types.InlineLink) (st
 		Class  string
 		Target string
 	}{
-		URL:    location,
+		URL:    rewriteURL(ctx, configuration.LinkURL, href),
 		Text:   text,
 		Class:  class,
 		Target: l.Attributes.GetAsStringWithDefault(types.AttrInlineLinkTarget, ""),
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render link")
+		return "", fmt.Errorf("unable to render link: %w", err)
 	}
 	// log.Debugf("rendered link: %s", result.String())
 	return result.String(), nil
 }
+
+// getRelativeDocumentLocation replaces the `.adoc` extension of the given location with
+// the configured `relfilesuffix`, so that the link points to the rendered document
+func getRelativeDocumentLocation(ctx *renderer.Context, location string) string {
+	path, fragment := location, ""
+	if i := strings.Index(location, "#"); i >= 0 {
+		path, fragment = location[:i], location[i:]
+	}
+	if !strings.HasSuffix(path, ".adoc") {
+		return location
+	}
+	return strings.TrimSuffix(path, ".adoc") + getRelFileSuffix(ctx) + fragment
+}
+
+// rewriteURL applies the configured URL rewriter (if any) on the given non-empty URL
+func rewriteURL(ctx *renderer.Context, kind, url string) string {
+	if kind == configuration.ImageURL || kind == configuration.IconURL {
+		ctx.AddAsset(url)
+	}
+	if ctx.Config.URLRewriter == nil || url == "" {
+		return url
+	}
+	return ctx.Config.URLRewriter(kind, url)
+}
@@ -1,12 +1,11 @@
 package sgml
 
 import (
-	"math"
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderLiteralBlock(ctx *renderer.Context, b types.LiteralBlock) (string, error) {
@@ -15,39 +14,19 @@ func (r *sgmlRenderer) renderLiteralBlock(ctx *renderer.Context, b types.Literal
 	var err error
 	for i, line := range b.Lines {
 		if lines[i], err = r.renderLine(ctx, line); err != nil {
-			return "", errors.Wrap(err, "unable to render literal block")
+			return "", fmt.Errorf("unable to render literal block: %w", err)
 		}
 	}
 	if t, found := b.Attributes[types.AttrLiteralBlockType]; found && t == types.LiteralBlockWithSpacesOnFirstLine {
-		if len(lines) == 1 {
-			lines = []string{strings.TrimLeft(lines[0], " ")}
-		} else {
-			// remove as many spaces as needed on each line
-			spaceCount := 0
-			// first pass to determine the minimum number of spaces to remove
-			for i, line := range lines {
-				l := strings.TrimLeft(line, " ")
-				if i == 0 {
-					spaceCount = len(line) - len(l)
-				} else {
-					spaceCount = int(math.Min(float64(spaceCount), float64(len(line)-len(l))))
-				}
-			}
-			// log.Debugf("trimming %d space(s) on each line", int(spaceCount))
-			// then remove the same number of spaces on each line
-			spaces := strings.Repeat(" ", spaceCount)
-			for i, line := range lines {
-				lines[i] = strings.TrimPrefix(line, spaces)
-			}
-		}
+		lines = types.RemoveCommonIndentation(lines, types.TabSize(b.Attributes))
 	}
 	roles, err := r.renderElementRoles(ctx, b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render literal block roles")
+		return "", fmt.Errorf("unable to render literal block roles: %w", err)
 	}
 	title, err := r.renderElementTitle(b.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	result := &strings.Builder{}
@@ -65,7 +44,7 @@ func (r *sgmlRenderer) renderLiteralBlock(ctx *renderer.Context, b types.Literal
 		Content: strings.Join(lines, "\n"),
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render literal block")
+		return "", fmt.Errorf("unable to render literal block: %w", err)
 	}
 	return result.String(), nil
 }
@@ -1,12 +1,12 @@
 package sgml
 
 import (
+	"fmt"
 	"io"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderOrderedList(ctx *renderer.Context, l types.OrderedList) (string, error) {
@@ -15,20 +15,20 @@ func (r *sgmlRenderer) renderOrderedList(ctx *renderer.Context, l types.OrderedL
 
 	for _, item := range l.Items {
 		if err := r.renderOrderedListItem(ctx, content, item); err != nil {
-			return "", errors.Wrap(err, "unable to render unordered list")
+			return "", fmt.Errorf("unable to render unordered list: %w", err)
 		}
 	}
 	roles, err := r.renderElementRoles(ctx, l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render ordered list roles")
+		return "", fmt.Errorf("unable to render ordered list roles: %w", err)
 	}
 	style, err := getNumberingStyle(l)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render ordered list roles")
+		return "", fmt.Errorf("unable to render ordered list roles: %w", err)
 	}
 	title, err := r.renderElementTitle(l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 	err = r.orderedList.Execute(result, struct {
 		Context   *renderer.Context
@@ -49,11 +49,11 @@ func (r *sgmlRenderer) renderOrderedList(ctx *renderer.Context, l types.OrderedL
 		ListStyle: r.numberingType(style),
 		Start:     l.Attributes.GetAsStringWithDefault(types.AttrStart, ""),
 		Content:   string(content.String()),
-		Reversed:  l.Attributes.HasOption("reversed"),
+		Reversed:  l.Attributes.HasOption(types.OptionReversed),
 		Items:     l.Items,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render ordered list")
+		return "", fmt.Errorf("unable to render ordered list: %w", err)
 	}
 	return result.String(), nil
 }
@@ -87,7 +87,7 @@ func (r *sgmlRenderer) renderOrderedListItem(ctx *renderer.Context, w io.Writer,
 
 	content, err := r.renderListElements(ctx, item.Elements)
 	if err != nil {
-		return errors.Wrap(err, "unable to render unordered list item content")
+		return fmt.Errorf("unable to render unordered list item content: %w", err)
 	}
 	return r.orderedListItem.Execute(w, struct {
 		Context *renderer.Context
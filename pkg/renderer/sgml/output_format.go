@@ -0,0 +1,166 @@
+package sgml
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+)
+
+// the elements around which the whitespaces containing a newline are not significant
+var blockElements = map[string]bool{
+	"html":       true,
+	"head":       true,
+	"title":      true,
+	"meta":       true,
+	"link":       true,
+	"style":      true,
+	"script":     true,
+	"body":       true,
+	"header":     true,
+	"footer":     true,
+	"nav":        true,
+	"section":    true,
+	"article":    true,
+	"aside":      true,
+	"div":        true,
+	"p":          true,
+	"pre":        true,
+	"blockquote": true,
+	"figure":     true,
+	"figcaption": true,
+	"hr":         true,
+	"br":         true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"ul":         true,
+	"ol":         true,
+	"li":         true,
+	"dl":         true,
+	"dt":         true,
+	"dd":         true,
+	"table":      true,
+	"caption":    true,
+	"colgroup":   true,
+	"col":        true,
+	"thead":      true,
+	"tbody":      true,
+	"tfoot":      true,
+	"tr":         true,
+	"th":         true,
+	"td":         true,
+	"audio":      true,
+	"video":      true,
+	"source":     true,
+	"iframe":     true,
+	"details":    true,
+	"summary":    true,
+}
+
+// the elements whose content is always written as-is
+var verbatimElements = map[string]bool{
+	"pre":      true,
+	"script":   true,
+	"style":    true,
+	"textarea": true,
+}
+
+// formatOutput reformats the given (pretty) output in the given format. In the `compact` format,
+// the whitespaces containing a newline are removed around the block elements, and replaced with a single space
+// elsewhere (ie, around the inline elements and between the lines of text). In the `minified` format, all other consecutive whitespaces are also replaced
+// with a single space. In both cases, the content of the verbatim elements (eg: `<pre>`) is retained as-is.
+func formatOutput(output string, format string) string {
+	result := &strings.Builder{}
+	result.Grow(len(output))
+	afterBlock := true // the beginning of the output is considered as a block boundary
+	for i := 0; i < len(output); {
+		switch {
+		case output[i] == '<':
+			end := tagEnd(output, i)
+			name, closing := tagName(output[i:end])
+			result.WriteString(output[i:end])
+			i = end
+			if verbatimElements[name] && !closing {
+				// copy the content until the closing tag
+				end := strings.Index(output[i:], "</"+name)
+				if end < 0 {
+					end = len(output) - i
+				}
+				result.WriteString(output[i : i+end])
+				i += end
+			}
+			afterBlock = isBlockElement(name)
+		case isWhitespace(output[i]):
+			end := i
+			for end < len(output) && isWhitespace(output[end]) {
+				end++
+			}
+			beforeBlock := end == len(output) // the end of the output is also a block boundary
+			if end < len(output) && output[end] == '<' {
+				name, _ := tagName(output[end:tagEnd(output, end)])
+				beforeBlock = isBlockElement(name)
+			}
+			switch newline := strings.Contains(output[i:end], "\n"); {
+			case newline && (afterBlock || beforeBlock):
+				// not significant
+			case format == configuration.MinifiedOutput, newline:
+				result.WriteByte(' ')
+			default:
+				result.WriteString(output[i:end])
+			}
+			i = end
+			afterBlock = false
+		default:
+			end := i
+			for end < len(output) && output[end] != '<' && !isWhitespace(output[end]) {
+				end++
+			}
+			result.WriteString(output[i:end])
+			i = end
+			afterBlock = false
+		}
+	}
+	return result.String()
+}
+
+// tagEnd returns the position after the end of the tag starting at the given position,
+// ignoring the `>` characters in quoted attribute values
+func tagEnd(output string, start int) int {
+	var quote byte
+	for i := start + 1; i < len(output); i++ {
+		switch c := output[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i + 1
+		}
+	}
+	return len(output)
+}
+
+// tagName returns the (lowercase) name of the given tag, and whether it is a closing tag
+func tagName(tag string) (string, bool) {
+	name := strings.TrimPrefix(tag, "<")
+	closing := strings.HasPrefix(name, "/")
+	name = strings.TrimPrefix(name, "/")
+	if end := strings.IndexAny(name, " \t\r\n/>"); end >= 0 {
+		name = name[:end]
+	}
+	return strings.ToLower(name), closing
+}
+
+func isBlockElement(name string) bool {
+	// doctype, XML declaration and comments are also considered as blocks
+	return blockElements[name] || strings.HasPrefix(name, "!") || strings.HasPrefix(name, "?")
+}
+
+func isWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -1,21 +1,19 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
 func (r *sgmlRenderer) renderParagraph(ctx *renderer.Context, p types.Paragraph) (string, error) {
 	result := &strings.Builder{}
-	hardbreaks := p.Attributes.HasOption(types.AttrHardBreaks) ||
-		ctx.Attributes.HasOption(types.DocumentAttrHardBreaks)
-	content, err := r.renderLines(ctx, p.Lines, r.withHardBreaks(hardbreaks))
+	content, err := r.renderLines(ctx, p.Lines, r.withHardBreaks(hasHardBreaks(ctx, p.Attributes)))
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render paragraph content")
+		return "", fmt.Errorf("unable to render paragraph content: %w", err)
 	}
 	if k, ok := p.Attributes[types.AttrStyle].(string); ok {
 		switch k {
@@ -42,11 +40,11 @@ func (r *sgmlRenderer) renderParagraph(ctx *renderer.Context, p types.Paragraph)
 	// default case
 	roles, err := r.renderElementRoles(ctx, p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render paragraph roles")
+		return "", fmt.Errorf("unable to render paragraph roles: %w", err)
 	}
 	title, err := r.renderElementTitle(p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render paragraph roles")
+		return "", fmt.Errorf("unable to render paragraph roles: %w", err)
 	}
 	log.Debug("rendering a standalone paragraph")
 	err = r.paragraph.Execute(result, struct {
@@ -63,18 +61,25 @@ func (r *sgmlRenderer) renderParagraph(ctx *renderer.Context, p types.Paragraph)
 		Content: string(content),
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render paragraph")
+		return "", fmt.Errorf("unable to render paragraph: %w", err)
 	}
 	return result.String(), nil
 }
 
+// hasHardBreaks returns `true` if the lines of the paragraph with the given attributes
+// should be rendered with hard breaks, either because of the paragraph or the document options
+func hasHardBreaks(ctx *renderer.Context, attrs types.Attributes) bool {
+	return attrs.HasOption(types.AttrHardBreaks) ||
+		ctx.Attributes.HasOption(types.DocumentAttrHardBreaks)
+}
+
 func (r *sgmlRenderer) renderManpageNameParagraph(ctx *renderer.Context, p types.Paragraph) (string, error) {
 	log.Debug("rendering name section paragraph in manpage...")
 	result := &strings.Builder{}
 
 	content, err := r.renderLines(ctx, p.Lines)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render quote paragraph lines")
+		return "", fmt.Errorf("unable to render quote paragraph lines: %w", err)
 	}
 
 	err = r.manpageNameParagraph.Execute(result, struct {
@@ -95,11 +100,11 @@ func (r *sgmlRenderer) renderParagraphWithinDelimitedBlock(ctx *renderer.Context
 
 	content, err := r.renderLines(ctx, p.Lines)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render delimited block paragraph content")
+		return "", fmt.Errorf("unable to render delimited block paragraph content: %w", err)
 	}
 	title, err := r.renderElementTitle(p.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render delimited block paragraph content")
+		return "", fmt.Errorf("unable to render delimited block paragraph content: %w", err)
 	}
 
 	err = r.delimitedBlockParagraph.Execute(result, struct {
@@ -137,6 +142,27 @@ func renderCheckStyle(style interface{}) string {
 }
 
 func (r *sgmlRenderer) renderElementTitle(attrs types.Attributes) (string, error) {
+	if title, ok := attrs[types.AttrTitle].([]interface{}); ok {
+		// title with predefined attributes, whose values must not be escaped
+		result := &strings.Builder{}
+		for _, e := range title {
+			switch e := e.(type) {
+			case types.PredefinedAttribute:
+				result.WriteString(predefinedAttribute(e.Name))
+			case types.StringElement:
+				result.WriteString(EscapeString(e.Content))
+			case types.RawText:
+				s, err := e.RawText()
+				if err != nil {
+					return "", err
+				}
+				result.WriteString(EscapeString(s))
+			default:
+				return "", fmt.Errorf("unexpected type of element in title: '%T'", e)
+			}
+		}
+		return strings.TrimSpace(result.String()), nil
+	}
 	if title, found, err := attrs.GetAsString(types.AttrTitle); err != nil {
 		return "", err
 	} else if found {
@@ -171,9 +197,17 @@ func (r *sgmlRenderer) withPlainText() RenderLinesOption {
 	}
 }
 
+// withVerbatimLines keeps the trailing spaces of each line, so that the content
+// of verbatim blocks is rendered as-is
+func (r *sgmlRenderer) withVerbatimLines() RenderLinesOption {
+	return func(c *RenderLinesConfig) {
+		c.render = r.renderLineWithTrailingSpaces
+	}
+}
+
 // renderLines renders all lines (i.e, all `InlineElements`` - each `InlineElements` being a slice of elements to generate a line)
 // and includes an `\n` character in-between, until the last one.
-// Trailing spaces are removed for each line.
+// Trailing spaces are removed for each line, unless the `withVerbatimLines` option is set.
 func (r *sgmlRenderer) renderLines(ctx *renderer.Context, lines [][]interface{}, options ...RenderLinesOption) (string, error) { // renderLineFunc renderFunc, hardbreak bool
 	linesRenderer := RenderLinesConfig{
 		render:     r.renderLine,
@@ -186,11 +220,11 @@ func (r *sgmlRenderer) renderLines(ctx *renderer.Context, lines [][]interface{},
 	for i, e := range lines {
 		renderedLine, err := linesRenderer.render(ctx, e)
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render lines")
+			return "", fmt.Errorf("unable to render lines: %w", err)
 		}
 		if len(renderedLine) > 0 {
 			if _, err := buf.WriteString(renderedLine); err != nil {
-				return "", errors.Wrap(err, "unable to render lines")
+				return "", fmt.Errorf("unable to render lines: %w", err)
 			}
 		}
 
@@ -199,14 +233,14 @@ func (r *sgmlRenderer) renderLines(ctx *renderer.Context, lines [][]interface{},
 			var err error
 			if linesRenderer.hardBreaks {
 				if br, err := r.renderLineBreak(); err != nil {
-					return "", errors.Wrap(err, "unable to render hardbreak")
+					return "", fmt.Errorf("unable to render hardbreak: %w", err)
 				} else if _, err = buf.WriteString(br); err != nil {
-					return "", errors.Wrap(err, "unable to write hardbreak")
+					return "", fmt.Errorf("unable to write hardbreak: %w", err)
 				}
 			}
 			_, err = buf.WriteString("\n")
 			if err != nil {
-				return "", errors.Wrap(err, "unable to render lines")
+				return "", fmt.Errorf("unable to render lines: %w", err)
 			}
 		}
 	}
@@ -219,5 +253,12 @@ func (r *sgmlRenderer) renderLine(ctx *renderer.Context, element interface{}) (s
 		return r.renderInlineElements(ctx, elements)
 	}
 
-	return "", errors.Errorf("invalid type of element for a line: %T", element)
+	return "", fmt.Errorf("invalid type of element for a line: %T", element)
+}
+
+func (r *sgmlRenderer) renderLineWithTrailingSpaces(ctx *renderer.Context, element interface{}) (string, error) {
+	if elements, ok := element.([]interface{}); ok {
+		return r.renderInlineElements(ctx, elements, r.withTrailingSpaces())
+	}
+	return "", fmt.Errorf("invalid type of element for a line: %T", element)
 }
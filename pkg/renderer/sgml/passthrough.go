@@ -1,21 +1,21 @@
 package sgml
 
 import (
+	"fmt"
 	"html/template"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderInlinePassthrough(ctx *renderer.Context, p types.InlinePassthrough) (string, error) {
 	renderedContent, err := r.renderPassthroughContent(ctx, p)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render passthrough")
+		return "", fmt.Errorf("unable to render passthrough: %w", err)
 	}
 	switch p.Kind {
-	case types.SinglePlusPassthrough:
+	case types.SinglePlusPassthrough, types.EscapedPassthrough:
 		// rendered passthrough content is in an HTML-escaped form
 		buf := &strings.Builder{}
 		template.HTMLEscape(buf, []byte(renderedContent))
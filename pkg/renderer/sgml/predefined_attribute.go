@@ -1,10 +1,10 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderPredefinedAttribute(a types.PredefinedAttribute) (string, error) {
@@ -14,7 +14,7 @@ func (r *sgmlRenderer) renderPredefinedAttribute(a types.PredefinedAttribute) (s
 	}{
 		Name: a.Name,
 	}); err != nil {
-		return "", errors.Wrap(err, "error while rendering predefined attribute")
+		return "", fmt.Errorf("error while rendering predefined attribute: %w", err)
 	}
 	// log.Debugf("rendered predefined attribute for '%s': '%s'", a.Name, result.String())
 	return result.String(), nil
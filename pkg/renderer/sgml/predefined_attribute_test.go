@@ -22,12 +22,12 @@ var _ = DescribeTable("predefined attributes",
 	Entry("ldquo", "ldquo", "\u201c"),
 	Entry("rdquo", "rdquo", "\u201d"),
 	Entry("deg", "deg", "\u00b0"),
-	Entry("plus", "plus", "+"),
+	Entry("plus", "plus", "&#43;"),
 	Entry("brvbar", "brvbar", "\u00a6"),
 	Entry("vbar", "vbar", "|"),
-	Entry("amp", "amp", "&"),
-	Entry("lt", "lt", "<"),
-	Entry("gt", "gt", ">"),
+	Entry("amp", "amp", "&amp;"),
+	Entry("lt", "lt", "&lt;"),
+	Entry("gt", "gt", "&gt;"),
 	Entry("startsb", "startsb", "["),
 	Entry("endsb", "endsb", "]"),
 	Entry("caret", "caret", "^"),
@@ -36,6 +36,6 @@ var _ = DescribeTable("predefined attributes",
 	Entry("backslash", "backslash", `\`),
 	Entry("backtick", "backtick", "`"),
 	Entry("two-colons", "two-colons", "::"),
-	Entry("two-semicolons", "two-semicolons", ";"),
+	Entry("two-semicolons", "two-semicolons", ";;"),
 	Entry("cpp", "cpp", "C++"),
 )
@@ -1,11 +1,11 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 // TODO: The bold, italic, and monospace items should be refactored to support semantic tags instead.
@@ -15,11 +15,11 @@ func (r *sgmlRenderer) renderQuotedText(ctx *renderer.Context, t types.QuotedTex
 	for _, element := range t.Elements {
 		b, err := r.renderElement(ctx, element)
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render text quote")
+			return "", fmt.Errorf("unable to render text quote: %w", err)
 		}
 		_, err = elementsBuffer.WriteString(b)
 		if err != nil {
-			return "", errors.Wrapf(err, "unable to render text quote")
+			return "", fmt.Errorf("unable to render text quote: %w", err)
 		}
 	}
 	var tmpl *textTemplate
@@ -37,11 +37,11 @@ func (r *sgmlRenderer) renderQuotedText(ctx *renderer.Context, t types.QuotedTex
 	case types.SingleQuoteSuperscript:
 		tmpl = r.superscriptText
 	default:
-		return "", errors.Errorf("unsupported quoted text kind: '%v'", t.Kind)
+		return "", fmt.Errorf("unsupported quoted text kind: '%v'", t.Kind)
 	}
 	roles, err := r.renderElementRoles(ctx, t.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render quoted text roles")
+		return "", fmt.Errorf("unable to render quoted text roles: %w", err)
 	}
 	result := &strings.Builder{}
 	err = tmpl.Execute(result, struct {
@@ -56,7 +56,7 @@ func (r *sgmlRenderer) renderQuotedText(ctx *renderer.Context, t types.QuotedTex
 		Content:    string(elementsBuffer.String()),
 	}) //nolint: gosec
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render monospaced quote")
+		return "", fmt.Errorf("unable to render monospaced quote: %w", err)
 	}
 	// log.Debugf("rendered bold quote: %s", result.Bytes())
 	return result.String(), nil
@@ -2,6 +2,7 @@ package sgml
 
 import (
 	"fmt"
+	"html"
 	"io"
 	"strings"
 	texttemplate "text/template"
@@ -10,7 +11,6 @@ import (
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -29,6 +29,15 @@ type Renderer interface {
 	Templates() Templates
 }
 
+// ElementRenderer renders elements without the surrounding document structure.
+// The renderers returned by `NewRenderer` also implement this interface.
+type ElementRenderer interface {
+
+	// RenderElement renders a single block or inline element (or a slice of elements), without
+	// the surrounding document structure (HEAD, BODY, etc.)
+	RenderElement(ctx *renderer.Context, element interface{}) (string, error)
+}
+
 // NewRenderer returns a new renderer
 func NewRenderer(t Templates) Renderer {
 	r := &sgmlRenderer{
@@ -42,6 +51,8 @@ func NewRenderer(t Templates) Renderer {
 		"trim":                trimBoth,
 		"specialCharacter":    specialCharacter,
 		"predefinedAttribute": predefinedAttribute,
+		"attributes":          HTMLAttributes,
+		"classes":             HTMLClasses,
 	}
 
 	return r
@@ -69,41 +80,9 @@ func specialCharacter(c string) string {
 	return specialCharacters[c]
 }
 
-var predefinedAttributes = map[string]string{
-	"sp":             " ",
-	"blank":          "",
-	"empty":          "",
-	"nbsp":           "\u00a0",
-	"zwsp":           "\u200b",
-	"wj":             "\u2060",
-	"apos":           "&#39;",
-	"quot":           "&#34;",
-	"lsquo":          "\u2018",
-	"rsquo":          "\u2019",
-	"ldquo":          "\u201c",
-	"rdquo":          "\u201d",
-	"deg":            "\u00b0",
-	"plus":           "&#43;",
-	"brvbar":         "\u00a6",
-	"vbar":           "|", // TODO: maybe convert this because of tables?
-	"amp":            "&amp;",
-	"lt":             "<",
-	"gt":             ">",
-	"startsb":        "[",
-	"endsb":          "]",
-	"caret":          "^",
-	"asterisk":       "*",
-	"tilde":          "~",
-	"backslash":      `\`,
-	"backtick":       "`",
-	"two-colons":     "::",
-	"two-semicolons": ";",
-	"cpp":            "C++",
-}
-
 func predefinedAttribute(a string) string {
-	// log.Debugf("predefined attribute '%s': '%s", a, predefinedAttributes[a])
-	return predefinedAttributes[a]
+	// log.Debugf("predefined attribute '%s': '%s", a, types.PredefinedAttributeValue(a))
+	return types.PredefinedAttributeValue(a)
 }
 
 func (r *sgmlRenderer) SetFunction(name string, fn interface{}) {
@@ -136,39 +115,71 @@ func (r *sgmlRenderer) newTemplate(name string, tmpl string, err error) (*textTe
 
 // Render renders the given document in HTML and writes the result in the given `writer`
 func (r *sgmlRenderer) Render(ctx *renderer.Context, doc types.Document, output io.Writer) (types.Metadata, error) {
+	switch ctx.Config.OutputFormat {
+	case configuration.CompactOutput, configuration.MinifiedOutput:
+		result := &strings.Builder{}
+		metadata, err := r.render(ctx, doc, result)
+		if err != nil {
+			return metadata, err
+		}
+		if _, err := io.WriteString(output, formatOutput(result.String(), ctx.Config.OutputFormat)); err != nil {
+			return metadata, fmt.Errorf("unable to render full document: %w", err)
+		}
+		return metadata, nil
+	default:
+		return r.render(ctx, doc, output)
+	}
+}
+
+func (r *sgmlRenderer) render(ctx *renderer.Context, doc types.Document, output io.Writer) (types.Metadata, error) {
 
 	// metadata to be returned to the caller
 	var metadata types.Metadata
 	// arguably this should be a time.Time for use in Go
 	metadata.LastUpdated = ctx.Config.LastUpdated.Format(configuration.LastUpdatedFormat)
+	metadata.Attributes = doc.Attributes.Values()
 	err := r.prepareTemplates()
 	if err != nil {
 		return metadata, err
 	}
-	if doc.Attributes.Has(types.AttrUnicode) {
+	if ctx.Attributes.HasFlag(types.AttrUnicode) {
 		ctx.UseUnicode = true
 	}
 	renderedTitle, exists, err := r.renderDocumentTitle(ctx, doc)
 	if err != nil {
-		return metadata, errors.Wrapf(err, "unable to render full document")
+		return metadata, fmt.Errorf("unable to render full document: %w", err)
 	}
 	metadata.Title = string(renderedTitle) // retain an empty value if no title was defined in the document
-	if !exists {
-		renderedTitle = DefaultTitle
+	// the `title` attribute overrides the document title in the HTML `<title>` element
+	if title, found, err := ctx.Attributes.GetAsString(types.AttrTitle); err != nil {
+		return metadata, fmt.Errorf("unable to render full document: %w", err)
+	} else if found && title != "" {
+		renderedTitle = html.EscapeString(title)
+	} else if !exists {
+		renderedTitle = ctx.Attributes.GetAsStringWithDefault(types.AttrUntitledLabel, types.DefaultUntitledLabel)
 	}
 	// needs to be set before rendering the content elements
 	ctx.TableOfContents, err = r.newTableOfContents(ctx, doc)
 	if err != nil {
-		return metadata, errors.Wrapf(err, "unable to render full document")
+		return metadata, fmt.Errorf("unable to render full document: %w", err)
+	}
+	tocLevels, err := getTableOfContentsLevels(ctx, nil)
+	if err != nil {
+		return metadata, fmt.Errorf("unable to render full document: %w", err)
+	}
+	metadata.TableOfContents = ctx.TableOfContents.Prune(tocLevels)
+	ctx.CaptionLists, err = r.newCaptionLists(ctx, doc)
+	if err != nil {
+		return metadata, fmt.Errorf("unable to render full document: %w", err)
 	}
-	metadata.TableOfContents = ctx.TableOfContents
 	renderedHeader, renderedContent, err := r.splitAndRender(ctx, doc)
 	if err != nil {
-		return metadata, errors.Wrapf(err, "unable to render full document")
+		return metadata, fmt.Errorf("unable to render full document: %w", err)
 	}
+	metadata.Assets = ctx.Assets()
 	roles, err := r.renderDocumentRoles(ctx, doc)
 	if err != nil {
-		return metadata, errors.Wrap(err, "unable to render fenced block content")
+		return metadata, fmt.Errorf("unable to render fenced block content: %w", err)
 	}
 	if ctx.Config.WrapInHTMLBodyElement {
 		// log.Debugf("Rendering full document...")
@@ -183,7 +194,9 @@ func (r *sgmlRenderer) Render(ctx *renderer.Context, doc types.Document, output
 			Roles                 string
 			Content               string
 			RevNumber             string
+			VersionLabel          string
 			LastUpdated           string
+			LastUpdateLabel       string
 			CSS                   string
 			IncludeHTMLBodyHeader bool
 			IncludeHTMLBodyFooter bool
@@ -196,24 +209,41 @@ func (r *sgmlRenderer) Render(ctx *renderer.Context, doc types.Document, output
 			Roles:                 roles,
 			ID:                    r.renderDocumentID(doc),
 			Content:               string(renderedContent), //nolint: gosec
-			RevNumber:             doc.Attributes.GetAsStringWithDefault("revnumber", ""),
+			RevNumber:             doc.Attributes.GetAsStringWithDefault(types.AttrRevNumber, ""),
+			VersionLabel:          ctx.Attributes.GetAsStringWithDefault(types.AttrVersionLabel, types.DefaultVersionLabel),
 			LastUpdated:           ctx.Config.LastUpdated.Format(configuration.LastUpdatedFormat),
-			CSS:                   ctx.Config.CSS,
-			IncludeHTMLBodyHeader: !doc.Attributes.Has(types.AttrNoHeader),
-			IncludeHTMLBodyFooter: !doc.Attributes.Has(types.AttrNoFooter),
+			LastUpdateLabel:       ctx.Attributes.GetAsStringWithDefault(types.AttrLastUpdateLabel, types.DefaultLastUpdateLabel),
+			CSS:                   rewriteURL(ctx, configuration.StylesheetURL, ctx.Config.CSS),
+			IncludeHTMLBodyHeader: !ctx.Attributes.HasFlag(types.AttrNoHeader),
+			IncludeHTMLBodyFooter: !ctx.Attributes.HasFlag(types.AttrNoFooter),
 		})
 		if err != nil {
-			return metadata, errors.Wrapf(err, "unable to render full document")
+			return metadata, fmt.Errorf("unable to render full document: %w", err)
 		}
 	} else {
 		_, err = output.Write([]byte(renderedContent))
 		if err != nil {
-			return metadata, errors.Wrapf(err, "unable to render full document")
+			return metadata, fmt.Errorf("unable to render full document: %w", err)
 		}
 	}
 	return metadata, err
 }
 
+// RenderElement renders the given element, without the surrounding document structure
+func (r *sgmlRenderer) RenderElement(ctx *renderer.Context, element interface{}) (string, error) {
+	if err := r.prepareTemplates(); err != nil {
+		return "", err
+	}
+	if ctx.Attributes.HasFlag(types.AttrUnicode) {
+		ctx.UseUnicode = true
+	}
+	result, err := r.renderElement(ctx, element)
+	if err != nil {
+		return "", fmt.Errorf("unable to render element of type '%T': %w", element, err)
+	}
+	return result, nil
+}
+
 // splitAndRender the document with the header elements on one side
 // and all other elements (table of contents, with preamble, content) on the other side,
 // then renders the header and other elements
@@ -311,14 +341,15 @@ func (r *sgmlRenderer) renderAuthors(doc types.Document) string {
 }
 
 // DefaultTitle the default title to render when the document has none
-const DefaultTitle = "Untitled"
+// (unless the `untitled-label` attribute is set)
+const DefaultTitle = types.DefaultUntitledLabel
 
 func (r *sgmlRenderer) renderDocumentTitle(ctx *renderer.Context, doc types.Document) (string, bool, error) {
 	if header, found := doc.Header(); found {
 		// TODO: This feels wrong.  The title should not need markup.
 		title, err := r.renderPlainText(ctx, header.Title)
 		if err != nil {
-			return "", true, errors.Wrap(err, "unable to render document title")
+			return "", true, fmt.Errorf("unable to render document title: %w", err)
 		}
 		return string(title), true, nil
 	}
@@ -326,7 +357,12 @@ func (r *sgmlRenderer) renderDocumentTitle(ctx *renderer.Context, doc types.Docu
 }
 
 func (r *sgmlRenderer) renderArticleHeader(ctx *renderer.Context, header types.Section) (string, error) {
-	renderedHeader, err := r.renderInlineElements(ctx, header.Title)
+	title, subtitle := splitDocumentTitle(ctx, header.Title)
+	renderedHeader, err := r.renderInlineElements(ctx, title)
+	if err != nil {
+		return "", err
+	}
+	renderedSubtitle, err := r.renderInlineElements(ctx, subtitle)
 	if err != nil {
 		return "", err
 	}
@@ -334,14 +370,21 @@ func (r *sgmlRenderer) renderArticleHeader(ctx *renderer.Context, header types.S
 	if err != nil {
 		return "", err
 	}
-
+	roles, err := r.renderElementRoles(ctx, header.Attributes)
+	if err != nil {
+		return "", err
+	}
 	output := &strings.Builder{}
 	err = r.articleHeader.Execute(output, struct {
-		Header  string
-		Details *string // TODO: convert to string (no need to be a pointer)
+		Header   string
+		Subtitle string
+		Roles    string
+		Details  *string // TODO: convert to string (no need to be a pointer)
 	}{
-		Header:  renderedHeader,
-		Details: documentDetails,
+		Header:   renderedHeader,
+		Subtitle: renderedSubtitle,
+		Roles:    roles,
+		Details:  documentDetails,
 	})
 	if err != nil {
 		return "", err
@@ -349,6 +392,57 @@ func (r *sgmlRenderer) renderArticleHeader(ctx *renderer.Context, header types.S
 	return output.String(), nil
 }
 
+// splitDocumentTitle splits the given document title in a main title and a subtitle on the last occurrence
+// of the separator (followed by a space), if the `title-separator` attribute is set.
+// Otherwise, returns the title as-is, with no subtitle
+func splitDocumentTitle(ctx *renderer.Context, title []interface{}) ([]interface{}, []interface{}) {
+	if !ctx.Attributes.Has(types.AttrTitleSeparator) {
+		return title, nil
+	}
+	separator := ctx.Attributes.GetAsStringWithDefault(types.AttrTitleSeparator, "")
+	if separator == "" {
+		separator = types.DefaultTitleSeparator
+	}
+	separators := []string{separator + " "}
+	if separator == "--" {
+		// the `--` separator may have been replaced with an em dash surrounded by thin spaces
+		separators = append(separators, "\u2009\u2014\u2009")
+	}
+	for i := len(title) - 1; i >= 0; i-- {
+		s, ok := title[i].(types.StringElement)
+		if !ok {
+			continue
+		}
+		idx, separator := lastIndexOfAny(s.Content, separators)
+		if idx >= 0 {
+			main := make([]interface{}, 0, i+1)
+			main = append(main, title[:i]...)
+			if m := strings.TrimRight(s.Content[:idx], " "); m != "" {
+				main = append(main, types.StringElement{Content: m})
+			}
+			subtitle := make([]interface{}, 0, len(title)-i)
+			if sub := strings.TrimLeft(s.Content[idx+len(separator):], " "); sub != "" {
+				subtitle = append(subtitle, types.StringElement{Content: sub})
+			}
+			subtitle = append(subtitle, title[i+1:]...)
+			return main, subtitle
+		}
+	}
+	return title, nil
+}
+
+// lastIndexOfAny returns the index of the last occurrence of any of the given separators in s,
+// along with the matching separator, or -1 if none was found
+func lastIndexOfAny(s string, separators []string) (int, string) {
+	idx, sep := -1, ""
+	for _, separator := range separators {
+		if i := strings.LastIndex(s, separator); i > idx {
+			idx, sep = i, separator
+		}
+	}
+	return idx, sep
+}
+
 func (r *sgmlRenderer) renderManpageHeader(ctx *renderer.Context, header types.Section, nameSection types.Section) (string, error) {
 	renderedHeader, err := r.renderInlineElements(ctx, header.Title)
 	if err != nil {
@@ -359,6 +453,8 @@ func (r *sgmlRenderer) renderManpageHeader(ctx *renderer.Context, header types.S
 		return "", err
 	}
 	description := nameSection.Elements[0].(types.Paragraph) // TODO: type check
+	// do not modify the attributes of the paragraph in the document, which may be rendered again
+	description.Attributes = description.Attributes.Clone()
 	if description.Attributes == nil {
 		description.Attributes = types.Attributes{}
 	}
@@ -417,12 +513,12 @@ func (r *sgmlRenderer) renderDocumentElements(ctx *renderer.Context, source []in
 	buff := &strings.Builder{}
 	renderedElements, err := r.renderElements(ctx, elements)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render document elements")
+		return "", fmt.Errorf("failed to render document elements: %w", err)
 	}
 	buff.WriteString(renderedElements)
 	renderedFootnotes, err := r.renderFootnotes(ctx, footnotes)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render document elements")
+		return "", fmt.Errorf("failed to render document elements: %w", err)
 	}
 	buff.WriteString(renderedFootnotes)
 	return buff.String(), nil
@@ -1,11 +1,11 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderPreamble(ctx *renderer.Context, p types.Preamble) (string, error) {
@@ -16,7 +16,7 @@ func (r *sgmlRenderer) renderPreamble(ctx *renderer.Context, p types.Preamble) (
 
 	content, err := r.renderElements(ctx, p.Elements)
 	if err != nil {
-		return "", errors.Wrap(err, "error rendering preamble elements")
+		return "", fmt.Errorf("error rendering preamble elements: %w", err)
 	}
 	err = r.preamble.Execute(result, struct {
 		Context *renderer.Context
@@ -28,7 +28,7 @@ func (r *sgmlRenderer) renderPreamble(ctx *renderer.Context, p types.Preamble) (
 		Content: string(content),
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "error while rendering preamble")
+		return "", fmt.Errorf("error while rendering preamble: %w", err)
 	}
 	// log.Debugf("rendered preamble: %s", result.Bytes())
 	return result.String(), nil
@@ -36,18 +36,21 @@ func (r *sgmlRenderer) renderPreamble(ctx *renderer.Context, p types.Preamble) (
 
 func (r *sgmlRenderer) renderSection(ctx *renderer.Context, s types.Section) (string, error) {
 	// log.Debugf("rendering section level %d", s.Level)
+	if s.IsDiscrete() {
+		return r.renderDiscreteHeading(ctx, s)
+	}
 	title, err := r.renderSectionTitle(ctx, s)
 	if err != nil {
-		return "", errors.Wrap(err, "error while rendering section title")
+		return "", fmt.Errorf("error while rendering section title: %w", err)
 	}
 
 	content, err := r.renderElements(ctx, s.Elements)
 	if err != nil {
-		return "", errors.Wrap(err, "error while rendering section content")
+		return "", fmt.Errorf("error while rendering section content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, s.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render section roles")
+		return "", fmt.Errorf("unable to render section roles: %w", err)
 	}
 
 	result := &strings.Builder{}
@@ -69,24 +72,73 @@ func (r *sgmlRenderer) renderSection(ctx *renderer.Context, s types.Section) (st
 		Content:  string(content),
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "error while rendering section")
+		return "", fmt.Errorf("error while rendering section: %w", err)
 	}
 	// log.Debugf("rendered section: %s", result.Bytes())
 	return result.String(), nil
 }
 
+// sectionTitlePrefix returns the prefix of the given section title, i.e., the appendix caption
+// (eg: `Appendix A: `) if the section is an appendix, the section number (eg: `1.2. `) if the section is numbered,
+// or an empty string otherwise
+func sectionTitlePrefix(ctx *renderer.Context, s types.Section) string {
+	if number, found, err := s.Attributes.GetAsString(types.AttrAppendixNumber); err == nil && found {
+		if caption := ctx.Attributes.GetAsStringWithDefault(types.AttrAppendixCaption, types.DefaultAppendixCaption); caption != "" {
+			return caption + " " + number + ": "
+		}
+		return number + ". "
+	}
+	if number, found, err := s.Attributes.GetAsString(types.AttrSectionNumber); err == nil && found {
+		return number + ". "
+	}
+	return ""
+}
+
+func (r *sgmlRenderer) renderDiscreteHeading(ctx *renderer.Context, s types.Section) (string, error) {
+	renderedContent, err := r.renderInlineElements(ctx, s.Title)
+	if err != nil {
+		return "", fmt.Errorf("error while rendering discrete heading content: %w", err)
+	}
+	roles, err := r.renderElementRoles(ctx, s.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("unable to render discrete heading roles: %w", err)
+	}
+	result := &strings.Builder{}
+	err = r.discreteHeading.Execute(result, struct {
+		Level        int
+		LevelPlusOne int
+		ID           string
+		Roles        string
+		Content      string
+	}{
+		Level:        s.Level,
+		LevelPlusOne: s.Level + 1,
+		ID:           r.renderElementID(s.Attributes),
+		Roles:        roles,
+		Content:      strings.TrimSpace(renderedContent),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error while rendering discrete heading: %w", err)
+	}
+	return result.String(), nil
+}
+
 func (r *sgmlRenderer) renderSectionTitle(ctx *renderer.Context, s types.Section) (string, error) {
 	result := &strings.Builder{}
 	renderedContent, err := r.renderInlineElements(ctx, s.Title)
 	if err != nil {
-		return "", errors.Wrap(err, "error while rendering sectionTitle content")
+		return "", fmt.Errorf("error while rendering sectionTitle content: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, s.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render section content")
+		return "", fmt.Errorf("unable to render section content: %w", err)
 	}
 
-	renderedContentStr := strings.TrimSpace(renderedContent)
+	renderedContentStr := sectionTitlePrefix(ctx, s) + strings.TrimSpace(renderedContent)
+	if ctx.Config.SectionTitleDecorator != nil {
+		before, after := ctx.Config.SectionTitleDecorator(s)
+		renderedContentStr = before + renderedContentStr + after
+	}
 	err = r.sectionHeader.Execute(result, struct {
 		Level        int
 		LevelPlusOne int
@@ -101,7 +153,7 @@ func (r *sgmlRenderer) renderSectionTitle(ctx *renderer.Context, s types.Section
 		Content:      renderedContentStr,
 	})
 	if err != nil {
-		return "", errors.Wrapf(err, "error while rendering sectionTitle")
+		return "", fmt.Errorf("error while rendering sectionTitle: %w", err)
 	}
 	// log.Debugf("rendered sectionTitle: %s", result.Bytes())
 	return string(result.String()), nil
@@ -19,7 +19,11 @@ type sgmlRenderer struct {
 	calloutList               *textTemplate
 	calloutListItem           *textTemplate
 	calloutRef                *textTemplate
+	captionList               *textTemplate
+	captionListEntry          *textTemplate
+	collapsibleBlock          *textTemplate
 	delimitedBlockParagraph   *textTemplate
+	discreteHeading           *textTemplate
 	documentDetails           *textTemplate
 	documentAuthorDetails     *textTemplate
 	externalCrossReference    *textTemplate
@@ -33,6 +37,7 @@ type sgmlRenderer struct {
 	iconFont                  *textTemplate
 	iconImage                 *textTemplate
 	iconText                  *textTemplate
+	inlineAnchor              *textTemplate
 	inlineIcon                *textTemplate
 	inlineImage               *textTemplate
 	internalCrossReference    *textTemplate
@@ -65,6 +70,7 @@ type sgmlRenderer struct {
 	sectionHeader             *textTemplate
 	sidebarBlock              *textTemplate
 	sourceBlock               *textTemplate
+	sourceLine                *textTemplate
 	specialCharacter          *textTemplate
 	stringElement             *textTemplate
 	subscriptText             *textTemplate
@@ -72,6 +78,7 @@ type sgmlRenderer struct {
 	table                     *textTemplate
 	tableBody                 *textTemplate
 	tableCell                 *textTemplate
+	tableFooter               *textTemplate
 	tableHeader               *textTemplate
 	tableHeaderCell           *textTemplate
 	tableRow                  *textTemplate
@@ -100,7 +107,11 @@ func (r *sgmlRenderer) prepareTemplates() error {
 		r.calloutList, err = r.newTemplate("callout-list", tmpls.CalloutList, err)
 		r.calloutListItem, err = r.newTemplate("callout-list-item", tmpls.CalloutListItem, err)
 		r.calloutRef, err = r.newTemplate("callout-ref", tmpls.CalloutRef, err)
+		r.captionList, err = r.newTemplate("caption-list", tmpls.CaptionList, err)
+		r.captionListEntry, err = r.newTemplate("caption-list-entry", tmpls.CaptionListEntry, err)
+		r.collapsibleBlock, err = r.newTemplate("collapsible-block", tmpls.CollapsibleBlock, err)
 		r.delimitedBlockParagraph, err = r.newTemplate("delimited-block-paragraph", tmpls.DelimitedBlockParagraph, err)
+		r.discreteHeading, err = r.newTemplate("discrete-heading", tmpls.DiscreteHeading, err)
 		r.documentDetails, err = r.newTemplate("document-details", tmpls.DocumentDetails, err)
 		r.documentAuthorDetails, err = r.newTemplate("document-author-details", tmpls.DocumentAuthorDetails, err)
 		r.exampleBlock, err = r.newTemplate("example-block", tmpls.ExampleBlock, err)
@@ -114,6 +125,7 @@ func (r *sgmlRenderer) prepareTemplates() error {
 		r.iconFont, err = r.newTemplate("icon-font", tmpls.IconFont, err)
 		r.iconImage, err = r.newTemplate("icon-image", tmpls.IconImage, err)
 		r.iconText, err = r.newTemplate("icon-text", tmpls.IconText, err)
+		r.inlineAnchor, err = r.newTemplate("inline-anchor", tmpls.InlineAnchor, err)
 		r.inlineIcon, err = r.newTemplate("inline-icon", tmpls.InlineIcon, err)
 		r.inlineImage, err = r.newTemplate("inline-image", tmpls.InlineImage, err)
 		r.internalCrossReference, err = r.newTemplate("internal-xref", tmpls.InternalCrossReference, err)
@@ -147,12 +159,14 @@ func (r *sgmlRenderer) prepareTemplates() error {
 		r.stringElement, err = r.newTemplate("string-element", tmpls.StringElement, err)
 		r.sidebarBlock, err = r.newTemplate("sidebar-block", tmpls.SidebarBlock, err)
 		r.sourceBlock, err = r.newTemplate("source-block", tmpls.SourceBlock, err)
+		r.sourceLine, err = r.newTemplate("source-line", tmpls.SourceLine, err)
 		r.specialCharacter, err = r.newTemplate("special-character", tmpls.SpecialCharacter, err)
 		r.subscriptText, err = r.newTemplate("subscript", tmpls.SubscriptText, err)
 		r.superscriptText, err = r.newTemplate("superscript", tmpls.SuperscriptText, err)
 		r.table, err = r.newTemplate("table", tmpls.Table, err)
 		r.tableBody, err = r.newTemplate("table-body", tmpls.TableBody, err)
 		r.tableCell, err = r.newTemplate("table-cell", tmpls.TableCell, err)
+		r.tableFooter, err = r.newTemplate("table-footer", tmpls.TableFooter, err)
 		r.tableHeader, err = r.newTemplate("table-header", tmpls.TableHeader, err)
 		r.tableHeaderCell, err = r.newTemplate("table-header-cell", tmpls.TableHeaderCell, err)
 		r.tableRow, err = r.newTemplate("table-row", tmpls.TableRow, err)
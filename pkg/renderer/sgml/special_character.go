@@ -1,10 +1,10 @@
 package sgml
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderSpecialCharacter(ctx *Context, s types.SpecialCharacter) (string, error) {
@@ -20,7 +20,7 @@ func (r *sgmlRenderer) renderSpecialCharacter(ctx *Context, s types.SpecialChara
 	}{
 		Name: s.Name,
 	}); err != nil {
-		return "", errors.Wrap(err, "error while rendering special character")
+		return "", fmt.Errorf("error while rendering special character: %w", err)
 	}
 	return result.String(), nil
 }
@@ -7,7 +7,6 @@ import (
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 var quotes = map[types.QuotedStringKind]struct {
@@ -51,7 +50,7 @@ func (r *sgmlRenderer) renderStringElement(ctx *renderer.Context, str types.Stri
 	buf := &strings.Builder{}
 	err := r.stringElement.Execute(buf, str.Content)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render string")
+		return "", fmt.Errorf("unable to render string: %w", err)
 	}
 
 	// NB: For all SGML flavors we are aware of, the numeric entities from
@@ -1,12 +1,12 @@
 package sgml
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderTable(ctx *renderer.Context, t types.Table) (string, error) {
@@ -18,7 +18,7 @@ func (r *sgmlRenderer) renderTable(ctx *renderer.Context, t types.Table) (string
 	frame := t.Attributes.GetAsStringWithDefault(types.AttrFrame, "all")
 	grid := t.Attributes.GetAsStringWithDefault(types.AttrGrid, "all")
 	float := t.Attributes.GetAsStringWithDefault(types.AttrFloat, "")
-	stripes := t.Attributes.GetAsStringWithDefault(types.AttrStripes, "")
+	stripes := t.Attributes.GetAsStringWithDefault(types.AttrStripes, ctx.Attributes.GetAsStringWithDefault(types.AttrTableStripes, ""))
 
 	width, _ := strconv.Atoi(
 		strings.TrimSuffix(t.Attributes.GetAsStringWithDefault(types.AttrWidth, ""), "%"))
@@ -30,7 +30,7 @@ func (r *sgmlRenderer) renderTable(ctx *renderer.Context, t types.Table) (string
 	//   and instead use an explicit style for the width.
 	// * If width is unset, and %autowidth is set, then we use a fit-content role.
 	// * If none of the above cases are true, we use stretch role (default)
-	if t.Attributes.HasOption("autowidth") {
+	if t.Attributes.HasOption(types.OptionAutoWidth) {
 		fit = "fit-content"
 	}
 	if width >= 100 {
@@ -40,49 +40,47 @@ func (r *sgmlRenderer) renderTable(ctx *renderer.Context, t types.Table) (string
 		fit = ""
 	}
 
+	id := r.renderElementID(t.Attributes)
 	if t.Attributes.Has(types.AttrTitle) {
-		c, ok, err := t.Attributes.GetAsString(types.AttrCaption)
-		if err != nil {
-			return "", err
-		} else if !ok {
-			c = ctx.Attributes.GetAsStringWithDefault(types.AttrTableCaption, "Table")
-			if c != "" {
-				// We always append the figure number, unless the caption is disabled.
-				// This is for asciidoctor compatibility.
-				c += " {counter:table-number}. "
-			}
-		}
 		// TODO: This is a very primitive and incomplete replacement of the counter attribute only.
 		// This should be removed when attribute values are allowed to contain attributes.
 		// Also this expansion should be limited to just singly quoted strings in the Attribute list,
 		// or the default.  Ultimately this should all be done long before it gets into the renderer.
-		if strings.Contains(c, "{counter:table-number}") {
-			number = ctx.GetAndIncrementTableCounter()
-			c = strings.ReplaceAll(c, "{counter:table-number}", strconv.Itoa(number))
+		c, n, err := renderCaption(ctx, t.Attributes, types.AttrTableCaption, "Table", "table-number", ctx.GetAndIncrementTableCounter)
+		if err != nil {
+			return "", err
 		}
+		number = n
 		caption.WriteString(c)
+		id = captionListID(ctx, types.TablesCaptionList, id)
 	}
 
 	header, err := r.renderTableHeader(ctx, t.Header, t.Columns)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render table")
+		return "", fmt.Errorf("failed to render table: %w", err)
 	}
 
 	body, err := r.renderTableBody(ctx, t)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render table")
+		return "", fmt.Errorf("failed to render table: %w", err)
+	}
+
+	footer, err := r.renderTableFooter(ctx, t.Footer, t.Columns)
+	if err != nil {
+		return "", fmt.Errorf("failed to render table: %w", err)
 	}
 	roles, err := r.renderElementRoles(ctx, t.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render table roles")
+		return "", fmt.Errorf("unable to render table roles: %w", err)
 	}
 	title, err := r.renderElementTitle(t.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	err = r.table.Execute(result, struct {
 		Context     *renderer.Context
+		ID          string
 		Title       string
 		Columns     []types.TableColumn
 		TableNumber int
@@ -96,8 +94,10 @@ func (r *sgmlRenderer) renderTable(ctx *renderer.Context, t types.Table) (string
 		Roles       string
 		Header      string
 		Body        string
+		Footer      string
 	}{
 		Context:     ctx,
+		ID:          id,
 		Title:       title,
 		Columns:     t.Columns,
 		TableNumber: number,
@@ -111,9 +111,10 @@ func (r *sgmlRenderer) renderTable(ctx *renderer.Context, t types.Table) (string
 		Width:       width,
 		Header:      header,
 		Body:        body,
+		Footer:      footer,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render table")
+		return "", fmt.Errorf("failed to render table: %w", err)
 	}
 	return result.String(), nil
 }
@@ -126,7 +127,7 @@ func (r *sgmlRenderer) renderTableHeader(ctx *renderer.Context, l types.TableLin
 		c, err := r.renderTableHeaderCell(ctx, cell, cols[col%len(cols)])
 		col++
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render header")
+			return "", fmt.Errorf("unable to render header: %w", err)
 		}
 		content.WriteString(c)
 	}
@@ -146,7 +147,7 @@ func (r *sgmlRenderer) renderTableHeaderCell(ctx *renderer.Context, cell []inter
 	result := &strings.Builder{}
 	content, err := r.renderInlineElements(ctx, cell)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render header cell")
+		return "", fmt.Errorf("unable to render header cell: %w", err)
 	}
 	err = r.tableHeaderCell.Execute(result, struct {
 		Context *renderer.Context
@@ -170,7 +171,7 @@ func (r *sgmlRenderer) renderTableBody(ctx *renderer.Context, t types.Table) (st
 	for _, row := range t.Lines {
 		c, err := r.renderTableRow(ctx, row, t.Columns)
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render header")
+			return "", fmt.Errorf("unable to render header: %w", err)
 		}
 		content.WriteString(c)
 	}
@@ -188,6 +189,28 @@ func (r *sgmlRenderer) renderTableBody(ctx *renderer.Context, t types.Table) (st
 	return result.String(), err
 }
 
+func (r *sgmlRenderer) renderTableFooter(ctx *renderer.Context, l types.TableLine, cols []types.TableColumn) (string, error) {
+	result := &strings.Builder{}
+	content := &strings.Builder{}
+	if len(l.Cells) > 0 {
+		c, err := r.renderTableRow(ctx, l, cols)
+		if err != nil {
+			return "", fmt.Errorf("unable to render footer: %w", err)
+		}
+		content.WriteString(c)
+	}
+	err := r.tableFooter.Execute(result, struct {
+		Context *renderer.Context
+		Content string
+		Cells   [][]interface{}
+	}{
+		Context: ctx,
+		Content: content.String(),
+		Cells:   l.Cells,
+	})
+	return result.String(), err
+}
+
 func (r *sgmlRenderer) renderTableRow(ctx *renderer.Context, l types.TableLine, cols []types.TableColumn) (string, error) {
 	result := &strings.Builder{}
 	content := &strings.Builder{}
@@ -196,7 +219,7 @@ func (r *sgmlRenderer) renderTableRow(ctx *renderer.Context, l types.TableLine,
 		c, err := r.renderTableCell(ctx, cell, cols[col%len(cols)])
 		col++
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render header")
+			return "", fmt.Errorf("unable to render header: %w", err)
 		}
 		content.WriteString(c)
 	}
@@ -214,22 +237,43 @@ func (r *sgmlRenderer) renderTableRow(ctx *renderer.Context, l types.TableLine,
 
 func (r *sgmlRenderer) renderTableCell(ctx *renderer.Context, cell []interface{}, col types.TableColumn) (string, error) {
 	result := &strings.Builder{}
-	content, err := r.renderInlineElements(ctx, cell)
+	var content string
+	var err error
+	doc, asciidoc := innerDocument(cell)
+	if asciidoc {
+		content, err = r.renderElements(ctx, doc.Elements)
+		content = strings.TrimSuffix(content, "\n")
+	} else {
+		content, err = r.renderInlineElements(ctx, cell)
+	}
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render header cell")
+		return "", fmt.Errorf("unable to render cell: %w", err)
 	}
 	err = r.tableCell.Execute(result, struct {
-		Context *renderer.Context
-		Content string
-		Cell    []interface{}
-		HAlign  string
-		VAlign  string
+		Context  *renderer.Context
+		Content  string
+		Cell     []interface{}
+		HAlign   string
+		VAlign   string
+		AsciiDoc bool
 	}{
-		Context: ctx,
-		Content: content,
-		Cell:    cell,
-		HAlign:  col.HAlign,
-		VAlign:  col.VAlign,
+		Context:  ctx,
+		Content:  content,
+		Cell:     cell,
+		HAlign:   col.HAlign,
+		VAlign:   col.VAlign,
+		AsciiDoc: asciidoc,
 	})
 	return result.String(), err
 }
+
+// innerDocument returns the inner document of the given cell (and `true`)
+// if the cell has the `a` style, or `false` otherwise
+func innerDocument(cell []interface{}) (types.InnerDocument, bool) {
+	if len(cell) == 1 {
+		if d, ok := cell[0].(types.InnerDocument); ok {
+			return d, true
+		}
+	}
+	return types.InnerDocument{}, false
+}
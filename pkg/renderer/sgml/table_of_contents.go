@@ -1,29 +1,53 @@
 package sgml
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
-func (r *sgmlRenderer) renderTableOfContents(ctx *renderer.Context, toc types.TableOfContents) (string, error) {
+func (r *sgmlRenderer) renderTableOfContents(ctx *renderer.Context, p types.TableOfContentsPlaceHolder) (string, error) {
+	if p.Macro && ctx.Attributes.GetAsStringWithDefault(types.AttrTableOfContents, "") != types.TableOfContentsMacro {
+		// the `toc::[]` macro is ignored unless the `toc` attribute is set to `macro`
+		return "", nil
+	}
 	log.Debug("rendering table of contents...")
-	renderedSections, err := r.renderTableOfContentsSections(ctx, toc.Sections)
+	levels, err := getTableOfContentsLevels(ctx, p.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("error while rendering table of contents: %w", err)
+	}
+	renderedSections, err := r.renderTableOfContentsSections(ctx, ctx.TableOfContents.Prune(levels).Sections)
 	if err != nil {
-		return "", errors.Wrap(err, "error while rendering table of contents")
+		return "", fmt.Errorf("error while rendering table of contents: %w", err)
 	}
 	if renderedSections == "" {
 		// nothing to render (document has no section)
 		return "", nil
 	}
+	// the title of the macro (if any) trumps the `toc-title` document attribute
+	title, err := r.renderElementTitle(p.Attributes)
+	if err != nil {
+		return "", fmt.Errorf("error while rendering table of contents: %w", err)
+	}
+	if title == "" {
+		title = ctx.Attributes.GetAsStringWithDefault(types.AttrTableOfContentsTitle, types.DefaultTableOfContentsTitle)
+	}
 	result := &strings.Builder{}
-	err = r.tocRoot.Execute(result, renderedSections)
+	err = r.tocRoot.Execute(result, struct {
+		Context *renderer.Context
+		Title   string
+		Content string
+	}{
+		Context: ctx,
+		Title:   title,
+		Content: renderedSections,
+	})
 	if err != nil {
-		return "", errors.Wrap(err, "error while rendering table of contents")
+		return "", fmt.Errorf("error while rendering table of contents: %w", err)
 	}
 	// log.Debugf("rendered ToC: %s", result.Bytes())
 	return result.String(), nil
@@ -38,7 +62,7 @@ func (r *sgmlRenderer) renderTableOfContentsSections(ctx *renderer.Context, sect
 	for _, entry := range sections {
 		buf, err := r.renderTableOfContentsEntry(ctx, entry)
 		if err != nil {
-			return "", errors.Wrap(err, "unable to render ToC section")
+			return "", fmt.Errorf("unable to render ToC section: %w", err)
 		}
 		contents.WriteString(buf)
 	}
@@ -55,7 +79,7 @@ func (r *sgmlRenderer) renderTableOfContentsSections(ctx *renderer.Context, sect
 		Sections: sections,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render document ToC")
+		return "", fmt.Errorf("failed to render document ToC: %w", err)
 	}
 	// log.Debugf("retrieved sections for ToC: %+v", sections)
 	return resultBuf.String(), nil //nolint: gosec
@@ -66,7 +90,7 @@ func (r *sgmlRenderer) renderTableOfContentsEntry(ctx *renderer.Context, entry t
 
 	content, err := r.renderTableOfContentsSections(ctx, entry.Children)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render ToC entry children")
+		return "", fmt.Errorf("unable to render ToC entry children: %w", err)
 	}
 
 	err = r.tocEntry.Execute(resultBuf, struct {
@@ -79,24 +103,30 @@ func (r *sgmlRenderer) renderTableOfContentsEntry(ctx *renderer.Context, entry t
 	}{
 		Context:  ctx,
 		Level:    entry.Level,
-		ID:       string(entry.ID),
+		ID:       escapeAnchorHref(entry.ID),
 		Title:    entry.Title,
 		Content:  content,
 		Children: entry.Children,
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "failed to render document ToC")
+		return "", fmt.Errorf("failed to render document ToC: %w", err)
 	}
 	return resultBuf.String(), nil //nolint: gosec
 }
 
-// newTableOfContents initializes a TableOfContents from the sections
-// of the given document
+// newTableOfContents initializes a TableOfContents from all the sections
+// of the given document, regardless of the `toclevels` attribute
+// (which is applied when the ToC is rendered)
 func (r *sgmlRenderer) newTableOfContents(ctx *renderer.Context, doc types.Document) (types.TableOfContents, error) {
 	sections := make([]types.ToCSection, 0, len(doc.Elements))
 	for _, e := range doc.Elements {
-		if s, ok := e.(types.Section); ok {
-			tocs, err := r.visitSection(ctx, s, 1)
+		if s, ok := e.(types.Section); ok && includeInTableOfContents(s) {
+			if skip, err := r.isSkippedByRoles(ctx, s); err != nil {
+				return types.TableOfContents{}, err
+			} else if skip {
+				continue
+			}
+			tocs, err := r.visitSection(ctx, s)
 			if err != nil {
 				return types.TableOfContents{}, err
 			}
@@ -108,22 +138,20 @@ func (r *sgmlRenderer) newTableOfContents(ctx *renderer.Context, doc types.Docum
 	}, nil
 }
 
-func (r *sgmlRenderer) visitSection(ctx *renderer.Context, section types.Section, currentLevel int) ([]types.ToCSection, error) {
-	tocLevels, err := getTableOfContentsLevels(ctx)
-	if err != nil {
-		return []types.ToCSection{}, err
-	}
+func (r *sgmlRenderer) visitSection(ctx *renderer.Context, section types.Section) ([]types.ToCSection, error) {
 	children := make([]types.ToCSection, 0, len(section.Elements))
-	// log.Debugf("visiting children section: %t (%d < %d)", currentLevel < tocLevels, currentLevel, tocLevels)
-	if currentLevel <= tocLevels {
-		for _, e := range section.Elements {
-			if s, ok := e.(types.Section); ok {
-				tocs, err := r.visitSection(ctx, s, currentLevel+1)
-				if err != nil {
-					return []types.ToCSection{}, err
-				}
-				children = append(children, tocs...)
+	for _, e := range section.Elements {
+		if s, ok := e.(types.Section); ok && includeInTableOfContents(s) {
+			if skip, err := r.isSkippedByRoles(ctx, s); err != nil {
+				return []types.ToCSection{}, err
+			} else if skip {
+				continue
 			}
+			tocs, err := r.visitSection(ctx, s)
+			if err != nil {
+				return []types.ToCSection{}, err
+			}
+			children = append(children, tocs...)
 		}
 	}
 	if section.Level == 0 {
@@ -139,14 +167,27 @@ func (r *sgmlRenderer) visitSection(ctx *renderer.Context, section types.Section
 		{
 			ID:       section.Attributes.GetAsStringWithDefault(types.AttrID, ""),
 			Level:    section.Level,
-			Title:    renderedTitle,
+			Title:    sectionTitlePrefix(ctx, section) + renderedTitle,
 			Children: children,
 		},
 	}, nil
 
 }
 
-func getTableOfContentsLevels(ctx *renderer.Context) (int, error) {
+// includeInTableOfContents returns `true` if the given section is a regular section,
+// or a discrete heading with the `toc` option (eg: `[discrete%toc]`)
+func includeInTableOfContents(s types.Section) bool {
+	return !s.IsDiscrete() || s.Attributes.HasOption(types.AttrTableOfContents)
+}
+
+// getTableOfContentsLevels returns the number of section levels to include in the ToC,
+// as specified in the `levels` attribute of the `toc::[]` macro or in the `toclevels` document attribute
+func getTableOfContentsLevels(ctx *renderer.Context, attrs types.Attributes) (int, error) {
+	if l, found, err := attrs.GetAsString(types.AttrTableOfContentsMacroLevels); err != nil {
+		return -1, err
+	} else if found {
+		return strconv.Atoi(l)
+	}
 	// log.Debugf("doc attributes: %v", ctx.Attributes)
 	if l, found, err := ctx.Attributes.GetAsString(types.AttrTableOfContentsLevels); err != nil {
 		return -1, err
@@ -12,7 +12,11 @@ type Templates struct {
 	CalloutList               string
 	CalloutListItem           string
 	CalloutRef                string
+	CaptionList               string
+	CaptionListEntry          string
+	CollapsibleBlock          string
 	DelimitedBlockParagraph   string
+	DiscreteHeading           string
 	DocumentDetails           string
 	DocumentAuthorDetails     string
 	ExampleBlock              string
@@ -26,6 +30,7 @@ type Templates struct {
 	IconFont                  string
 	IconImage                 string
 	IconText                  string
+	InlineAnchor              string
 	InlineIcon                string
 	InlineImage               string
 	InternalCrossReference    string
@@ -58,6 +63,7 @@ type Templates struct {
 	SectionHeader             string
 	SidebarBlock              string
 	SourceBlock               string
+	SourceLine                string
 	SpecialCharacter          string
 	StringElement             string
 	SubscriptText             string
@@ -65,6 +71,7 @@ type Templates struct {
 	Table                     string
 	TableBody                 string
 	TableCell                 string
+	TableFooter               string
 	TableHeader               string
 	TableHeaderCell           string
 	TableRow                  string
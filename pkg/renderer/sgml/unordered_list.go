@@ -1,12 +1,12 @@
 package sgml
 
 import (
+	"fmt"
 	"io"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc/pkg/renderer"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
-	"github.com/pkg/errors"
 )
 
 func (r *sgmlRenderer) renderUnorderedList(ctx *renderer.Context, l types.UnorderedList) (string, error) {
@@ -22,16 +22,16 @@ func (r *sgmlRenderer) renderUnorderedList(ctx *renderer.Context, l types.Unorde
 
 	for _, item := range l.Items {
 		if err := r.renderUnorderedListItem(ctx, content, item); err != nil {
-			return "", errors.Wrap(err, "unable to render unordered list")
+			return "", fmt.Errorf("unable to render unordered list: %w", err)
 		}
 	}
 	roles, err := r.renderElementRoles(ctx, l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render unordered list roles")
+		return "", fmt.Errorf("unable to render unordered list roles: %w", err)
 	}
 	title, err := r.renderElementTitle(l.Attributes)
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render callout list roles")
+		return "", fmt.Errorf("unable to render callout list roles: %w", err)
 	}
 
 	// here we must preserve the HTML tags
@@ -55,7 +55,7 @@ func (r *sgmlRenderer) renderUnorderedList(ctx *renderer.Context, l types.Unorde
 		Style:     r.renderElementStyle(l.Attributes),
 	})
 	if err != nil {
-		return "", errors.Wrap(err, "unable to render unordered list")
+		return "", fmt.Errorf("unable to render unordered list: %w", err)
 	}
 	return result.String(), nil
 }
@@ -63,7 +63,7 @@ func (r *sgmlRenderer) renderUnorderedListItem(ctx *renderer.Context, w io.Write
 
 	content, err := r.renderListElements(ctx, item.Elements)
 	if err != nil {
-		return errors.Wrap(err, "unable to render unordered list item content")
+		return fmt.Errorf("unable to render unordered list item content: %w", err)
 	}
 	return r.unorderedListItem.Execute(w, struct {
 		Context *renderer.Context
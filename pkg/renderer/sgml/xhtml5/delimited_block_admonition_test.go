@@ -251,6 +251,63 @@ an admonition text on 1 line.
 </tr>
 </table>
 </div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("admonition block with table, listing and nested admonition", func() {
+			source := `[NOTE]
+====
+|===
+|a |b
+|===
+
+----
+some listing
+----
+
+TIP: a nested admonition
+====`
+			expected := `<div class="admonitionblock note">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Note</div>
+</td>
+<td class="content">
+<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;"/>
+<col style="width: 50%;"/>
+</colgroup>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">b</p></td>
+</tr>
+</tbody>
+</table>
+<div class="listingblock">
+<div class="content">
+<pre>some listing</pre>
+</div>
+</div>
+<div class="admonitionblock tip">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Tip</div>
+</td>
+<td class="content">
+a nested admonition
+</td>
+</tr>
+</table>
+</div>
+</td>
+</tr>
+</table>
+</div>
 `
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
 		})
@@ -24,7 +24,8 @@ here
 <div class="content">
 <pre>some source code
 
-here</pre>
+here
+</pre>
 </div>
 </div>
 `
@@ -57,7 +58,8 @@ some source code
 			expected := `<div id="id-for-listing-block" class="listingblock">
 <div class="title">listing block title</div>
 <div class="content">
-<pre>some source code</pre>
+<pre>some source code
+</pre>
 </div>
 </div>
 `
@@ -109,7 +109,7 @@ ____`
 </div>
 <div class="listingblock">
 <div class="content">
-<pre>* quote</pre>
+<pre>* quote </pre>
 </div>
 </div>
 <div class="ulist">
@@ -1,8 +1,7 @@
 package xhtml5
 
 const (
-	quoteBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"quoteblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	quoteBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "quoteblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<blockquote>\n" +
 		"{{ .Content }}" +
@@ -51,7 +51,8 @@ end
 
 get '/hi' do
   "Hello World!"
-end</code></pre>
+end
+</code></pre>
 </div>
 </div>
 `
@@ -76,7 +77,8 @@ end
 
 get '/hi' do
   "Hello World!"
-end</code></pre>
+end
+</code></pre>
 </div>
 </div>
 `
@@ -177,6 +179,26 @@ printf("Hello world!\n"); // <1>
 <pre>  a&lt;&lt;b</pre>
 </div>
 </div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("with collapsible and open options", func() {
+			source := `[source%collapsible%open,go]
+.The cookie
+----
+const Cookie = "cookie"
+----`
+			expected := `<details open="open">
+<summary class="title">The cookie</summary>
+<div class="content">
+<div class="listingblock">
+<div class="content">
+<pre class="highlight"><code class="language-go" data-lang="go">const Cookie = "cookie"</code></pre>
+</div>
+</div>
+</div>
+</details>
 `
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
 		})
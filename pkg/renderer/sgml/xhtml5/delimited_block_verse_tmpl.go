@@ -1,8 +1,7 @@
 package xhtml5
 
 const (
-	verseBlockTmpl = `<div {{ if .ID }}id="{{ .ID }}" {{ end }}` +
-		"class=\"verseblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	verseBlockTmpl = `<div{{ attributes "id" .ID "class" (classes "verseblock" .Roles) }}>` + "\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Title }}</div>\n{{ end }}" +
 		"<pre class=\"content\">{{ .Content }}</pre>\n" +
 		"{{ if .Attribution.First }}<div class=\"attribution\">\n&#8212; {{ .Attribution.First }}" +
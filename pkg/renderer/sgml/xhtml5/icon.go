@@ -10,12 +10,7 @@ const (
 
 	iconImageTmpl = `<img src="{{ .Path }}"` +
 		`{{ if .Alt }} alt="{{ .Alt }}{{ end }}"` +
-		`{{ if .Width }} width="{{ .Width }}"{{ end }}` +
-		`{{ if .Height }} height="{{ .Height }}"{{ end }}` +
-		`{{ if .Title }} title="{{ .Title }}"{{ end }}` +
-		`{{ if or .Size .Rotate .Flip }} class="` +
-		`{{ if .Size }}fa-{{ .Size }}{{ end }}` +
-		`{{ if .Rotate }}{{ if .Size }} {{ end }}fa-rotate-{{ .Rotate }}{{ end }}` +
-		`{{ if .Flip }}{{ if or .Size .Rotate }} {{ end }}fa-flip-{{ .Flip }}{{ end }}` +
-		`"{{ end }}/>`
+		`{{ attributes ` +
+		`"class" (classes (and .Size (print "fa-" .Size)) (and .Rotate (print "fa-rotate-" .Rotate)) (and .Flip (print "fa-flip-" .Flip))) ` +
+		`"width" .Width "height" .Height "title" .Title }}/>`
 )
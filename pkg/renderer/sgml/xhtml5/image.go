@@ -1,24 +1,28 @@
 package xhtml5
 
 const (
-	blockImageTmpl = "<div" +
-		"{{ if .ID }} id=\"{{ .ID }}\"{{ end }}" +
-		" class=\"imageblock{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+	blockImageTmpl = `<div{{ attributes "id" .ID "class" (classes "imageblock" .Roles) }}>` + "\n" +
 		"<div class=\"content\">\n" +
 		`{{ if .Href }}<a class="image" href="{{ .Href }}">{{ end }}` +
 		`<img src="{{ .Path }}" alt="{{ .Alt }}"` +
 		`{{ if .Width }} width="{{ .Width }}"{{ end }}` +
 		`{{ if .Height }} height="{{ .Height }}"{{ end }}` +
+		`{{ if .Srcset }} srcset="{{ .Srcset }}"{{ end }}` +
+		`{{ if .Sizes }} sizes="{{ .Sizes }}"{{ end }}` +
+		`{{ if .Loading }} loading="{{ .Loading }}"{{ end }}` +
 		"/>{{ if .Href }}</a>{{ end }}\n" +
 		"</div>\n" +
 		"{{ if .Title }}<div class=\"title\">{{ .Caption }}{{ .Title }}</div>\n{{ end }}" +
 		"</div>\n"
 
-	inlineImageTmpl = `<span class="image{{ if .Roles }} {{ .Roles }}{{ end }}">` +
-		`{{ if .Href }}<a class="image" href="{{ .Href }}">{{ end }}` +
+	inlineImageTmpl = `<span{{ attributes "class" (classes "image" .Roles) }}>` +
+		`{{ if .Href }}<a class="image" href="{{ .Href }}"{{ if .Window }} target="{{ .Window }}"{{ end }}>{{ end }}` +
 		`<img src="{{ .Path }}" alt="{{ .Alt }}"` +
 		`{{ if .Width }} width="{{ .Width }}"{{ end }}` +
 		`{{ if .Height }} height="{{ .Height }}"{{ end }}` +
+		`{{ if .Srcset }} srcset="{{ .Srcset }}"{{ end }}` +
+		`{{ if .Sizes }} sizes="{{ .Sizes }}"{{ end }}` +
+		`{{ if .Loading }} loading="{{ .Loading }}"{{ end }}` +
 		`{{ if .Title }} title="{{ .Title }}"{{ end }}` +
 		`/>{{ if .Href }}</a>{{ end }}</span>`
 )
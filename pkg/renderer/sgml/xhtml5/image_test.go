@@ -59,6 +59,20 @@ var _ = Describe("images", func() {
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("block image with title, float and align in block attributes", func() {
+			source := `.a title
+[.role1,float=right,align=center]
+image::foo.png[]`
+			expected := `<div class="imageblock right text-center role1">
+<div class="content">
+<img src="foo.png" alt="foo"/>
+</div>
+<div class="title">Figure 1. a title</div>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("block image with custom caption", func() {
 
 			source := ".Image Title\nimage::foo.png[foo image, 600, 400,caption=\"Bar A. \"]"
@@ -142,6 +156,17 @@ image::foo.png[foo image, 600, 400]`
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("block image with srcset, sizes and lazy option", func() {
+			source := `image::foo.png[foo image, opts=lazy, srcset="foo-320.png 320w, foo-640.png 640w", sizes="(max-width: 600px) 320px, 640px"]`
+			expected := `<div class="imageblock">
+<div class="content">
+<img src="foo.png" alt="foo image" srcset="foo-320.png 320w, foo-640.png 640w" sizes="(max-width: 600px) 320px, 640px" loading="lazy"/>
+</div>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("2 block images", func() {
 			source := `image::app.png[]
 image::appa.png[]`
@@ -210,6 +235,24 @@ image::appa.png[]`
 				Expect(RenderXHTML(source)).To(MatchHTML(expected))
 			})
 
+			It("inline image with link, window, title, dimensions and role", func() {
+				source := `image:foo.png[foo image, 60, 40, title="the foo image", link=https://foo.bar/?a=1&b=2, window=_blank, role=middle]`
+				expected := `<div class="paragraph">
+<p><span class="image middle"><a class="image" href="https://foo.bar/?a=1&amp;b=2" target="_blank"><img src="foo.png" alt="foo image" width="60" height="40" title="the foo image"/></a></span></p>
+</div>
+`
+				Expect(RenderXHTML(source)).To(MatchHTML(expected))
+			})
+
+			It("inline image with srcset and loading attribute", func() {
+				source := `image:foo.png[foo image, srcset="foo-1x.png 1x, foo-2x.png 2x", loading=lazy]`
+				expected := `<div class="paragraph">
+<p><span class="image"><img src="foo.png" alt="foo image" srcset="foo-1x.png 1x, foo-2x.png 2x" loading="lazy"/></span></p>
+</div>
+`
+				Expect(RenderXHTML(source)).To(MatchHTML(expected))
+			})
+
 			It("paragraph with inline image with alt and dimensions", func() {
 				source := "a foo image:foo.png[foo image, 600, 400] bar"
 				expected := `<div class="paragraph">
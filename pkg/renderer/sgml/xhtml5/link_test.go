@@ -1,6 +1,7 @@
 package xhtml5_test
 
 import (
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
 	. "github.com/onsi/ginkgo" //nolint golint
@@ -21,6 +22,24 @@ var _ = Describe("links", func() {
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("external link with custom scheme", func() {
+			source := "a link to ssh://git@example.com[repo]."
+			expected := `<div class="paragraph">
+<p>a link to <a href="ssh://git@example.com">repo</a>.</p>
+</div>
+`
+			Expect(RenderXHTML(source, configuration.WithURLScheme("ssh://"))).To(MatchHTML(expected))
+		})
+
+		It("external link escaped with a backslash", func() {
+			source := `a link to \https://example.com[].`
+			expected := `<div class="paragraph">
+<p>a link to https://example.com[].</p>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("external link with quoted text", func() {
 			source := "https://foo.com[_a_ *b* `c`]"
 			expected := `<div class="paragraph">
@@ -153,7 +172,7 @@ a link to {scheme}://{path} and https://foo.baz`
 		It("relative link to doc without text", func() {
 			source := "a link to link:foo.adoc[]."
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc" class="bare">foo.adoc</a>.</p>
+<p>a link to <a href="foo.html" class="bare">foo.adoc</a>.</p>
 </div>
 `
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
@@ -162,7 +181,7 @@ a link to {scheme}://{path} and https://foo.baz`
 		It("relative link to doc with text", func() {
 			source := "a link to link:foo.adoc[foo doc]."
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc">foo doc</a>.</p>
+<p>a link to <a href="foo.html">foo doc</a>.</p>
 </div>
 `
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
@@ -171,7 +190,7 @@ a link to {scheme}://{path} and https://foo.baz`
 		It("relative link with text having comma", func() {
 			source := `a link to link:foo.adoc[A, B, and C]` // `B` and `and C` are considered as other positional attributes
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc">A</a></p>
+<p>a link to <a href="foo.html">A</a></p>
 </div>
 `
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
@@ -180,7 +199,48 @@ a link to {scheme}://{path} and https://foo.baz`
 		It("relative link with quoted text having comma", func() {
 			source := `a link to link:foo.adoc["A, B, and C"]`
 			expected := `<div class="paragraph">
-<p>a link to <a href="foo.adoc">A, B, and C</a></p>
+<p>a link to <a href="foo.html">A, B, and C</a></p>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to doc with fragment", func() {
+			source := "a link to link:../guide/index.adoc#install[Guide]."
+			expected := `<div class="paragraph">
+<p>a link to <a href="../guide/index.html#install">Guide</a>.</p>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to doc with custom outfilesuffix", func() {
+			source := `:outfilesuffix: .xhtml
+
+a link to link:foo.adoc[foo doc].`
+			expected := `<div class="paragraph">
+<p>a link to <a href="foo.xhtml">foo doc</a>.</p>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to doc with custom relfilesuffix", func() {
+			source := `:outfilesuffix: .xhtml
+:relfilesuffix: /
+
+a link to link:foo.adoc[foo doc].`
+			expected := `<div class="paragraph">
+<p>a link to <a href="foo/">foo doc</a>.</p>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
+		It("relative link to other file", func() {
+			source := "a link to link:foo.pdf[foo doc]."
+			expected := `<div class="paragraph">
+<p>a link to <a href="foo.pdf">foo doc</a>.</p>
 </div>
 `
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
@@ -191,6 +191,27 @@ warning!
 			Expect(RenderXHTML(source)).To(MatchHTML(expected))
 		})
 
+		It("multiline admonition paragraph with hardbreaks", func() {
+			source := `[%hardbreaks]
+TIP: this is a multiline
+tip!`
+			expected := `<div class="admonitionblock tip">
+<table>
+<tr>
+<td class="icon">
+<div class="title">Tip</div>
+</td>
+<td class="content">
+this is a multiline<br/>
+tip!
+</td>
+</tr>
+</table>
+</div>
+`
+			Expect(RenderXHTML(source)).To(MatchHTML(expected))
+		})
+
 		It("admonition note paragraph with id and title", func() {
 			source := `[[foo]]
 .bar
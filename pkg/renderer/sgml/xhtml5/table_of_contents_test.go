@@ -198,7 +198,7 @@ var _ = Describe("table of contents initialization", func() {
 
 		It("should return empty table of contents when doc has no section", func() {
 			actual := types.Document{
-				Attributes:        types.Attributes{},
+				Attributes:        types.DocumentAttributes{},
 				ElementReferences: types.ElementReferences{},
 				Footnotes:         []types.Footnote{},
 				Elements: []interface{}{
@@ -251,7 +251,7 @@ var _ = Describe("table of contents initialization", func() {
 			types.StringElement{Content: "Section B"},
 		}
 		document := types.Document{
-			Attributes: types.Attributes{},
+			Attributes: types.DocumentAttributes{},
 			ElementReferences: types.ElementReferences{
 				"_a_header":    doctitle,
 				"_section_a":   sectionATitle,
@@ -344,7 +344,7 @@ var _ = Describe("table of contents initialization", func() {
 		}
 
 		It("should return table of contents with section level 1,2,3,2 with default level", func() {
-			delete(document.Attributes, types.AttrTableOfContentsLevels)
+			document.Attributes = types.DocumentAttributes{}
 			expected := types.TableOfContents{
 				Sections: []types.ToCSection{
 					{
@@ -373,7 +373,7 @@ var _ = Describe("table of contents initialization", func() {
 		})
 
 		It("should return table of contents with section level 1,2,3,2 with custom level", func() {
-			document.Attributes[types.AttrTableOfContentsLevels] = "4" // must be a string
+			document.Attributes = document.Attributes.Set(types.AttrTableOfContentsLevels, "4") // must be a string
 			expected := types.TableOfContents{
 				Sections: []types.ToCSection{
 					{
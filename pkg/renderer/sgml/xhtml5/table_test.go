@@ -363,6 +363,54 @@ var _ = Describe("tables", func() {
 		Expect(RenderXHTML(source)).To(MatchHTML(expected))
 	})
 
+	It("table with header and footer options", func() {
+		source := "[options=\"header,footer\"]\n|===\n|h1|h2\n|a|b\n|f1|f2\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;"/>
+<col style="width: 50%;"/>
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">h1</th>
+<th class="tableblock halign-left valign-top">h2</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">a</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">b</p></td>
+</tr>
+</tbody>
+<tfoot>
+<tr>
+<td class="tableblock halign-left valign-top"><p class="tableblock">f1</p></td>
+<td class="tableblock halign-left valign-top"><p class="tableblock">f2</p></td>
+</tr>
+</tfoot>
+</table>
+`
+		Expect(RenderXHTML(source)).To(MatchHTML(expected))
+	})
+
+	It("table with header only", func() {
+		source := "[%header]\n|===\n|h1|h2\n|==="
+		expected := `<table class="tableblock frame-all grid-all stretch">
+<colgroup>
+<col style="width: 50%;"/>
+<col style="width: 50%;"/>
+</colgroup>
+<thead>
+<tr>
+<th class="tableblock halign-left valign-top">h1</th>
+<th class="tableblock halign-left valign-top">h2</th>
+</tr>
+</thead>
+</table>
+`
+		Expect(RenderXHTML(source)).To(MatchHTML(expected))
+	})
+
 	It("table with cols relative widths", func() {
 		source := "[cols=\"3,2,5\"]\n|===\n|one|two|three\n|==="
 		expected := `<table class="tableblock frame-all grid-all stretch">
@@ -20,6 +20,7 @@ func init() {
 	templates.Article = articleTmpl
 	templates.BlankLine = blankLineTmpl
 	templates.BlockImage = blockImageTmpl
+	templates.CollapsibleBlock = collapsibleBlockTmpl
 	templates.LineBreak = lineBreakTmpl
 	templates.DocumentAuthorDetails = documentAuthorDetailsTmpl
 	templates.DocumentDetails = documentDetailsTmpl
@@ -44,6 +45,12 @@ func Render(ctx *renderer.Context, doc types.Document, output io.Writer) (types.
 	return defaultRenderer.Render(ctx, doc, output)
 }
 
+// RenderElement renders a single block or inline element in XHTML5, using a default instance
+// of the renderer, with default templates.
+func RenderElement(ctx *renderer.Context, element interface{}) (string, error) {
+	return defaultRenderer.(sgml.ElementRenderer).RenderElement(ctx, element)
+}
+
 // Templates returns the default Templates use for HTML5.  It may be useful
 // for derived implementations.
 func Templates() sgml.Templates {
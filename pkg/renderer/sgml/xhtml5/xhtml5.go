@@ -12,17 +12,15 @@ const (
 		"{{ if .CSS}}<link type=\"text/css\" rel=\"stylesheet\" href=\"{{ .CSS }}\"/>\n{{ end }}" +
 		"<title>{{ .Title }}</title>\n" +
 		"</head>\n" +
-		"<body" +
-		"{{ if .ID }} id=\"{{ .ID }}\"{{ end }}" +
-		" class=\"{{ .Doctype }}{{ if .Roles }} {{ .Roles }}{{ end }}\">\n" +
+		`<body{{ attributes "id" .ID "class" (classes .Doctype .Roles) }}>` + "\n" +
 		"{{ if .IncludeHTMLBodyHeader }}{{ .Header }}{{ end }}" +
 		"<div id=\"content\">\n" +
 		"{{ .Content }}" +
 		"</div>\n" +
 		"{{ if .IncludeHTMLBodyFooter }}<div id=\"footer\">\n" +
 		"<div id=\"footer-text\">\n" +
-		"{{ if .RevNumber }}Version {{ .RevNumber }}<br/>\n{{ end }}" +
-		"Last updated {{ .LastUpdated }}\n" +
+		"{{ if .RevNumber }}{{ .VersionLabel }} {{ .RevNumber }}<br/>\n{{ end }}" +
+		"{{ .LastUpdateLabel }} {{ .LastUpdated }}\n" +
 		"</div>\n" +
 		"</div>\n{{ end }}" +
 		"</body>\n" +
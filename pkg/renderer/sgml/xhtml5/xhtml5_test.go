@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/bytesparadise/libasciidoc/pkg/renderer/sgml/xhtml5"
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	. "github.com/bytesparadise/libasciidoc/testsupport"
 
@@ -60,7 +62,7 @@ Last updated {{.LastUpdated}}
 <title>My Title</title>
 </head>
 <body class="article my_role">
-<div id="header">
+<div id="header" class="my_role">
 <h1>My Title</h1>
 </div>
 <div id="content">
@@ -94,7 +96,7 @@ Last updated {{.LastUpdated}}
 <title>My Title</title>
 </head>
 <body id="anchor" class="article role1 role2">
-<div id="header">
+<div id="header" class="role1 role2">
 <h1>My Title</h1>
 </div>
 <div id="content">
@@ -114,6 +116,103 @@ Last updated {{.LastUpdated}}
 			To(MatchHTMLTemplate(expected, now))
 	})
 
+	It("header with subtitle and default separator", func() {
+		source := `= Main Title: Sub: *Title*
+:title-separator:`
+		expected := `<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" lang="en">
+<head>
+<meta charset="UTF-8"/>
+<meta http-equiv="X-UA-Compatible" content="IE=edge"/>
+<meta name="viewport" content="width=device-width, initial-scale=1.0"/>
+<meta name="generator" content="libasciidoc"/>
+<title>Main Title: Sub: Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Main Title: Sub</h1>
+<div class="subtitle"><strong>Title</strong></div>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderXHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
+	It("header with subtitle and custom separator", func() {
+		source := `= Main Title: Sub -- Title
+:title-separator: --`
+		expected := `<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" lang="en">
+<head>
+<meta charset="UTF-8"/>
+<meta http-equiv="X-UA-Compatible" content="IE=edge"/>
+<meta name="viewport" content="width=device-width, initial-scale=1.0"/>
+<meta name="generator" content="libasciidoc"/>
+<title>Main Title: Sub` + "\u2009\u2014\u2009" + `Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Main Title: Sub</h1>
+<div class="subtitle">Title</div>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderXHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
+	It("header without title separator", func() {
+		source := `= Main Title: Sub Title`
+		expected := `<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" lang="en">
+<head>
+<meta charset="UTF-8"/>
+<meta http-equiv="X-UA-Compatible" content="IE=edge"/>
+<meta name="viewport" content="width=device-width, initial-scale=1.0"/>
+<meta name="generator" content="libasciidoc"/>
+<title>Main Title: Sub Title</title>
+</head>
+<body class="article">
+<div id="header">
+<h1>Main Title: Sub Title</h1>
+</div>
+<div id="content">
+</div>
+<div id="footer">
+<div id="footer-text">
+Last updated {{.LastUpdated}}
+</div>
+</div>
+</body>
+</html>
+`
+		now := time.Now()
+		Expect(RenderXHTML(source, configuration.WithHeaderFooter(true),
+			configuration.WithLastUpdated(now))).
+			To(MatchHTMLTemplate(expected, now))
+	})
+
 	It("should include adoc file without leveloffset from relative file", func() {
 		source := "include::../../../../../test/includes/grandchild-include.adoc[]" // with filename `tmp/foo.adoc`, we are virtually in a subfolder
 		expectedContent := `<div class="sect1">
@@ -455,3 +554,57 @@ Free use of this software is granted under the terms of the MIT License.</p>
 			To(MatchHTMLTemplate(expected, now))
 	})
 })
+
+var _ = Describe("element rendering", func() {
+
+	It("should render paragraph", func() {
+		// given
+		ctx := renderer.NewContext(types.Document{}, configuration.NewConfiguration())
+		element := types.Paragraph{
+			Lines: [][]interface{}{
+				{
+					types.StringElement{Content: "some "},
+					types.QuotedText{
+						Kind: types.SingleQuoteBold,
+						Elements: []interface{}{
+							types.StringElement{Content: "bold"},
+						},
+					},
+					types.StringElement{Content: " content"},
+				},
+				{
+					types.StringElement{Content: "on two lines"},
+				},
+			},
+		}
+		// when
+		result, err := xhtml5.RenderElement(ctx, element)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(`<div class="paragraph">
+<p>some <strong>bold</strong> content
+on two lines</p>
+</div>
+`))
+	})
+
+	It("should render inline elements", func() {
+		// given
+		ctx := renderer.NewContext(types.Document{}, configuration.NewConfiguration())
+		elements := []interface{}{
+			types.StringElement{Content: "some "},
+			types.QuotedText{
+				Kind: types.SingleQuoteItalic,
+				Elements: []interface{}{
+					types.StringElement{Content: "italic"},
+				},
+			},
+			types.LineBreak{},
+		}
+		// when
+		result, err := xhtml5.RenderElement(ctx, elements)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal("some <em>italic</em><br/>"))
+	})
+})
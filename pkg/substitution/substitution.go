@@ -0,0 +1,170 @@
+// Package substitution defines the names and the order of the substitutions
+// which are applied on the inline elements of paragraphs, delimited blocks and titles,
+// and resolves the value of the `subs` attribute into an explicit, ordered pipeline.
+package substitution
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedSubstitution the error returned when a `subs` attribute refers to an unknown substitution
+var ErrUnsupportedSubstitution = errors.New("unsupported substitution")
+
+// Names of the supported substitutions
+const (
+	// InlinePassthrough the inline passthrough substitution (protects the content of inline passthroughs from other substitutions)
+	InlinePassthrough string = "inline_passthrough"
+	// Callouts the callouts substitution
+	Callouts string = "callouts"
+	// SpecialCharacters the special characters substitution
+	SpecialCharacters string = "specialcharacters"
+	// SpecialChars the short name of the special characters substitution
+	SpecialChars string = "specialchars"
+	// Quotes the quoted texts substitution
+	Quotes string = "quotes"
+	// Attributes the attribute references substitution
+	Attributes string = "attributes"
+	// Replacements the textual symbols replacements substitution
+	Replacements string = "replacements"
+	// Macros the inline macros substitution
+	Macros string = "macros"
+	// PostReplacements the line breaks substitution
+	PostReplacements string = "post_replacements"
+	// None the substitution which does nothing
+	None string = "none"
+	// Normal the group of substitutions applied on regular paragraphs
+	Normal string = "normal"
+)
+
+// Pipeline an ordered list of substitutions to apply
+type Pipeline []string
+
+// NormalPipeline the substitutions of the `normal` group, in order
+var NormalPipeline = Pipeline{
+	SpecialCharacters,
+	Quotes,
+	Attributes,
+	Replacements,
+	Macros,
+	PostReplacements,
+}
+
+// InlinePipeline the substitutions applied on paragraphs, blocks of blocks and titles, in order
+var InlinePipeline = append(Pipeline{InlinePassthrough}, NormalPipeline...)
+
+// VerbatimPipeline the substitutions applied on verbatim blocks (listing, literal, etc.), in order
+var VerbatimPipeline = Pipeline{
+	Callouts, // must be executed before "specialcharacters"
+	SpecialCharacters,
+}
+
+// AttributesPipeline the substitutions applied on locations (eg: image paths)
+var AttributesPipeline = Pipeline{
+	Attributes,
+}
+
+// Resolve returns the pipeline of substitutions corresponding to the given `subs`,
+// with the given defaults used as a starting point when the first
+// substitution is an incremental one (eg: `+quotes`, `quotes+` or `-quotes`)
+func Resolve(subs []string, defaults Pipeline) (Pipeline, error) {
+	result := make(Pipeline, 0, len(InlinePipeline)+1)
+	for _, s := range subs {
+		s = strings.TrimSpace(s)
+		switch {
+		case s == Normal:
+			result = result.append(NormalPipeline...)
+		case strings.HasPrefix(s, "+"):
+			name := strings.TrimPrefix(s, "+")
+			if !isIncremental(name) {
+				return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedSubstitution, s)
+			}
+			if len(result) == 0 {
+				result = result.append(defaults...)
+			}
+			result = result.append(name)
+		case strings.HasSuffix(s, "+"):
+			name := strings.TrimSuffix(s, "+")
+			if !isIncremental(name) {
+				return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedSubstitution, s)
+			}
+			if len(result) == 0 {
+				result = result.append(defaults...)
+			}
+			result = result.prepend(name)
+		case strings.HasPrefix(s, "-"):
+			name := strings.TrimPrefix(s, "-")
+			if !isIncremental(name) {
+				return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedSubstitution, s)
+			}
+			if len(result) == 0 {
+				result = result.append(defaults...)
+			}
+			result = result.remove(name)
+		case isSupported(s):
+			result = result.append(s)
+		default:
+			return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedSubstitution, s)
+		}
+	}
+	return result, nil
+}
+
+// abbreviations the short names of the substitutions which can be used in the inline passthrough macro (eg: `pass:q,a[]`)
+var abbreviations = map[string]string{
+	"a": Attributes,
+	"c": SpecialCharacters,
+	"m": Macros,
+	"n": Normal,
+	"p": PostReplacements,
+	"q": Quotes,
+	"r": Replacements,
+}
+
+// ResolveInline returns the pipeline of substitutions corresponding to the
+// comma-separated `subs` of an inline passthrough macro, in which
+// substitutions can also be referred to by their abbreviation (eg: `q` for `quotes`)
+func ResolveInline(subs string) (Pipeline, error) {
+	names := strings.Split(subs, ",")
+	for i, n := range names {
+		n = strings.TrimSpace(n)
+		if name, found := abbreviations[n]; found {
+			n = name
+		}
+		names[i] = n
+	}
+	return Resolve(names, Pipeline{})
+}
+
+func isSupported(name string) bool {
+	switch name {
+	case InlinePassthrough, Callouts, SpecialCharacters, SpecialChars, Quotes, Attributes, Replacements, Macros, PostReplacements, None:
+		return true
+	default:
+		return false
+	}
+}
+
+// the inline passthrough substitution cannot be added or removed incrementally
+func isIncremental(name string) bool {
+	return name != InlinePassthrough && isSupported(name)
+}
+
+func (p Pipeline) append(others ...string) Pipeline {
+	return append(p, others...)
+}
+
+func (p Pipeline) prepend(other string) Pipeline {
+	return append(Pipeline{other}, p...)
+}
+
+func (p Pipeline) remove(other string) Pipeline {
+	for i, s := range p {
+		if s == other {
+			return append(p[:i], p[i+1:]...)
+		}
+	}
+	// unchanged
+	return p
+}
@@ -0,0 +1,13 @@
+package substitution_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+func TestSubstitution(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Substitution Suite")
+}
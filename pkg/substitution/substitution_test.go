@@ -0,0 +1,145 @@
+package substitution_test
+
+import (
+	"errors"
+
+	"github.com/bytesparadise/libasciidoc/pkg/substitution"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("substitution resolution", func() {
+
+	It("should resolve normal subs", func() {
+		Expect(substitution.Resolve([]string{"normal"}, substitution.VerbatimPipeline)).To(Equal(substitution.NormalPipeline))
+	})
+
+	It("should resolve explicit subs in the given order", func() {
+		Expect(substitution.Resolve([]string{"macros", "quotes"}, substitution.InlinePipeline)).To(Equal(substitution.Pipeline{"macros", "quotes"}))
+	})
+
+	It("should resolve subs with surrounding spaces", func() {
+		Expect(substitution.Resolve([]string{"attributes", " quotes"}, substitution.InlinePipeline)).To(Equal(substitution.Pipeline{"attributes", "quotes"}))
+	})
+
+	It("should append sub to defaults", func() {
+		Expect(substitution.Resolve([]string{"+quotes"}, substitution.VerbatimPipeline)).To(Equal(substitution.Pipeline{"callouts", "specialcharacters", "quotes"}))
+	})
+
+	It("should prepend sub to defaults", func() {
+		Expect(substitution.Resolve([]string{"attributes+"}, substitution.VerbatimPipeline)).To(Equal(substitution.Pipeline{"attributes", "callouts", "specialcharacters"}))
+	})
+
+	It("should remove sub from defaults", func() {
+		Expect(substitution.Resolve([]string{"-quotes", "-macros"}, substitution.InlinePipeline)).To(Equal(substitution.Pipeline{"inline_passthrough", "specialcharacters", "attributes", "replacements", "post_replacements"}))
+	})
+
+	It("should not alter defaults", func() {
+		_, err := substitution.Resolve([]string{"-specialcharacters"}, substitution.InlinePipeline)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(substitution.InlinePipeline).To(Equal(substitution.Pipeline{"inline_passthrough", "specialcharacters", "quotes", "attributes", "replacements", "macros", "post_replacements"}))
+	})
+
+	It("should fail on unsupported sub", func() {
+		_, err := substitution.Resolve([]string{"unknown"}, substitution.InlinePipeline)
+		Expect(err).To(MatchError("unsupported substitution: 'unknown'"))
+		Expect(errors.Is(err, substitution.ErrUnsupportedSubstitution)).To(BeTrue())
+	})
+
+	It("should fail on incremental inline passthrough sub", func() {
+		_, err := substitution.Resolve([]string{"-inline_passthrough"}, substitution.InlinePipeline)
+		Expect(err).To(MatchError("unsupported substitution: '-inline_passthrough'"))
+	})
+
+	It("should fail on unsupported incremental sub", func() {
+		_, err := substitution.Resolve([]string{"+unknown"}, substitution.InlinePipeline)
+		Expect(err).To(MatchError("unsupported substitution: '+unknown'"))
+	})
+})
+
+var _ = Describe("substitution pipeline", func() {
+
+	It("should append sub", func() {
+		// given
+		defaults := substitution.Pipeline{"attributes", "quotes"}
+		// when
+		f, err := substitution.Resolve([]string{"+macros"}, defaults)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).To(Equal(substitution.Pipeline{"attributes", "quotes", "macros"}))
+	})
+
+	It("should append subs", func() {
+		// given
+		defaults := substitution.Pipeline{"attributes"}
+		// when
+		f, err := substitution.Resolve([]string{"+quotes", "+macros"}, defaults)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).To(Equal(substitution.Pipeline{"attributes", "quotes", "macros"}))
+	})
+
+	It("should prepend sub", func() {
+		// given
+		defaults := substitution.Pipeline{"attributes", "quotes"}
+		// when
+		f, err := substitution.Resolve([]string{"macros+"}, defaults)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).To(Equal(substitution.Pipeline{"macros", "attributes", "quotes"}))
+	})
+
+	It("should remove first sub", func() {
+		// given
+		defaults := substitution.Pipeline{"attributes", "quotes", "macros"}
+		// when
+		f, err := substitution.Resolve([]string{"-attributes"}, defaults)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).To(Equal(substitution.Pipeline{"quotes", "macros"}))
+	})
+
+	It("should remove middle sub", func() {
+		// given
+		defaults := substitution.Pipeline{"attributes", "quotes", "macros"}
+		// when
+		f, err := substitution.Resolve([]string{"-quotes"}, defaults)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).To(Equal(substitution.Pipeline{"attributes", "macros"}))
+	})
+
+	It("should remove last sub", func() {
+		// given
+		defaults := substitution.Pipeline{"attributes", "quotes", "macros"}
+		// when
+		f, err := substitution.Resolve([]string{"-macros"}, defaults)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).To(Equal(substitution.Pipeline{"attributes", "quotes"}))
+	})
+
+	It("should not remove non existing sub", func() {
+		// given
+		defaults := substitution.Pipeline{"attributes", "quotes", "macros"}
+		// when
+		f, err := substitution.Resolve([]string{"-replacements"}, defaults)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f).To(Equal(substitution.Pipeline{"attributes", "quotes", "macros"}))
+	})
+
+	It("should resolve inline subs with abbreviations", func() {
+		Expect(substitution.ResolveInline("q, a,macros")).To(Equal(substitution.Pipeline{"quotes", "attributes", "macros"}))
+	})
+
+	It("should resolve inline normal subs", func() {
+		Expect(substitution.ResolveInline("n")).To(Equal(substitution.NormalPipeline))
+	})
+
+	It("should fail on unsupported inline sub", func() {
+		_, err := substitution.ResolveInline("q,x")
+		Expect(err).To(MatchError("unsupported substitution: 'x'"))
+	})
+})
@@ -32,10 +32,33 @@ const (
 	AttrTableOfContents = "toc"
 	// AttrTableOfContentsLevels the document attribute which specifies the number of levels to display in the ToC
 	AttrTableOfContentsLevels = "toclevels"
+	// AttrTableOfContentsMacroLevels the `levels` attribute of the `toc::[]` macro, which overrides the `toclevels` document attribute
+	AttrTableOfContentsMacroLevels = "levels"
+	// TableOfContentsMacro the value of the `toc` document attribute to render the ToC at the location of the `toc::[]` macro
+	TableOfContentsMacro = "macro"
+	// AttrSectionNumbers the document attribute to number the sections (eg: `1.2. A Section`)
+	AttrSectionNumbers = "sectnums"
+	// AttrSectionNumberLevels the document attribute which specifies the number of section levels to number,
+	// regardless of the number of levels displayed in the ToC
+	AttrSectionNumberLevels = "sectnumlevels"
+	// DefaultSectionNumberLevels the default number of section levels to number
+	DefaultSectionNumberLevels = 3
+	// AttrSectionNumber the number of a section (eg: `1.2`), when the sections are numbered
+	AttrSectionNumber = "section-number"
+	// AttrTableOfContentsTitle the document attribute which specifies the title of the ToC
+	AttrTableOfContentsTitle = "toc-title"
+	// DefaultTableOfContentsTitle the default title of the ToC
+	DefaultTableOfContentsTitle = "Table of Contents"
 	// AttrNoHeader attribute to disable the rendering of document footer
 	AttrNoHeader = "noheader"
 	// AttrNoFooter attribute to disable the rendering of document footer
 	AttrNoFooter = "nofooter"
+	// AttrTitleSeparator the key to retrieve the separator between the document title and subtitle
+	AttrTitleSeparator = "title-separator"
+	// DefaultTitleSeparator the default separator between the document title and subtitle
+	DefaultTitleSeparator = ":"
+	// AttrReftext the key to retrieve the text to use in cross references to an element
+	AttrReftext = "reftext"
 	// AttrCustomID the key to retrieve the flag that indicates if the element ID is custom or generated
 	AttrCustomID = "@customID"
 	// AttrTitle the key to retrieve the title
@@ -60,17 +83,25 @@ const (
 	AttrQuoteAuthor = "quoteAuthor"
 	// AttrQuoteTitle attribute for the title of a verse
 	AttrQuoteTitle = "quoteTitle"
+	// AttrAttribution the named attribute for the author of a quote or a verse (eg: `[quote, attribution="Albert Einstein"]`)
+	AttrAttribution = "attribution"
+	// AttrCiteTitle the named attribute for the title of the work cited in a quote or a verse (eg: `[quote, citetitle="Why Socialism?"]`)
+	AttrCiteTitle = "citetitle"
 	// AttrSource the `source` attribute for a source block or a source paragraph (this is a placeholder, ie, it does not expect any value for this attribute)
 	AttrSource = "source"
 	// AttrLanguage the `language` attribute for a source block or a source paragraph
 	AttrLanguage = "language"
 	// AttrLineNums the `linenums` attribute for a source block or a source paragraph
 	AttrLineNums = "linenums"
+	// AttrHighlight the `highlight` attribute for a source block, to emphasize some ranges of lines
+	AttrHighlight = "highlight"
 	// AttrCheckStyle the attribute to mark the first element of an unordered list item as a checked or not
 	AttrCheckStyle = "checkstyle"
 	// AttrInteractive the attribute to mark the first element of an unordered list item as n interactive checkbox or not
 	// (paired with `AttrCheckStyle`)
 	AttrInteractive = "interactive"
+	// AttrNoTitle the `notitle` option on a discrete heading, to exclude it from the cross reference targets
+	AttrNoTitle = "notitle"
 	// AttrStart the `start` attribute in an ordered list
 	AttrStart = "start"
 	// AttrLevelOffset the `leveloffset` attribute used in file inclusions
@@ -89,6 +120,12 @@ const (
 	AttrImageWindow = "window"
 	// AttrImageAlign is for image alignment
 	AttrImageAlign = "align"
+	// AttrImageSrcset the image `srcset` attribute, with the candidate images for responsive images
+	AttrImageSrcset = "srcset"
+	// AttrImageSizes the image `sizes` attribute, paired with `AttrImageSrcset`
+	AttrImageSizes = "sizes"
+	// AttrImageLoading the image `loading` attribute (eg: `lazy`)
+	AttrImageLoading = "loading"
 	// AttrIconSize the icon `size`, and can be one of 1x, 2x, 3x, 4x, 5x, lg, fw
 	AttrIconSize = "size"
 	// AttrIconRotate the icon `rotate` attribute, and can be one of 90, 180, or 270
@@ -101,10 +138,18 @@ const (
 	AttrCaption = "caption"
 	// AttrStyle block or list style
 	AttrStyle = "style"
+	// AttrRelaxSectionLevels the attribute to repair the level of sections which are out of sequence
+	AttrRelaxSectionLevels = "relax-section-levels"
+	// AttrSourceLine the line at which the block starts in the source document (only set when the source map is enabled)
+	AttrSourceLine = "sourceline"
+	// AttrSourceFile the file in which the block is defined, if this is an included file (only set when the source map is enabled)
+	AttrSourceFile = "sourcefile"
 	// AttrInlineLinkText the text attribute (first positional) of links
 	AttrInlineLinkText = "text"
 	// AttrInlineLinkTarget the 'window' attribute
 	AttrInlineLinkTarget = "window"
+	// AttrHideURIScheme the attribute to hide the scheme of the URL in the text of bare links
+	AttrHideURIScheme = "hide-uri-scheme"
 	// AttrWidth the `width` attribute used ior images, tables, and so forth
 	AttrWidth = "width"
 	// AttrFrame the frame used mostly for tables (all, topbot, sides, none)
@@ -113,6 +158,10 @@ const (
 	AttrGrid = "grid"
 	// AttrStripes controls table row background (even, odd, all, none, hover)
 	AttrStripes = "stripes"
+	// AttrGlossaryLinks the document attribute to link all occurrences of the glossary terms to their entry
+	AttrGlossaryLinks = "glossary-links"
+	// AttrTableStripes the document attribute which controls the default table row background
+	AttrTableStripes = "table-stripes"
 	// AttrFloat is for image or table float (text flows around)
 	AttrFloat = "float"
 	// AttrCols the table columns attribute
@@ -129,12 +178,30 @@ const (
 	AttrPositional3 = "@positional-3"
 	// AttrVersionLabel labels the version number in the document
 	AttrVersionLabel = "version-label"
+	// DefaultVersionLabel the default label of the version number in the document footer
+	DefaultVersionLabel = "Version"
+	// AttrLastUpdateLabel labels the last update date in the document footer
+	AttrLastUpdateLabel = "last-update-label"
+	// DefaultLastUpdateLabel the default label of the last update date in the document footer
+	DefaultLastUpdateLabel = "Last updated"
+	// AttrUntitledLabel the title of a document which has none
+	AttrUntitledLabel = "untitled-label"
+	// DefaultUntitledLabel the default title of a document which has none
+	DefaultUntitledLabel = "Untitled"
+	// AttrAppendixCaption is the appendix caption
+	AttrAppendixCaption = "appendix-caption"
+	// DefaultAppendixCaption the default appendix caption
+	DefaultAppendixCaption = "Appendix"
+	// AttrAppendixNumber the number (i.e., the letter) of an appendix section
+	AttrAppendixNumber = "appendix-number"
 	// AttrExampleCaption is the example caption
 	AttrExampleCaption = "example-caption"
 	// AttrFigureCaption is the figure (image) caption
 	AttrFigureCaption = "figure-caption"
 	// AttrTableCaption is the table caption
 	AttrTableCaption = "table-caption"
+	// AttrListingCaption is the listing caption (listing blocks have no caption unless this attribute is set)
+	AttrListingCaption = "listing-caption"
 	// AttrCautionCaption is the CAUTION caption
 	AttrCautionCaption = "caution-caption"
 	// AttrImportantCaption is the IMPORTANT caption
@@ -147,8 +214,108 @@ const (
 	AttrWarningCaption = "warning-caption"
 	// AttrSubstitutions the "subs" attribute to configure substitutions on delimited blocks and paragraphs
 	AttrSubstitutions = "subs"
+	// AttrOutFileSuffix the file extension of the generated output files
+	AttrOutFileSuffix = "outfilesuffix"
+	// AttrRelFileSuffix the file extension used in links to other documents (defaults to the `outfilesuffix` value)
+	AttrRelFileSuffix = "relfilesuffix"
+	// AttrCompatMode the `compat-mode` document attribute, to support the legacy AsciiDoc syntax
+	// (eg: text in single quotes is emphasized and text in `+` signs is a monospace passthrough)
+	AttrCompatMode = "compat-mode"
+	// AttrAttributeMissing the `attribute-missing` document attribute, which defines how references to undefined attributes are processed:
+	// `skip` (the reference is kept), `drop` (the reference is removed), `drop-line` (the line is removed)
+	// or `warn` (the reference is kept and a warning is logged, which is the default)
+	AttrAttributeMissing = "attribute-missing"
+	// AttrImagesDir the `imagesdir` document attribute, which is the base directory of images
+	AttrImagesDir = "imagesdir"
+	// AttrIcons the `icons` document attribute, which defines how icons are rendered (`text`, `font` or `image`)
+	AttrIcons = "icons"
+	// AttrIconsDir the `iconsdir` document attribute, which is the base directory of icon images (defaults to `{imagesdir}/icons`)
+	AttrIconsDir = "iconsdir"
+	// AttrIconType the `icontype` document attribute, which is the file extension of icon images (defaults to `png`)
+	AttrIconType = "icontype"
+	// AttrFirstName the first name of the (first) author of the document
+	AttrFirstName = "firstname"
+	// AttrMiddleName the middle name of the (first) author of the document
+	AttrMiddleName = "middlename"
+	// AttrLastName the last name of the (first) author of the document
+	AttrLastName = "lastname"
+	// AttrAuthor the full name of the (first) author of the document
+	AttrAuthor = "author"
+	// AttrAuthorInitials the initials of the (first) author of the document
+	AttrAuthorInitials = "authorinitials"
+	// AttrEmail the email address of the (first) author of the document
+	AttrEmail = "email"
+	// AttrRevNumber the version number of the document
+	AttrRevNumber = "revnumber"
+	// AttrRevDate the revision date of the document
+	AttrRevDate = "revdate"
+	// AttrRevRemark the revision remark of the document
+	AttrRevRemark = "revremark"
 )
 
+// ------------------------------------------
+// Attribute values
+// ------------------------------------------
+
+const (
+	// IconsText the `icons` attribute value to render icons as text (default)
+	IconsText = "text"
+	// IconsFont the `icons` attribute value to render icons with a font
+	IconsFont = "font"
+	// IconsImage the `icons` attribute value to render icons as images
+	IconsImage = "image"
+)
+
+// ------------------------------------------
+// Options
+// ------------------------------------------
+
+const (
+	// OptionAutoWidth the `autowidth` option on tables
+	OptionAutoWidth = "autowidth"
+	// OptionReversed the `reversed` option on ordered lists
+	OptionReversed = "reversed"
+	// OptionNoWrap the `nowrap` option on source blocks
+	OptionNoWrap = "nowrap"
+	// OptionLazy the `lazy` option on images
+	OptionLazy = "lazy"
+	// OptionHeader the `header` option on tables (first row is the header row)
+	OptionHeader = "header"
+	// OptionNoHeader the `noheader` option on tables (first row is not a header row)
+	OptionNoHeader = "noheader"
+	// OptionFooter the `footer` option on tables (last row is the footer row)
+	OptionFooter = "footer"
+	// OptionCollapsible the `collapsible` option on example, listing and source blocks
+	OptionCollapsible = "collapsible"
+	// OptionOpen the `open` option on collapsible blocks (block is expanded by default)
+	OptionOpen = "open"
+)
+
+// headerOnlyAttributes the document attributes which can only be set (or reset) in the document header
+var headerOnlyAttributes = map[string]bool{
+	AttrDocType:               true,
+	AttrSyntaxHighlighter:     true,
+	AttrTableOfContents:       true,
+	AttrTableOfContentsLevels: true,
+	AttrTableOfContentsTitle:  true,
+	AttrSectionNumbers:        true,
+	AttrSectionNumberLevels:   true,
+	AttrNoHeader:              true,
+	AttrNoFooter:              true,
+	AttrNoTitle:               true,
+	AttrIcons:                 true,
+}
+
+// IsHeaderOnlyAttribute returns `true` if the attribute with the given name
+// can only be set (or reset) in the document header
+func IsHeaderOnlyAttribute(name string) bool {
+	return headerOnlyAttributes[name]
+}
+
+// UnsetAttribute the value of a document attribute which was explicitly unset (eg: `:name!:`),
+// so that it can be told apart from an attribute which was never set (eg: to disable a default caption)
+type UnsetAttribute struct{}
+
 // Attribute is a key/value pair wrapper
 type Attribute struct {
 	Key   string
@@ -398,13 +565,15 @@ func NewNamedAttribute(key string, value interface{}) (Attribute, error) {
 	}, nil
 }
 
-// NewInlineIDAttribute initializes a new attribute map with a single entry for the ID using the given value
-func NewInlineIDAttribute(id string) (Attribute, error) {
-	// log.Debugf("initializing a new inline ElementID with ID=%s", id)
-	return Attribute{
-		Key:   AttrID,
-		Value: id,
-	}, nil
+// NewAnchorAttributes initializes a new attribute map with an entry for the ID, and an optional entry for the reftext
+func NewAnchorAttributes(id, reftext interface{}) (Attributes, error) {
+	result := Attributes{
+		AttrID: id,
+	}
+	if reftext != nil {
+		result[AttrReftext] = reftext
+	}
+	return result, nil
 }
 
 // NewTitleAttribute initializes a new attribute map with a single entry for the title using the given value
@@ -460,12 +629,8 @@ func (a Attributes) Set(key string, value interface{}) Attributes {
 				a[AttrRoles] = r
 			}
 		}
-	case AttrOption:
-		if options, ok := a[AttrOptions].([]interface{}); ok {
-			a[AttrOptions] = append(options, value)
-		} else {
-			a[AttrOptions] = []interface{}{value}
-		}
+	case AttrOption, AttrOptions, AttrOpts:
+		a.addOptions(value)
 	default:
 		a[key] = value
 	}
@@ -476,6 +641,47 @@ func (a Attributes) Set(key string, value interface{}) Attributes {
 	return a
 }
 
+// addOptions adds the given option(s) to the current ones, so that the `options` attribute is always a set of options,
+// whether they were declared with the `options` (or `opts`) attribute (eg: `opts="header,footer"`) or with the `%option` shorthand
+func (a Attributes) addOptions(value interface{}) {
+	options, _ := a[AttrOptions].([]interface{})
+	if s, ok := a[AttrOptions].(string); ok {
+		options = appendOptions(nil, s)
+	}
+	switch value := value.(type) {
+	case []interface{}:
+		for _, v := range value {
+			options = appendOptions(options, v)
+		}
+	default:
+		options = appendOptions(options, value)
+	}
+	a[AttrOptions] = options
+}
+
+// appendOptions appends the given option(s) to the given options, unless they already exist.
+// A string option is split into multiple options if it contains commas
+func appendOptions(options []interface{}, value interface{}) []interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return append(options, value)
+	}
+values:
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		for _, o := range options {
+			if o == v {
+				continue values
+			}
+		}
+		options = append(options, v)
+	}
+	return options
+}
+
 // SetAll adds the given attributes to the current ones
 func (a Attributes) SetAll(attr interface{}) Attributes {
 	log.Debug("setting attributes")
@@ -504,24 +710,54 @@ func (a Attributes) SetAll(attr interface{}) Attributes {
 	return a
 }
 
+// Clone returns a shallow copy of the attributes, in which entries can be added, replaced or removed
+// without affecting the current ones (or `nil` if the current attributes are `nil`)
+func (a Attributes) Clone() Attributes {
+	if a == nil {
+		return nil
+	}
+	result := make(Attributes, len(a))
+	for k, v := range a {
+		result[k] = v
+	}
+	return result
+}
+
 // Has returns the true if an entry with the given key exists
 func (a Attributes) Has(key string) bool {
-	_, ok := a[key]
+	v, ok := a[key]
+	if _, unset := v.(UnsetAttribute); unset {
+		return false
+	}
 	return ok
 }
 
+// Unset removes the entry with the given key
+// (see `DocumentAttributes.Unset()` to retain the attributes which were reset in a document)
+func (a Attributes) Unset(key string) Attributes {
+	delete(a, key)
+	return a
+}
+
 // HasOption returns true if the option is set.
 func (a Attributes) HasOption(key string) bool {
 	// in block attributes: search key in the `Options`
-	if opts, ok := a[AttrOptions].([]interface{}); ok {
+	switch opts := a[AttrOptions].(type) {
+	case []interface{}:
 		for _, opt := range opts {
 			if opt == key {
 				return true
 			}
 		}
+	case string: // eg: `opts="lazy,autowidth"` in the attributes of a macro
+		for _, opt := range strings.Split(opts, ",") {
+			if strings.TrimSpace(opt) == key {
+				return true
+			}
+		}
 	}
 	// in document attributes: direct lookup
-	if a.Has(key) {
+	if a.HasFlag(key) {
 		return true
 	}
 	return false
@@ -530,13 +766,23 @@ func (a Attributes) HasOption(key string) bool {
 // GetAsString gets the string value for the given key (+ `true`),
 // or empty string (+ `false`) if none was found
 func (a Attributes) GetAsString(key string) (string, bool, error) {
-	if value, found := a[key]; found {
+	if value, found := a[key]; found && a.Has(key) {
 		result, err := asString(value)
 		return result, true, err
 	}
 	return "", false, nil
 }
 
+// GetString gets the string value for the given key (+ `true`),
+// or empty string (+ `false`) if none was found, if the attribute was reset or if its value could not be converted
+func (a Attributes) GetString(key string) (string, bool) {
+	value, found, err := a.GetAsString(key)
+	if err != nil {
+		return "", false
+	}
+	return value, found
+}
+
 func asString(v interface{}) (string, error) {
 	switch v := v.(type) {
 	case string:
@@ -564,13 +810,11 @@ func asString(v interface{}) (string, error) {
 // or returns the given default value
 func (a Attributes) GetAsStringWithDefault(key, defaultValue string) string {
 	if value, found := a[key]; found {
-		if value == nil {
-			return "" // nil present means attribute was reset
+		if _, unset := value.(UnsetAttribute); value == nil || unset {
+			return "" // attribute was set with an empty value, or was reset
 		}
-		if value, ok := value.(string); ok {
-			return value
-		} else if v, ok := a[key]; ok {
-			return fmt.Sprintf("%v", v)
+		if value, err := asString(value); err == nil {
+			return value // complex values (eg: with special characters) are converted into a single string
 		}
 	}
 	return defaultValue
@@ -587,6 +831,24 @@ func (a Attributes) GetAsBool(key string) bool {
 	return false
 }
 
+// HasFlag returns `true` if the boolean attribute (or flag) with the given key is set, regardless of its value
+// (eg: `:nofooter:` or `:nofooter: yes`), or `false` if there is no such attribute, if it was reset (eg: `:nofooter!:`)
+// or if its value is the `false` bool
+func (a Attributes) HasFlag(key string) bool {
+	v, found := a[key]
+	if !found {
+		return false
+	}
+	switch v := v.(type) {
+	case UnsetAttribute:
+		return false
+	case bool:
+		return v
+	default:
+		return true
+	}
+}
+
 // AddNonEmpty adds the given attribute if its value is non-nil and non-empty
 // TODO: raise a warning if there was already a name/value
 func (a Attributes) AddNonEmpty(key string, value interface{}) {
@@ -0,0 +1,145 @@
+package types
+
+import "sort"
+
+// DocumentAttributes the attributes of a document, as an ordered map: the attributes are listed in the order
+// in which they were set (or reset) in the front-matter, in the header and in the body of the document.
+// Each change is retained as a separate entry and the latest entry of an attribute is its current value,
+// so that the values at the end of the document header also remain available (see `Header()`).
+type DocumentAttributes []DocumentAttribute
+
+// DocumentAttribute an attribute set (or reset) in a document
+type DocumentAttribute struct {
+	Name  string
+	Value interface{} // `UnsetAttribute{}` if the attribute was reset
+	Body  bool        // `true` if the attribute was set (or reset) in the document body
+}
+
+// Set sets the attribute with the given name and value, and returns the resulting attributes
+func (a DocumentAttributes) Set(name string, value interface{}) DocumentAttributes {
+	return append(a, DocumentAttribute{
+		Name:  name,
+		Value: value,
+	})
+}
+
+// SetAll sets the given attributes (in the alphabetical order of their names), and returns the resulting attributes
+func (a DocumentAttributes) SetAll(attrs Attributes) DocumentAttributes {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		a = a.Set(name, attrs[name])
+	}
+	return a
+}
+
+// Unset resets the attribute with the given name, and returns the resulting attributes.
+// The attribute is retained with an `UnsetAttribute` value, so that it can be told apart from an attribute which was never set
+func (a DocumentAttributes) Unset(name string) DocumentAttributes {
+	return a.Set(name, UnsetAttribute{})
+}
+
+// Get returns the current value of the attribute with the given name (+ `true`),
+// or `nil` (+ `false`) if the attribute was never set or if it was reset
+func (a DocumentAttributes) Get(name string) (interface{}, bool) {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i].Name == name {
+			if _, unset := a[i].Value.(UnsetAttribute); unset {
+				return nil, false
+			}
+			return a[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Has returns `true` if the attribute with the given name is set (and was not reset)
+func (a DocumentAttributes) Has(name string) bool {
+	_, found := a.Get(name)
+	return found
+}
+
+// HasFlag returns `true` if the boolean attribute (or flag) with the given name is set (see `Attributes.HasFlag()`)
+func (a DocumentAttributes) HasFlag(name string) bool {
+	value, found := a.Get(name)
+	return found && Attributes{name: value}.HasFlag(name)
+}
+
+// GetAsString gets the string value of the attribute with the given name (+ `true`),
+// or empty string (+ `false`) if none was found
+func (a DocumentAttributes) GetAsString(name string) (string, bool, error) {
+	value, found := a.Get(name)
+	if !found {
+		return "", false, nil
+	}
+	return Attributes{name: value}.GetAsString(name)
+}
+
+// GetString gets the string value of the attribute with the given name (+ `true`),
+// or empty string (+ `false`) if none was found, if the attribute was reset or if its value could not be converted
+func (a DocumentAttributes) GetString(name string) (string, bool) {
+	value, found, err := a.GetAsString(name)
+	if err != nil {
+		return "", false
+	}
+	return value, found
+}
+
+// GetAsStringWithDefault gets the string value of the attribute with the given name,
+// or returns the given default value if the attribute was never set
+func (a DocumentAttributes) GetAsStringWithDefault(name, defaultValue string) string {
+	return a.Values().GetAsStringWithDefault(name, defaultValue)
+}
+
+// History returns all the values of the attribute with the given name (including the resets), in the order in which they were set
+func (a DocumentAttributes) History(name string) []DocumentAttribute {
+	var result []DocumentAttribute
+	for _, attr := range a {
+		if attr.Name == name {
+			result = append(result, attr)
+		}
+	}
+	return result
+}
+
+// Names returns the names of all the attributes (including the ones which were reset), in the order of their first declaration
+func (a DocumentAttributes) Names() []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, attr := range a {
+		if !seen[attr.Name] {
+			result = append(result, attr.Name)
+			seen[attr.Name] = true
+		}
+	}
+	return result
+}
+
+// Values returns the current values of all the attributes (including the `UnsetAttribute` values of the ones which were reset),
+// or `nil` if there is none
+func (a DocumentAttributes) Values() Attributes {
+	return a.values(true)
+}
+
+// Header returns the values of the attributes as they were at the end of the document header (ie, ignoring the changes
+// in the document body, which are applied in order during the rendering), or `nil` if there is none
+func (a DocumentAttributes) Header() Attributes {
+	return a.values(false)
+}
+
+func (a DocumentAttributes) values(body bool) Attributes {
+	var result Attributes
+	for _, attr := range a {
+		if attr.Body && !body {
+			continue
+		}
+		if result == nil {
+			result = Attributes{}
+		}
+		result[attr.Name] = attr.Value
+	}
+	return result
+}
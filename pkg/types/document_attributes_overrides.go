@@ -1,5 +1,7 @@
 package types
 
+import "strings"
+
 // AttributesWithOverrides the document attributes with some overrides provided by the CLI (for example)
 type AttributesWithOverrides struct {
 	Content   map[string]interface{}
@@ -17,6 +19,11 @@ func (a AttributesWithOverrides) All() Attributes {
 		result[k] = v
 	}
 	for k, v := range a.Overrides {
+		if strings.HasPrefix(k, "!") {
+			// attribute is reset
+			result[k[1:]] = UnsetAttribute{}
+			continue
+		}
 		result[k] = v
 	}
 	return result
@@ -27,6 +34,11 @@ func (a AttributesWithOverrides) Set(key string, value interface{}) {
 	a.Content[key] = value
 }
 
+// Reset unsets the given attribute
+func (a AttributesWithOverrides) Reset(key string) {
+	a.Content[key] = UnsetAttribute{}
+}
+
 // Add adds the given attributes
 func (a AttributesWithOverrides) Add(attrs map[string]interface{}) {
 	for k, v := range attrs {
@@ -34,6 +46,30 @@ func (a AttributesWithOverrides) Add(attrs map[string]interface{}) {
 	}
 }
 
+// Has returns `true` if an attribute with the given key is set (and was not reset),
+// in the overrides or in the content
+func (a AttributesWithOverrides) Has(key string) bool {
+	if _, found := a.Overrides[key]; found {
+		return true
+	}
+	if _, found := a.Overrides["!"+key]; found {
+		return false
+	}
+	return Attributes(a.Content).Has(key)
+}
+
+// HasFlag returns `true` if the boolean attribute (or flag) with the given key is set in the overrides
+// (regardless of its value) or in the content (see `Attributes.HasFlag()`), and was not reset
+func (a AttributesWithOverrides) HasFlag(key string) bool {
+	if _, found := a.Overrides[key]; found {
+		return true
+	}
+	if _, found := a.Overrides["!"+key]; found {
+		return false
+	}
+	return Attributes(a.Content).HasFlag(key)
+}
+
 // GetAsString gets the string value for the given key (+ `true`),
 // or empty string (+ `false`) if none was found
 func (a AttributesWithOverrides) GetAsString(key string) (string, bool) {
@@ -55,10 +91,7 @@ func (a AttributesWithOverrides) GetAsString(key string) (string, bool) {
 // GetAsStringWithDefault gets the string value for the given key,
 // or returns the given default value
 func (a AttributesWithOverrides) GetAsStringWithDefault(key, defaultValue string) string {
-	if value, found := a.Overrides[key]; found {
-		return value
-	}
-	if value, found := a.Content[key].(string); found {
+	if value, found := a.GetAsString(key); found {
 		return value
 	}
 	// TODO: raise a warning if there was no entry found
@@ -14,6 +14,7 @@ var _ = DescribeTable("document attribute overrides",
 			Content: map[string]interface{}{
 				"normal":   "ok",
 				"override": "ok, too",
+				"reset":    types.UnsetAttribute{},
 			},
 			Overrides: map[string]string{
 				"foo":      "cheesecake",
@@ -31,8 +32,9 @@ var _ = DescribeTable("document attribute overrides",
 	Entry("normal", "normal", "ok", true),
 	Entry("override", "override", "overridden", true), // entry is overridden
 	Entry("foo", "foo", "cheesecake", true),
-	Entry("!bar", "bar", "", false), // entry is reset
-	Entry("baz", "baz", "", true),   // entry exists but its value is empty
+	Entry("!bar", "bar", "", false),    // entry is reset
+	Entry("baz", "baz", "", true),      // entry exists but its value is empty
+	Entry("reset", "reset", "", false), // entry is reset in the document
 )
 
 var _ = DescribeTable("document attribute overrides with default",
@@ -42,6 +44,7 @@ var _ = DescribeTable("document attribute overrides with default",
 			Content: map[string]interface{}{
 				"normal":   "ok",
 				"override": "ok, too",
+				"reset":    types.UnsetAttribute{},
 			},
 			Overrides: map[string]string{
 				"foo":      "cheesecake",
@@ -58,6 +61,44 @@ var _ = DescribeTable("document attribute overrides with default",
 	Entry("normal", "normal", "ok"),
 	Entry("override", "override", "overridden"), // entry is overridden
 	Entry("foo", "foo", "cheesecake"),
-	Entry("!bar", "bar", "default"), // entry is reset, default is returned
-	Entry("baz", "baz", ""),         // entry exists but its value is empty
+	Entry("!bar", "bar", "default"),    // entry is reset, default is returned
+	Entry("baz", "baz", ""),            // entry exists but its value is empty
+	Entry("reset", "reset", "default"), // entry is reset in the document, default is returned
+)
+
+var _ = DescribeTable("document attribute flags",
+	func(key string, expected bool) {
+		// given
+		attributes := types.AttributesWithOverrides{
+			Content: map[string]interface{}{
+				"empty":    "",
+				"nil":      nil,
+				"value":    "false", // any string value sets the flag
+				"true":     true,
+				"false":    false,
+				"reset":    types.UnsetAttribute{},
+				"override": types.UnsetAttribute{},
+			},
+			Overrides: map[string]string{
+				"foo":      "",
+				"!bar":     "",
+				"override": "",
+				"!empty":   "",
+			},
+		}
+		// when
+		result := attributes.HasFlag(key)
+		// then
+		Expect(result).To(Equal(expected))
+	},
+	Entry("empty", "empty", false), // entry is reset in the overrides
+	Entry("nil", "nil", true),
+	Entry("value", "value", true),
+	Entry("true", "true", true),
+	Entry("false", "false", false),
+	Entry("reset", "reset", false), // entry is reset in the document
+	Entry("override", "override", true),
+	Entry("foo", "foo", true),
+	Entry("bar", "bar", false), // entry is reset in the overrides
+	Entry("unknown", "unknown", false),
 )
@@ -0,0 +1,86 @@
+package types_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("document attributes", func() {
+
+	attrs := types.DocumentAttributes{
+		{Name: "foo", Value: "bar"},
+		{Name: "baz", Value: nil},
+		{Name: "foo", Value: "bar2", Body: true},
+		{Name: "baz", Value: types.UnsetAttribute{}, Body: true},
+		{Name: "qux", Value: "quux", Body: true},
+	}
+
+	It("should return the names in their order of declaration", func() {
+		Expect(attrs.Names()).To(Equal([]string{"foo", "baz", "qux"}))
+	})
+
+	It("should get current values", func() {
+		value, found, err := attrs.GetAsString("foo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("bar2"))
+		Expect(attrs.Has("baz")).To(BeFalse())
+		Expect(attrs.HasFlag("baz")).To(BeFalse())
+		Expect(attrs.GetAsStringWithDefault("baz", "default")).To(BeEmpty()) // reset
+		Expect(attrs.GetAsStringWithDefault("unknown", "default")).To(Equal("default"))
+		Expect(attrs.Values()).To(Equal(types.Attributes{
+			"foo": "bar2",
+			"baz": types.UnsetAttribute{},
+			"qux": "quux",
+		}))
+	})
+
+	It("should get string values", func() {
+		value, found := attrs.GetString("foo")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("bar2"))
+		value, found = attrs.GetString("baz") // reset
+		Expect(found).To(BeFalse())
+		Expect(value).To(BeEmpty())
+		_, found = attrs.GetString("unknown")
+		Expect(found).To(BeFalse())
+	})
+
+	It("should return the history of an attribute", func() {
+		Expect(attrs.History("baz")).To(Equal([]types.DocumentAttribute{
+			{Name: "baz", Value: nil},
+			{Name: "baz", Value: types.UnsetAttribute{}, Body: true},
+		}))
+		Expect(attrs.History("unknown")).To(BeEmpty())
+	})
+
+	It("should get values at the end of the header", func() {
+		Expect(attrs.Header()).To(Equal(types.Attributes{
+			"foo": "bar",
+			"baz": nil,
+		}))
+		Expect(attrs.Header().HasFlag("baz")).To(BeTrue())
+	})
+
+	It("should unset attribute", func() {
+		result := types.DocumentAttributes{}.Set("foo", "bar").Unset("foo")
+		Expect(result).To(Equal(types.DocumentAttributes{
+			{Name: "foo", Value: "bar"},
+			{Name: "foo", Value: types.UnsetAttribute{}},
+		}))
+		Expect(result.Has("foo")).To(BeFalse())
+		Expect(result.Names()).To(Equal([]string{"foo"}))
+	})
+
+	It("should set all attributes in alphabetical order", func() {
+		Expect(types.DocumentAttributes{}.SetAll(types.Attributes{
+			"foo": "bar",
+			"baz": "qux",
+		})).To(Equal(types.DocumentAttributes{
+			{Name: "baz", Value: "qux"},
+			{Name: "foo", Value: "bar"},
+		}))
+	})
+})
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"strings"
+)
+
+// ------------------------------------------
+// Glossary
+// ------------------------------------------
+
+// GlossaryEntries the terms defined in the glossary of a document,
+// indexed by their lower-cased text, with the ID of their entry as value
+type GlossaryEntries map[string]string
+
+// NewGlossaryEntryID returns the ID of the glossary entry of the given term,
+// generated by the given slugger, with an additional `glossary` prefix
+func NewGlossaryEntryID(term []interface{}, slugger Slugger) string {
+	slugger.Prefix = slugger.Prefix + Glossary + slugger.Separator
+	return slugger.Slug(term)
+}
+
+// Add registers the given term with the ID of its entry
+func (g GlossaryEntries) Add(term []interface{}, id string) {
+	text := &strings.Builder{}
+	writeSlugText(text, term)
+	g[strings.ToLower(strings.TrimSpace(text.String()))] = id
+}
+
+// Lookup returns the ID of the entry of the given term (and `true`),
+// or an empty string (and `false`) if the term is not defined in the glossary
+func (g GlossaryEntries) Lookup(term string) (string, bool) {
+	id, found := g[strings.ToLower(strings.TrimSpace(term))]
+	return id, found
+}
+
+// GlossaryTerm a reference to a term of the glossary. Eg: `term:[word]`
+type GlossaryTerm struct {
+	Term string
+}
+
+// NewGlossaryTerm returns a new GlossaryTerm
+func NewGlossaryTerm(term string) (GlossaryTerm, error) {
+	return GlossaryTerm{
+		Term: term,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// ------------------------------------------
+// Document Outline
+// ------------------------------------------
+
+// DefaultWordsPerMinute the default reading speed used to estimate the reading time of a document and its sections
+const DefaultWordsPerMinute = 200
+
+// DocumentOutline the outline of a document, with the number of words and the estimated reading time
+// of the whole content of the document and of each one of its sections
+type DocumentOutline struct {
+	Title       string // the plain text of the document title (if any)
+	Words       int
+	ReadingTime time.Duration
+	Sections    []OutlineSection
+}
+
+// OutlineSection a section in the outline of a document.
+// The number of words and the reading time include the content of the subsections, but not the section titles
+type OutlineSection struct {
+	ID          string
+	Level       int
+	Title       string // the plain text of the section title
+	Words       int
+	ReadingTime time.Duration
+	Children    []OutlineSection
+}
+
+// Outline returns the outline of the document, in which the reading time of the document and of its sections
+// is estimated with the given number of words per minute (or `DefaultWordsPerMinute` if the given value is not positive).
+// Discrete headings are not part of the outline, and the content of comments is not counted.
+func (d Document) Outline(wordsPerMinute int) DocumentOutline {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	elements := d.Elements
+	result := DocumentOutline{}
+	if header, found := d.Header(); found {
+		result.Title = plainText(header.Title)
+		elements = header.Elements
+	}
+	result.Sections, result.Words = newOutlineSections(elements, wordsPerMinute)
+	result.ReadingTime = readingTime(result.Words, wordsPerMinute)
+	return result
+}
+
+// newOutlineSections returns the sections among the given elements, along with the number of words of all the elements
+func newOutlineSections(elements []interface{}, wordsPerMinute int) ([]OutlineSection, int) {
+	sections := []OutlineSection{}
+	words := 0
+	for _, element := range elements {
+		switch e := element.(type) {
+		case Section:
+			children, w := newOutlineSections(e.Elements, wordsPerMinute)
+			words += w
+			if e.IsDiscrete() {
+				sections = append(sections, children...)
+				continue
+			}
+			sections = append(sections, OutlineSection{
+				ID:          e.Attributes.GetAsStringWithDefault(AttrID, ""),
+				Level:       e.Level,
+				Title:       plainText(e.Title),
+				Words:       w,
+				ReadingTime: readingTime(w, wordsPerMinute),
+				Children:    children,
+			})
+		case Preamble:
+			children, w := newOutlineSections(e.Elements, wordsPerMinute)
+			sections = append(sections, children...)
+			words += w
+		default:
+			w := newTextWriter(true)
+			w.writeBlock(element)
+			words += len(strings.Fields(w.buf.String()))
+		}
+	}
+	return sections, words
+}
+
+// readingTime returns the time needed to read the given number of words at the given speed, truncated to the second
+func readingTime(words, wordsPerMinute int) time.Duration {
+	return time.Duration(words*60/wordsPerMinute) * time.Second
+}
@@ -0,0 +1,251 @@
+package types_test
+
+import (
+	"time"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("document outline", func() {
+
+	// a paragraph with the given number of words
+	paragraph := func(words int) types.Paragraph {
+		lines := [][]interface{}{}
+		for i := 0; i < words; i++ {
+			lines = append(lines, []interface{}{
+				types.StringElement{
+					Content: "word",
+				},
+			})
+		}
+		return types.Paragraph{
+			Lines: lines,
+		}
+	}
+
+	It("should return outline of document with header and sections", func() {
+		doc := types.Document{
+			Elements: []interface{}{
+				types.Section{
+					Level: 0,
+					Attributes: types.Attributes{
+						types.AttrID: "_a_title",
+					},
+					Title: []interface{}{
+						types.StringElement{
+							Content: "a ",
+						},
+						types.QuotedText{
+							Kind: types.SingleQuoteBold,
+							Elements: []interface{}{
+								types.StringElement{
+									Content: "title",
+								},
+							},
+						},
+					},
+					Elements: []interface{}{
+						types.Preamble{
+							Elements: []interface{}{
+								paragraph(100),
+							},
+						},
+						types.Section{
+							Level: 1,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_1",
+							},
+							Title: []interface{}{
+								types.StringElement{
+									Content: "section 1",
+								},
+							},
+							Elements: []interface{}{
+								paragraph(200),
+								types.Section{
+									Level: 2,
+									Attributes: types.Attributes{
+										types.AttrID: "_section_1_1",
+									},
+									Title: []interface{}{
+										types.StringElement{
+											Content: "section 1.1",
+										},
+									},
+									Elements: []interface{}{
+										types.UnorderedList{
+											Items: []types.UnorderedListItem{
+												{
+													Level: 1,
+													Elements: []interface{}{
+														paragraph(50),
+													},
+												},
+												{
+													Level: 1,
+													Elements: []interface{}{
+														paragraph(50),
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						types.Section{
+							Level: 1,
+							Attributes: types.Attributes{
+								types.AttrID: "_section_2",
+							},
+							Title: []interface{}{
+								types.StringElement{
+									Content: "section 2",
+								},
+							},
+							Elements: []interface{}{
+								types.ListingBlock{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{
+												Content: "some listing",
+											},
+										},
+									},
+								},
+								types.CommentBlock{
+									Lines: [][]interface{}{
+										{
+											types.StringElement{
+												Content: "not counted",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		expected := types.DocumentOutline{
+			Title:       "a title",
+			Words:       402,
+			ReadingTime: 2 * time.Minute, // truncated
+			Sections: []types.OutlineSection{
+				{
+					ID:          "_section_1",
+					Level:       1,
+					Title:       "section 1",
+					Words:       300,
+					ReadingTime: 90 * time.Second,
+					Children: []types.OutlineSection{
+						{
+							ID:          "_section_1_1",
+							Level:       2,
+							Title:       "section 1.1",
+							Words:       100,
+							ReadingTime: 30 * time.Second,
+							Children:    []types.OutlineSection{},
+						},
+					},
+				},
+				{
+					ID:          "_section_2",
+					Level:       1,
+					Title:       "section 2",
+					Words:       2,
+					ReadingTime: 0,
+					Children:    []types.OutlineSection{},
+				},
+			},
+		}
+		Expect(doc.Outline(0)).To(Equal(expected))
+	})
+
+	It("should return outline of document without header and with custom reading speed", func() {
+		doc := types.Document{
+			Elements: []interface{}{
+				paragraph(60),
+				types.Section{
+					Level: 1,
+					Attributes: types.Attributes{
+						types.AttrID: "_section_1",
+					},
+					Title: []interface{}{
+						types.StringElement{
+							Content: "section 1",
+						},
+					},
+					Elements: []interface{}{
+						paragraph(30),
+						types.Section{
+							Level: 2,
+							Attributes: types.Attributes{
+								types.AttrID:    "_discrete",
+								types.AttrStyle: types.Discrete,
+							},
+							Title: []interface{}{
+								types.StringElement{
+									Content: "discrete",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		expected := types.DocumentOutline{
+			Words:       90,
+			ReadingTime: 90 * time.Second,
+			Sections: []types.OutlineSection{
+				{
+					ID:          "_section_1",
+					Level:       1,
+					Title:       "section 1",
+					Words:       30,
+					ReadingTime: 30 * time.Second,
+					Children:    []types.OutlineSection{},
+				},
+			},
+		}
+		Expect(doc.Outline(60)).To(Equal(expected))
+	})
+
+	It("should not split words on special characters", func() {
+		doc := types.Document{
+			Elements: []interface{}{
+				types.Paragraph{
+					Lines: [][]interface{}{
+						{
+							types.StringElement{
+								Content: "AT",
+							},
+							types.SpecialCharacter{
+								Name: "&",
+							},
+							types.StringElement{
+								Content: "T and AT ",
+							},
+							types.SpecialCharacter{
+								Name: "&",
+							},
+							types.StringElement{
+								Content: " T",
+							},
+						},
+					},
+				},
+			},
+		}
+		expected := types.DocumentOutline{
+			Words:       5,
+			ReadingTime: 1 * time.Second,
+			Sections:    []types.OutlineSection{},
+		}
+		Expect(doc.Outline(300)).To(Equal(expected))
+	})
+})
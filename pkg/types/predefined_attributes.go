@@ -1,44 +1,57 @@
 package types
 
-// PredefinedAttributes the predefined document attributes
-// May be converted into HTML entities
-var predefinedAttributes = []string{
-	"sp",
-	"blank",
-	"empty",
-	"nbsp",
-	"zwsp",
-	"wj",
-	"apos",
-	"quot",
-	"lsquo",
-	"rsquo",
-	"ldquo",
-	"rdquo",
-	"deg",
-	"plus",
-	"brvbar",
-	"vbar",
-	"amp",
-	"lt",
-	"gt",
-	"startsb",
-	"endsb",
-	"caret",
-	"asterisk",
-	"tilde",
-	"backslash",
-	"backtick",
-	"two-colons",
-	"two-semicolons",
-	"cpp",
+// predefinedAttributes the predefined document attributes, with their replacement values
+// (which may be converted into HTML entities).
+// This table is read-only: a predefined attribute can be overridden for a given document with an attribute
+// declaration (eg: `:nbsp: &#160;`) or via the configuration (see `configuration.WithAttribute`)
+var predefinedAttributes = map[string]string{
+	"sp":             " ",
+	"blank":          "",
+	"empty":          "",
+	"nbsp":           "\u00a0",
+	"zwsp":           "\u200b",
+	"wj":             "\u2060",
+	"apos":           "&#39;",
+	"quot":           "&#34;",
+	"lsquo":          "\u2018",
+	"rsquo":          "\u2019",
+	"ldquo":          "\u201c",
+	"rdquo":          "\u201d",
+	"deg":            "\u00b0",
+	"plus":           "&#43;",
+	"brvbar":         "\u00a6",
+	"vbar":           "|", // TODO: maybe convert this because of tables?
+	"amp":            "&amp;",
+	"lt":             "&lt;",
+	"gt":             "&gt;",
+	"startsb":        "[",
+	"endsb":          "]",
+	"caret":          "^",
+	"asterisk":       "*",
+	"tilde":          "~",
+	"backslash":      `\`,
+	"backtick":       "`",
+	"two-colons":     "::",
+	"two-semicolons": ";;",
+	"cpp":            "C++",
 }
 
-func isPrefedinedAttribute(a string) bool {
-	for _, v := range predefinedAttributes {
-		if v == a {
-			return true
-		}
+// PredefinedAttributes returns a copy of the predefined document attributes, with their replacement values
+func PredefinedAttributes() map[string]string {
+	result := make(map[string]string, len(predefinedAttributes))
+	for k, v := range predefinedAttributes {
+		result[k] = v
 	}
-	return false
+	return result
+}
+
+// PredefinedAttributeValue returns the replacement value of the predefined attribute with the given name
+// (or an empty string if there is no such predefined attribute)
+func PredefinedAttributeValue(name string) string {
+	return predefinedAttributes[name]
+}
+
+func isPrefedinedAttribute(a string) bool {
+	_, found := predefinedAttributes[a]
+	return found
 }
@@ -0,0 +1,80 @@
+package types
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugger generates the IDs of elements (eg: sections) from their title,
+// based on the `idprefix` and `idseparator` document attributes
+type Slugger struct {
+	Prefix    string
+	Separator string
+}
+
+// NewSlugger returns a new Slugger configured with the `idprefix` and `idseparator` attributes
+// of the given document (or their default value)
+func NewSlugger(attrs AttributesWithOverrides) Slugger {
+	return Slugger{
+		Prefix:    attrs.GetAsStringWithDefault(AttrIDPrefix, DefaultIDPrefix),
+		Separator: attrs.GetAsStringWithDefault(AttrIDSeparator, DefaultIDSeparator),
+	}
+}
+
+// Slug returns the ID for the given title elements:
+// - the formatting markup, special characters and unresolved attributes are excluded,
+// - the text is lower-cased,
+// - the sequences of spaces, dots and hyphens are collapsed into a single separator,
+// - all other punctuation and symbol characters are removed,
+// - the result is prefixed with the `idprefix`.
+func (s Slugger) Slug(elements []interface{}) string {
+	text := &strings.Builder{}
+	writeSlugText(text, elements)
+	result := &strings.Builder{}
+	result.WriteString(s.Prefix)
+	body := false     // true once some characters of the title have been written
+	separate := false // true when a separator must be written before the next character
+	for _, r := range strings.ToLower(text.String()) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r) || r == '_':
+			if separate && body {
+				result.WriteString(s.Separator)
+			}
+			result.WriteRune(r)
+			body = true
+			separate = false
+		case unicode.IsSpace(r) || r == '.' || r == '-':
+			separate = true
+		default:
+			// other characters are dropped
+		}
+	}
+	return result.String()
+}
+
+// writeSlugText writes the raw text of the given elements, ignoring their formatting
+func writeSlugText(buf *strings.Builder, elements []interface{}) {
+	for _, element := range elements {
+		switch element := element.(type) {
+		case StringElement:
+			buf.WriteString(element.Content)
+		case QuotedText:
+			writeSlugText(buf, element.Elements)
+		case InlinePassthrough:
+			writeSlugText(buf, element.Elements)
+		case InlineLink:
+			switch text := element.Attributes[AttrInlineLinkText].(type) {
+			case string:
+				buf.WriteString(text)
+			case []interface{}:
+				writeSlugText(buf, text)
+			default:
+				buf.WriteString(element.Location.Stringify())
+			}
+		case Icon:
+			buf.WriteString(element.Attributes.GetAsStringWithDefault(AttrImageAlt, element.Class))
+		default:
+			// other types are ignored
+		}
+	}
+}
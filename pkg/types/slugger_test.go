@@ -0,0 +1,187 @@
+package types_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo"                  //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
+)
+
+var _ = Describe("slugger", func() {
+
+	Context("with default prefix and separator", func() {
+
+		slugger := types.Slugger{
+			Prefix:    "_",
+			Separator: "_",
+		}
+
+		DescribeTable("plain text",
+			func(title, expected string) {
+				Expect(slugger.Slug([]interface{}{
+					types.StringElement{Content: title},
+				})).To(Equal(expected))
+			},
+			Entry("hello", "hello", "_hello"),
+			Entry("upper case", "Hello World", "_hello_world"),
+			Entry("héllo with an accent", "  héllo 1.2   and 3 Spaces", "_héllo_1_2_and_3_spaces"),
+			Entry("an accent and a symbol", `A à ⌘`, "_a_à"),
+			Entry("AŁA", `AŁA 0.1 ?`, "_ała_0_1"),
+			Entry("apostrophe and comma", `it's  2 spaces, here !`, "_its_2_spaces_here"),
+			Entry("tab and new line", "foo\tbar\nbaz", "_foo_bar_baz"),
+			Entry("hyphens and dots", "foo - bar...baz", "_foo_bar_baz"),
+			Entry("underscores", "foo_bar", "_foo_bar"),
+			Entry("leading and trailing punctuation", `"(foo)" !`, "_foo"),
+			Entry("slashes and colons", "client/server: setup", "_clientserver_setup"),
+			Entry("attribute braces", "{foo} bar", "_foo_bar"),
+			Entry("ampersand", "foo & bar", "_foo_bar"),
+			Entry("only punctuation", "?!", "_"),
+		)
+
+		It("content with bold markup", func() {
+			// == a section title, with *bold content*
+			source := []interface{}{
+				types.StringElement{Content: "a section title, with "},
+				types.QuotedText{
+					Kind: types.SingleQuoteBold,
+					Elements: []interface{}{
+						types.StringElement{Content: "bold content"},
+					},
+				},
+			}
+			Expect(slugger.Slug(source)).To(Equal("_a_section_title_with_bold_content"))
+		})
+
+		It("content with nested markup within a word", func() {
+			// == a **b**_c_ d
+			source := []interface{}{
+				types.StringElement{Content: "a "},
+				types.QuotedText{
+					Kind: types.DoubleQuoteBold,
+					Elements: []interface{}{
+						types.StringElement{Content: "b"},
+						types.QuotedText{
+							Kind: types.SingleQuoteItalic,
+							Elements: []interface{}{
+								types.StringElement{Content: "c"},
+							},
+						},
+					},
+				},
+				types.StringElement{Content: " d"},
+			}
+			Expect(slugger.Slug(source)).To(Equal("_a_bc_d"))
+		})
+
+		It("content with special characters", func() {
+			// == foo & bar
+			source := []interface{}{
+				types.StringElement{Content: "foo "},
+				types.SpecialCharacter{Name: "&"},
+				types.StringElement{Content: " bar"},
+			}
+			Expect(slugger.Slug(source)).To(Equal("_foo_bar"))
+		})
+
+		It("content with unresolved attribute", func() {
+			// == foo {bar}
+			source := []interface{}{
+				types.StringElement{Content: "foo "},
+				types.AttributeSubstitution{Name: "bar"},
+			}
+			Expect(slugger.Slug(source)).To(Equal("_foo"))
+		})
+
+		It("content with bare link", func() {
+			source := []interface{}{
+				types.StringElement{Content: "link to "},
+				types.InlineLink{
+					Attributes: types.Attributes{},
+					Location: types.Location{
+						Scheme: "https://",
+						Path: []interface{}{
+							types.StringElement{
+								Content: "foo.bar",
+							},
+						},
+					},
+				},
+			}
+			Expect(slugger.Slug(source)).To(Equal("_link_to_httpsfoo_bar"))
+		})
+
+		It("content with link with text", func() {
+			source := []interface{}{
+				types.StringElement{Content: "link to "},
+				types.InlineLink{
+					Attributes: types.Attributes{
+						types.AttrInlineLinkText: "the Foo site",
+					},
+					Location: types.Location{
+						Scheme: "https://",
+						Path: []interface{}{
+							types.StringElement{
+								Content: "foo.bar",
+							},
+						},
+					},
+				},
+			}
+			Expect(slugger.Slug(source)).To(Equal("_link_to_the_foo_site"))
+		})
+
+		It("content with icon", func() {
+			source := []interface{}{
+				types.Icon{
+					Class: "tip",
+				},
+				types.StringElement{Content: " tips"},
+			}
+			Expect(slugger.Slug(source)).To(Equal("_tip_tips"))
+		})
+	})
+
+	Context("with custom prefix and separator", func() {
+
+		DescribeTable("plain text",
+			func(prefix, separator, title, expected string) {
+				slugger := types.Slugger{
+					Prefix:    prefix,
+					Separator: separator,
+				}
+				Expect(slugger.Slug([]interface{}{
+					types.StringElement{Content: title},
+				})).To(Equal(expected))
+			},
+			Entry("custom prefix", "id_", "_", "Hello World", "id_hello_world"),
+			Entry("upper case prefix is retained", "ID-", "-", "Hello World", "ID-hello-world"),
+			Entry("empty prefix", "", "_", " Hello World ", "hello_world"),
+			Entry("custom separator", "_", "-", "Hello,  World", "_hello-world"),
+			Entry("empty separator", "_", "", "Hello World", "_helloworld"),
+		)
+
+		It("from document attributes", func() {
+			slugger := types.NewSlugger(types.AttributesWithOverrides{
+				Content: map[string]interface{}{
+					types.AttrIDPrefix:    "id_",
+					types.AttrIDSeparator: "-",
+				},
+				Overrides: map[string]string{},
+			})
+			Expect(slugger.Slug([]interface{}{
+				types.StringElement{Content: "Hello World"},
+			})).To(Equal("id_hello-world"))
+		})
+
+		It("with default document attributes", func() {
+			slugger := types.NewSlugger(types.AttributesWithOverrides{
+				Content:   map[string]interface{}{},
+				Overrides: map[string]string{},
+			})
+			Expect(slugger.Slug([]interface{}{
+				types.StringElement{Content: "Hello World"},
+			})).To(Equal("_hello_world"))
+		})
+	})
+})
@@ -6,7 +6,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/pkg/errors"
+	"errors"
 )
 
 // ------------------------------------------
@@ -34,6 +34,7 @@ type Table struct {
 	Header     TableLine
 	Columns    []TableColumn
 	Lines      []TableLine
+	Footer     TableLine
 }
 
 // parseNum like atoi, but stops on non-digit character (and only unsigned)
@@ -74,7 +75,8 @@ func (t Table) parseColumnsAttr() ([]TableColumn, error) {
 			repeat = n
 			c = c[1:]
 		} else if n != 0 {
-			return nil, fmt.Errorf("bad column repeat (expected '*', got %s)", c)
+			// a number which is not followed by `*` is the column width (eg: `2a`)
+			col.widthVal = float64(n)
 		}
 
 		// Horizontal alignment
@@ -144,7 +146,7 @@ func (t Table) processColumnWidths() ([]TableColumn, error) {
 	cols := make([]TableColumn, 0, len(t.Columns))
 
 	// Autowidth table uses full autowidth on all columns.
-	if !t.Attributes.HasOption("autowidth") {
+	if !t.Attributes.HasOption(OptionAutoWidth) {
 
 		percent := false
 		total := 0.0
@@ -164,21 +166,20 @@ func (t Table) processColumnWidths() ([]TableColumn, error) {
 			}
 		} else {
 			// Relative widths, we have to calculate as percentages.
-			used := 0.0
+			// As in Asciidoctor, each width is truncated to 4 decimal places,
+			// so we compute with integer units of 0.0001 percent.
+			used := 0
 			for i, v := range widths {
+				var w int
 				if i == len(widths)-1 {
 					// Last column uses remainder -- addresses rounding errors.
-					// (Also, faster, simpler math.)
-					v = 100 - used
+					w = 1000000 - used
 				} else {
-					// This rounds to nearest .001 percent.  This allows us to
-					// use %.6g format in templates without precision loss.
-					v = v * 1000000 / total
-					v = math.Round(v)
-					v /= 10000
+					w = int(math.Floor(v * 1000000 / total))
 				}
-				used += v
-				widths[i] = v
+				used += w
+				// This allows us to use %.6g format in templates without precision loss.
+				widths[i] = float64(w) / 10000
 			}
 		}
 	}
@@ -202,20 +203,28 @@ func NewTable(header interface{}, lines []interface{}, attributes interface{}) (
 
 	var err error
 	if t.Columns, err = t.parseColumnsAttr(); err != nil {
-		return Table{}, errors.Wrap(err, "failed to initialize a Table element")
+		return Table{}, fmt.Errorf("failed to initialize a Table element: %w", err)
 	}
 
 	if header, ok := header.(TableLine); ok {
-		t.Header = header
-		if t.Columns == nil {
-			// columns determined by our cell count here
-			for i := 0; i < len(header.Cells); i++ {
-				t.Columns = append(t.Columns, defaultColumn)
+		if t.Attributes.HasOption(OptionNoHeader) {
+			// the implicit header is processed as a regular line
+			lines = append([]interface{}{header}, lines...)
+		} else {
+			t.Header = TableLine{
+				Cells: header.Cells,
+			}
+			if t.Columns == nil {
+				// columns determined by our cell count here
+				for i := 0; i < len(header.Cells); i++ {
+					t.Columns = append(t.Columns, defaultColumn)
+				}
 			}
 		}
 	}
 	// need to regroup columns of all lines, they dispatch on lines
 	cells := make([][]interface{}, 0, len(lines))
+	sources := make([]TableCell, 0, len(lines))
 	for _, l := range lines {
 		if l, ok := l.(TableLine); ok {
 			// if no header line was set, inspect the first line to determine the number of columns per line
@@ -225,53 +234,132 @@ func NewTable(header interface{}, lines []interface{}, attributes interface{}) (
 				}
 			}
 			cells = append(cells, l.Cells...)
+			sources = append(sources, l.sourceCells...)
 		}
 	}
 
 	// Calculate the actual widths now
 	if t.Columns, err = t.processColumnWidths(); err != nil {
-		return Table{}, errors.Wrap(err, "failed to initialize a Table element")
+		return Table{}, fmt.Errorf("failed to initialize a Table element: %w", err)
 	}
 
 	t.Lines = make([]TableLine, 0, len(cells))
 	if len(lines) > 0 {
 		// log.Debugf("buffered %d columns for the table", len(cells))
 		l := TableLine{
-			Cells: make([][]interface{}, len(t.Columns)),
+			Cells:       make([][]interface{}, len(t.Columns)),
+			sourceCells: make([]TableCell, len(t.Columns)),
 		}
 		for i, c := range cells {
 			// log.Debugf("adding cell with content '%v' in table line at offset %d", c, i%len(t.Columns))
 			l.Cells[i%len(t.Columns)] = c
+			l.sourceCells[i%len(t.Columns)] = sources[i]
 			if (i+1)%len(t.Columns) == 0 { // switch to next line
 				// log.Debugf("adding line with content '%v' in table", l)
 				t.Lines = append(t.Lines, l)
 				l = TableLine{
-					Cells: make([][]interface{}, len(t.Columns)),
+					Cells:       make([][]interface{}, len(t.Columns)),
+					sourceCells: make([]TableCell, len(t.Columns)),
 				}
 			}
 		}
 	}
+	// explicit header and footer rows
+	if t.Attributes.HasOption(OptionHeader) && len(t.Header.Cells) == 0 && len(t.Lines) > 0 {
+		t.Header = TableLine{
+			Cells: t.Lines[0].Cells,
+		}
+		t.Lines = t.Lines[1:]
+	}
+	if t.Attributes.HasOption(OptionFooter) && len(t.Lines) > 0 {
+		t.Footer = t.Lines[len(t.Lines)-1].withInnerDocuments(t.Columns)
+		t.Lines = t.Lines[:len(t.Lines)-1]
+	}
+	for i, l := range t.Lines {
+		t.Lines[i] = l.withInnerDocuments(t.Columns)
+	}
 	// log.Debugf("initialized a new table with %d line(s)", len(lines))
 	return t, nil
 }
 
 // TableLine a table line is made of columns, each column being a group of []interface{} (to support quoted text, etc.)
 type TableLine struct {
-	Cells [][]interface{}
+	Cells       [][]interface{}
+	sourceCells []TableCell // internally used to retain the cell styles and raw content, cleared post processing
 }
 
 // NewTableLine initializes a new TableLine with the given columns
 func NewTableLine(columns []interface{}) (TableLine, error) {
 	c := make([][]interface{}, 0, len(columns))
+	s := make([]TableCell, 0, len(columns))
 	for _, column := range columns {
-		if e, ok := column.([]interface{}); ok {
+		switch e := column.(type) {
+		case TableCell:
+			c = append(c, e.Elements)
+			s = append(s, e)
+		case []interface{}:
 			c = append(c, e)
-		} else {
-			return TableLine{}, errors.Errorf("unsupported element of type %T", column)
+			s = append(s, TableCell{Elements: e})
+		default:
+			return TableLine{}, fmt.Errorf("unsupported element of type %T", column)
 		}
 	}
 	// log.Debugf("initialized a new table line with %d columns", len(c))
 	return TableLine{
-		Cells: c,
+		Cells:       c,
+		sourceCells: s,
 	}, nil
 }
+
+// withInnerDocuments returns a copy of this line in which the content of each cell with the `a` style
+// (set on the cell itself or on its column) is replaced by an InnerDocument to parse later
+func (l TableLine) withInnerDocuments(cols []TableColumn) TableLine {
+	result := TableLine{
+		Cells: make([][]interface{}, len(l.Cells)),
+	}
+	for i, cell := range l.Cells {
+		result.Cells[i] = cell
+		if i >= len(l.sourceCells) {
+			continue
+		}
+		if l.sourceCells[i].Style == AsciiDocCellStyle || (len(cols) > 0 && cols[i%len(cols)].Style == AsciiDocCellStyle) {
+			result.Cells[i] = []interface{}{
+				InnerDocument{
+					Source: l.sourceCells[i].RawText,
+				},
+			}
+		}
+	}
+	return result
+}
+
+// AsciiDocCellStyle the style of the table cells whose content is parsed as a nested document
+const AsciiDocCellStyle = "a"
+
+// TableCell a table cell, as it was parsed in the source document.
+// Only used to initialize the lines of a Table.
+type TableCell struct {
+	Style    string // the optional cell specifier (eg: `a` for `a|`)
+	Elements []interface{}
+	RawText  string // the content of the cell, as written in the source document
+}
+
+// NewTableCell initializes a new TableCell with the given style and elements.
+// The given text is the whole cell, including its specifier and separator
+func NewTableCell(style interface{}, elements []interface{}, text string) (TableCell, error) {
+	c := TableCell{
+		Elements: Merge(elements),
+		RawText:  strings.TrimSpace(text[strings.Index(text, "|")+1:]),
+	}
+	if style, ok := style.(string); ok {
+		c.Style = style
+	}
+	return c, nil
+}
+
+// InnerDocument the content of a table cell with the `a` style, which is parsed as a standalone document
+// with its own scope of attributes, in which the attributes of the enclosing document are inherited
+type InnerDocument struct {
+	Source   string // the raw content of the cell, cleared once it has been parsed
+	Elements []interface{}
+}
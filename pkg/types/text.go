@@ -0,0 +1,230 @@
+package types
+
+import (
+	"strings"
+)
+
+// ------------------------------------------
+// Document Text
+// ------------------------------------------
+
+// TextSection the plain text of a section of a document
+type TextSection struct {
+	ID    string
+	Level int
+	Title string // the plain text of the section title
+	Text  string // the plain text of the section content (excluding its subsections), with one line per block
+}
+
+// Text returns the plain text of the document, split in sections: the first section is the document header (if any)
+// with the content of the preamble, followed by all the other sections in the order of the document.
+// The content of each block is normalized on a single line, without its formatting, and the comments are ignored.
+// The content of the verbatim blocks (listing, source, fenced, literal and passthrough blocks) is included only if
+// `includeVerbatim` is `true`.
+func (d Document) Text(includeVerbatim bool) []TextSection {
+	result := []TextSection{}
+	elements := d.Elements
+	first := TextSection{}
+	if header, found := d.Header(); found {
+		first.ID = header.Attributes.GetAsStringWithDefault(AttrID, "")
+		first.Title = plainText(header.Title)
+		elements = header.Elements
+	}
+	return newTextSections(result, first, elements, includeVerbatim)
+}
+
+// newTextSections appends the given section completed with the text of the given elements, followed by the subsections,
+// unless the given section has no title and no text
+func newTextSections(result []TextSection, section TextSection, elements []interface{}, includeVerbatim bool) []TextSection {
+	lines := []string{}
+	subsections := []Section{}
+	for _, element := range elements {
+		switch e := element.(type) {
+		case Section:
+			if e.IsDiscrete() {
+				lines = appendTextLine(lines, blockText(e, includeVerbatim))
+				continue
+			}
+			subsections = append(subsections, e)
+		case Preamble:
+			for _, b := range e.Elements {
+				lines = appendTextLine(lines, blockText(b, includeVerbatim))
+			}
+		default:
+			lines = appendTextLine(lines, blockText(e, includeVerbatim))
+		}
+	}
+	section.Text = strings.Join(lines, "\n")
+	if section.Title != "" || section.Text != "" {
+		result = append(result, section)
+	}
+	for _, s := range subsections {
+		result = newTextSections(result, TextSection{
+			ID:    s.Attributes.GetAsStringWithDefault(AttrID, ""),
+			Level: s.Level,
+			Title: plainText(s.Title),
+		}, s.Elements, includeVerbatim)
+	}
+	return result
+}
+
+func appendTextLine(lines []string, line string) []string {
+	if line == "" {
+		return lines
+	}
+	return append(lines, line)
+}
+
+// blockText returns the text of the given block, on a single line
+func blockText(element interface{}, includeVerbatim bool) string {
+	w := newTextWriter(includeVerbatim)
+	w.writeBlock(element)
+	return strings.Join(strings.Fields(w.buf.String()), " ")
+}
+
+// plainText returns the text of the given inline elements, without their formatting
+func plainText(elements []interface{}) string {
+	w := newTextWriter(false)
+	w.writeInline(elements)
+	return strings.Join(strings.Fields(w.buf.String()), " ")
+}
+
+// textWriter writes the text of the blocks and inline elements, ignoring their formatting
+type textWriter struct {
+	buf      *strings.Builder
+	verbatim bool // true if the content of the verbatim blocks should be written
+}
+
+func newTextWriter(verbatim bool) textWriter {
+	return textWriter{
+		buf:      &strings.Builder{},
+		verbatim: verbatim,
+	}
+}
+
+// writeBlock writes the text of the given block, followed by a new line so that
+// the words of consecutive blocks are not merged
+func (w textWriter) writeBlock(element interface{}) {
+	switch e := element.(type) {
+	case []interface{}:
+		for _, b := range e {
+			w.writeBlock(b)
+		}
+		return
+	case Section: // discrete heading
+		w.writeInline(e.Title)
+		w.buf.WriteString("\n")
+		w.writeBlock(e.Elements)
+	case Paragraph:
+		w.writeLines(e.Lines)
+	case OrderedList:
+		for _, item := range e.Items {
+			w.writeBlock(item.Elements)
+		}
+	case UnorderedList:
+		for _, item := range e.Items {
+			w.writeBlock(item.Elements)
+		}
+	case LabeledList:
+		for _, item := range e.Items {
+			w.writeInline(item.Term)
+			w.buf.WriteString("\n")
+			w.writeBlock(item.Elements)
+		}
+	case CalloutList:
+		for _, item := range e.Items {
+			w.writeBlock(item.Elements)
+		}
+	case ExampleBlock:
+		w.writeBlock(e.Elements)
+	case QuoteBlock:
+		w.writeBlock(e.Elements)
+	case SidebarBlock:
+		w.writeBlock(e.Elements)
+	case VerseBlock:
+		w.writeLines(e.Lines)
+	case MarkdownQuoteBlock:
+		w.writeLines(e.Lines)
+	case FencedBlock:
+		if w.verbatim {
+			w.writeLines(e.Lines)
+		}
+	case ListingBlock:
+		if w.verbatim {
+			w.writeLines(e.Lines)
+		}
+	case LiteralBlock:
+		if w.verbatim {
+			w.writeLines(e.Lines)
+		}
+	case PassthroughBlock:
+		if w.verbatim {
+			w.writeLines(e.Lines)
+		}
+	case Table:
+		w.writeLines(e.Header.Cells)
+		for _, l := range e.Lines {
+			w.writeLines(l.Cells)
+		}
+		w.writeLines(e.Footer.Cells)
+	default:
+		// other types (eg: comments, images, etc.) are ignored
+	}
+	w.buf.WriteString("\n")
+}
+
+// writeLines writes the text of the given lines (or table cells), each one followed by a new line
+func (w textWriter) writeLines(lines [][]interface{}) {
+	for _, l := range lines {
+		w.writeInline(l)
+		w.buf.WriteString("\n")
+	}
+}
+
+// writeInline writes the text of the given inline elements
+func (w textWriter) writeInline(elements []interface{}) {
+	for _, element := range elements {
+		switch e := element.(type) {
+		case StringElement:
+			w.buf.WriteString(e.Content)
+		case QuotedText:
+			w.writeInline(e.Elements)
+		case QuotedString:
+			w.writeInline(e.Elements)
+		case InlinePassthrough:
+			w.writeInline(e.Elements)
+		case VerbatimLine:
+			w.writeInline(e.Elements)
+		case IndexTerm:
+			w.writeInline(e.Term)
+		case InlineLink:
+			switch text := e.Attributes[AttrInlineLinkText].(type) {
+			case string:
+				w.buf.WriteString(text)
+			case []interface{}:
+				w.writeInline(text)
+			default:
+				w.buf.WriteString(e.Location.Stringify())
+			}
+		case InternalCrossReference:
+			w.writeLabel(e.Label)
+		case ExternalCrossReference:
+			w.writeLabel(e.Label)
+		case InnerDocument:
+			w.writeBlock(e.Elements)
+		case SpecialCharacter:
+			w.buf.WriteString(e.Name)
+		default:
+			// other types (eg: footnote references, images, etc.) are ignored
+		}
+	}
+}
+
+func (w textWriter) writeLabel(label interface{}) {
+	switch l := label.(type) {
+	case string:
+		w.buf.WriteString(l)
+	case []interface{}:
+		w.writeInline(l)
+	}
+}
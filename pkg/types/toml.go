@@ -0,0 +1,53 @@
+package types
+
+import (
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// parseTOML parses the given content. The integers are returned as `int` and the dates and times as strings,
+// as they are with a YAML front-matter
+func parseTOML(content string) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	if _, err := toml.Decode(content, &result); err != nil {
+		return nil, err
+	}
+	return normalizeTOML(result).(map[string]interface{}), nil
+}
+
+func normalizeTOML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int64:
+		return int(v)
+	case time.Time:
+		switch v.Location().String() {
+		case "date-local":
+			return v.Format("2006-01-02")
+		case "datetime-local":
+			return v.Format("2006-01-02T15:04:05.999999999")
+		case "time-local":
+			return v.Format("15:04:05.999999999")
+		default:
+			return v.Format(time.RFC3339Nano)
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeTOML(e)
+		}
+		return v
+	case []map[string]interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = normalizeTOML(e)
+		}
+		return result
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeTOML(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
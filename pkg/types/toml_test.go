@@ -0,0 +1,89 @@
+package types_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
+)
+
+var _ = DescribeTable("toml front-matter",
+	func(content string, expected map[string]interface{}) {
+		Expect(types.NewTomlFrontMatter(content)).To(Equal(types.FrontMatter{Content: expected}))
+	},
+	Entry("empty", ``, map[string]interface{}{}),
+	Entry("strings", `basic = "a \"quoted\" # value"
+literal = 'C:\path'
+"quoted key" = "tab\tbed"`, map[string]interface{}{
+		"basic":      `a "quoted" # value`,
+		"literal":    `C:\path`,
+		"quoted key": "tab\tbed",
+	}),
+	Entry("numbers and booleans", `int = -42
+hex = 0xff
+float = 3.14
+exp = 1e3
+yes = true`, map[string]interface{}{
+		"int":   -42,
+		"hex":   255,
+		"float": 3.14,
+		"exp":   1000.0,
+		"yes":   true,
+	}),
+	Entry("dates", `date = 2021-03-21
+datetime = 1979-05-27T07:32:00-08:00`, map[string]interface{}{
+		"date":     "2021-03-21",
+		"datetime": "1979-05-27T07:32:00-08:00",
+	}),
+	Entry("nested arrays", `matrix = [ [1, 2], ["a, b"] ]`, map[string]interface{}{
+		"matrix": []interface{}{
+			[]interface{}{1, 2},
+			[]interface{}{"a, b"},
+		},
+	}),
+	Entry("multi-line string", `title = """a
+title"""`, map[string]interface{}{
+		"title": "a\ntitle",
+	}),
+	Entry("local date and time", `datetime = 1979-05-27T07:32:00
+time = 07:32:00`, map[string]interface{}{
+		"datetime": "1979-05-27T07:32:00",
+		"time":     "07:32:00",
+	}),
+	Entry("array of tables", `[[items]]
+name = "a"
+
+[[items]]
+name = "b"`, map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"name": "b"},
+		},
+	}),
+	Entry("dotted keys and tables", `site.name = "docs"
+
+[menu.main]
+weight = 1 # first
+`, map[string]interface{}{
+		"site": map[string]interface{}{
+			"name": "docs",
+		},
+		"menu": map[string]interface{}{
+			"main": map[string]interface{}{
+				"weight": 1,
+			},
+		},
+	}),
+)
+
+var _ = DescribeTable("invalid toml front-matter",
+	func(content string) {
+		_, err := types.NewTomlFrontMatter(content)
+		Expect(err).To(HaveOccurred())
+	},
+	Entry("missing value", `title`),
+	Entry("unterminated string", `title = "a title`),
+	Entry("redefined key", `title = "a title"
+title.main = "main title"`),
+	Entry("unknown value", `title = a title`),
+)
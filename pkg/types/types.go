@@ -7,8 +7,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/bytesparadise/libasciidoc/pkg/substitution"
 	"github.com/davecgh/go-spew/spew"
-	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	log "github.com/sirupsen/logrus"
 	yaml "gopkg.in/yaml.v2"
@@ -18,6 +18,15 @@ import (
 type RawDocument struct {
 	FrontMatter FrontMatter
 	Elements    []interface{}
+	Warnings    []SourceWarning
+}
+
+// SourceWarning a problem found in the source of a document which did not prevent its parsing (eg: an unterminated delimited block),
+// along with the file (the main document or an included file) and the line in this file where it was found
+type SourceWarning struct {
+	Filename string
+	Line     int
+	Message  string
 }
 
 // NewRawDocument initializes a new `RawDocument` from the given lines
@@ -36,36 +45,55 @@ func NewRawDocument(frontMatter interface{}, elements []interface{}) (RawDocumen
 // and all the document attribute declarations at the top of the document only.
 func (d RawDocument) Attributes() Attributes {
 	result := Attributes{}
-elements:
-	for _, b := range d.Elements {
+	for _, b := range d.Header() {
 		switch b := b.(type) {
 		case Section:
-			if b.Level == 0 {
-				// also, expand document authors and revision
-				if authors, ok := b.Attributes[AttrAuthors].([]DocumentAuthor); ok {
-					// move to the Document attributes
-					result.SetAll(expandAuthors(authors))
-					delete(b.Attributes, AttrAuthors)
-				}
-				// also, expand document authors and revision
-				if revision, ok := b.Attributes[AttrRevision].(DocumentRevision); ok {
-					// move to the Document attributes
-					result.SetAll(expandRevision(revision))
-					delete(b.Attributes, AttrRevision)
-				}
-				continue // allow to continue if the section is level 0
+			// also, expand document authors and revision
+			if authors, ok := b.Attributes[AttrAuthors].([]DocumentAuthor); ok {
+				// move to the Document attributes
+				result.SetAll(expandAuthors(authors))
+				delete(b.Attributes, AttrAuthors)
+			}
+			// also, expand document authors and revision
+			if revision, ok := b.Attributes[AttrRevision].(DocumentRevision); ok {
+				// move to the Document attributes
+				result.SetAll(expandRevision(revision))
+				delete(b.Attributes, AttrRevision)
 			}
-			break elements // otherwise, just stop
 		case AttributeDeclaration:
 			result.Set(b.Name, b.Value)
-		default:
-			break elements
+		case AttributeReset:
+			result[b.Name] = UnsetAttribute{}
 		}
 	}
 	// log.Debugf("document attributes: %+v", result)
 	return result
 }
 
+// Header returns the elements of the document header, i.e., the top-level section
+// and all the document attribute declarations and resets at the top of the document,
+// including the comments which may be interleaved with them.
+func (d RawDocument) Header() []interface{} {
+	return header(d.Elements)
+}
+
+func header(elements []interface{}) []interface{} {
+	for i, b := range elements {
+		switch b := b.(type) {
+		case Section:
+			if b.Level == 0 {
+				continue // allow to continue if the section is level 0
+			}
+			return elements[:i] // otherwise, just stop
+		case AttributeDeclaration, AttributeReset, SingleLineComment, CommentBlock:
+			continue
+		default:
+			return elements[:i]
+		}
+	}
+	return elements
+}
+
 // RawSection a document section (when processing file inclusions)
 // We only care about the level here
 type RawSection struct {
@@ -75,25 +103,62 @@ type RawSection struct {
 }
 
 // NewRawSection returns a new RawSection
-func NewRawSection(level int, title string) (RawSection, error) {
+func NewRawSection(level int, title string, rawText string) (RawSection, error) {
 	// log.Debugf("new rawsection: '%s' (%d)", title, level)
+	if err := validateSectionLevel(level); err != nil {
+		return RawSection{}, fmt.Errorf("failed to initialize a RawSection: %w", err)
+	}
 	return RawSection{
-		Level: level,
-		Title: title,
+		Level:   level,
+		Title:   title,
+		RawText: rawText,
 	}, nil
 }
 
 var _ Stringer = RawSection{}
 
 // Stringify returns the string representation of this section, as it existed in the source document
+// (with the spaces after the `=` marker), but with its current level
 func (s RawSection) Stringify() string {
-	return strings.Repeat("=", s.Level+1) + " " + s.Title
+	if s.RawText == "" {
+		return strings.Repeat("=", s.Level+1) + " " + s.Title
+	}
+	return strings.Repeat("=", s.Level+1) + strings.TrimLeft(s.RawText, "=")
 }
 
 // ------------------------------------------
 // Lines
 // ------------------------------------------
 
+// RawSourceLine an element of the raw source (a line, a section title, a preprocessor directive, etc.)
+// along with the line at which it starts
+type RawSourceLine struct {
+	Line    int
+	Element interface{}
+}
+
+// NewRawSourceLine returns a new RawSourceLine
+func NewRawSourceLine(line int, element interface{}) (RawSourceLine, error) {
+	return RawSourceLine{
+		Line:    line,
+		Element: element,
+	}, nil
+}
+
+// SourcePosition the position of a line in the original source: the file (empty for the main document) and the line in this file
+type SourcePosition struct {
+	Filename string
+	Line     int
+}
+
+// String returns the position as `<filename>:<line>`, or as `line <line>` if the filename is unknown
+func (p SourcePosition) String() string {
+	if p.Filename == "" {
+		return fmt.Sprintf("line %d", p.Line)
+	}
+	return fmt.Sprintf("%s:%d", p.Filename, p.Line)
+}
+
 // NewRawLine returns a new slice containing a single StringElement with the given content
 func NewRawLine(content string) ([]interface{}, error) {
 	// log.Debugf("new line: '%v'", content)
@@ -104,6 +169,29 @@ func NewRawLine(content string) ([]interface{}, error) {
 	}, nil
 }
 
+// TrimIndentation removes the given indentation at the beginning of each raw line.
+// Lines which are less indented are only stripped from their leading spaces.
+func TrimIndentation(lines []interface{}, indentation string) []interface{} {
+	for i, line := range lines {
+		l, ok := line.([]interface{})
+		if !ok || len(l) != 1 {
+			continue
+		}
+		if s, ok := l[0].(StringElement); ok {
+			n := 0
+			for n < len(indentation) && n < len(s.Content) && (s.Content[n] == ' ' || s.Content[n] == '\t') {
+				n++
+			}
+			lines[i] = []interface{}{
+				StringElement{
+					Content: s.Content[n:],
+				},
+			}
+		}
+	}
+	return lines
+}
+
 // ------------------------------------------
 // common interfaces
 // ------------------------------------------
@@ -172,15 +260,7 @@ type WithNestedElementSubstitution interface {
 	WithElementsToSubstitute
 }
 
-var defaultSubstitutionsForBlockElements = []string{
-	"inline_passthrough",
-	"specialcharacters",
-	"quotes",
-	"attributes",
-	"replacements",
-	"macros",
-	"post_replacements",
-}
+var defaultSubstitutionsForBlockElements = substitution.InlinePipeline
 var defaultExampleBlockSubstitutions = defaultSubstitutionsForBlockElements
 var defaultQuoteBlockSubstitutions = defaultSubstitutionsForBlockElements
 var defaultSidebarBlockSubstitutions = defaultSubstitutionsForBlockElements
@@ -188,16 +268,13 @@ var defaultVerseBlockSubstitutions = defaultSubstitutionsForBlockElements // eve
 var defaultParagraphSubstitutions = defaultSubstitutionsForBlockElements  // even though it's a block of lines, not a block of blocks
 
 // blocks of lines
-var defaultSubstitutionsForBlockLines = []string{
-	"callouts", // must be executed before "specialcharacters"
-	"specialcharacters",
-}
+var defaultSubstitutionsForBlockLines = substitution.VerbatimPipeline
 var defaultFencedBlockSubstitutions = defaultSubstitutionsForBlockLines
 var defaultListingBlockSubstitutions = defaultSubstitutionsForBlockLines
 var defaultLiteralBlockSubstitutions = defaultSubstitutionsForBlockLines
 
 // other blocks
-var defaultPassthroughBlockSubstitutions = []string{}
+var defaultPassthroughBlockSubstitutions = substitution.Pipeline{}
 
 // ------------------------------------------
 // Draft Document: document in which
@@ -211,22 +288,36 @@ type DraftDocument struct {
 	Elements    []interface{}
 }
 
+// Header returns the elements of the document header (see `RawDocument.Header()`)
+func (d DraftDocument) Header() []interface{} {
+	return header(d.Elements)
+}
+
 // ------------------------------------------
 // Document
 // ------------------------------------------
 
+// ASTVersion the version of the AST, ie, of the types of the `Document` and of its elements.
+// This version is incremented on every breaking change in these types, in which case the previous versions
+// remain available in a compatibility package (eg: `types/v1`) with the functions to convert the documents
+const ASTVersion = 2
+
 // Document the top-level structure for a document
 type Document struct {
-	Attributes        Attributes
+	Attributes        DocumentAttributes
 	Elements          []interface{} // TODO: rename to `Blocks`?
 	ElementReferences ElementReferences
 	Footnotes         []Footnote
+	Glossary          GlossaryEntries
+	Warnings          []SourceWarning
 }
 
 // Authors retrieves the document authors from the document header, or empty array if no author was found
 func (d Document) Authors() ([]DocumentAuthor, bool) {
-	if authors, ok := d.Attributes[AttrAuthors].([]DocumentAuthor); ok {
-		return authors, true
+	if authors, found := d.Attributes.Get(AttrAuthors); found {
+		if authors, ok := authors.([]DocumentAuthor); ok {
+			return authors, true
+		}
 	}
 	return []DocumentAuthor{}, false
 }
@@ -254,6 +345,31 @@ type Metadata struct {
 	TableOfContents TableOfContents
 	Authors         []DocumentAuthor
 	Revision        DocumentRevision
+	// Assets the local files (eg: images) referenced in the rendered document, relative to the document
+	Assets []string
+	// Attributes the attributes of the document
+	Attributes Attributes
+	// InlineCache the statistics of the cache of rendered inline elements, or `nil` if the cache was disabled
+	InlineCache *InlineCacheStats
+}
+
+// InlineCacheStats the number of hits and misses in the cache of rendered inline elements
+type InlineCacheStats struct {
+	Hits   int
+	Misses int
+}
+
+// HitRatio returns the ratio of hits (between `0` and `1`), or `0` if the cache was never used
+func (s InlineCacheStats) HitRatio() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// String returns a human-readable representation of the statistics (eg: `10 hit(s), 5 miss(es) (66.7% hit ratio)`)
+func (s InlineCacheStats) String() string {
+	return fmt.Sprintf("%d hit(s), %d miss(es) (%.1f%% hit ratio)", s.Hits, s.Misses, s.HitRatio()*100)
 }
 
 // TableOfContents the table of contents
@@ -269,6 +385,26 @@ type ToCSection struct {
 	Children []ToCSection
 }
 
+// Prune returns a copy of this table of contents which only includes the sections
+// whose level is lower than or equal to the given level
+func (t TableOfContents) Prune(level int) TableOfContents {
+	return TableOfContents{
+		Sections: pruneToCSections(t.Sections, level),
+	}
+}
+
+func pruneToCSections(sections []ToCSection, level int) []ToCSection {
+	result := make([]ToCSection, 0, len(sections))
+	for _, s := range sections {
+		if s.Level > level {
+			continue
+		}
+		s.Children = pruneToCSections(s.Children, level)
+		result = append(result, s)
+	}
+	return result
+}
+
 // ------------------------------------------
 // Document Element
 // ------------------------------------------
@@ -297,7 +433,7 @@ func NewDocumentAuthors(authors []interface{}) ([]DocumentAuthor, error) {
 		case DocumentAuthor:
 			result[i] = author
 		default:
-			return nil, errors.Errorf("unexpected type of author: %T", author)
+			return nil, fmt.Errorf("unexpected type of author: %T", author)
 		}
 	}
 	return result, nil
@@ -387,21 +523,47 @@ func NewAttributeDeclaration(name string, value interface{}) (AttributeDeclarati
 	}, nil
 }
 
+// NewAttributeDeclarationValue joins the lines of an attribute declaration value which spans multiple lines.
+// Lines are joined with a space, unless the previous line ends with a hard line break (` +`),
+// in which case the line break is preserved.
+func NewAttributeDeclarationValue(lines []interface{}) (interface{}, error) {
+	result := make([]interface{}, 0, len(lines))
+	for i, line := range lines {
+		elements, ok := line.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected type of line in attribute value: '%T'", line)
+		}
+		if i > 0 && len(result) > 0 {
+			separator := " "
+			if last, ok := result[len(result)-1].(StringElement); ok && strings.HasSuffix(last.Content, " +") {
+				separator = "\n"
+			}
+			result = append(result, StringElement{
+				Content: separator,
+			})
+		}
+		result = append(result, elements...)
+	}
+	return Reduce(result, strings.TrimSpace), nil
+}
+
 var _ Stringer = AttributeDeclaration{}
 
 // Stringify returns the string representation of this attribute declaration, as it existed in the source document
 func (a AttributeDeclaration) Stringify() string {
 	result := strings.Builder{}
 	result.WriteString(":" + a.Name + ":")
+	// preserve the line breaks in multi-line values
+	continuation := strings.NewReplacer("\n", " \\\n")
 	switch v := a.Value.(type) {
 	case string:
-		result.WriteString(" " + v)
+		result.WriteString(" " + continuation.Replace(v))
 	case []interface{}:
 		result.WriteString(" ")
 		for _, e := range v {
 			switch e := e.(type) {
 			case StringElement:
-				result.WriteString(e.Content)
+				result.WriteString(continuation.Replace(e.Content))
 			case AttributeSubstitution:
 				result.WriteString("{" + e.Name + "}")
 			}
@@ -439,6 +601,13 @@ func NewAttributeReset(attrName string) (AttributeReset, error) {
 	return AttributeReset{Name: attrName}, nil
 }
 
+var _ Stringer = AttributeReset{}
+
+// Stringify returns the string representation of this attribute reset, as it existed in the source document
+func (a AttributeReset) Stringify() string {
+	return ":" + a.Name + "!:"
+}
+
 // AttributeSubstitution the type for AttributeSubstitution
 type AttributeSubstitution struct {
 	Name string
@@ -523,6 +692,16 @@ const (
 	Source = "source"
 	// Passthrough a passthrough block
 	Passthrough = "pass"
+	// Normal a regular paragraph, even if it is indented
+	Normal = "normal"
+	// Discrete a discrete heading, i.e., a section title which is not part of the document structure
+	Discrete = "discrete"
+	// Float a discrete heading (legacy style)
+	Float = "float"
+	// Appendix an appendix section
+	Appendix = "appendix"
+	// Glossary a glossary section or labeled list
+	Glossary = "glossary"
 
 	// AttrSourceBlockOption the option set on a source block, using the `source%<option>` attribute
 	AttrSourceBlockOption = "source-option" // DEPRECATED
@@ -535,6 +714,57 @@ const (
 // TableOfContentsPlaceHolder a place holder for Table of Contents, so
 // the renderer knows when to render it.
 type TableOfContentsPlaceHolder struct {
+	Attributes Attributes
+	Macro      bool // true if the place holder was defined with the `toc::[]` macro
+}
+
+// NewTableOfContentsMacro initializes a new TableOfContentsPlaceHolder from a `toc::[]` macro
+// with its optional `title` and `levels` attributes
+func NewTableOfContentsMacro(inlineAttributes Attributes, attributes interface{}) (TableOfContentsPlaceHolder, error) {
+	// inline attributes trump block attributes
+	attrs := Attributes(nil).SetAll(attributes).SetAll(inlineAttributes)
+	return TableOfContentsPlaceHolder{
+		Attributes: attrs,
+		Macro:      true,
+	}, nil
+}
+
+// ------------------------------------------
+// Lists of Figures, Tables and Listings
+// ------------------------------------------
+
+const (
+	// FiguresCaptionList the kind of caption list with all titled images of the document (`toc::figures[]`)
+	FiguresCaptionList = "figures"
+	// TablesCaptionList the kind of caption list with all titled tables of the document (`toc::tables[]`)
+	TablesCaptionList = "tables"
+	// ListingsCaptionList the kind of caption list with all titled listing and source blocks of the document (`toc::listings[]`)
+	ListingsCaptionList = "listings"
+)
+
+// CaptionListPlaceHolder a place holder for the list of figures, tables or listings of the document,
+// so the renderer knows when to render it.
+type CaptionListPlaceHolder struct {
+	Kind       string // `figures`, `tables` or `listings`
+	Attributes Attributes
+}
+
+// NewCaptionListMacro initializes a new CaptionListPlaceHolder from a `toc::figures[]`, `toc::tables[]` or `toc::listings[]` macro
+// with its optional `title` attribute
+func NewCaptionListMacro(kind string, inlineAttributes Attributes, attributes interface{}) (CaptionListPlaceHolder, error) {
+	// inline attributes trump block attributes
+	attrs := Attributes(nil).SetAll(attributes).SetAll(inlineAttributes)
+	return CaptionListPlaceHolder{
+		Kind:       kind,
+		Attributes: attrs,
+	}, nil
+}
+
+// CaptionListEntry an entry in a list of figures, tables or listings
+type CaptionListEntry struct {
+	ID      string
+	Caption string // eg: `Figure 1. `
+	Title   string
 }
 
 // ------------------------------------------
@@ -582,6 +812,64 @@ func NewInlineUserMacro(name, value string, attributes interface{}, raw string)
 	}, nil
 }
 
+// ------------------------------------------
+// Generic Block Macro
+// ------------------------------------------
+
+// builtInBlockMacros the names of the block macros which are processed by the parser itself
+// (or which are preprocessor directives)
+var builtInBlockMacros = map[string]bool{
+	"image":   true,
+	"video":   true,
+	"audio":   true,
+	"toc":     true,
+	"include": true,
+	"tag":     true,
+	"end":     true,
+	"ifdef":   true,
+	"ifndef":  true,
+	"ifeval":  true,
+	"endif":   true,
+}
+
+// IsBuiltInBlockMacro returns `true` if the block macro with the given name is a built-in
+func IsBuiltInBlockMacro(name string) bool {
+	return builtInBlockMacros[name]
+}
+
+// GenericBlockMacro a block macro which is not built-in (eg: `plantuml::diagram.puml[]`),
+// and which is expected to be handled by an extension registered in the configuration
+type GenericBlockMacro struct {
+	Name       string
+	Target     string
+	Attributes Attributes
+	RawText    string
+}
+
+// NewGenericBlockMacro returns a new GenericBlockMacro
+func NewGenericBlockMacro(name, target string, inlineAttributes Attributes, attributes interface{}, rawText string) (GenericBlockMacro, error) {
+	return GenericBlockMacro{
+		Name:   name,
+		Target: target,
+		// inline attributes trump block attributes
+		Attributes: Attributes(nil).SetAll(attributes).SetAll(inlineAttributes),
+		RawText:    rawText,
+	}, nil
+}
+
+var _ WithAttributesToSubstitute = GenericBlockMacro{}
+
+// AttributesToSubstitute returns the attributes of this block macro
+func (m GenericBlockMacro) AttributesToSubstitute() Attributes {
+	return m.Attributes
+}
+
+// ReplaceAttributes replaces the attributes in this block macro
+func (m GenericBlockMacro) ReplaceAttributes(attributes Attributes) interface{} {
+	m.Attributes = attributes
+	return m
+}
+
 // ------------------------------------------
 // Preamble
 // ------------------------------------------
@@ -591,18 +879,10 @@ type Preamble struct {
 	Elements []interface{}
 }
 
-// HasContent returns `true` if this Preamble has at least one element which is neither a
-// BlankLine nor a AttributeDeclaration
+// HasContent returns `true` if this Preamble has at least one element
+// (the blank lines are already filtered out of the final document)
 func (p Preamble) HasContent() bool {
-	for _, pe := range p.Elements {
-		switch pe.(type) {
-		case BlankLine:
-			continue
-		default:
-			return true
-		}
-	}
-	return false
+	return len(p.Elements) > 0
 }
 
 // ------------------------------------------
@@ -619,12 +899,21 @@ func NewYamlFrontMatter(content string) (FrontMatter, error) {
 	attributes := make(map[string]interface{})
 	err := yaml.Unmarshal([]byte(content), &attributes)
 	if err != nil {
-		return FrontMatter{}, errors.Wrapf(err, "failed to parse yaml content in front-matter of document")
+		return FrontMatter{}, fmt.Errorf("failed to parse yaml content in front-matter of document: %w", err)
 	}
 	// log.Debugf("new FrontMatter with attributes: %+v", attributes)
 	return FrontMatter{Content: attributes}, nil
 }
 
+// NewTomlFrontMatter initializes a new FrontMatter from the given `content`
+func NewTomlFrontMatter(content string) (FrontMatter, error) {
+	attributes, err := parseTOML(content)
+	if err != nil {
+		return FrontMatter{}, fmt.Errorf("failed to parse toml content in front-matter of document: %w", err)
+	}
+	return FrontMatter{Content: attributes}, nil
+}
+
 // ------------------------------------------
 // Sections
 // ------------------------------------------
@@ -638,14 +927,35 @@ type Section struct {
 }
 
 // NewSection initializes a new `Section` from the given section title and elements
-func NewSection(level int, title []interface{}, ids []interface{}, attributes interface{}) (Section, error) {
+func NewSection(level int, title []interface{}, titleAttributes []interface{}, attributes interface{}) (Section, error) {
+	if err := validateSectionLevel(level); err != nil {
+		return Section{}, fmt.Errorf("failed to initialize a Section: %w", err)
+	}
+	if title == nil {
+		return Section{}, fmt.Errorf("failed to initialize a Section: missing title")
+	}
 	attrs := toAttributes(attributes)
+	// trailing anchors and shorthand attributes (eg: `[[an_id]] [.role]`) on the title
 	// multiple IDs can be defined (by mistake), but only the last one is used
-	attrs = attrs.SetAll(ids)
+	attrs = attrs.SetAll(titleAttributes)
 	// also, set the `AttrCustomID` flag if an ID was set
 	if _, exists := attrs[AttrID]; exists {
 		attrs[AttrCustomID] = true
 	}
+	if style, ok := attrs[AttrPositional1].(string); ok {
+		switch style {
+		case Discrete, Float:
+			// `[discrete]` and `[float]` styles turn the section title into a discrete heading
+			delete(attrs, AttrPositional1)
+			attrs[AttrStyle] = Discrete
+		case Appendix:
+			delete(attrs, AttrPositional1)
+			attrs[AttrStyle] = Appendix
+		case Glossary:
+			delete(attrs, AttrPositional1)
+			attrs[AttrStyle] = Glossary
+		}
+	}
 	return Section{
 		Level:      level,
 		Attributes: attrs,
@@ -654,6 +964,17 @@ func NewSection(level int, title []interface{}, ids []interface{}, attributes in
 	}, nil
 }
 
+// MaxSectionLevel the maximum level of a section (`======`)
+const MaxSectionLevel = 5
+
+// validateSectionLevel verifies that the given level is between `0` and `MaxSectionLevel`
+func validateSectionLevel(level int) error {
+	if level < 0 || level > MaxSectionLevel {
+		return fmt.Errorf("invalid section level: %d (must be between 0 and %d)", level, MaxSectionLevel)
+	}
+	return nil
+}
+
 var _ WithElementsToSubstitute = Section{}
 
 // ElementsToSubstitute returns this section's title so that substitutions can be applied onto its elements
@@ -680,6 +1001,17 @@ func (s Section) ReplaceAttributes(attributes Attributes) interface{} {
 	return s
 }
 
+// IsDiscrete returns `true` if this section is a discrete heading, which is
+// rendered as a standalone heading and does not contain any element
+func (s Section) IsDiscrete() bool {
+	return s.Attributes.GetAsStringWithDefault(AttrStyle, "") == Discrete
+}
+
+// IsAppendix returns `true` if this section is an appendix
+func (s Section) IsAppendix() bool {
+	return s.Attributes.GetAsStringWithDefault(AttrStyle, "") == Appendix
+}
+
 // ResolveID resolves/updates the "ID" attribute in the section (in case the title changed after some document attr substitution)
 func (s Section) ResolveID(docAttributes AttributesWithOverrides) (Section, error) {
 	if log.IsLevelEnabled(log.DebugLevel) {
@@ -689,13 +1021,7 @@ func (s Section) ResolveID(docAttributes AttributesWithOverrides) (Section, erro
 
 	if !s.Attributes.Has(AttrID) {
 		// log.Debugf("resolving section id")
-		separator := docAttributes.GetAsStringWithDefault(AttrIDSeparator, DefaultIDSeparator)
-		replacement, err := ReplaceNonAlphanumerics(s.Title, separator)
-		if err != nil {
-			return s, errors.Wrapf(err, "failed to generate default ID on Section element")
-		}
-		idPrefix := docAttributes.GetAsStringWithDefault(AttrIDPrefix, DefaultIDPrefix)
-		s.Attributes = s.Attributes.Set(AttrID, idPrefix+replacement)
+		s.Attributes = s.Attributes.Set(AttrID, NewSlugger(docAttributes).Slug(s.Title))
 		// log.Debugf("updated section id to '%s'", s.Attributes[AttrID])
 	}
 	return s, nil
@@ -777,27 +1103,27 @@ func expandAuthors(authors []DocumentAuthor) Attributes {
 			email = strings.TrimSpace(author.Email)
 		}
 		if part2 != "" && part3 != "" {
-			result[key("firstname", i)] = strings.TrimSpace(part1)
-			result[key("middlename", i)] = strings.TrimSpace(part2)
-			result[key("lastname", i)] = strings.TrimSpace(part3)
-			result[key("author", i)] = strings.Join([]string{part1, part2, part3}, " ")
-			result[key("authorinitials", i)] = strings.Join([]string{initial(part1), initial(part2), initial(part3)}, "")
+			result[key(AttrFirstName, i)] = strings.TrimSpace(part1)
+			result[key(AttrMiddleName, i)] = strings.TrimSpace(part2)
+			result[key(AttrLastName, i)] = strings.TrimSpace(part3)
+			result[key(AttrAuthor, i)] = strings.Join([]string{part1, part2, part3}, " ")
+			result[key(AttrAuthorInitials, i)] = strings.Join([]string{initial(part1), initial(part2), initial(part3)}, "")
 		} else if part2 != "" {
-			result[key("firstname", i)] = strings.TrimSpace(part1)
-			result[key("lastname", i)] = strings.TrimSpace(part2)
-			result[key("author", i)] = strings.Join([]string{part1, part2}, " ")
-			result[key("authorinitials", i)] = strings.Join([]string{initial(part1), initial(part2)}, "")
+			result[key(AttrFirstName, i)] = strings.TrimSpace(part1)
+			result[key(AttrLastName, i)] = strings.TrimSpace(part2)
+			result[key(AttrAuthor, i)] = strings.Join([]string{part1, part2}, " ")
+			result[key(AttrAuthorInitials, i)] = strings.Join([]string{initial(part1), initial(part2)}, "")
 		} else {
-			result[key("firstname", i)] = strings.TrimSpace(part1)
-			result[key("author", i)] = strings.TrimSpace(part1)
-			result[key("authorinitials", i)] = initial(part1)
+			result[key(AttrFirstName, i)] = strings.TrimSpace(part1)
+			result[key(AttrAuthor, i)] = strings.TrimSpace(part1)
+			result[key(AttrAuthorInitials, i)] = initial(part1)
 		}
 		if email != "" {
-			result[key("email", i)] = email
+			result[key(AttrEmail, i)] = email
 		}
 		// also include a "string" version of the given author
 		s[i] = DocumentAuthor{
-			FullName: result[key("author", i)].(string),
+			FullName: result[key(AttrAuthor, i)].(string),
 			Email:    email,
 		}
 	}
@@ -824,9 +1150,9 @@ func initial(s string) string {
 // those attributes can be used in attribute substitutions in the document
 func expandRevision(revision DocumentRevision) Attributes {
 	result := make(Attributes, 3)
-	result.AddNonEmpty("revnumber", revision.Revnumber)
-	result.AddNonEmpty("revdate", revision.Revdate)
-	result.AddNonEmpty("revremark", revision.Revremark)
+	result.AddNonEmpty(AttrRevNumber, revision.Revnumber)
+	result.AddNonEmpty(AttrRevDate, revision.Revdate)
+	result.AddNonEmpty(AttrRevRemark, revision.Revremark)
 	// also add the revision itself
 	result.AddNonEmpty(AttrRevision, revision)
 	// log.Debugf("revision: %v", result)
@@ -874,6 +1200,11 @@ type OrderedList struct {
 
 var _ List = &OrderedList{}
 
+// GetAttributes returns the attributes of this OrderedList
+func (l OrderedList) GetAttributes() Attributes {
+	return l.Attributes
+}
+
 const (
 	// Arabic the arabic numbering (1, 2, 3, etc.)
 	Arabic = "arabic"
@@ -906,20 +1237,8 @@ func NewOrderedList(item *OrderedListItem) *OrderedList {
 func rearrangeListAttributes(attributes Attributes) Attributes {
 	for k := range attributes {
 		switch k {
-		case "upperalpha":
-			attributes[AttrStyle] = "upperalpha"
-			delete(attributes, k)
-		case "upperroman":
-			attributes[AttrStyle] = "upperroman"
-			delete(attributes, k)
-		case "lowerroman":
-			attributes[AttrStyle] = "lowerroman"
-			delete(attributes, k)
-		case "loweralpha":
-			attributes[AttrStyle] = "loweralpha"
-			delete(attributes, k)
-		case "arabic":
-			attributes[AttrStyle] = "arabic"
+		case UpperAlpha, UpperRoman, LowerRoman, LowerAlpha, Arabic:
+			attributes[AttrStyle] = k
 			delete(attributes, k)
 		}
 
@@ -1021,6 +1340,11 @@ type UnorderedList struct {
 
 var _ List = &UnorderedList{}
 
+// GetAttributes returns the attributes of this UnorderedList
+func (l UnorderedList) GetAttributes() Attributes {
+	return l.Attributes
+}
+
 // NewUnorderedList returns a new UnorderedList with 1 item
 // The attributes of the given item are moved to the resulting list
 func NewUnorderedList(item UnorderedListItem) *UnorderedList {
@@ -1193,6 +1517,9 @@ const (
 // `***` -> `****`
 // `****` -> `*****`
 // `*****` -> `-`
+//
+// Deprecated: the style of the unordered list items is now the style of their actual marker,
+// and this method is not used by the parser anymore.
 func (b BulletStyle) NextLevel(p BulletStyle) BulletStyle {
 	switch p {
 	case Dash:
@@ -1259,6 +1586,11 @@ type LabeledList struct {
 
 var _ List = &LabeledList{}
 
+// GetAttributes returns the attributes of this LabeledList
+func (l LabeledList) GetAttributes() Attributes {
+	return l.Attributes
+}
+
 // NewLabeledList returns a new LabeledList with 1 item
 // The attributes of the given item are moved to the resulting list
 func NewLabeledList(item LabeledListItem) *LabeledList {
@@ -1297,6 +1629,10 @@ var _ ListItem = &LabeledListItem{}
 // NewLabeledListItem initializes a new LabeledListItem
 func NewLabeledListItem(level int, term []interface{}, description interface{}, attributes interface{}) (LabeledListItem, error) {
 	// log.Debugf("new LabeledListItem")
+	// level is `1` for `::` and up to `3` for `::::`
+	if level < 1 || level > 3 {
+		return LabeledListItem{}, fmt.Errorf("failed to initialize a LabeledListItem: invalid level: %d (must be between 1 and 3)", level)
+	}
 	var elements []interface{}
 	if description, ok := description.([]interface{}); ok {
 		elements = description
@@ -1369,7 +1705,7 @@ func NewParagraph(lines []interface{}, attributes interface{}) (Paragraph, error
 	// log.Debugf("new paragraph with attributes: '%v'", attributes)
 	l, err := toLines(lines)
 	if err != nil {
-		return Paragraph{}, errors.Wrapf(err, "failed to initialize a Paragraph")
+		return Paragraph{}, fmt.Errorf("failed to initialize a Paragraph: %w", err)
 	}
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		AttrPositional1: AttrStyle,
@@ -1383,7 +1719,13 @@ func NewParagraph(lines []interface{}, attributes interface{}) (Paragraph, error
 		attrs = toAttributesWithMapping(attrs, map[string]string{
 			AttrPositional2: AttrQuoteAuthor,
 			AttrPositional3: AttrQuoteTitle,
+			AttrAttribution: AttrQuoteAuthor,
+			AttrCiteTitle:   AttrQuoteTitle,
 		})
+	case Normal:
+		// an indented paragraph with the `normal` style is not a literal block,
+		// but its common indentation is removed
+		l = removeIndentation(l, TabSize(attrs))
 	}
 	return Paragraph{
 		Attributes: attrs,
@@ -1391,6 +1733,25 @@ func NewParagraph(lines []interface{}, attributes interface{}) (Paragraph, error
 	}, nil
 }
 
+// removeIndentation removes the common indentation on each raw line (see `RemoveCommonIndentation`)
+func removeIndentation(lines [][]interface{}, tabSize int) [][]interface{} {
+	indices := []int{}
+	contents := []string{}
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+		if s, ok := line[0].(StringElement); ok {
+			indices = append(indices, i)
+			contents = append(contents, s.Content)
+		}
+	}
+	for i, content := range RemoveCommonIndentation(contents, tabSize) {
+		lines[indices[i]][0] = StringElement{Content: content}
+	}
+	return lines
+}
+
 func toLines(lines []interface{}) ([][]interface{}, error) {
 	result := make([][]interface{}, len(lines))
 	for i, line := range lines {
@@ -1540,10 +1901,27 @@ func NewInternalCrossReference(id, label interface{}) (InternalCrossReference, e
 	}, nil
 }
 
+// InlineAnchor an anchor within a block of text, which can be the target of cross references.
+// Eg: `[[an_id]]` or `[[an_id,A Reference Text]]`
+type InlineAnchor struct {
+	ID      string
+	RefText interface{}
+}
+
+// NewInlineAnchor initializes a new `InlineAnchor` with the given ID and optional reftext
+func NewInlineAnchor(id string, reftext interface{}) (InlineAnchor, error) {
+	// log.Debugf("new InlineAnchor with ID=%s", id)
+	return InlineAnchor{
+		ID:      id,
+		RefText: reftext,
+	}, nil
+}
+
 // ExternalCrossReference the struct for Cross References
 type ExternalCrossReference struct {
-	Location Location
-	Label    interface{}
+	Location   Location
+	Label      interface{}
+	Attributes Attributes // other attributes (eg: roles), besides the label
 }
 
 // NewExternalCrossReference initializes a new `InternalCrossReference` from the given ID
@@ -1553,10 +1931,17 @@ func NewExternalCrossReference(location Location, attributes interface{}) (Exter
 	if l, ok := attrs[AttrPositional1]; ok {
 		label = l
 	}
+	var others Attributes
+	for k, v := range attrs {
+		if k != AttrPositional1 {
+			others = others.Set(k, v)
+		}
+	}
 	// log.Debugf("new ExternalCrossReference with Location=%v and label='%s' (attrs=%v / %T)", location, label, attributes, attrs[AttrInlineLinkText])
 	return ExternalCrossReference{
-		Location: location,
-		Label:    label,
+		Location:   location,
+		Label:      label,
+		Attributes: others,
 	}, nil
 }
 
@@ -1596,8 +1981,7 @@ type ImageBlock struct {
 // NewImageBlock initializes a new `ImageBlock`
 func NewImageBlock(location Location, inlineAttributes Attributes, attributes interface{}) (ImageBlock, error) {
 	// inline attributes trump block attributes
-	attrs := toAttributes(inlineAttributes)
-	attrs.SetAll(attributes)
+	attrs := Attributes(nil).SetAll(attributes).SetAll(inlineAttributes)
 	attrs = toAttributesWithMapping(attrs, map[string]string{
 		AttrPositional1: AttrImageAlt,
 		AttrPositional2: AttrWidth,
@@ -1928,6 +2312,8 @@ func NewQuoteBlock(elements []interface{}, attributes interface{}) (QuoteBlock,
 		AttrPositional1: AttrStyle,
 		AttrPositional2: AttrQuoteAuthor,
 		AttrPositional3: AttrQuoteTitle,
+		AttrAttribution: AttrQuoteAuthor,
+		AttrCiteTitle:   AttrQuoteTitle,
 	})
 	return QuoteBlock{
 		Attributes: attrs,
@@ -2046,7 +2432,7 @@ func NewFencedBlock(lines []interface{}, attributes interface{}) (FencedBlock, e
 	// log.Debugf("new FencedBlock with %d lines", len(lines))
 	l, err := toLines(lines)
 	if err != nil {
-		return FencedBlock{}, errors.Wrapf(err, "failed to initialize a new fenced block")
+		return FencedBlock{}, fmt.Errorf("failed to initialize a new fenced block: %w", err)
 	}
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		AttrPositional1: AttrStyle,
@@ -2109,13 +2495,13 @@ func NewListingBlock(lines []interface{}, attributes interface{}) (ListingBlock,
 	// log.Debugf("new ListingBlock with %d lines", len(lines))
 	l, err := toLines(lines)
 	if err != nil {
-		return ListingBlock{}, errors.Wrapf(err, "failed to initialize a new listing block")
+		return ListingBlock{}, fmt.Errorf("failed to initialize a new listing block: %w", err)
 	}
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		AttrPositional1: AttrStyle,
 	})
 	if style, ok, err := attrs.GetAsString(AttrStyle); err != nil {
-		return ListingBlock{}, errors.Wrapf(err, "failed to initialize a new listing block")
+		return ListingBlock{}, fmt.Errorf("failed to initialize a new listing block: %w", err)
 	} else if ok && style == AttrSource {
 		attrs = toAttributesWithMapping(attributes, map[string]string{
 			AttrPositional1: AttrStyle,
@@ -2157,6 +2543,25 @@ func (b ListingBlock) SubstituteLines(lines [][]interface{}) interface{} {
 	return b
 }
 
+var _ RawText = ListingBlock{}
+
+// RawText returns the raw text representation of the lines of this listing block
+// as they were (supposedly) written in the source document
+func (b ListingBlock) RawText() (string, error) {
+	result := strings.Builder{}
+	for i, l := range b.Lines {
+		if i > 0 {
+			result.WriteString("\n")
+		}
+		s, err := toRawText(l)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(s)
+	}
+	return result.String(), nil
+}
+
 var _ WithAttributesToSubstitute = ListingBlock{}
 
 // AttributesToSubstitute returns this listing block's attributes
@@ -2181,12 +2586,14 @@ func NewVerseBlock(lines []interface{}, attributes interface{}) (VerseBlock, err
 	// log.Debugf("new VerseBlock with %d lines", len(lines))
 	l, err := toLines(lines)
 	if err != nil {
-		return VerseBlock{}, errors.Wrapf(err, "failed to initialize a new verse block")
+		return VerseBlock{}, fmt.Errorf("failed to initialize a new verse block: %w", err)
 	}
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		AttrPositional1: AttrStyle,
 		AttrPositional2: AttrQuoteAuthor,
 		AttrPositional3: AttrQuoteTitle,
+		AttrAttribution: AttrQuoteAuthor,
+		AttrCiteTitle:   AttrQuoteTitle,
 	})
 	return VerseBlock{
 		Attributes: attrs,
@@ -2246,7 +2653,7 @@ func NewMarkdownQuoteBlock(lines []interface{}, attributes interface{}) (Markdow
 	// log.Debugf("new MarkdownQuoteBlock with %d lines", len(lines))
 	l, err := toLines(lines)
 	if err != nil {
-		return MarkdownQuoteBlock{}, errors.Wrapf(err, "failed to initialize a new markdown quote block")
+		return MarkdownQuoteBlock{}, fmt.Errorf("failed to initialize a new markdown quote block: %w", err)
 	}
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		AttrPositional1: AttrStyle,
@@ -2268,7 +2675,7 @@ func NewPassthroughBlock(lines []interface{}, attributes interface{}) (Passthrou
 	// log.Debugf("new PassthroughBlock with %d lines", len(lines))
 	l, err := toLines(lines)
 	if err != nil {
-		return PassthroughBlock{}, errors.Wrapf(err, "failed to initialize a new passthrough block")
+		return PassthroughBlock{}, fmt.Errorf("failed to initialize a new passthrough block: %w", err)
 	}
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		AttrPositional1: AttrStyle,
@@ -2331,7 +2738,7 @@ func NewCommentBlock(lines []interface{}, attributes interface{}) (CommentBlock,
 	// log.Debugf("new CommentBlock with %d lines", len(lines))
 	l, err := toLines(lines)
 	if err != nil {
-		return CommentBlock{}, errors.Wrapf(err, "failed to initialize a new comment block")
+		return CommentBlock{}, fmt.Errorf("failed to initialize a new comment block: %w", err)
 	}
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		AttrPositional1: AttrStyle,
@@ -2357,6 +2764,8 @@ const (
 	LiteralBlockWithSpacesOnFirstLine = "literalBlockWithSpacesOnFirstLine"
 	// LiteralBlockWithAttribute a literal block parsed with a `[literal]` attribute`
 	LiteralBlockWithAttribute = "literalBlockWithAttribute"
+	// AttrTabSize the number of columns between two tab stops, when removing the indentation of the lines
+	AttrTabSize = "tabsize"
 )
 
 // NewLiteralBlock initializes a new `LiteralBlock` of the given kind with the given content,
@@ -2371,7 +2780,7 @@ func NewLiteralBlock(origin string, lines []interface{}, attributes interface{})
 	attrs = attrs.Set(AttrLiteralBlockType, origin)
 	l, err := toLines(lines)
 	if err != nil {
-		return LiteralBlock{}, errors.Wrapf(err, "failed to initialize a new literal block")
+		return LiteralBlock{}, fmt.Errorf("failed to initialize a new literal block: %w", err)
 	}
 	return LiteralBlock{
 		Attributes: attrs,
@@ -2446,6 +2855,13 @@ func NewCallout(ref int) (Callout, error) {
 	}, nil
 }
 
+var _ RawText = Callout{}
+
+// RawText returns the raw text representation of this element as it was (supposedly) written in the source document
+func (c Callout) RawText() (string, error) {
+	return fmt.Sprintf("<%d>", c.Ref), nil
+}
+
 // CalloutListItem the description of a call out which will appear as an ordered list item after the delimited block
 type CalloutListItem struct {
 	Attributes Attributes
@@ -2468,9 +2884,9 @@ func (i *CalloutListItem) AddElement(element interface{}) {
 }
 
 // NewCalloutListItem returns a new CalloutListItem
-func NewCalloutListItem(ref int, description []interface{}) (CalloutListItem, error) {
+func NewCalloutListItem(ref int, description []interface{}, attributes interface{}) (CalloutListItem, error) {
 	return CalloutListItem{
-		Attributes: nil,
+		Attributes: toAttributes(attributes),
 		Ref:        ref,
 		Elements:   description,
 	}, nil
@@ -2484,6 +2900,11 @@ type CalloutList struct {
 
 var _ List = &CalloutList{}
 
+// GetAttributes returns the attributes of this CalloutList
+func (l CalloutList) GetAttributes() Attributes {
+	return l.Attributes
+}
+
 // NewCalloutList initializes a new CalloutList and uses the given item's attributes as the list attributes
 func NewCalloutList(item CalloutListItem) *CalloutList {
 	attrs := item.Attributes
@@ -2797,6 +3218,7 @@ func (s QuotedString) RestoreElements(placeholders map[string]interface{}) inter
 type InlinePassthrough struct {
 	Kind     PassthroughKind
 	Elements []interface{} // TODO: refactor to `Content string` ?
+	Subs     string        // the substitutions to apply on the content of a passthrough macro (eg: `q,a` in `pass:q,a[]`), cleared once applied
 }
 
 // PassthroughKind the kind of passthrough
@@ -2809,6 +3231,10 @@ const (
 	TriplePlusPassthrough = PassthroughKind("+++")
 	// PassthroughMacro a passthrough with the `pass:[]` macro
 	PassthroughMacro = PassthroughKind("pass:[]")
+	// EscapedPassthrough an inline element (or the opening of an inline macro) escaped with a leading backslash (eg: `\{name}` or `\+`)
+	EscapedPassthrough = PassthroughKind(`\`)
+	// HugoShortcodePassthrough a Hugo shortcode (eg: `{{< youtube id="w7Ft2ymGmfc" >}}`), including its delimiters
+	HugoShortcodePassthrough = PassthroughKind("{{}}")
 )
 
 // NewInlinePassthrough returns a new passthrough
@@ -2819,6 +3245,26 @@ func NewInlinePassthrough(kind PassthroughKind, elements []interface{}) (InlineP
 	}, nil
 }
 
+// NewMonospacePassthrough returns a new passthrough wrapped in a monospace quoted text
+// (eg: `+text+` in compat mode)
+func NewMonospacePassthrough(kind PassthroughKind, elements []interface{}) (QuotedText, error) {
+	p, err := NewInlinePassthrough(kind, elements)
+	if err != nil {
+		return QuotedText{}, err
+	}
+	return NewQuotedText(SingleQuoteMonospace, p)
+}
+
+// NewInlinePassthroughWithSubs returns a new passthrough macro with the given substitutions
+// to apply on its content
+func NewInlinePassthroughWithSubs(subs string, elements []interface{}) (InlinePassthrough, error) {
+	return InlinePassthrough{
+		Kind:     PassthroughMacro,
+		Elements: Merge(elements...),
+		Subs:     subs,
+	}, nil
+}
+
 var _ RawText = InlinePassthrough{}
 
 // RawText returns the raw text representation of this element as it was (supposedly) written in the source document
@@ -2826,7 +3272,9 @@ func (p InlinePassthrough) RawText() (string, error) {
 	result := strings.Builder{}
 	switch p.Kind {
 	case PassthroughMacro:
-		result.WriteString("pass:[") // opening delimiter
+		result.WriteString("pass:" + p.Subs + "[") // opening delimiter
+	case HugoShortcodePassthrough:
+		// delimiters are part of the content
 	default:
 		result.WriteString(string(p.Kind)) // opening delimiter
 	}
@@ -2838,6 +3286,8 @@ func (p InlinePassthrough) RawText() (string, error) {
 	switch p.Kind {
 	case PassthroughMacro:
 		result.WriteString("]") // closing delimiter
+	case EscapedPassthrough, HugoShortcodePassthrough:
+		// no closing delimiter
 	default:
 		result.WriteString(string(p.Kind)) // closing delimiter
 	}
@@ -2927,10 +3377,11 @@ type FileInclusion struct {
 	Attributes Attributes
 	Location   Location
 	RawText    string
+	Line       int // the line of the directive in the including document
 }
 
 // NewFileInclusion initializes a new inline `InlineLink`
-func NewFileInclusion(location Location, attributes interface{}, rawtext string) (FileInclusion, error) {
+func NewFileInclusion(location Location, attributes interface{}, rawtext string, line int) (FileInclusion, error) {
 	attrs := toAttributesWithMapping(attributes, map[string]string{
 		"tag": "tags", // convert `tag` to `tags`
 	})
@@ -2938,6 +3389,7 @@ func NewFileInclusion(location Location, attributes interface{}, rawtext string)
 		Attributes: attrs,
 		Location:   location,
 		RawText:    rawtext,
+		Line:       line,
 	}, nil
 }
 
@@ -2962,6 +3414,77 @@ func (f *FileInclusion) TagRanges() (TagRanges, bool) {
 	return TagRanges{}, false // default tag ranges: include all content
 }
 
+// ------------------------------------------
+// Conditional Inclusions
+// ------------------------------------------
+
+// the kinds of conditional inclusions
+const (
+	// IfdefCondition the `ifdef` preprocessor directive
+	IfdefCondition string = "ifdef"
+	// IfndefCondition the `ifndef` preprocessor directive
+	IfndefCondition string = "ifndef"
+	// IfevalCondition the `ifeval` preprocessor directive
+	IfevalCondition string = "ifeval"
+)
+
+// ConditionalInclusion the structure for the `ifdef`, `ifndef` and `ifeval` preprocessor directives,
+// which determine if their content (on the same line) or the following lines (until the matching `endif`)
+// are included in the document
+type ConditionalInclusion struct {
+	Kind string
+	// Names the names of the attributes of the `ifdef` and `ifndef` directives, separated with `,` (any) or `+` (all)
+	Names string
+	// Expression the expression of the `ifeval` directive (eg: `{sectnumlevels} >= 3`)
+	Expression string
+	// Content the content of a single line `ifdef` or `ifndef` directive (eg: `ifdef::foo[some content]`)
+	Content string
+}
+
+// NewIfdefCondition initializes a new `ifdef` ConditionalInclusion
+func NewIfdefCondition(names, content string) (ConditionalInclusion, error) {
+	return ConditionalInclusion{
+		Kind:    IfdefCondition,
+		Names:   names,
+		Content: content,
+	}, nil
+}
+
+// NewIfndefCondition initializes a new `ifndef` ConditionalInclusion
+func NewIfndefCondition(names, content string) (ConditionalInclusion, error) {
+	return ConditionalInclusion{
+		Kind:    IfndefCondition,
+		Names:   names,
+		Content: content,
+	}, nil
+}
+
+// NewIfevalCondition initializes a new `ifeval` ConditionalInclusion
+func NewIfevalCondition(expression string) (ConditionalInclusion, error) {
+	return ConditionalInclusion{
+		Kind:       IfevalCondition,
+		Expression: expression,
+	}, nil
+}
+
+// SingleLine returns `true` if the content of this conditional inclusion is on the same line as the directive
+func (c ConditionalInclusion) SingleLine() bool {
+	return c.Content != ""
+}
+
+// EndOfConditionalInclusion the structure for the `endif` preprocessor directive
+type EndOfConditionalInclusion struct {
+	// Names the names of the attributes of the matching `ifdef` or `ifndef` directive (optional)
+	Names string
+}
+
+// NewEndOfConditionalInclusion initializes a new EndOfConditionalInclusion
+func NewEndOfConditionalInclusion(names string) (EndOfConditionalInclusion, error) {
+	return EndOfConditionalInclusion{
+		Names: names,
+	}, nil
+}
+
 // -------------------------------------------------------------------------------------
 // LineRanges: one or more ranges of lines to limit the content of a file to include
 // -------------------------------------------------------------------------------------
@@ -1,6 +1,9 @@
 package types_test
 
 import (
+	"strings"
+	"testing/quick"
+
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 
 	. "github.com/onsi/ginkgo" //nolint golint
@@ -1344,4 +1347,178 @@ var _ = DescribeTable("rawtest",
 			},
 		},
 		"*some `\"content<>\"` {here}*"),
+	// listing block
+	Entry("listing block with special characters and callout",
+		types.ListingBlock{
+			Lines: [][]interface{}{
+				{
+					types.StringElement{
+						Content: "Alice -",
+					},
+					types.SpecialCharacter{
+						Name: ">",
+					},
+					types.StringElement{
+						Content: " Bob ",
+					},
+					types.Callout{
+						Ref: 1,
+					},
+				},
+				{
+					types.StringElement{
+						Content: "@enduml",
+					},
+				},
+			},
+		},
+		"Alice -> Bob <1>\n@enduml"),
 )
+
+var _ = Describe("constructor invariants", func() {
+
+	Context("sections", func() {
+
+		DescribeTable("should only accept levels between 0 and 5",
+			func(level int, valid bool) {
+				s, err := types.NewSection(level, []interface{}{}, nil, nil)
+				if !valid {
+					Expect(err).To(HaveOccurred())
+					return
+				}
+				Expect(err).ToNot(HaveOccurred())
+				Expect(s.Level).To(Equal(level))
+			},
+			Entry("level -1", -1, false),
+			Entry("level 0", 0, true),
+			Entry("level 5", 5, true),
+			Entry("level 6", 6, false),
+		)
+
+		It("should not accept a nil title", func() {
+			_, err := types.NewSection(1, nil, nil, nil)
+			Expect(err).To(MatchError("failed to initialize a Section: missing title"))
+		})
+
+		It("should report an invalid level", func() {
+			_, err := types.NewSection(6, []interface{}{}, nil, nil)
+			Expect(err).To(MatchError("failed to initialize a Section: invalid section level: 6 (must be between 0 and 5)"))
+		})
+	})
+
+	Context("raw sections", func() {
+
+		DescribeTable("should only accept levels between 0 and 5",
+			func(level int, valid bool) {
+				_, err := types.NewRawSection(level, "title", "")
+				if !valid {
+					Expect(err).To(HaveOccurred())
+					return
+				}
+				Expect(err).ToNot(HaveOccurred())
+			},
+			Entry("level -1", -1, false),
+			Entry("level 0", 0, true),
+			Entry("level 5", 5, true),
+			Entry("level 6", 6, false),
+		)
+
+		It("should stringify with as many markers as the level", func() {
+			property := func(level uint8, title string) bool {
+				l := int(level) % (types.MaxSectionLevel + 1)
+				s, err := types.NewRawSection(l, title, "")
+				if err != nil {
+					return false
+				}
+				return s.Stringify() == strings.Repeat("=", l+1)+" "+title
+			}
+			Expect(quick.Check(property, nil)).To(Succeed())
+		})
+
+		It("should stringify with the spaces of the raw text", func() {
+			s, err := types.NewRawSection(1, "title", "==\ttitle")
+			Expect(err).NotTo(HaveOccurred())
+			s.Level = 2 // eg: with a level offset
+			Expect(s.Stringify()).To(Equal("===\ttitle"))
+		})
+	})
+
+	Context("labeled list items", func() {
+
+		DescribeTable("should only accept levels between 1 and 3",
+			func(level int, valid bool) {
+				i, err := types.NewLabeledListItem(level, []interface{}{}, nil, nil)
+				if !valid {
+					Expect(err).To(HaveOccurred())
+					return
+				}
+				Expect(err).ToNot(HaveOccurred())
+				Expect(i.Level).To(Equal(level))
+				Expect(i.Elements).ToNot(BeNil())
+			},
+			Entry("level 0", 0, false),
+			Entry("level 1", 1, true),
+			Entry("level 3", 3, true),
+			Entry("level 4", 4, false),
+		)
+	})
+
+	Context("string elements", func() {
+
+		It("should return the content as raw text", func() {
+			property := func(content string) bool {
+				s, err := types.NewStringElement(content)
+				if err != nil {
+					return false
+				}
+				raw, err := s.RawText()
+				return err == nil && raw == content
+			}
+			Expect(quick.Check(property, nil)).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("attributes", func() {
+
+	It("should get string value", func() {
+		attrs := types.Attributes{
+			"foo": "bar",
+			"baz": types.UnsetAttribute{},
+		}
+		value, found := attrs.GetString("foo")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("bar"))
+		value, found = attrs.GetString("baz")
+		Expect(found).To(BeFalse())
+		Expect(value).To(BeEmpty())
+		_, found = attrs.GetString("unknown")
+		Expect(found).To(BeFalse())
+	})
+
+	It("should unset attribute", func() {
+		attrs := types.Attributes{
+			"foo": "bar",
+			"baz": "qux",
+		}
+		Expect(attrs.Unset("foo")).To(Equal(types.Attributes{
+			"baz": "qux",
+		}))
+		Expect(attrs.Has("foo")).To(BeFalse())
+		Expect(attrs.GetAsStringWithDefault("foo", "default")).To(Equal("default"))
+		_, found := attrs["foo"]
+		Expect(found).To(BeFalse())
+	})
+
+	It("should set options", func() {
+		attrs := types.Attributes{}.
+			Set(types.AttrOption, "header").
+			Set(types.AttrOptions, "footer,header").
+			Set(types.AttrOpts, []interface{}{" autowidth ", "footer"})
+		Expect(attrs).To(Equal(types.Attributes{
+			types.AttrOptions: []interface{}{"header", "footer", "autowidth"},
+		}))
+		Expect(attrs.HasOption("autowidth")).To(BeTrue())
+		Expect(attrs.HasOption("nowrap")).To(BeFalse())
+	})
+})
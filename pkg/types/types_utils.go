@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strconv"
 	"strings"
 )
 
@@ -8,6 +9,15 @@ import (
 func Merge(elements ...interface{}) []interface{} {
 	result := make([]interface{}, 0, len(elements))
 	buf := &strings.Builder{}
+	result, buf = merge(result, buf, elements)
+	// if buf was filled because some text was found
+	result, _ = appendBuffer(result, buf)
+	return result
+}
+
+// merge appends the given elements to the result, and writes the consecutive texts in the given buffer.
+// Nested slices are flattened in the same result (and buffer), so that they are visited only once
+func merge(result []interface{}, buf *strings.Builder, elements []interface{}) ([]interface{}, *strings.Builder) {
 	for _, element := range elements {
 		if element == nil {
 			continue
@@ -20,20 +30,14 @@ func Merge(elements ...interface{}) []interface{} {
 		case StringElement:
 			buf.WriteString(element.Content)
 		case []interface{}:
-			if len(element) > 0 {
-				f := Merge(element...)
-				result, buf = appendBuffer(result, buf)
-				result = Merge(append(result, f...)...)
-			}
+			result, buf = merge(result, buf, element)
 		default:
 			// log.Debugf("Merging with 'default' case an element of type %[1]T", element)
 			result, buf = appendBuffer(result, buf)
 			result = append(result, element)
 		}
 	}
-	// if buf was filled because some text was found
-	result, _ = appendBuffer(result, buf)
-	return result
+	return result, buf
 }
 
 // appendBuffer appends the content of the given buffer to the given array of elements,
@@ -87,3 +91,61 @@ func Apply(source string, fs ...applyFunc) string {
 	}
 	return result
 }
+
+// DefaultTabSize the number of columns between two tab stops when the `tabsize` attribute is not set
+const DefaultTabSize = 4
+
+// TabSize returns the value of the `tabsize` attribute in the given attributes,
+// or the default tab size if it is not set or invalid
+func TabSize(attrs Attributes) int {
+	if s, found, err := attrs.GetAsString(AttrTabSize); err == nil && found {
+		if size, err := strconv.Atoi(s); err == nil && size > 0 {
+			return size
+		}
+	}
+	return DefaultTabSize
+}
+
+// RemoveCommonIndentation removes the indentation which is common to all the non-blank given lines.
+// The tabs in the indentation are expanded to the next tab stop beforehand, so the indentation
+// of the lines is compared in columns, regardless of how it is made of spaces and tabs.
+func RemoveCommonIndentation(lines []string, tabSize int) []string {
+	indentation := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if width, _ := indentationOf(line, tabSize); indentation == -1 || width < indentation {
+			indentation = width
+		}
+	}
+	if indentation <= 0 {
+		return lines
+	}
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		width, content := indentationOf(line, tabSize)
+		if width < indentation {
+			result[i] = content
+			continue
+		}
+		result[i] = strings.Repeat(" ", width-indentation) + content
+	}
+	return result
+}
+
+// indentationOf returns the width (in columns) of the indentation of the given line, and the rest of the line
+func indentationOf(line string, tabSize int) (int, string) {
+	width := 0
+	for i, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += tabSize - width%tabSize
+		default:
+			return width, line[i:]
+		}
+	}
+	return width, ""
+}
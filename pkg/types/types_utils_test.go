@@ -1,8 +1,9 @@
 package types
 
 import (
-	. "github.com/onsi/ginkgo" //nolint golint
-	. "github.com/onsi/gomega" //nolint golint
+	. "github.com/onsi/ginkgo"                  //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
 )
 
 var _ = Describe("convert to inline elements", func() {
@@ -27,4 +28,41 @@ var _ = Describe("convert to inline elements", func() {
 		}
 		Expect(Merge(source...)).To(Equal(expected))
 	})
+
+	It("inline content with nested elements", func() {
+		source := []interface{}{
+			StringElement{Content: "hello, "},
+			[]interface{}{
+				"wor",
+				[]interface{}{
+					[]byte("ld"),
+				},
+				QuotedText{Kind: SingleQuoteBold},
+				"!",
+			},
+			StringElement{Content: "!"},
+		}
+		expected := []interface{}{
+			StringElement{Content: "hello, world"},
+			QuotedText{Kind: SingleQuoteBold},
+			StringElement{Content: "!!"},
+		}
+		Expect(Merge(source...)).To(Equal(expected))
+	})
+})
+
+var _ = Describe("remove common indentation", func() {
+
+	DescribeTable("lines",
+		func(lines []string, tabSize int, expected []string) {
+			Expect(RemoveCommonIndentation(lines, tabSize)).To(Equal(expected))
+		},
+		Entry("spaces", []string{"  a", "    b"}, 4, []string{"a", "  b"}),
+		Entry("tabs", []string{"\ta", "\t\tb"}, 4, []string{"a", "    b"}),
+		Entry("spaces and tab", []string{"  a", "\tb"}, 4, []string{"a", "  b"}),
+		Entry("spaces before tab", []string{"  \ta", "    b"}, 4, []string{"a", "b"}),
+		Entry("custom tab size", []string{"  a", "\t\tb"}, 2, []string{"a", "  b"}),
+		Entry("blank line", []string{"  a", "", "  b"}, 4, []string{"a", "", "b"}),
+		Entry("no indentation", []string{"a", "  b"}, 4, []string{"a", "  b"}),
+	)
 })
@@ -0,0 +1,267 @@
+// Package v1 is the compatibility layer for the version 1 of the AST produced by the parser.
+//
+// Downstream tools which depend on this package (rather than on the `types` package) keep working with the same
+// types when the `types` package is redesigned with breaking changes: in such a case, the types of this package
+// are replaced with copies of their version 1, and the `FromDocument` and `ToDocument` functions convert the
+// documents between the current version of the AST and this version.
+//
+// In the version 1 of the AST, the attributes of the `Document` are the attributes as they were at the end of the document header,
+// whereas in the version 2, they are the ordered attributes of the document header and body. All other types of this package
+// are the same as in the current version of the AST, and thus, aliases of the types of the `types` package.
+package v1
+
+import (
+	"fmt"
+
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+)
+
+// Version the version of the AST provided by this package
+const Version = 1
+
+// FromDocument converts the given document produced by the parser (with the current version of the AST)
+// into a document of this version of the AST
+func FromDocument(doc types.Document) (Document, error) {
+	if types.ASTVersion != 2 {
+		return Document{}, fmt.Errorf("unable to convert document from AST version %d to version %d", types.ASTVersion, Version)
+	}
+	return Document{
+		Attributes:        doc.Attributes.Header(),
+		Elements:          doc.Elements,
+		ElementReferences: doc.ElementReferences,
+		Footnotes:         doc.Footnotes,
+		Glossary:          doc.Glossary,
+		Warnings:          doc.Warnings,
+	}, nil
+}
+
+// ToDocument converts the given document of this version of the AST into a document with the current version
+// of the AST (eg: to render it)
+func ToDocument(doc Document) (types.Document, error) {
+	if types.ASTVersion != 2 {
+		return types.Document{}, fmt.Errorf("unable to convert document from AST version %d to version %d", Version, types.ASTVersion)
+	}
+	var attrs types.DocumentAttributes
+	if len(doc.Attributes) > 0 {
+		attrs = attrs.SetAll(doc.Attributes)
+	}
+	return types.Document{
+		Attributes:        attrs,
+		Elements:          doc.Elements,
+		ElementReferences: doc.ElementReferences,
+		Footnotes:         doc.Footnotes,
+		Glossary:          doc.Glossary,
+		Warnings:          doc.Warnings,
+	}, nil
+}
+
+// ------------------------------------------
+// Document
+// ------------------------------------------
+
+// Document the top-level structure for a document
+type Document struct {
+	Attributes        Attributes // the attributes as they were at the end of the document header
+	Elements          []interface{}
+	ElementReferences ElementReferences
+	Footnotes         []Footnote
+	Glossary          GlossaryEntries
+	Warnings          []SourceWarning
+}
+
+// Authors retrieves the document authors from the document header, or empty array if no author was found
+func (d Document) Authors() ([]DocumentAuthor, bool) {
+	if authors, ok := d.Attributes[types.AttrAuthors].([]DocumentAuthor); ok {
+		return authors, true
+	}
+	return []DocumentAuthor{}, false
+}
+
+// Header returns the header, i.e., the section with level 0 if it found as the first element of the document
+func (d Document) Header() (Section, bool) {
+	return types.Document{Elements: d.Elements}.Header()
+}
+
+// Outline returns the outline of the document (see `types.Document.Outline()`)
+func (d Document) Outline(wordsPerMinute int) DocumentOutline {
+	return types.Document{Elements: d.Elements}.Outline(wordsPerMinute)
+}
+
+// Text returns the plain text of the document, split in sections (see `types.Document.Text()`)
+func (d Document) Text(includeVerbatim bool) []TextSection {
+	return types.Document{Elements: d.Elements}.Text(includeVerbatim)
+}
+
+type (
+	// Attributes the attributes of the document and of its elements
+	Attributes = types.Attributes
+	// UnsetAttribute the value of an attribute which was reset
+	UnsetAttribute = types.UnsetAttribute
+	// ElementReferences the element references in the document
+	ElementReferences = types.ElementReferences
+	// SourceWarning a warning about the source of the document
+	SourceWarning = types.SourceWarning
+	// DocumentAuthor a document author
+	DocumentAuthor = types.DocumentAuthor
+	// DocumentRevision a document revision
+	DocumentRevision = types.DocumentRevision
+	// DocumentOutline the outline of a document
+	DocumentOutline = types.DocumentOutline
+	// OutlineSection a section in the outline of a document
+	OutlineSection = types.OutlineSection
+	// TextSection the plain text of a section of a document
+	TextSection = types.TextSection
+	// FrontMatter the front matter of a document
+	FrontMatter = types.FrontMatter
+	// Footnote a footnote
+	Footnote = types.Footnote
+	// GlossaryEntries the terms defined in the glossary of a document
+	GlossaryEntries = types.GlossaryEntries
+)
+
+// ------------------------------------------
+// Blocks
+// ------------------------------------------
+
+type (
+	// Section a section
+	Section = types.Section
+	// Preamble the preamble of a document
+	Preamble = types.Preamble
+	// Paragraph a paragraph
+	Paragraph = types.Paragraph
+	// BlankLine a blank line
+	BlankLine = types.BlankLine
+	// ThematicBreak a thematic break
+	ThematicBreak = types.ThematicBreak
+	// AttributeDeclaration an attribute declaration in the body of a document
+	AttributeDeclaration = types.AttributeDeclaration
+	// AttributeReset an attribute reset in the body of a document
+	AttributeReset = types.AttributeReset
+	// ImageBlock an image block
+	ImageBlock = types.ImageBlock
+	// GenericBlockMacro a block macro which is not built-in
+	GenericBlockMacro = types.GenericBlockMacro
+	// UserMacro a user macro
+	UserMacro = types.UserMacro
+	// MacroKind the kind of a user macro (block or inline)
+	MacroKind = types.MacroKind
+	// TableOfContentsPlaceHolder the placeholder of the table of contents
+	TableOfContentsPlaceHolder = types.TableOfContentsPlaceHolder
+	// CaptionListPlaceHolder the placeholder of a list of figures or tables
+	CaptionListPlaceHolder = types.CaptionListPlaceHolder
+	// SingleLineComment a single line comment
+	SingleLineComment = types.SingleLineComment
+	// ExampleBlock an example block
+	ExampleBlock = types.ExampleBlock
+	// QuoteBlock a quote block
+	QuoteBlock = types.QuoteBlock
+	// SidebarBlock a sidebar block
+	SidebarBlock = types.SidebarBlock
+	// FencedBlock a fenced block
+	FencedBlock = types.FencedBlock
+	// ListingBlock a listing (or source) block
+	ListingBlock = types.ListingBlock
+	// VerseBlock a verse block
+	VerseBlock = types.VerseBlock
+	// MarkdownQuoteBlock a Markdown-style quote block
+	MarkdownQuoteBlock = types.MarkdownQuoteBlock
+	// PassthroughBlock a passthrough block
+	PassthroughBlock = types.PassthroughBlock
+	// CommentBlock a comment block
+	CommentBlock = types.CommentBlock
+	// LiteralBlock a literal block
+	LiteralBlock = types.LiteralBlock
+	// VerbatimLine a line in a verbatim block
+	VerbatimLine = types.VerbatimLine
+	// Table a table
+	Table = types.Table
+	// TableColumn a column of a table
+	TableColumn = types.TableColumn
+	// TableLine a line of a table
+	TableLine = types.TableLine
+	// InnerDocument the content of a table cell with the `a` style
+	InnerDocument = types.InnerDocument
+)
+
+// ------------------------------------------
+// Lists
+// ------------------------------------------
+
+type (
+	// OrderedList an ordered list
+	OrderedList = types.OrderedList
+	// OrderedListItem an item in an ordered list
+	OrderedListItem = types.OrderedListItem
+	// UnorderedList an unordered list
+	UnorderedList = types.UnorderedList
+	// UnorderedListItem an item in an unordered list
+	UnorderedListItem = types.UnorderedListItem
+	// BulletStyle the style of the bullet of an unordered list item
+	BulletStyle = types.BulletStyle
+	// UnorderedListItemCheckStyle the check style of an unordered list item
+	UnorderedListItemCheckStyle = types.UnorderedListItemCheckStyle
+	// LabeledList a labeled list
+	LabeledList = types.LabeledList
+	// LabeledListItem an item in a labeled list
+	LabeledListItem = types.LabeledListItem
+	// CalloutList a callout list
+	CalloutList = types.CalloutList
+	// CalloutListItem an item in a callout list
+	CalloutListItem = types.CalloutListItem
+)
+
+// ------------------------------------------
+// Inline elements
+// ------------------------------------------
+
+type (
+	// StringElement a string
+	StringElement = types.StringElement
+	// SpecialCharacter a special character
+	SpecialCharacter = types.SpecialCharacter
+	// PredefinedAttribute a predefined attribute
+	PredefinedAttribute = types.PredefinedAttribute
+	// AttributeSubstitution a reference to an attribute
+	AttributeSubstitution = types.AttributeSubstitution
+	// CounterSubstitution a reference to a counter
+	CounterSubstitution = types.CounterSubstitution
+	// LineBreak a line break
+	LineBreak = types.LineBreak
+	// QuotedText a quoted text (eg: bold, italic, etc.)
+	QuotedText = types.QuotedText
+	// QuotedTextKind the kind of a quoted text
+	QuotedTextKind = types.QuotedTextKind
+	// QuotedString a quoted string (with typographic quotes)
+	QuotedString = types.QuotedString
+	// QuotedStringKind the kind of a quoted string
+	QuotedStringKind = types.QuotedStringKind
+	// InlinePassthrough an inline passthrough
+	InlinePassthrough = types.InlinePassthrough
+	// PassthroughKind the kind of an inline passthrough
+	PassthroughKind = types.PassthroughKind
+	// InlineLink an inline link
+	InlineLink = types.InlineLink
+	// Location the location of a link or an image
+	Location = types.Location
+	// InlineImage an inline image
+	InlineImage = types.InlineImage
+	// Icon an icon
+	Icon = types.Icon
+	// InlineAnchor an inline anchor
+	InlineAnchor = types.InlineAnchor
+	// InternalCrossReference a cross reference to an element of the document
+	InternalCrossReference = types.InternalCrossReference
+	// ExternalCrossReference a cross reference to another document
+	ExternalCrossReference = types.ExternalCrossReference
+	// FootnoteReference a reference to a footnote
+	FootnoteReference = types.FootnoteReference
+	// GlossaryTerm a reference to a term of the glossary
+	GlossaryTerm = types.GlossaryTerm
+	// IndexTerm a flow index term
+	IndexTerm = types.IndexTerm
+	// ConcealedIndexTerm a concealed index term
+	ConcealedIndexTerm = types.ConcealedIndexTerm
+	// Callout a callout in a verbatim line
+	Callout = types.Callout
+)
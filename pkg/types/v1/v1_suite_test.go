@@ -0,0 +1,15 @@
+package v1_test
+
+import (
+	"testing"
+
+	_ "github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+func TestV1(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Types V1 Suite")
+}
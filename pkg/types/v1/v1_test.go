@@ -0,0 +1,68 @@
+package v1_test
+
+import (
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/configuration"
+	"github.com/bytesparadise/libasciidoc/pkg/parser"
+	"github.com/bytesparadise/libasciidoc/pkg/types"
+	v1 "github.com/bytesparadise/libasciidoc/pkg/types/v1"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("AST version 1", func() {
+
+	source := `== a section
+
+a *paragraph*`
+
+	It("should convert document from and to current AST version", func() {
+		doc, err := parser.ParseDocument(strings.NewReader(source), configuration.NewConfiguration())
+		Expect(err).NotTo(HaveOccurred())
+		// when
+		result, err := v1.FromDocument(doc)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Elements).To(HaveLen(1))
+		section, ok := result.Elements[0].(v1.Section)
+		Expect(ok).To(BeTrue())
+		Expect(section.Elements).To(HaveLen(1))
+		Expect(section.Elements[0]).To(BeAssignableToTypeOf(v1.Paragraph{}))
+		// and back
+		Expect(v1.ToDocument(result)).To(Equal(doc))
+	})
+
+	It("should convert document attributes from and to current AST version", func() {
+		source := `= a title
+:toc:
+:version: 1.0
+
+:version: 2.0
+
+a paragraph`
+		doc, err := parser.ParseDocument(strings.NewReader(source), configuration.NewConfiguration())
+		Expect(err).NotTo(HaveOccurred())
+		// when
+		result, err := v1.FromDocument(doc)
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		// only the attributes at the end of the header are retained
+		Expect(result.Attributes).To(Equal(v1.Attributes{
+			types.AttrTableOfContents: nil,
+			"version":                 "1.0",
+		}))
+		// and back
+		doc, err = v1.ToDocument(result)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(doc.Attributes).To(Equal(types.DocumentAttributes{
+			{Name: types.AttrTableOfContents, Value: nil},
+			{Name: "version", Value: "1.0"},
+		}))
+	})
+
+	It("should be the previous AST version", func() {
+		Expect(v1.Version).To(Equal(types.ASTVersion - 1))
+	})
+})
@@ -9,20 +9,32 @@ import (
 // Validate validates the given document
 // May also alter some attributes (eg: doctype from `manpage` to `article`)
 func Validate(doc *types.Document) ([]Problem, error) {
+	var problems []Problem
+	// include the warnings found while parsing the document
+	for _, w := range doc.Warnings {
+		problems = append(problems, Problem{
+			Severity: Warning,
+			Message:  w.Message,
+			Filename: w.Filename,
+			Line:     w.Line,
+		})
+	}
 	if doctype, found, err := doc.Attributes.GetAsString(types.AttrDocType); err != nil {
 		return nil, err
 	} else if found && doctype == "manpage" {
-		return validateManpage(doc), nil
+		problems = append(problems, validateManpage(doc)...)
 	}
-	return nil, nil
+	return problems, nil
 }
 
 // Problem a problem detected during validation
-// Must have a severity and an associated message
+// Must have a severity and an associated message, and may have the file and the line where it was found
 // TODO: include element position once available in the AST.
 type Problem struct {
 	Severity Severity
 	Message  string
+	Filename string // the main document or an included file, or empty if unknown
+	Line     int    // the line in the file, or `0` if unknown
 }
 
 // Severity the problem severity
@@ -67,7 +79,7 @@ func validateManpage(doc *types.Document) []Problem {
 	}
 	// if any problem found, change the doctype to render the document as a regular article
 	if len(problems) > 0 {
-		doc.Attributes.Set(types.AttrDocType, "article")
+		doc.Attributes = doc.Attributes.Set(types.AttrDocType, "article")
 	}
 	return problems
 }
@@ -14,7 +14,7 @@ var _ = Describe("document validator", func() {
 		It("should not report problems", func() {
 			// given
 			doc := types.Document{
-				Attributes:        types.Attributes{},
+				Attributes:        types.DocumentAttributes{},
 				ElementReferences: types.ElementReferences{},
 				Footnotes:         []types.Footnote{},
 				Elements: []interface{}{
@@ -37,6 +37,35 @@ var _ = Describe("document validator", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(problems).To(BeEmpty()) // no problem found
 		})
+
+		It("should report warnings of the source", func() {
+			// given
+			doc := types.Document{
+				Attributes:        types.DocumentAttributes{},
+				ElementReferences: types.ElementReferences{},
+				Footnotes:         []types.Footnote{},
+				Elements:          []interface{}{},
+				Warnings: []types.SourceWarning{
+					{
+						Filename: "test.adoc",
+						Line:     3,
+						Message:  "unterminated listing block: the '----' delimiter at test.adoc:3 is not closed before the end of the document",
+					},
+				},
+			}
+
+			// when
+			problems, err := Validate(&doc)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(problems).To(ConsistOf(Problem{
+				Severity: Warning,
+				Message:  "unterminated listing block: the '----' delimiter at test.adoc:3 is not closed before the end of the document",
+				Filename: "test.adoc",
+				Line:     3,
+			}))
+		})
 	})
 
 	Context("manpage", func() {
@@ -44,8 +73,8 @@ var _ = Describe("document validator", func() {
 		It("should not report problems", func() {
 			// given
 			doc := types.Document{
-				Attributes: types.Attributes{
-					types.AttrDocType: "manpage",
+				Attributes: types.DocumentAttributes{
+					{Name: types.AttrDocType, Value: "manpage"},
 				},
 				ElementReferences: types.ElementReferences{},
 				Footnotes:         []types.Footnote{},
@@ -109,8 +138,8 @@ var _ = Describe("document validator", func() {
 			It("missing header - invalid level", func() {
 				// given
 				doc := types.Document{
-					Attributes: types.Attributes{
-						types.AttrDocType: "manpage",
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrDocType, Value: "manpage"},
 					},
 					ElementReferences: types.ElementReferences{},
 					Footnotes:         []types.Footnote{},
@@ -175,8 +204,8 @@ var _ = Describe("document validator", func() {
 			It("missing name section - invalid level", func() {
 				// given
 				doc := types.Document{
-					Attributes: types.Attributes{
-						types.AttrDocType: "manpage",
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrDocType, Value: "manpage"},
 					},
 					ElementReferences: types.ElementReferences{},
 					Footnotes:         []types.Footnote{},
@@ -241,8 +270,8 @@ var _ = Describe("document validator", func() {
 			It("missing name section - invalid title", func() {
 				// given
 				doc := types.Document{
-					Attributes: types.Attributes{
-						types.AttrDocType: "manpage",
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrDocType, Value: "manpage"},
 					},
 					ElementReferences: types.ElementReferences{},
 					Footnotes:         []types.Footnote{},
@@ -307,8 +336,8 @@ var _ = Describe("document validator", func() {
 			It("missing name section - invalid elements", func() {
 				// given
 				doc := types.Document{
-					Attributes: types.Attributes{
-						types.AttrDocType: "manpage",
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrDocType, Value: "manpage"},
 					},
 					ElementReferences: types.ElementReferences{},
 					Footnotes:         []types.Footnote{},
@@ -362,8 +391,8 @@ var _ = Describe("document validator", func() {
 			It("missing synopsis section - invalid level", func() {
 				// given
 				doc := types.Document{
-					Attributes: types.Attributes{
-						types.AttrDocType: "manpage",
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrDocType, Value: "manpage"},
 					},
 					ElementReferences: types.ElementReferences{},
 					Footnotes:         []types.Footnote{},
@@ -428,8 +457,8 @@ var _ = Describe("document validator", func() {
 			It("missing synopsis section - invalid title", func() {
 				// given
 				doc := types.Document{
-					Attributes: types.Attributes{
-						types.AttrDocType: "manpage",
+					Attributes: types.DocumentAttributes{
+						{Name: types.AttrDocType, Value: "manpage"},
 					},
 					ElementReferences: types.ElementReferences{},
 					Footnotes:         []types.Footnote{},
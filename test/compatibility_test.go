@@ -0,0 +1,95 @@
+// +build compat
+
+package test_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo"                  //nolint golint
+	. "github.com/onsi/ginkgo/extensions/table" //nolint golint
+	. "github.com/onsi/gomega"                  //nolint golint
+)
+
+// compatibility scores of the documents in the corpus, indexed by filename
+var scores = map[string]float64{}
+
+var _ = Describe("compatibility", func() {
+
+	// compares the output of all documents in the corpus with the output of asciidoctor (if available)
+	// or with their sibling golden file (otherwise), and records the compatibility score of each document
+	DescribeTable("corpus", score, corpus("fixtures/supported/*.adoc", "fixtures/pending/*.adoc", "bench/*.adoc")...)
+
+})
+
+var _ = AfterSuite(func() {
+	report := compatibilityReport(scores)
+	fmt.Fprint(GinkgoWriter, report)
+	// also, write the report in the file specified by the `COMPAT_REPORT` env var
+	if path, found := os.LookupEnv("COMPAT_REPORT"); found {
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(report), 0644)).To(Succeed())
+	}
+})
+
+func corpus(patterns ...string) []TableEntry {
+	result := []TableEntry{}
+	for _, p := range patterns {
+		result = append(result, entries(p)...)
+	}
+	return result
+}
+
+func score(file string) {
+	actual, err := convert(file)
+	if err != nil {
+		// do not fail, but count as incompatible
+		fmt.Fprintf(GinkgoWriter, "failed to convert '%s': %v\n", file, err)
+		scores[file] = 0
+		return
+	}
+	var expected string
+	if testsupport.AsciidoctorAvailable() {
+		expected, err = testsupport.RenderWithAsciidoctor(file)
+		Expect(err).ShouldNot(HaveOccurred())
+	} else if expected, err = getGoldenFile(file); os.IsNotExist(err) {
+		scores[file] = -1 // no reference to compare with
+		Skip(fmt.Sprintf("asciidoctor is not available and '%s' has no golden file", file))
+	} else {
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+	scores[file] = testsupport.CompatibilityScore(expected, actual)
+}
+
+// compatibilityReport returns a report (in Markdown) with the score of each document
+// and the overall compatibility score
+func compatibilityReport(scores map[string]float64) string {
+	files := make([]string, 0, len(scores))
+	for f := range scores {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	report := strings.Builder{}
+	report.WriteString("| document | score |\n|---|---|\n")
+	total := 0.0
+	count := 0
+	for _, f := range files {
+		if scores[f] < 0 {
+			report.WriteString(fmt.Sprintf("| %s | n/a |\n", f))
+			continue
+		}
+		report.WriteString(fmt.Sprintf("| %s | %.1f%% |\n", f, scores[f]*100))
+		total += scores[f]
+		count++
+	}
+	if count > 0 {
+		report.WriteString(fmt.Sprintf("\ncompatibility score: %.1f%% (%d documents)\n", total/float64(count)*100, count))
+	}
+	return report.String()
+}
@@ -0,0 +1,60 @@
+package testsupport
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/bytesparadise/libasciidoc/pkg/renderer"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// AsciidoctorAvailable returns `true` if the `asciidoctor` command is available in the `PATH`
+func AsciidoctorAvailable() bool {
+	_, err := exec.LookPath("asciidoctor")
+	return err == nil
+}
+
+// RenderWithAsciidoctor renders the HTML body of the given file with the `asciidoctor` command
+func RenderWithAsciidoctor(filename string) (string, error) {
+	cmd := exec.Command("asciidoctor", "--no-header-footer", "--out-file", "-", filename)
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("unable to render '%s' with asciidoctor: %s: %w", filename, stderr.String(), err)
+	}
+	return stdout.String(), nil
+}
+
+// CompatibilityScore returns a score between `0` and `1` indicating how close the actual
+// HTML content is to the expected one, once both have been normalized.
+// The score is the ratio of lines in common between both contents, where `1` means that
+// both contents are identical.
+func CompatibilityScore(expected, actual string) float64 {
+	expected = renderer.NormalizeHTML(expected)
+	actual = renderer.NormalizeHTML(actual)
+	if expected == actual {
+		return 1
+	}
+	dmp := diffmatchpatch.New()
+	e, a, _ := dmp.DiffLinesToChars(expected+"\n", actual+"\n")
+	diffs := dmp.DiffMain(e, a, false)
+	common := 0
+	for _, d := range diffs {
+		if d.Type == diffmatchpatch.DiffEqual {
+			// each line was encoded as a single rune
+			common += len([]rune(d.Text))
+		}
+	}
+	return 2 * float64(common) / float64(countLines(expected)+countLines(actual))
+}
+
+func countLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	return strings.Count(content, "\n") + 1
+}
@@ -0,0 +1,31 @@
+package testsupport_test
+
+import (
+	"github.com/bytesparadise/libasciidoc/testsupport"
+
+	. "github.com/onsi/ginkgo" //nolint golint
+	. "github.com/onsi/gomega" //nolint golint
+)
+
+var _ = Describe("compatibility score", func() {
+
+	It("should be 1 with identical contents", func() {
+		Expect(testsupport.CompatibilityScore("<p>foo</p>\n<p>bar</p>", "<p>foo</p>\n<p>bar</p>")).To(Equal(1.0))
+	})
+
+	It("should be 1 with equivalent contents", func() {
+		Expect(testsupport.CompatibilityScore("<p>foo<br/></p>\n\n<p>bar&#8217;</p>\n", "  <p>foo<br></p>\n<p>bar’</p>")).To(Equal(1.0))
+	})
+
+	It("should be 0.5 with half of the lines in common", func() {
+		Expect(testsupport.CompatibilityScore("<p>foo</p>\n<p>bar</p>", "<p>foo</p>\n<p>baz</p>")).To(Equal(0.5))
+	})
+
+	It("should be 0 with no line in common", func() {
+		Expect(testsupport.CompatibilityScore("<p>foo</p>", "<p>bar</p>")).To(Equal(0.0))
+	})
+
+	It("should be 0 with empty actual content", func() {
+		Expect(testsupport.CompatibilityScore("<p>foo</p>", "")).To(Equal(0.0))
+	})
+})
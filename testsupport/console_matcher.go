@@ -9,7 +9,6 @@ import (
 	"os"
 
 	"github.com/onsi/gomega/types"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -74,14 +73,14 @@ type containMessageMatcher struct {
 func (m *containMessageMatcher) Match(actual interface{}) (success bool, err error) {
 	console, ok := actual.(io.Reader)
 	if !ok {
-		return false, errors.Errorf("ContainMessageWithLevel matcher expects an io.Reader (actual: %T)", actual)
+		return false, fmt.Errorf("ContainMessageWithLevel matcher expects an io.Reader (actual: %T)", actual)
 	}
 	scanner := bufio.NewScanner(console)
 	for scanner.Scan() {
 		out := make(map[string]interface{})
 		err := json.Unmarshal(scanner.Bytes(), &out)
 		if err != nil {
-			return false, errors.Wrapf(err, "failed to decode console line")
+			return false, fmt.Errorf("failed to decode console line: %w", err)
 		}
 		if level, ok := out["level"].(string); !ok || level != m.level.String() {
 			continue
@@ -122,14 +121,14 @@ type containAnyMessageMatcher struct {
 func (m *containAnyMessageMatcher) Match(actual interface{}) (success bool, err error) {
 	console, ok := actual.(io.Reader)
 	if !ok {
-		return false, errors.Errorf("ContainAnyMessageWithLevels matcher expects an io.Reader (actual: %T)", actual)
+		return false, fmt.Errorf("ContainAnyMessageWithLevels matcher expects an io.Reader (actual: %T)", actual)
 	}
 	scanner := bufio.NewScanner(console)
 	for scanner.Scan() {
 		out := make(map[string]interface{})
 		err := json.Unmarshal(scanner.Bytes(), &out)
 		if err != nil {
-			return false, errors.Wrapf(err, "failed to decode console line")
+			return false, fmt.Errorf("failed to decode console line: %w", err)
 		}
 		if level, ok := out["level"].(string); ok {
 			for _, l := range m.levels {
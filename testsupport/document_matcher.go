@@ -8,7 +8,6 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	gomegatypes "github.com/onsi/gomega/types"
-	"github.com/pkg/errors"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
@@ -27,7 +26,7 @@ type documentMatcher struct {
 
 func (m *documentMatcher) Match(actual interface{}) (success bool, err error) {
 	if _, ok := actual.(types.Document); !ok {
-		return false, errors.Errorf("MatchDocument matcher expects a Document (actual: %T)", actual)
+		return false, fmt.Errorf("MatchDocument matcher expects a Document (actual: %T)", actual)
 	}
 	if !reflect.DeepEqual(m.expected, actual) {
 		dmp := diffmatchpatch.New()
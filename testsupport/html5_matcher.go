@@ -5,7 +5,6 @@ import (
 
 	. "github.com/onsi/ginkgo" //nolint go-lint
 	gomegatypes "github.com/onsi/gomega/types"
-	"github.com/pkg/errors"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
@@ -24,7 +23,7 @@ type htmlMatcher struct {
 
 func (m *htmlMatcher) Match(actual interface{}) (success bool, err error) {
 	if _, ok := actual.(string); !ok {
-		return false, errors.Errorf("MatchHTML matcher expects a string (actual: %T)", actual)
+		return false, fmt.Errorf("MatchHTML matcher expects a string (actual: %T)", actual)
 	}
 	if m.expected != actual {
 		GinkgoT().Logf("actual HTML:\n%s", actual)
@@ -7,7 +7,6 @@ import (
 
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
 	gomegatypes "github.com/onsi/gomega/types"
-	"github.com/pkg/errors"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
@@ -28,7 +27,7 @@ type htmlTemplateMatcher struct {
 
 func (m *htmlTemplateMatcher) Match(actual interface{}) (success bool, err error) {
 	if _, ok := actual.(string); !ok {
-		return false, errors.Errorf("MatchHTMLTemplate matcher expects a string (actual: %T)", actual)
+		return false, fmt.Errorf("MatchHTMLTemplate matcher expects a string (actual: %T)", actual)
 	}
 	m.expected = strings.Replace(m.expected, "{{.LastUpdated}}", m.lastUpdated.Format(configuration.LastUpdatedFormat), 1)
 	if m.expected != actual {
@@ -6,7 +6,6 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	gomegatypes "github.com/onsi/gomega/types"
-	"github.com/pkg/errors"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
@@ -25,7 +24,7 @@ type inlineElementsMatcher struct {
 
 func (m *inlineElementsMatcher) Match(actual interface{}) (success bool, err error) {
 	if _, ok := actual.([]interface{}); !ok {
-		return false, errors.Errorf("MatchInlineElements matcher expects a []interface{} (actual: %T)", actual)
+		return false, fmt.Errorf("MatchInlineElements matcher expects a []interface{} (actual: %T)", actual)
 	}
 	if !reflect.DeepEqual(m.expected, actual) {
 		dmp := diffmatchpatch.New()
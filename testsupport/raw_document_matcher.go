@@ -7,7 +7,6 @@ import (
 	"github.com/bytesparadise/libasciidoc/pkg/types"
 	"github.com/davecgh/go-spew/spew"
 	gomegatypes "github.com/onsi/gomega/types"
-	"github.com/pkg/errors"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	log "github.com/sirupsen/logrus"
 )
@@ -27,7 +26,7 @@ type rawDocumentMatcher struct {
 
 func (m *rawDocumentMatcher) Match(actual interface{}) (success bool, err error) {
 	if _, ok := actual.(types.RawDocument); !ok {
-		return false, errors.Errorf("MatchRawDocument matcher expects a RawDocument (actual: %T)", actual)
+		return false, fmt.Errorf("MatchRawDocument matcher expects a RawDocument (actual: %T)", actual)
 	}
 	if !reflect.DeepEqual(m.expected, actual) {
 		if log.IsLevelEnabled(log.DebugLevel) {
@@ -2,12 +2,12 @@ package testsupport
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/bytesparadise/libasciidoc"
 	"github.com/bytesparadise/libasciidoc/pkg/configuration"
-	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -51,7 +51,7 @@ func RenderHTML5Document(filename string, options ...configuration.Setting) (str
 	configuration.WithBackEnd("html5")(&config)
 	stat, err := os.Stat(filename)
 	if err != nil {
-		return "", errors.Wrapf(err, "unable to get stats for file '%s'", filename)
+		return "", fmt.Errorf("unable to get stats for file '%s': %w", filename, err)
 	}
 	config.LastUpdated = stat.ModTime()
 	_, err = libasciidoc.ConvertFile(resultWriter, config)
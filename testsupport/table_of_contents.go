@@ -11,8 +11,9 @@ import (
 // TableOfContents returns the Table of Contents for the given document
 func TableOfContents(doc types.Document) (types.TableOfContents, error) {
 	ctx := renderer.NewContext(doc, configuration.NewConfiguration())
-	if _, err := html5.Render(ctx, doc, ioutil.Discard); err != nil {
-		return ctx.TableOfContents, err
+	metadata, err := html5.Render(ctx, doc, ioutil.Discard)
+	if err != nil {
+		return types.TableOfContents{}, err
 	}
-	return ctx.TableOfContents, nil
+	return metadata.TableOfContents, nil
 }